@@ -261,7 +261,11 @@ func Uintptrs(key string, us []uintptr) Field {
 	return Array(key, uintptrs(us))
 }
 
-// Errors constructs a field that carries a slice of errors.
+// Errors constructs a field that carries a slice of errors. Each error is
+// encoded as an object like the one the Error field produces, along with the
+// chain of errors it wraps and their type names, the separate causes of
+// errors built with errors.Join, and a stack trace if one of the errors
+// recorded one.
 func Errors(key string, errs []error) Field {
 	return Array(key, errArray(errs))
 }
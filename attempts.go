@@ -0,0 +1,81 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lad
+
+import (
+	"time"
+
+	"github.com/auwixcom/lad/ladcore"
+)
+
+// AttemptResult records one attempt of a retried operation.
+type AttemptResult struct {
+	// Attempt is the 1-based attempt number. If zero, Attempts numbers the
+	// result by its position.
+	Attempt int
+
+	// Delay is how long the caller waited before making this attempt.
+	Delay time.Duration
+
+	// Err is the attempt's error, or nil if it succeeded.
+	Err error
+}
+
+// MarshalLogObject implements ladcore.ObjectMarshaler. The error, if any, is
+// encoded like Error.
+func (r AttemptResult) MarshalLogObject(enc ladcore.ObjectEncoder) error {
+	enc.AddInt("attempt", r.Attempt)
+	enc.AddDuration("delay", r.Delay)
+	Error(r.Err).AddTo(enc)
+	return nil
+}
+
+// Attempts constructs a field that carries the history of a retry loop as
+// an array of {attempt, delay, error} objects, giving retrying code a
+// standard way to report what happened:
+//
+//	var history []lad.AttemptResult
+//	for i, delay := range backoff {
+//	  time.Sleep(delay)
+//	  err := call()
+//	  history = append(history, lad.AttemptResult{Attempt: i + 1, Delay: delay, Err: err})
+//	  if err == nil {
+//	    break
+//	  }
+//	}
+//	logger.Info("called upstream", lad.Attempts("attempts", history))
+func Attempts(key string, results []AttemptResult) Field {
+	return Array(key, attempts(results))
+}
+
+type attempts []AttemptResult
+
+func (as attempts) MarshalLogArray(arr ladcore.ArrayEncoder) error {
+	for i, r := range as {
+		if r.Attempt == 0 {
+			r.Attempt = i + 1
+		}
+		if err := arr.AppendObject(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lad
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/auwixcom/lad/ladcore"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttempts(t *testing.T) {
+	enc := ladcore.NewMapObjectEncoder()
+	Attempts("attempts", []AttemptResult{
+		{Err: errors.New("timeout")},
+		{Delay: time.Second, Err: errors.New("unavailable")},
+		{Attempt: 5, Delay: 2 * time.Second},
+	}).AddTo(enc)
+
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"attempt": 1, "delay": time.Duration(0), "error": "timeout"},
+		map[string]interface{}{"attempt": 2, "delay": time.Second, "error": "unavailable"},
+		map[string]interface{}{"attempt": 5, "delay": 2 * time.Second},
+	}, enc.Fields["attempts"], "Unexpected retry history.")
+}
+
+func TestAttemptsEmpty(t *testing.T) {
+	enc := ladcore.NewMapObjectEncoder()
+	Attempts("attempts", nil).AddTo(enc)
+	assert.Equal(t, []interface{}{}, enc.Fields["attempts"], "Expected an empty array.")
+}
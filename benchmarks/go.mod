@@ -22,4 +22,5 @@ require (
 	github.com/pkg/errors v0.9.1 // indirect
 	golang.org/x/sys v0.12.0 // indirect
 	golang.org/x/term v0.12.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,130 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lad
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// _maxDecimalZeros is the most zeros formatDecimal pads a decimal with;
+// decimals that need more are written in scientific notation instead, so
+// that an extreme exponent can't produce an enormous string.
+const _maxDecimalZeros = 64
+
+// BigInt constructs a field that carries an arbitrary-precision integer. The
+// integer is encoded as a base-10 string, since most consumers of JSON logs
+// can't represent integers wider than 53 bits exactly.
+func BigInt(key string, val *big.Int) Field {
+	if val == nil {
+		return nilField(key)
+	}
+	return Stringer(key, val)
+}
+
+// BigFloat constructs a field that carries an arbitrary-precision float. The
+// float is encoded as the shortest decimal string that round-trips at its
+// precision, rather than being truncated to a float64.
+func BigFloat(key string, val *big.Float) Field {
+	if val == nil {
+		return nilField(key)
+	}
+	return Stringer(key, bigFloat{val})
+}
+
+// bigFloat formats a big.Float exactly; big.Float's own String method
+// rounds to ten significant digits.
+type bigFloat struct{ f *big.Float }
+
+func (b bigFloat) String() string {
+	return b.f.Text('g', -1)
+}
+
+// DecimalValue is implemented by arbitrary-precision decimal types, which
+// represent a number as coefficient × 10^exponent. It's satisfied by
+// github.com/shopspring/decimal.Decimal, so lad can log decimals without
+// depending on any particular decimal package.
+type DecimalValue interface {
+	Coefficient() *big.Int
+	Exponent() int32
+}
+
+// Decimal constructs a field that carries an arbitrary-precision decimal.
+// The decimal is encoded as a base-10 string that keeps its full scale, so
+// 1.50 is logged as "1.50" instead of a float64 approximation. Decimals that
+// would need more than 64 padding zeros, like 1e100, are written in
+// scientific notation.
+func Decimal(key string, val DecimalValue) Field {
+	if val == nil {
+		return nilField(key)
+	}
+	return Stringer(key, decimal{val})
+}
+
+type decimal struct{ d DecimalValue }
+
+func (d decimal) String() string {
+	return formatDecimal(d.d.Coefficient(), d.d.Exponent())
+}
+
+func formatDecimal(coef *big.Int, exp int32) string {
+	digits := coef.Append(nil, 10)
+	var sign string
+	if len(digits) > 0 && digits[0] == '-' {
+		sign, digits = "-", digits[1:]
+	}
+
+	var sb strings.Builder
+	sb.WriteString(sign)
+	point := int64(len(digits)) + int64(exp) // may overflow an int
+	if (coef.Sign() != 0 && exp > _maxDecimalZeros) || -point > _maxDecimalZeros {
+		// d.ddd × 10^(point-1), like strconv's 'e' format.
+		sb.WriteByte(digits[0])
+		if len(digits) > 1 {
+			sb.WriteByte('.')
+			sb.Write(digits[1:])
+		}
+		sb.WriteByte('e')
+		if point > 0 {
+			sb.WriteByte('+')
+		}
+		sb.WriteString(strconv.FormatInt(point-1, 10))
+		return sb.String()
+	}
+
+	switch {
+	case exp >= 0:
+		sb.Write(digits)
+		if coef.Sign() != 0 {
+			sb.WriteString(strings.Repeat("0", int(exp)))
+		}
+	case point > 0:
+		sb.Write(digits[:point])
+		sb.WriteByte('.')
+		sb.Write(digits[point:])
+	default:
+		sb.WriteString("0.")
+		sb.WriteString(strings.Repeat("0", int(-point)))
+		sb.Write(digits)
+	}
+	return sb.String()
+}
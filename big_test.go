@@ -0,0 +1,90 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lad
+
+import (
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/auwixcom/lad/internal/ztest"
+	"github.com/auwixcom/lad/ladcore"
+	"github.com/stretchr/testify/assert"
+)
+
+type testDecimal struct {
+	coef int64
+	exp  int32
+}
+
+func (d testDecimal) Coefficient() *big.Int { return big.NewInt(d.coef) }
+func (d testDecimal) Exponent() int32       { return d.exp }
+
+func TestBigFields(t *testing.T) {
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	precise, _ := new(big.Float).SetPrec(200).SetString("3.14159265358979323846264338327950288")
+
+	tests := []struct {
+		desc  string
+		field Field
+		want  interface{}
+	}{
+		{"BigInt", BigInt("k", huge), "123456789012345678901234567890"},
+		{"BigInt negative", BigInt("k", big.NewInt(-42)), "-42"},
+		{"BigInt nil", BigInt("k", nil), nil},
+		{"BigFloat", BigFloat("k", precise), "3.14159265358979323846264338327950288"},
+		{"BigFloat nil", BigFloat("k", nil), nil},
+		{"Decimal scaled", Decimal("k", testDecimal{150, -2}), "1.50"},
+		{"Decimal negative fraction", Decimal("k", testDecimal{-5, -3}), "-0.005"},
+		{"Decimal zero", Decimal("k", testDecimal{0, -2}), "0.00"},
+		{"Decimal positive exponent", Decimal("k", testDecimal{12, 3}), "12000"},
+		{"Decimal integer", Decimal("k", testDecimal{7, 0}), "7"},
+		{"Decimal huge exponent", Decimal("k", testDecimal{15, math.MaxInt32}), "1.5e+2147483648"},
+		{"Decimal tiny exponent", Decimal("k", testDecimal{-15, math.MinInt32}), "-1.5e-2147483647"},
+		{"Decimal zero tiny exponent", Decimal("k", testDecimal{0, math.MinInt32}), "0e-2147483648"},
+		{"Decimal most zeros", Decimal("k", testDecimal{1, 64}), "1" + strings.Repeat("0", 64)},
+		{"Decimal too many zeros", Decimal("k", testDecimal{1, 65}), "1e+65"},
+		{"Decimal nil", Decimal("k", nil), nil},
+		{"Any BigInt", Any("k", huge), "123456789012345678901234567890"},
+		{"Any BigFloat", Any("k", precise), "3.14159265358979323846264338327950288"},
+		{"Any Decimal", Any("k", testDecimal{1999, -2}), "19.99"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			enc := ladcore.NewMapObjectEncoder()
+			tt.field.AddTo(enc)
+			assert.Equal(t, tt.want, enc.Fields["k"], "Unexpected encoded value.")
+		})
+	}
+}
+
+func TestBigFieldAllocs(t *testing.T) {
+	ztest.SkipIfRace(t)
+
+	n, f := big.NewInt(42), big.NewFloat(1)
+	allocs := testing.AllocsPerRun(10, func() {
+		_ = BigInt("k", n)
+		_ = BigFloat("k", f)
+	})
+	assert.Zero(t, allocs, "Constructing big fields shouldn't allocate.")
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lad
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"strconv"
+)
+
+// Hex constructs a field that carries a byte slice encoded as lowercase
+// hexadecimal, which suits protocol debugging. Use HexN to cap how much of a
+// large slice is encoded. Encoding happens lazily.
+func Hex(key string, val []byte) Field {
+	return HexN(key, val, -1)
+}
+
+// HexN is like Hex, but it encodes at most n bytes of val. If val is
+// truncated, the encoded string ends with a note of val's full length, like
+// "0a1b2c... (1024 bytes)". A negative n encodes all of val.
+func HexN(key string, val []byte, n int) Field {
+	return Stringer(key, encodedBytes{enc: hexEncoding{}, val: val, n: n})
+}
+
+// Base64 constructs a field that carries a byte slice encoded with standard,
+// padded base64, which suits logging payloads compactly. Use Base64N to cap
+// how much of a large slice is encoded. Encoding happens lazily.
+func Base64(key string, val []byte) Field {
+	return Base64N(key, val, -1)
+}
+
+// Base64N is like Base64, but it encodes at most n bytes of val. If val is
+// truncated, the encoded string ends with a note of val's full length, like
+// "AQID... (1024 bytes)". A negative n encodes all of val.
+func Base64N(key string, val []byte, n int) Field {
+	return Stringer(key, encodedBytes{enc: base64.StdEncoding, val: val, n: n})
+}
+
+// byteEncoding is implemented by hexEncoding and *base64.Encoding.
+type byteEncoding interface {
+	EncodedLen(n int) int
+	Encode(dst, src []byte)
+}
+
+type hexEncoding struct{}
+
+func (hexEncoding) EncodedLen(n int) int   { return hex.EncodedLen(n) }
+func (hexEncoding) Encode(dst, src []byte) { hex.Encode(dst, src) }
+
+type encodedBytes struct {
+	enc byteEncoding
+	val []byte
+	n   int
+}
+
+func (e encodedBytes) String() string {
+	src := e.val
+	truncated := e.n >= 0 && len(src) > e.n
+	if truncated {
+		src = src[:e.n]
+	}
+
+	dst := make([]byte, e.enc.EncodedLen(len(src)))
+	e.enc.Encode(dst, src)
+	if truncated {
+		dst = append(dst, "... ("...)
+		dst = strconv.AppendInt(dst, int64(len(e.val)), 10)
+		dst = append(dst, " bytes)"...)
+	}
+	return string(dst)
+}
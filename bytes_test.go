@@ -0,0 +1,73 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lad
+
+import (
+	"testing"
+
+	"github.com/auwixcom/lad/ladcore"
+	"github.com/auwixcom/lad/ladtest/observer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestByteEncodingFields(t *testing.T) {
+	payload := []byte{0xde, 0xad, 0xbe, 0xef, 0x01}
+
+	tests := []struct {
+		desc  string
+		field Field
+		want  string
+	}{
+		{"Hex", Hex("k", payload), "deadbeef01"},
+		{"Hex empty", Hex("k", nil), ""},
+		{"HexN under limit", HexN("k", payload, 10), "deadbeef01"},
+		{"HexN at limit", HexN("k", payload, 5), "deadbeef01"},
+		{"HexN truncated", HexN("k", payload, 2), "dead... (5 bytes)"},
+		{"HexN zero", HexN("k", payload, 0), "... (5 bytes)"},
+		{"Base64", Base64("k", payload), "3q2+7wE="},
+		{"Base64N truncated", Base64N("k", payload, 3), "3q2+... (5 bytes)"},
+		{"Base64N negative", Base64N("k", payload, -1), "3q2+7wE="},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			enc := ladcore.NewMapObjectEncoder()
+			tt.field.AddTo(enc)
+			assert.Equal(t, tt.want, enc.Fields["k"], "Unexpected encoded bytes.")
+		})
+	}
+}
+
+func TestByteEncodingFieldsEquals(t *testing.T) {
+	payload := []byte{0xde, 0xad, 0xbe, 0xef}
+	for _, f := range []Field{Hex("k", payload), Base64N("k", payload, 2)} {
+		assert.NotPanics(t, func() {
+			assert.True(t, f.Equals(f), "Expected a field to equal itself.")
+			assert.False(t, f.Equals(Hex("k", []byte{1})), "Expected fields with other bytes to differ.")
+		}, "Unexpected panic comparing fields.")
+
+		core, logs := observer.New(DebugLevel)
+		New(core).Info("payload", f, String("other", "v"))
+		assert.NotPanics(t, func() {
+			assert.Equal(t, 1, logs.FilterField(f).Len(), "Expected to filter on the field.")
+		}, "Unexpected panic filtering on the field.")
+	}
+}
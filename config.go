@@ -22,26 +22,42 @@ package lad
 
 import (
 	"errors"
+	"fmt"
 	"sort"
 	"time"
 
 	"github.com/auwixcom/lad/ladcore"
 )
 
+var errMissingLevel = errors.New("missing Level")
+
 // SamplingConfig sets a sampling strategy for the logger. Sampling caps the
 // global CPU and I/O load that logging puts on your process while attempting
 // to preserve a representative subset of your logs.
 //
 // If specified, the Sampler will invoke the Hook after each decision.
+// Entries are grouped by level and message, along with the values of any
+// KeyFields; see ladcore.SamplerKeyFields.
 //
 // Values configured here are per-second. See ladcore.NewSamplerWithOptions for
 // details.
 type SamplingConfig struct {
 	Initial    int                                           `json:"initial" yaml:"initial"`
 	Thereafter int                                           `json:"thereafter" yaml:"thereafter"`
+	KeyFields  []string                                      `json:"keyFields" yaml:"keyFields"`
 	Hook       func(ladcore.Entry, ladcore.SamplingDecision) `json:"-" yaml:"-"`
 }
 
+// SugarConfig sets options specific to the SugaredLogger built by
+// Config.BuildSugared. See the StrictKeyValues and SugarErrorKey options for
+// details.
+type SugarConfig struct {
+	// StrictKeyValues reports malformed key-value pairs at DPanicLevel.
+	StrictKeyValues bool `json:"strictKeyValues" yaml:"strictKeyValues"`
+	// ErrorKey is the key for errors passed without one. Defaults to "error".
+	ErrorKey string `json:"errorKey" yaml:"errorKey"`
+}
+
 // Config offers a declarative way to construct a logger. It doesn't do
 // anything that can't be done with New, Options, and the various
 // ladcore.WriteSyncer and ladcore.Core wrappers, but it's a simpler way to
@@ -72,9 +88,9 @@ type Config struct {
 	DisableStacktrace bool `json:"disableStacktrace" yaml:"disableStacktrace"`
 	// Sampling sets a sampling policy. A nil SamplingConfig disables sampling.
 	Sampling *SamplingConfig `json:"sampling" yaml:"sampling"`
-	// Encoding sets the logger's encoding. Valid values are "json" and
-	// "console", as well as any third-party encodings registered via
-	// RegisterEncoder.
+	// Encoding sets the logger's encoding. Valid values are "json",
+	// "console", and "msgpack", as well as any third-party encodings
+	// registered via RegisterEncoder.
 	Encoding string `json:"encoding" yaml:"encoding"`
 	// EncoderConfig sets options for the chosen encoder. See
 	// ladcore.EncoderConfig for details.
@@ -82,6 +98,18 @@ type Config struct {
 	// OutputPaths is a list of URLs or file paths to write logging output to.
 	// See Open for details.
 	OutputPaths []string `json:"outputPaths" yaml:"outputPaths"`
+	// OutputLevels sets minimum levels for some of the OutputPaths, keyed by
+	// path. For example, to log everything to standard out but only errors to
+	// a separate file:
+	//
+	//	outputPaths: [stdout, /var/log/app-error.log]
+	//	outputLevels:
+	//	  /var/log/app-error.log: error
+	//
+	// A path gets the entries at or above both its own level and Level, so
+	// Level still controls the whole logger. Paths without a level get all
+	// the entries Level enables.
+	OutputLevels map[string]ladcore.Level `json:"outputLevels" yaml:"outputLevels"`
 	// ErrorOutputPaths is a list of URLs to write internal logger errors to.
 	// The default is standard error.
 	//
@@ -89,8 +117,32 @@ type Config struct {
 	// sends error-level logs to a different location from info- and debug-level
 	// logs, see the package-level AdvancedConfiguration example.
 	ErrorOutputPaths []string `json:"errorOutputPaths" yaml:"errorOutputPaths"`
+	// OutputBOM writes a UTF-8 byte order mark at the start of each output
+	// path, for consumers that require one. Files that already have content
+	// are left without one. Use it with EncoderConfig.LineEnding and
+	// EncoderConfig.ASCIIOnly to feed agents with other legacy requirements.
+	OutputBOM bool `json:"outputBOM" yaml:"outputBOM"`
 	// InitialFields is a collection of fields to add to the root logger.
 	InitialFields map[string]interface{} `json:"initialFields" yaml:"initialFields"`
+	// NamedFields is a collection of fields to add to the loggers created by
+	// Named, keyed by their full, period-separated names. For example,
+	// fields under "payments.db" are added to
+	// logger.Named("payments").Named("db").
+	NamedFields map[string]map[string]interface{} `json:"namedFields" yaml:"namedFields"`
+	// SchemaVersion, if set, stamps every entry with the version of the
+	// application's log schema. See the SchemaVersion option for details.
+	SchemaVersion string `json:"schemaVersion" yaml:"schemaVersion"`
+	// Filters drop or reroute entries matching predicates on their level,
+	// logger name, and message. Each entry is handled by the first rule it
+	// matches. See FilterConfig for details.
+	Filters []FilterConfig `json:"filters" yaml:"filters"`
+	// DynamicFilters, if created with NewAtomicFilters, holds drop rules
+	// that can be replaced while the program is running. They're evaluated
+	// before Filters.
+	DynamicFilters AtomicFilters `json:"-" yaml:"-"`
+	// Sugar sets options for loggers' sugared API. They only affect
+	// SugaredLoggers, such as those built with BuildSugared.
+	Sugar SugarConfig `json:"sugar" yaml:"sugar"`
 }
 
 // NewProductionEncoderConfig returns an opinionated EncoderConfig for
@@ -236,31 +288,61 @@ func NewDevelopmentConfig() Config {
 }
 
 // Build constructs a logger from the Config and Options.
-func (cfg Config) Build(opts ...Option) (*Logger, error) {
+//
+// If it fails, the outputs it opened are closed.
+func (cfg Config) Build(opts ...Option) (_ *Logger, err error) {
 	enc, err := cfg.buildEncoder()
 	if err != nil {
 		return nil, err
 	}
 
-	sink, errSink, err := cfg.openSinks()
+	if err := cfg.checkOutputLevels(); err != nil {
+		return nil, err
+	}
+
+	sinks, errSink, closeSinks, err := cfg.openSinks()
 	if err != nil {
 		return nil, err
 	}
+	defer func() {
+		if err != nil {
+			closeSinks()
+		}
+	}()
 
 	if cfg.Level == (AtomicLevel{}) {
-		return nil, errors.New("missing Level")
+		return nil, errMissingLevel
 	}
 
-	log := New(
-		ladcore.NewCore(enc, sink, cfg.Level),
-		cfg.buildOptions(errSink)...,
-	)
+	core, err := cfg.buildOutputCore(enc, sinks)
+	if err != nil {
+		return nil, err
+	}
+	if len(cfg.Filters) > 0 {
+		core, err = cfg.buildFilterCore(core)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	log := New(core, cfg.buildOptions(errSink)...)
 	if len(opts) > 0 {
 		log = log.WithOptions(opts...)
 	}
 	return log, nil
 }
 
+// BuildSugared constructs a SugaredLogger from the Config and Options, like
+// Build followed by Logger.Sugar. The options under Config.Sugar apply to
+// it.
+func (cfg Config) BuildSugared(opts ...Option) (*SugaredLogger, error) {
+	log, err := cfg.Build(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return log.Sugar(), nil
+}
+
 func (cfg Config) buildOptions(errSink ladcore.WriteSyncer) []Option {
 	opts := []Option{ErrorOutput(errSink)}
 
@@ -272,12 +354,8 @@ func (cfg Config) buildOptions(errSink ladcore.WriteSyncer) []Option {
 		opts = append(opts, AddCaller())
 	}
 
-	stackLevel := ErrorLevel
-	if cfg.Development {
-		stackLevel = WarnLevel
-	}
 	if !cfg.DisableStacktrace {
-		opts = append(opts, AddStacktrace(stackLevel))
+		opts = append(opts, AddStacktrace(cfg.stacktraceLevel()))
 	}
 
 	if scfg := cfg.Sampling; scfg != nil {
@@ -286,6 +364,9 @@ func (cfg Config) buildOptions(errSink ladcore.WriteSyncer) []Option {
 			if scfg.Hook != nil {
 				samplerOpts = append(samplerOpts, ladcore.SamplerHook(scfg.Hook))
 			}
+			if len(scfg.KeyFields) > 0 {
+				samplerOpts = append(samplerOpts, ladcore.SamplerKeyFields(scfg.KeyFields...))
+			}
 			return ladcore.NewSamplerWithOptions(
 				core,
 				time.Second,
@@ -296,33 +377,151 @@ func (cfg Config) buildOptions(errSink ladcore.WriteSyncer) []Option {
 		}))
 	}
 
+	if cfg.DynamicFilters != (AtomicFilters{}) {
+		opts = append(opts, AddFilters(cfg.DynamicFilters))
+	}
+
 	if len(cfg.InitialFields) > 0 {
-		fs := make([]Field, 0, len(cfg.InitialFields))
-		keys := make([]string, 0, len(cfg.InitialFields))
-		for k := range cfg.InitialFields {
-			keys = append(keys, k)
-		}
-		sort.Strings(keys)
-		for _, k := range keys {
-			fs = append(fs, Any(k, cfg.InitialFields[k]))
+		opts = append(opts, Fields(sortedFields(cfg.InitialFields)...))
+	}
+
+	if cfg.SchemaVersion != "" {
+		opts = append(opts, SchemaVersion(cfg.SchemaVersion))
+	}
+
+	if len(cfg.NamedFields) > 0 {
+		named := make(map[string][]Field, len(cfg.NamedFields))
+		for name, fields := range cfg.NamedFields {
+			named[name] = sortedFields(fields)
 		}
-		opts = append(opts, Fields(fs...))
+		opts = append(opts, NamedFields(named))
+	}
+
+	if cfg.Sugar.StrictKeyValues {
+		opts = append(opts, StrictKeyValues())
+	}
+
+	if cfg.Sugar.ErrorKey != "" {
+		opts = append(opts, SugarErrorKey(cfg.Sugar.ErrorKey))
 	}
 
 	return opts
 }
 
-func (cfg Config) openSinks() (ladcore.WriteSyncer, ladcore.WriteSyncer, error) {
-	sink, closeOut, err := Open(cfg.OutputPaths...)
+// sortedFields converts a map to fields, sorted by key.
+func sortedFields(m map[string]interface{}) []Field {
+	fs := make([]Field, 0, len(m))
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fs = append(fs, Any(k, m[k]))
+	}
+	return fs
+}
+
+func (cfg Config) openSinks() ([]ladcore.WriteSyncer, ladcore.WriteSyncer, func(), error) {
+	writers, closeOut, err := open(cfg.OutputPaths)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
+	}
+	if cfg.OutputBOM {
+		for i, w := range writers {
+			writers[i] = ladcore.AddBOM(w)
+		}
 	}
-	errSink, _, err := Open(cfg.ErrorOutputPaths...)
+	errSink, closeErr, err := Open(cfg.ErrorOutputPaths...)
 	if err != nil {
 		closeOut()
-		return nil, nil, err
+		return nil, nil, nil, err
+	}
+	return writers, errSink, func() {
+		closeOut()
+		closeErr()
+	}, nil
+}
+
+// checkOutputLevels makes sure that OutputLevels only has levels for
+// OutputPaths.
+func (cfg Config) checkOutputLevels() error {
+	if len(cfg.OutputLevels) == 0 {
+		return nil
+	}
+	paths := make(map[string]struct{}, len(cfg.OutputPaths))
+	for _, path := range cfg.OutputPaths {
+		paths[path] = struct{}{}
+	}
+	for path := range cfg.OutputLevels {
+		if _, ok := paths[path]; !ok {
+			return fmt.Errorf("outputLevels has a level for %q, which isn't in outputPaths", path)
+		}
+	}
+	return nil
+}
+
+// buildOutputCore builds the core that writes to the OutputPaths, whose
+// sinks are given in the same order. Paths that share a level share a core.
+// Each core after the first gets its own encoder, since encoders may keep
+// state about the entries they've encoded, like the console encoder's
+// repeated context.
+func (cfg Config) buildOutputCore(enc ladcore.Encoder, sinks []ladcore.WriteSyncer) (ladcore.Core, error) {
+	if len(cfg.OutputLevels) == 0 {
+		return ladcore.NewCore(enc, CombineWriteSyncers(sinks...), cfg.Level), nil
 	}
-	return sink, errSink, nil
+
+	var (
+		unleveled []ladcore.WriteSyncer
+		levels    []ladcore.Level
+		leveled   = make(map[ladcore.Level][]ladcore.WriteSyncer)
+	)
+	for i, path := range cfg.OutputPaths {
+		lvl, ok := cfg.OutputLevels[path]
+		if !ok {
+			unleveled = append(unleveled, sinks[i])
+			continue
+		}
+		if _, seen := leveled[lvl]; !seen {
+			levels = append(levels, lvl)
+		}
+		leveled[lvl] = append(leveled[lvl], sinks[i])
+	}
+
+	var cores []ladcore.Core
+	newCore := func(ws ladcore.WriteSyncer, enab ladcore.LevelEnabler) error {
+		if len(cores) > 0 {
+			var err error
+			if enc, err = cfg.buildEncoder(); err != nil {
+				return err
+			}
+		}
+		cores = append(cores, ladcore.NewCore(enc, ws, enab))
+		return nil
+	}
+	if len(unleveled) > 0 {
+		if err := newCore(CombineWriteSyncers(unleveled...), cfg.Level); err != nil {
+			return nil, err
+		}
+	}
+	for _, lvl := range levels {
+		enab := outputLevel{base: cfg.Level, min: lvl}
+		if err := newCore(CombineWriteSyncers(leveled[lvl]...), enab); err != nil {
+			return nil, err
+		}
+	}
+	return ladcore.NewTee(cores...), nil
+}
+
+// outputLevel enables the entries at or above both an output's own minimum
+// level and the Config's Level.
+type outputLevel struct {
+	base AtomicLevel
+	min  ladcore.Level
+}
+
+func (l outputLevel) Enabled(lvl ladcore.Level) bool {
+	return lvl >= l.min && l.base.Enabled(lvl)
 }
 
 func (cfg Config) buildEncoder() (ladcore.Encoder, error) {
@@ -21,6 +21,7 @@
 package lad
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"sync/atomic"
@@ -85,6 +86,164 @@ func TestConfig(t *testing.T) {
 	}
 }
 
+func TestConfigNamedFields(t *testing.T) {
+	logOut := filepath.Join(t.TempDir(), "test.log")
+
+	cfg := NewProductionConfig()
+	cfg.OutputPaths = []string{logOut}
+	cfg.EncoderConfig = ladcore.EncoderConfig{MessageKey: "msg", NameKey: "logger"}
+	cfg.NamedFields = map[string]map[string]interface{}{
+		"payments": {"component": "payments", "team": 7},
+	}
+
+	logger, err := cfg.Build()
+	require.NoError(t, err, "Unexpected error constructing logger.")
+	logger.Named("payments").Info("charged")
+	logger.Info("root")
+
+	contents, err := os.ReadFile(logOut)
+	require.NoError(t, err, "Couldn't read log contents from temp file.")
+	assert.Equal(t,
+		`{"logger":"payments","msg":"charged","component":"payments","team":7}`+"\n"+
+			`{"msg":"root"}`+"\n",
+		string(contents), "Unexpected log output.")
+}
+
+func TestConfigOutputBOM(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.log")
+	cfg := Config{
+		Level:            NewAtomicLevelAt(InfoLevel),
+		Encoding:         "json",
+		EncoderConfig:    ladcore.EncoderConfig{MessageKey: "msg", LineEnding: ladcore.CRLFLineEnding, ASCIIOnly: true},
+		OutputPaths:      []string{path},
+		ErrorOutputPaths: []string{"stderr"},
+		OutputBOM:        true,
+	}
+	logger, err := cfg.Build()
+	require.NoError(t, err, "Unexpected error building logger.")
+	logger.Info("héllo")
+	logger.Info("bye")
+	require.NoError(t, logger.Sync(), "Unexpected error syncing.")
+
+	out, err := os.ReadFile(path)
+	require.NoError(t, err, "Unexpected error reading log file.")
+	assert.Equal(t, ladcore.UTF8BOM+`{"msg":"h\u00e9llo"}`+"\r\n"+`{"msg":"bye"}`+"\r\n", string(out), "Unexpected output.")
+}
+
+func TestConfigBuildSugared(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.log")
+	cfg := Config{
+		Level:            NewAtomicLevelAt(InfoLevel),
+		Encoding:         "json",
+		EncoderConfig:    ladcore.EncoderConfig{MessageKey: "msg", LevelKey: "level", EncodeLevel: ladcore.LowercaseLevelEncoder},
+		OutputPaths:      []string{path},
+		ErrorOutputPaths: []string{"stderr"},
+		Sugar:            SugarConfig{StrictKeyValues: true, ErrorKey: "err"},
+	}
+	sugar, err := cfg.BuildSugared()
+	require.NoError(t, err, "Unexpected error building sugared logger.")
+	sugar.Infow("failed", errors.New("boom"), "dangling")
+	require.NoError(t, sugar.Sync(), "Unexpected error syncing.")
+
+	out, err := os.ReadFile(path)
+	require.NoError(t, err, "Unexpected error reading log file.")
+	assert.Equal(t,
+		`{"level":"dpanic","msg":"Ignored key without a value.","ignored":"dangling"}`+"\n"+
+			`{"level":"info","msg":"failed","err":"boom"}`+"\n",
+		string(out), "Unexpected output.")
+
+	_, err = Config{}.BuildSugared()
+	assert.Error(t, err, "Expected an error for an invalid config.")
+}
+
+func TestConfigSamplingKeyFields(t *testing.T) {
+	shook, dcount, scount := makeSamplerCountingHook()
+	cfg := Config{
+		Level:            NewAtomicLevelAt(InfoLevel),
+		Sampling:         &SamplingConfig{Initial: 1, KeyFields: []string{"endpoint"}, Hook: shook},
+		Encoding:         "json",
+		EncoderConfig:    ladcore.EncoderConfig{MessageKey: "msg"},
+		OutputPaths:      []string{filepath.Join(t.TempDir(), "out.log")},
+		ErrorOutputPaths: []string{"stderr"},
+	}
+	logger, err := cfg.Build()
+	require.NoError(t, err, "Unexpected error constructing logger.")
+
+	for _, endpoint := range []string{"/a", "/b", "/a"} {
+		logger.Info("request", String("endpoint", endpoint))
+	}
+	assert.Equal(t, int64(2), scount.Load(), "Expected an entry per endpoint.")
+	assert.Equal(t, int64(1), dcount.Load(), "Expected repeated endpoints to be dropped.")
+}
+
+func TestConfigOutputLevels(t *testing.T) {
+	dir := t.TempDir()
+	all, errs, warns := filepath.Join(dir, "all.log"), filepath.Join(dir, "error.log"), filepath.Join(dir, "warn.log")
+	cfg := Config{
+		Level:            NewAtomicLevelAt(InfoLevel),
+		Encoding:         "json",
+		EncoderConfig:    ladcore.EncoderConfig{MessageKey: "msg"},
+		OutputPaths:      []string{all, errs, warns},
+		OutputLevels:     map[string]ladcore.Level{errs: ErrorLevel, warns: DebugLevel},
+		ErrorOutputPaths: []string{"stderr"},
+	}
+	logger, err := cfg.Build()
+	require.NoError(t, err, "Unexpected error building logger.")
+
+	logger.Debug("debug")
+	logger.Info("info")
+	logger.Error("error")
+	cfg.Level.SetLevel(ErrorLevel)
+	logger.Warn("warn")
+	require.NoError(t, logger.Sync(), "Unexpected error syncing.")
+
+	for path, want := range map[string]string{
+		all:   `{"msg":"info"}` + "\n" + `{"msg":"error"}` + "\n",
+		errs:  `{"msg":"error"}` + "\n",
+		warns: `{"msg":"info"}` + "\n" + `{"msg":"error"}` + "\n",
+	} {
+		out, err := os.ReadFile(path)
+		require.NoError(t, err, "Unexpected error reading log file.")
+		assert.Equal(t, want, string(out), "Unexpected output in %s.", filepath.Base(path))
+	}
+
+	cfg.OutputLevels = map[string]ladcore.Level{"stdout": ErrorLevel}
+	_, err = cfg.Build()
+	assert.ErrorContains(t, err, `level for "stdout", which isn't in outputPaths`, "Expected an error for an unknown path.")
+}
+
+func TestConfigOutputLevelsRepeatedContext(t *testing.T) {
+	dir := t.TempDir()
+	all, errs := filepath.Join(dir, "all.log"), filepath.Join(dir, "error.log")
+	cfg := Config{
+		Level:    NewAtomicLevelAt(InfoLevel),
+		Encoding: "console",
+		EncoderConfig: ladcore.EncoderConfig{
+			MessageKey:             "msg",
+			ConsoleRepeatedContext: ladcore.ConsoleContextElide,
+		},
+		OutputPaths:      []string{all, errs},
+		OutputLevels:     map[string]ladcore.Level{errs: ErrorLevel},
+		ErrorOutputPaths: []string{"stderr"},
+	}
+	logger, err := cfg.Build()
+	require.NoError(t, err, "Unexpected error building logger.")
+
+	child := logger.With(String("req", "42"))
+	child.Info("start")
+	child.Error("boom")
+	require.NoError(t, logger.Sync(), "Unexpected error syncing.")
+
+	for path, want := range map[string]string{
+		all:  "start	{\"req\": \"42\"}\nboom\t{...}\n",
+		errs: "boom\t{\"req\": \"42\"}\n",
+	} {
+		out, err := os.ReadFile(path)
+		require.NoError(t, err, "Unexpected error reading log file.")
+		assert.Equal(t, want, string(out), "Unexpected output in %s.", filepath.Base(path))
+	}
+}
+
 func TestConfigWithInvalidPaths(t *testing.T) {
 	tests := []struct {
 		desc      string
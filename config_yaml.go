@@ -0,0 +1,125 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lad
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+
+	"gopkg.in/yaml.v3"
+)
+
+// _envRef matches ${NAME} and ${NAME:-default} references, as well as $$,
+// which escapes a dollar sign.
+var _envRef = regexp.MustCompile(`\$\$|\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// ConfigFromYAML parses a Config from YAML, such as a file or a Kubernetes
+// ConfigMap, using the same keys as Config's struct tags:
+//
+//	level: ${LOG_LEVEL:-info}
+//	encoding: json
+//	outputPaths: [stdout, "/var/log/${SERVICE}.log"]
+//	initialFields:
+//	  service: ${SERVICE}
+//
+// References to environment variables in scalar values, written ${NAME}, are
+// replaced with the variable's value before decoding, so they may be used in
+// any field, including output paths, encoder settings, sampling, and initial
+// fields. ${NAME:-default} uses default if the variable is unset or empty,
+// and $$ is a literal dollar sign. A reference to an unset variable without a
+// default is an error. Expanded
+// values that weren't quoted are decoded as though they'd been written
+// literally, so "initial: ${SAMPLE_INITIAL}" can produce a number.
+//
+// Fields that aren't set in the YAML keep their zero values; start from
+// NewProductionConfig and use yaml.Unmarshal directly to layer a file over
+// the defaults instead.
+func ConfigFromYAML(data []byte) (Config, error) {
+	var cfg Config
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return cfg, err
+	}
+	if err := expandEnv(&doc); err != nil {
+		return cfg, err
+	}
+	if len(doc.Content) == 0 {
+		// Empty document.
+		return cfg, nil
+	}
+	if err := doc.Decode(&cfg); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// expandEnv replaces environment variable references in the scalar values
+// of n and its descendants. Mapping keys are left alone.
+func expandEnv(n *yaml.Node) error {
+	switch n.Kind {
+	case yaml.ScalarNode:
+		var err error
+		expanded := _envRef.ReplaceAllStringFunc(n.Value, func(ref string) string {
+			if ref == "$$" {
+				return "$"
+			}
+			m := _envRef.FindStringSubmatch(ref)
+			name, hasDefault, def := m[1], m[2] != "", m[3]
+			v, ok := os.LookupEnv(name)
+			switch {
+			case v != "":
+				return v
+			case hasDefault:
+				return def
+			case ok:
+				return ""
+			}
+			if err == nil {
+				err = fmt.Errorf("line %d: environment variable %q isn't set", n.Line, m[1])
+			}
+			return ref
+		})
+		if err != nil {
+			return err
+		}
+		if expanded != n.Value {
+			n.Value = expanded
+			if n.Style == 0 {
+				// Resolve the tag again from the expanded value.
+				n.Tag = ""
+			}
+		}
+	case yaml.MappingNode:
+		for i := 1; i < len(n.Content); i += 2 {
+			if err := expandEnv(n.Content[i]); err != nil {
+				return err
+			}
+		}
+	default:
+		for _, c := range n.Content {
+			if err := expandEnv(c); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lad
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/auwixcom/lad/ladcore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigFromYAML(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("LAD_TEST_DIR", dir)
+	t.Setenv("LAD_TEST_SERVICE", "billing")
+	t.Setenv("LAD_TEST_INITIAL", "5")
+	t.Setenv("LAD_TEST_EMPTY", "")
+
+	cfg, err := ConfigFromYAML([]byte(`
+level: ${LAD_TEST_LEVEL:-warn}
+encoding: json
+encoderConfig:
+  messageKey: msg
+  levelKey: ${LAD_TEST_LEVEL_KEY:-level}
+outputPaths:
+  - ${LAD_TEST_DIR}/${LAD_TEST_SERVICE}.log
+sampling:
+  initial: ${LAD_TEST_INITIAL}
+  thereafter: 10
+initialFields:
+  service: ${LAD_TEST_SERVICE}
+  shard: "${LAD_TEST_INITIAL}"
+  note: "${LAD_TEST_EMPTY}$${literal}"
+  ${LAD_TEST_SERVICE}: key
+`))
+	require.NoError(t, err, "Unexpected error parsing config.")
+
+	assert.Equal(t, WarnLevel, cfg.Level.Level(), "Unexpected level.")
+	assert.Equal(t, "level", cfg.EncoderConfig.LevelKey, "Expected default to be used for unset variable.")
+	assert.Equal(t, []string{filepath.Join(dir, "billing.log")}, cfg.OutputPaths, "Unexpected output paths.")
+	require.NotNil(t, cfg.Sampling, "Expected sampling to be configured.")
+	assert.Equal(t, 5, cfg.Sampling.Initial, "Expected expanded value to decode as a number.")
+	assert.Equal(t, map[string]interface{}{
+		"service":             "billing",
+		"shard":               "5",
+		"note":                "${literal}",
+		"${LAD_TEST_SERVICE}": "key",
+	}, cfg.InitialFields, "Unexpected initial fields.")
+
+	logger, err := cfg.Build()
+	require.NoError(t, err, "Unexpected error building logger.")
+	logger.Warn("hello")
+	require.NoError(t, logger.Sync(), "Unexpected error syncing.")
+
+	out, err := os.ReadFile(filepath.Join(dir, "billing.log"))
+	require.NoError(t, err, "Unexpected error reading log file.")
+	assert.Contains(t, string(out), `"msg":"hello"`, "Expected message in output.")
+	assert.Contains(t, string(out), `"service":"billing"`, "Expected initial field in output.")
+}
+
+func TestConfigFromYAMLErrors(t *testing.T) {
+	tests := []struct {
+		desc    string
+		yaml    string
+		wantErr string
+	}{
+		{
+			desc:    "unset variable",
+			yaml:    "encoding: json\nlevel: ${LAD_TEST_UNSET}",
+			wantErr: `line 2: environment variable "LAD_TEST_UNSET" isn't set`,
+		},
+		{
+			desc:    "invalid YAML",
+			yaml:    "level: [",
+			wantErr: "yaml:",
+		},
+		{
+			desc:    "invalid level",
+			yaml:    "level: loud",
+			wantErr: `unrecognized level: "loud"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			_, err := ConfigFromYAML([]byte(tt.yaml))
+			require.Error(t, err, "Expected an error.")
+			assert.Contains(t, err.Error(), tt.wantErr, "Unexpected error message.")
+		})
+	}
+}
+
+func TestConfigFromYAMLEmpty(t *testing.T) {
+	cfg, err := ConfigFromYAML(nil)
+	require.NoError(t, err, "Unexpected error parsing empty config.")
+	assert.Equal(t, Config{}, cfg, "Expected empty config to be the zero value.")
+	assert.Equal(t, ladcore.EncoderConfig{}, cfg.EncoderConfig, "Unexpected encoder config.")
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lad
+
+import "context"
+
+// A ContextExtractor pulls fields, such as trace IDs, request IDs, or
+// tenants, out of a context.Context. Register extractors on a Logger with
+// WithContextExtractors, then use Logger.WithContext or Ctx to attach the
+// fields they find.
+//
+// Extract must be safe for concurrent use and should return nil if the
+// context carries nothing of interest.
+type ContextExtractor interface {
+	Extract(ctx context.Context) []Field
+}
+
+// ContextExtractorFunc adapts a function into a ContextExtractor.
+type ContextExtractorFunc func(ctx context.Context) []Field
+
+// Extract calls f(ctx).
+func (f ContextExtractorFunc) Extract(ctx context.Context) []Field {
+	return f(ctx)
+}
+
+// WithContextExtractors registers extractors that Logger.WithContext runs,
+// in order, to pull fields from a context.Context. Extractors accumulate
+// across calls and are inherited by child Loggers.
+func WithContextExtractors(extractors ...ContextExtractor) Option {
+	return optionFunc(func(log *Logger) {
+		n := len(log.contextExtractors)
+		log.contextExtractors = append(log.contextExtractors[:n:n], extractors...)
+	})
+}
+
+// WithContext creates a child logger with the fields that the registered
+// ContextExtractors find in ctx. It returns the Logger unchanged if no
+// extractor finds anything, so it's cheap to call on every request:
+//
+//	logger.WithContext(ctx).Info("handled request")
+func (log *Logger) WithContext(ctx context.Context) *Logger {
+	if ctx == nil || len(log.contextExtractors) == 0 {
+		return log
+	}
+	var fields []Field
+	for _, ex := range log.contextExtractors {
+		fields = append(fields, ex.Extract(ctx)...)
+	}
+	return log.With(fields...)
+}
+
+// WithContext creates a child logger with the fields that the registered
+// ContextExtractors find in ctx. See Logger.WithContext.
+func (s *SugaredLogger) WithContext(ctx context.Context) *SugaredLogger {
+	base := s.base.WithContext(ctx)
+	if base == s.base {
+		return s
+	}
+	return &SugaredLogger{base: base}
+}
+
+// Ctx returns the global Logger with the fields its ContextExtractors find
+// in ctx. It's shorthand for L().WithContext(ctx):
+//
+//	lad.Ctx(ctx).Info("charged card", lad.Int("cents", 1999))
+func Ctx(ctx context.Context) *Logger {
+	return L().WithContext(ctx)
+}
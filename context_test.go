@@ -0,0 +1,93 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lad
+
+import (
+	"context"
+	"testing"
+
+	"github.com/auwixcom/lad/ladtest/observer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type ctxKey string
+
+func ctxValueExtractor(key ctxKey) ContextExtractor {
+	return ContextExtractorFunc(func(ctx context.Context) []Field {
+		if v, ok := ctx.Value(key).(string); ok {
+			return []Field{String(string(key), v)}
+		}
+		return nil
+	})
+}
+
+func TestLoggerWithContext(t *testing.T) {
+	ctx := context.WithValue(context.Background(), ctxKey("request_id"), "r1")
+	ctx = context.WithValue(ctx, ctxKey("tenant"), "acme")
+
+	withLogger(t, InfoLevel, opts(
+		WithContextExtractors(ctxValueExtractor("request_id")),
+		WithContextExtractors(ctxValueExtractor("tenant"), ctxValueExtractor("missing")),
+	), func(logger *Logger, logs *observer.ObservedLogs) {
+		logger.With(String("k", "v")).WithContext(ctx).Info("hello")
+		logger.Sugar().WithContext(ctx).Infow("sugared", "n", 1)
+
+		entries := logs.AllUntimed()
+		require.Len(t, entries, 2, "Unexpected number of entries.")
+		assert.Equal(t, []Field{
+			String("k", "v"),
+			String("request_id", "r1"),
+			String("tenant", "acme"),
+		}, entries[0].Context, "Unexpected fields.")
+		assert.Equal(t, []Field{
+			String("request_id", "r1"),
+			String("tenant", "acme"),
+			Int("n", 1),
+		}, entries[1].Context, "Unexpected sugared fields.")
+	})
+}
+
+func TestLoggerWithContextNoFields(t *testing.T) {
+	withLogger(t, InfoLevel, opts(WithContextExtractors(ctxValueExtractor("request_id"))), func(logger *Logger, _ *observer.ObservedLogs) {
+		assert.Same(t, logger, logger.WithContext(context.Background()), "Expected the same logger without extracted fields.")
+		assert.Same(t, logger, logger.WithContext(nil), "Expected the same logger for a nil context.") //nolint:staticcheck // testing nil
+
+		sugar := logger.Sugar()
+		assert.Same(t, sugar, sugar.WithContext(context.Background()), "Expected the same sugared logger without extracted fields.")
+	})
+	withLogger(t, InfoLevel, nil, func(logger *Logger, _ *observer.ObservedLogs) {
+		ctx := context.WithValue(context.Background(), ctxKey("request_id"), "r1")
+		assert.Same(t, logger, logger.WithContext(ctx), "Expected the same logger without extractors.")
+	})
+}
+
+func TestCtx(t *testing.T) {
+	withLogger(t, InfoLevel, opts(WithContextExtractors(ctxValueExtractor("request_id"))), func(logger *Logger, logs *observer.ObservedLogs) {
+		defer ReplaceGlobals(logger)()
+
+		Ctx(context.WithValue(context.Background(), ctxKey("request_id"), "r1")).Info("hello")
+
+		entries := logs.AllUntimed()
+		require.Len(t, entries, 1, "Unexpected number of entries.")
+		assert.Equal(t, []Field{String("request_id", "r1")}, entries[0].Context, "Unexpected fields.")
+	})
+}
@@ -0,0 +1,376 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lad
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+	"unicode/utf8"
+
+	"github.com/auwixcom/lad/internal/exit"
+	"github.com/auwixcom/lad/ladcore"
+)
+
+// _defaultCrashRecent is the default number of recent entries a
+// CrashHandler includes in its final entry.
+const _defaultCrashRecent = 32
+
+var (
+	// _defaultCrashSignals are the signals a CrashHandler handles by default.
+	_defaultCrashSignals = []os.Signal{syscall.SIGABRT, syscall.SIGSEGV, syscall.SIGBUS}
+
+	// _crashReraise is stubbed in tests.
+	_crashReraise = reraise
+)
+
+// A CrashHandler writes a final structured entry when the process crashes,
+// capturing context that's otherwise lost when a crash only reaches stderr.
+// The entry records the reason for the crash, the program's build
+// information, and the most recent entries logged through cores wrapped with
+// WrapCore:
+//
+//	f, err := os.OpenFile("/var/log/app/crash.json", os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+//	// ...
+//	h := &lad.CrashHandler{Output: f}
+//	logger := lad.New(core, lad.WrapCore(h.WrapCore))
+//	h.Start()
+//	defer h.Stop()
+//	defer h.Recover()
+//
+// Start handles fatal signals delivered to the process, such as a SIGABRT
+// from a watchdog. After writing the final entry, the handler restores the
+// signal's default behavior and re-raises it. Faults in Go code surface as
+// panics rather than signals; Recover handles those.
+//
+// The output is opened ahead of time and recent entries are kept
+// pre-encoded, so that writing the final entry needs little more than a
+// single Write while the process is in a bad state. The final entry is
+// assembled without taking the handler's locks or calling into
+// encoding/json, but it still reads the recent entries through Storage, so
+// it can't be written from a goroutine that crashed while appending to
+// Storage. Signals are handled by Go's os/signal package on an ordinary
+// goroutine, not in an asynchronous signal handler.
+type CrashHandler struct {
+	// Output receives the final entry as a line of JSON.
+	//
+	// This field is required.
+	Output ladcore.WriteSyncer
+
+	// Recent is the number of recent entries included in the final entry.
+	// It's ignored if Storage is specified.
+	//
+	// Defaults to 32 if unspecified.
+	Recent int
+
+	// Storage holds the recent entries. Use a FileRing to keep them
+	// recoverable even if the process dies before the final entry is
+	// written.
+	//
+	// Defaults to an in-memory ring of Recent entries.
+	Storage RingStorage
+
+	// Signals are the signals handled by Start.
+	//
+	// Defaults to SIGABRT, SIGSEGV, and SIGBUS if unspecified.
+	Signals []os.Signal
+
+	// Clock, if specified, provides control of the source of time for the
+	// final entry.
+	//
+	// Defaults to the system clock.
+	Clock ladcore.Clock
+
+	once    sync.Once
+	crashed int32       // atomic
+	ring    RingStorage // pre-encoded recent entries
+	build   []byte      // pre-encoded build information
+	buf     []byte      // space for the final entry
+
+	mu sync.Mutex // guards the signal handling state below
+
+	sigs    chan os.Signal
+	stop    chan struct{}
+	done    chan struct{}
+	started bool
+	stopped bool
+}
+
+func (h *CrashHandler) initialize() {
+	h.ring = h.Storage
+	if h.ring == nil {
+		h.ring = NewMemoryRing(h.Recent)
+	}
+	h.build = encodeBuildInfo()
+	h.buf = make([]byte, 0, 64<<10)
+}
+
+// WrapCore returns a core that records the entries written to core, so that
+// the most recent of them are included in the final entry. It's meant to be
+// used with the WrapCore option.
+//
+// Recording isn't free: every entry that core enables is encoded a second
+// time, as JSON, and appended to Storage, which serializes the appends of
+// all the wrapped cores. Wrap only the cores whose entries are worth that
+// cost, or raise their level.
+func (h *CrashHandler) WrapCore(core ladcore.Core) ladcore.Core {
+	h.once.Do(h.initialize)
+	return ladcore.NewTee(core, &crashRingCore{
+		LevelEnabler: core,
+		enc:          ladcore.NewJSONEncoder(NewProductionEncoderConfig()),
+		ring:         h.ring,
+	})
+}
+
+// Start starts handling fatal signals.
+func (h *CrashHandler) Start() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.started || h.stopped {
+		return
+	}
+	h.started = true
+	h.once.Do(h.initialize)
+
+	signals := h.Signals
+	if len(signals) == 0 {
+		signals = _defaultCrashSignals
+	}
+	h.sigs = make(chan os.Signal, 1)
+	h.stop = make(chan struct{})
+	h.done = make(chan struct{})
+	signal.Notify(h.sigs, signals...)
+	go h.handleSignals()
+}
+
+// Stop stops handling fatal signals. It doesn't close Output.
+func (h *CrashHandler) Stop() {
+	h.mu.Lock()
+	if !h.started || h.stopped {
+		h.stopped = true
+		h.mu.Unlock()
+		return
+	}
+	h.stopped = true
+	h.mu.Unlock()
+
+	signal.Stop(h.sigs)
+	close(h.stop)
+	<-h.done
+}
+
+func (h *CrashHandler) handleSignals() {
+	defer close(h.done)
+
+	select {
+	case sig := <-h.sigs:
+		h.crash("signal: "+sig.String(), nil)
+		signal.Reset(sig)
+		_crashReraise(sig)
+	case <-h.stop:
+	}
+}
+
+// reraise sends sig to the current process so that its default action
+// applies, exiting if that isn't possible.
+func reraise(sig os.Signal) {
+	if p, err := os.FindProcess(os.Getpid()); err == nil && p.Signal(sig) == nil {
+		// Give the default action a chance to take effect.
+		time.Sleep(time.Second)
+	}
+	exit.With(2)
+}
+
+// Recover writes the final entry if the calling goroutine is panicking, then
+// continues panicking. It must be deferred directly:
+//
+//	defer h.Recover()
+func (h *CrashHandler) Recover() {
+	if r := recover(); r != nil {
+		h.crash(fmt.Sprintf("panic: %v", r), debug.Stack())
+		panic(r)
+	}
+}
+
+// Crash writes the final entry with the given reason. Only the first crash
+// is recorded; later calls are no-ops.
+func (h *CrashHandler) Crash(reason string) {
+	h.crash(reason, nil)
+}
+
+func (h *CrashHandler) crash(reason string, stack []byte) {
+	if !atomic.CompareAndSwapInt32(&h.crashed, 0, 1) {
+		return
+	}
+	h.once.Do(h.initialize)
+
+	now := ladcore.DefaultClock.Now()
+	if h.Clock != nil {
+		now = h.Clock.Now()
+	}
+
+	buf := append(h.buf[:0], `{"level":"fatal","ts":`...)
+	buf = appendJSONString(buf, now.UTC().AppendFormat(nil, time.RFC3339Nano))
+	buf = append(buf, `,"msg":"crash","reason":`...)
+	buf = appendJSONString(buf, []byte(reason))
+	if len(stack) > 0 {
+		buf = append(buf, `,"stacktrace":`...)
+		buf = appendJSONString(buf, stack)
+	}
+	if len(h.build) > 0 {
+		buf = append(buf, `,"build":`...)
+		buf = append(buf, h.build...)
+	}
+	buf = append(buf, `,"recent":[`...)
+	first := true
+	h.ring.Range(func(entry []byte) {
+		if !first {
+			buf = append(buf, ',')
+		}
+		first = false
+		buf = append(buf, entry...)
+	})
+	buf = append(buf, "]}\n"...)
+	h.buf = buf
+
+	_, _ = h.Output.Write(buf)
+	_ = h.Output.Sync()
+}
+
+// appendJSONString appends s to buf as a quoted JSON string, replacing
+// invalid UTF-8 with the replacement character. Unlike encoding/json, it
+// doesn't allocate beyond growing buf.
+func appendJSONString(buf []byte, s []byte) []byte {
+	const hex = "0123456789abcdef"
+
+	buf = append(buf, '"')
+	for i := 0; i < len(s); {
+		b := s[i]
+		if b >= utf8.RuneSelf {
+			r, size := utf8.DecodeRune(s[i:])
+			if r == utf8.RuneError && size == 1 {
+				buf = append(buf, `\ufffd`...)
+			} else {
+				buf = append(buf, s[i:i+size]...)
+			}
+			i += size
+			continue
+		}
+		switch {
+		case b == '"' || b == '\\':
+			buf = append(buf, '\\', b)
+		case b == '\n':
+			buf = append(buf, '\\', 'n')
+		case b == '\r':
+			buf = append(buf, '\\', 'r')
+		case b == '\t':
+			buf = append(buf, '\\', 't')
+		case b < 0x20:
+			buf = append(buf, '\\', 'u', '0', '0', hex[b>>4], hex[b&0xf])
+		default:
+			buf = append(buf, b)
+		}
+		i++
+	}
+	return append(buf, '"')
+}
+
+func encodeBuildInfo() []byte {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return nil
+	}
+	build := map[string]string{
+		"go":      info.GoVersion,
+		"path":    info.Main.Path,
+		"version": info.Main.Version,
+	}
+	for _, s := range info.Settings {
+		switch s.Key {
+		case "vcs.revision", "vcs.time", "vcs.modified":
+			build[s.Key] = s.Value
+		}
+	}
+	b, err := json.Marshal(build)
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+// crashRingCore encodes entries into a CrashHandler's storage of recent
+// entries.
+type crashRingCore struct {
+	ladcore.LevelEnabler
+
+	enc  ladcore.Encoder
+	ring RingStorage
+}
+
+var _ ladcore.Core = (*crashRingCore)(nil)
+
+func (c *crashRingCore) Level() ladcore.Level {
+	return ladcore.LevelOf(c.LevelEnabler)
+}
+
+func (c *crashRingCore) With(fields []Field) ladcore.Core {
+	enc := c.enc.Clone()
+	for i := range fields {
+		fields[i].AddTo(enc)
+	}
+	return &crashRingCore{
+		LevelEnabler: c.LevelEnabler,
+		enc:          enc,
+		ring:         c.ring,
+	}
+}
+
+func (c *crashRingCore) Check(ent ladcore.Entry, ce *ladcore.CheckedEntry) *ladcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *crashRingCore) Write(ent ladcore.Entry, fields []Field) error {
+	buf, err := c.enc.EncodeEntry(ent, fields)
+	if err != nil {
+		return err
+	}
+	defer buf.Free()
+
+	entry := buf.Bytes()
+	if n := len(entry); n > 0 && entry[n-1] == '\n' {
+		entry = entry[:n-1]
+	}
+	return c.ring.Append(entry)
+}
+
+func (c *crashRingCore) Sync() error {
+	return nil
+}
@@ -0,0 +1,185 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lad
+
+import (
+	"encoding/json"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/auwixcom/lad/internal/ztest"
+	"github.com/auwixcom/lad/ladcore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type crashEntry struct {
+	Level      string                   `json:"level"`
+	TS         string                   `json:"ts"`
+	Msg        string                   `json:"msg"`
+	Reason     string                   `json:"reason"`
+	Stacktrace string                   `json:"stacktrace"`
+	Recent     []map[string]interface{} `json:"recent"`
+}
+
+func decodeCrash(t *testing.T, buf *ztest.Buffer) crashEntry {
+	lines := buf.Lines()
+	require.Len(t, lines, 1, "Expected exactly one crash entry.")
+	var ent crashEntry
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &ent), "Crash entry isn't valid JSON.")
+	return ent
+}
+
+func TestCrashHandlerRecent(t *testing.T) {
+	out := &ztest.Buffer{}
+	clock := ztest.NewMockClock()
+	h := &CrashHandler{Output: out, Recent: 2, Clock: clock}
+
+	core := ladcore.NewCore(ladcore.NewJSONEncoder(NewProductionEncoderConfig()), &ztest.Discarder{}, DebugLevel)
+	logger := New(core, WrapCore(h.WrapCore)).With(String("svc", "api"))
+	logger.Info("one")
+	logger.Info("two")
+	logger.Warn("three", Int("n", 3))
+
+	h.Crash("out of memory")
+	h.Crash("ignored")
+
+	ent := decodeCrash(t, out)
+	assert.Equal(t, "fatal", ent.Level, "Unexpected level.")
+	assert.Equal(t, "crash", ent.Msg, "Unexpected message.")
+	assert.Equal(t, "out of memory", ent.Reason, "Unexpected reason.")
+	assert.Equal(t, clock.Now().UTC().Format(time.RFC3339Nano), ent.TS, "Unexpected timestamp.")
+	require.Len(t, ent.Recent, 2, "Expected only the most recent entries.")
+	assert.Equal(t, "two", ent.Recent[0]["msg"], "Expected recent entries oldest first.")
+	assert.Equal(t, "three", ent.Recent[1]["msg"], "Expected recent entries oldest first.")
+	assert.Equal(t, "api", ent.Recent[1]["svc"], "Expected context fields in recent entries.")
+	assert.Equal(t, float64(3), ent.Recent[1]["n"], "Expected fields in recent entries.")
+}
+
+func TestCrashHandlerRecentLevel(t *testing.T) {
+	out := &ztest.Buffer{}
+	h := &CrashHandler{Output: out}
+
+	core := ladcore.NewCore(ladcore.NewJSONEncoder(NewProductionEncoderConfig()), &ztest.Discarder{}, WarnLevel)
+	logger := New(core, WrapCore(h.WrapCore))
+	logger.Info("skipped")
+	logger.Warn("kept")
+	assert.Equal(t, WarnLevel, logger.Level(), "Expected wrapping not to change the logger's level.")
+
+	h.Crash("boom")
+	ent := decodeCrash(t, out)
+	require.Len(t, ent.Recent, 1, "Expected only enabled entries to be recorded.")
+	assert.Equal(t, "kept", ent.Recent[0]["msg"], "Unexpected recent entry.")
+}
+
+func TestCrashHandlerRecover(t *testing.T) {
+	out := &ztest.Buffer{}
+	h := &CrashHandler{Output: out}
+
+	assert.PanicsWithValue(t, "oops", func() {
+		defer h.Recover()
+		panic("oops")
+	}, "Expected Recover to continue panicking.")
+
+	ent := decodeCrash(t, out)
+	assert.Equal(t, "panic: oops", ent.Reason, "Unexpected reason.")
+	assert.Contains(t, ent.Stacktrace, "TestCrashHandlerRecover", "Expected the panicking goroutine's stack.")
+	assert.Empty(t, ent.Recent, "Expected no recent entries.")
+
+	assert.NotPanics(t, func() {
+		defer h.Recover()
+	}, "Expected Recover to do nothing without a panic.")
+}
+
+func TestCrashHandlerSignal(t *testing.T) {
+	reraised := make(chan os.Signal, 1)
+	defer func(f func(os.Signal)) { _crashReraise = f }(_crashReraise)
+	_crashReraise = func(sig os.Signal) { reraised <- sig }
+
+	out := &ztest.Buffer{}
+	h := &CrashHandler{Output: out}
+	h.Start()
+	h.sigs <- syscall.SIGABRT
+
+	select {
+	case sig := <-reraised:
+		assert.Equal(t, syscall.SIGABRT, sig, "Expected the signal to be re-raised.")
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the signal to be handled.")
+	}
+	h.Stop()
+
+	ent := decodeCrash(t, out)
+	assert.Equal(t, "signal: "+syscall.SIGABRT.String(), ent.Reason, "Unexpected reason.")
+}
+
+func TestCrashHandlerStop(t *testing.T) {
+	h := &CrashHandler{Output: &ztest.Buffer{}}
+	h.Stop() // stopping before starting is a no-op
+	h.Start()
+	assert.False(t, h.started, "Expected Start after Stop to be a no-op.")
+
+	h = &CrashHandler{Output: &ztest.Buffer{}}
+	h.Start()
+	h.Start()
+	h.Stop()
+	h.Stop()
+}
+
+func TestCrashHandlerRecentDynamicLevel(t *testing.T) {
+	out := &ztest.Buffer{}
+	h := &CrashHandler{Output: out}
+
+	lvl := NewAtomicLevelAt(WarnLevel)
+	core := ladcore.NewCore(ladcore.NewJSONEncoder(NewProductionEncoderConfig()), &ztest.Discarder{}, lvl)
+	logger := New(core, WrapCore(h.WrapCore))
+	logger.Info("skipped")
+	lvl.SetLevel(InfoLevel)
+	logger.Info("kept")
+
+	h.Crash("boom")
+	ent := decodeCrash(t, out)
+	require.Len(t, ent.Recent, 1, "Expected recording to follow the wrapped core's level.")
+	assert.Equal(t, "kept", ent.Recent[0]["msg"], "Unexpected recent entry.")
+}
+
+func TestCrashAppendJSONString(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"plain",
+		`quote " and backslash \`,
+		"new\nline\ttab\rreturn",
+		"control \x00\x01\x1f",
+		"unicode ✓ 日本",
+		"invalid \xff\xfe utf-8",
+	} {
+		got := appendJSONString(nil, []byte(s))
+		var decoded string
+		require.NoError(t, json.Unmarshal(got, &decoded), "Expected valid JSON for %q.", s)
+		want, err := json.Marshal(s)
+		require.NoError(t, err, "Unexpected error marshaling %q.", s)
+		var wantDecoded string
+		require.NoError(t, json.Unmarshal(want, &wantDecoded), "Unexpected error unmarshaling %q.", s)
+		assert.Equal(t, wantDecoded, decoded, "Unexpected round trip for %q.", s)
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lad
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/auwixcom/lad/ladcore"
+)
+
+// _diffMaxDepth bounds how far Diff descends into nested values, which also
+// protects it from cyclic data structures.
+const _diffMaxDepth = 32
+
+// Diff constructs a field that records how after differs from before. Maps,
+// structs, slices, and arrays are compared member by member, and each
+// difference is logged as an object with the dotted path of the member, the
+// operation ("add", "remove", or "change"), and the before and after values.
+// For example, diffing two versions of a configuration struct might produce:
+//
+//	"changes": [
+//	  {"path": "Replicas", "op": "change", "before": 3, "after": 5},
+//	  {"path": "Labels.team", "op": "add", "after": "payments"}
+//	]
+//
+// Struct members are named by their JSON tags where present, and unexported
+// struct fields are ignored. Values are compared lazily, when the field is
+// encoded, and encoded with AddReflected.
+func Diff(key string, before, after any) Field {
+	return Array(key, diffValues{before: before, after: after})
+}
+
+type diffValues struct {
+	before, after any
+}
+
+func (d diffValues) MarshalLogArray(arr ladcore.ArrayEncoder) error {
+	var changes []diffChange
+	diffWalk(&changes, "", reflect.ValueOf(d.before), reflect.ValueOf(d.after), 0)
+
+	var err error
+	for i := range changes {
+		if e := arr.AppendObject(&changes[i]); e != nil && err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
+type diffOp string
+
+const (
+	_diffAdd    diffOp = "add"
+	_diffRemove diffOp = "remove"
+	_diffChange diffOp = "change"
+)
+
+type diffChange struct {
+	path          string
+	op            diffOp
+	before, after reflect.Value
+}
+
+func (c *diffChange) MarshalLogObject(enc ladcore.ObjectEncoder) error {
+	if c.path != "" {
+		enc.AddString("path", c.path)
+	}
+	enc.AddString("op", string(c.op))
+	if c.op != _diffAdd {
+		if err := enc.AddReflected("before", diffInterface(c.before)); err != nil {
+			return err
+		}
+	}
+	if c.op != _diffRemove {
+		return enc.AddReflected("after", diffInterface(c.after))
+	}
+	return nil
+}
+
+func diffInterface(v reflect.Value) any {
+	if !v.IsValid() {
+		return nil
+	}
+	return v.Interface()
+}
+
+func diffWalk(changes *[]diffChange, path string, before, after reflect.Value, depth int) {
+	before, after = diffIndirect(before), diffIndirect(after)
+	switch {
+	case !before.IsValid() && !after.IsValid():
+		return
+	case !before.IsValid() || !after.IsValid() || before.Type() != after.Type() || depth >= _diffMaxDepth:
+		diffLeaf(changes, path, before, after)
+		return
+	}
+
+	switch before.Kind() {
+	case reflect.Struct:
+		if !diffHasExportedFields(before.Type()) {
+			diffLeaf(changes, path, before, after)
+			return
+		}
+		t := before.Type()
+		for i := 0; i < t.NumField(); i++ {
+			name, ok := diffFieldName(t.Field(i))
+			if !ok {
+				continue
+			}
+			diffWalk(changes, diffJoin(path, name), before.Field(i), after.Field(i), depth+1)
+		}
+	case reflect.Map:
+		// Match keys by value rather than by how they print, so that keys
+		// like 1 and "1" in a map[any]T stay distinct.
+		seen := make(map[any]struct{})
+		var keys []diffMapKey
+		for _, ks := range [][]reflect.Value{before.MapKeys(), after.MapKeys()} {
+			for _, k := range ks {
+				if _, ok := seen[k.Interface()]; ok {
+					continue
+				}
+				seen[k.Interface()] = struct{}{}
+				keys = append(keys, diffMapKey{name: fmt.Sprint(k.Interface()), typ: fmt.Sprintf("%T", k.Interface()), key: k})
+			}
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			if keys[i].name != keys[j].name {
+				return keys[i].name < keys[j].name
+			}
+			return keys[i].typ < keys[j].typ
+		})
+		for _, k := range keys {
+			diffWalk(changes, diffJoin(path, k.name), before.MapIndex(k.key), after.MapIndex(k.key), depth+1)
+		}
+	case reflect.Slice, reflect.Array:
+		n := before.Len()
+		if after.Len() > n {
+			n = after.Len()
+		}
+		for i := 0; i < n; i++ {
+			var b, a reflect.Value
+			if i < before.Len() {
+				b = before.Index(i)
+			}
+			if i < after.Len() {
+				a = after.Index(i)
+			}
+			diffWalk(changes, path+"["+strconv.Itoa(i)+"]", b, a, depth+1)
+		}
+	default:
+		diffLeaf(changes, path, before, after)
+	}
+}
+
+// diffMapKey is a key of a map being diffed, with how it appears in paths.
+type diffMapKey struct {
+	name string
+	typ  string // breaks ties between keys that print alike
+	key  reflect.Value
+}
+
+func diffLeaf(changes *[]diffChange, path string, before, after reflect.Value) {
+	switch {
+	case !before.IsValid():
+		*changes = append(*changes, diffChange{path: path, op: _diffAdd, after: after})
+	case !after.IsValid():
+		*changes = append(*changes, diffChange{path: path, op: _diffRemove, before: before})
+	case !reflect.DeepEqual(before.Interface(), after.Interface()):
+		*changes = append(*changes, diffChange{path: path, op: _diffChange, before: before, after: after})
+	}
+}
+
+// diffIndirect follows pointers and interfaces, treating nil as absent.
+func diffIndirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+func diffHasExportedFields(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if _, ok := diffFieldName(t.Field(i)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func diffFieldName(f reflect.StructField) (string, bool) {
+	if !f.IsExported() {
+		return "", false
+	}
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+	if name, _, _ := strings.Cut(tag, ","); name != "" {
+		return name, true
+	}
+	return f.Name, true
+}
+
+func diffJoin(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
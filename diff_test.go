@@ -0,0 +1,142 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lad
+
+import (
+	"testing"
+	"time"
+
+	"github.com/auwixcom/lad/ladcore"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	type limits struct {
+		CPU    string `json:"cpu"`
+		Memory string `json:"memory,omitempty"`
+	}
+	type spec struct {
+		Replicas int
+		Labels   map[string]string
+		Limits   *limits `json:"limits"`
+		Ports    []int
+		Ignored  string `json:"-"`
+		hidden   string
+	}
+
+	moment := time.Unix(0, 0).UTC()
+	tests := []struct {
+		desc          string
+		before, after interface{}
+		want          []interface{}
+	}{
+		{
+			desc:   "equal",
+			before: spec{Replicas: 1, hidden: "a"},
+			after:  spec{Replicas: 1, hidden: "b"},
+			want:   []interface{}{},
+		},
+		{
+			desc: "structs",
+			before: spec{
+				Replicas: 3,
+				Labels:   map[string]string{"env": "prod", "old": "x"},
+				Limits:   &limits{CPU: "1", Memory: "1Gi"},
+				Ports:    []int{80, 443},
+				Ignored:  "a",
+			},
+			after: &spec{
+				Replicas: 5,
+				Labels:   map[string]string{"env": "prod", "team": "payments"},
+				Limits:   &limits{CPU: "2", Memory: "1Gi"},
+				Ports:    []int{80},
+				Ignored:  "b",
+			},
+			want: []interface{}{
+				map[string]interface{}{"path": "Replicas", "op": "change", "before": 3, "after": 5},
+				map[string]interface{}{"path": "Labels.old", "op": "remove", "before": "x"},
+				map[string]interface{}{"path": "Labels.team", "op": "add", "after": "payments"},
+				map[string]interface{}{"path": "limits.cpu", "op": "change", "before": "1", "after": "2"},
+				map[string]interface{}{"path": "Ports[1]", "op": "remove", "before": 443},
+			},
+		},
+		{
+			desc:   "nil pointer",
+			before: spec{},
+			after:  spec{Limits: &limits{CPU: "1"}},
+			want: []interface{}{
+				map[string]interface{}{"path": "limits", "op": "add", "after": limits{CPU: "1"}},
+			},
+		},
+		{
+			desc:   "opaque structs",
+			before: map[string]interface{}{"at": moment},
+			after:  map[string]interface{}{"at": moment.Add(time.Second)},
+			want: []interface{}{
+				map[string]interface{}{"path": "at", "op": "change", "before": moment, "after": moment.Add(time.Second)},
+			},
+		},
+		{
+			desc:   "keys that print alike",
+			before: map[interface{}]int{1: 1, "1": 2},
+			after:  map[interface{}]int{1: 4, "1": 3},
+			want: []interface{}{
+				map[string]interface{}{"path": "1", "op": "change", "before": 1, "after": 4},
+				map[string]interface{}{"path": "1", "op": "change", "before": 2, "after": 3},
+			},
+		},
+		{
+			desc:   "mismatched types",
+			before: 1,
+			after:  "1",
+			want: []interface{}{
+				map[string]interface{}{"op": "change", "before": 1, "after": "1"},
+			},
+		},
+		{
+			desc:   "nil before",
+			before: nil,
+			after:  2,
+			want: []interface{}{
+				map[string]interface{}{"op": "add", "after": 2},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			enc := ladcore.NewMapObjectEncoder()
+			Diff("changes", tt.before, tt.after).AddTo(enc)
+			assert.Equal(t, tt.want, enc.Fields["changes"], "Unexpected diff.")
+		})
+	}
+}
+
+func TestDiffCycle(t *testing.T) {
+	type node struct{ Next *node }
+	a, b := &node{}, &node{}
+	a.Next, b.Next = a, b
+
+	enc := ladcore.NewMapObjectEncoder()
+	assert.NotPanics(t, func() {
+		Diff("changes", a, b).AddTo(enc)
+	}, "Expected cyclic values not to recurse forever.")
+}
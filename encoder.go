@@ -33,18 +33,26 @@ var (
 
 	_encoderNameToConstructor = map[string]func(ladcore.EncoderConfig) (ladcore.Encoder, error){
 		"console": func(encoderConfig ladcore.EncoderConfig) (ladcore.Encoder, error) {
+			for _, h := range encoderConfig.ConsoleHighlights {
+				if err := h.Validate(); err != nil {
+					return nil, err
+				}
+			}
 			return ladcore.NewConsoleEncoder(encoderConfig), nil
 		},
 		"json": func(encoderConfig ladcore.EncoderConfig) (ladcore.Encoder, error) {
 			return ladcore.NewJSONEncoder(encoderConfig), nil
 		},
+		"msgpack": func(encoderConfig ladcore.EncoderConfig) (ladcore.Encoder, error) {
+			return ladcore.NewMsgpackEncoder(encoderConfig), nil
+		},
 	}
 	_encoderMutex sync.RWMutex
 )
 
 // RegisterEncoder registers an encoder constructor, which the Config struct
-// can then reference. By default, the "json" and "console" encoders are
-// registered.
+// can then reference. By default, the "json", "console", and "msgpack"
+// encoders are registered.
 //
 // Attempting to register an encoder whose name is already taken returns an
 // error.
@@ -29,7 +29,7 @@ import (
 )
 
 func TestRegisterDefaultEncoders(t *testing.T) {
-	testEncodersRegistered(t, "console", "json")
+	testEncodersRegistered(t, "console", "json", "msgpack")
 }
 
 func TestRegisterEncoder(t *testing.T) {
@@ -69,6 +69,13 @@ func TestNewEncoderNoName(t *testing.T) {
 	assert.Equal(t, errNoEncoderNameSpecified, err, "expected an error when creating an encoder with no name")
 }
 
+func TestNewConsoleEncoderInvalidHighlight(t *testing.T) {
+	_, err := newEncoder("console", ladcore.EncoderConfig{
+		ConsoleHighlights: []ladcore.ConsoleHighlight{{Message: "(", Bold: true}},
+	})
+	assert.ErrorContains(t, err, "invalid highlight message pattern", "expected an error for an invalid highlight rule")
+}
+
 func testEncoders(f func()) {
 	existing := _encoderNameToConstructor
 	_encoderNameToConstructor = make(map[string]func(ladcore.EncoderConfig) (ladcore.Encoder, error))
@@ -21,6 +21,10 @@
 package lad
 
 import (
+	"errors"
+	"fmt"
+	"reflect"
+
 	"github.com/auwixcom/lad/internal/pool"
 	"github.com/auwixcom/lad/ladcore"
 )
@@ -51,6 +55,21 @@ func NamedError(key string, err error) Field {
 type errArray []error
 
 func (errs errArray) MarshalLogArray(arr ladcore.ArrayEncoder) error {
+	return appendErrors(arr, errs, 0)
+}
+
+// errCauses is the causes of a joined error, depth levels below an element
+// of an Errors array.
+type errCauses struct {
+	errs  []error
+	depth int
+}
+
+func (c errCauses) MarshalLogArray(arr ladcore.ArrayEncoder) error {
+	return appendErrors(arr, c.errs, c.depth)
+}
+
+func appendErrors(arr ladcore.ArrayEncoder, errs []error, depth int) error {
 	for i := range errs {
 		if errs[i] == nil {
 			continue
@@ -61,6 +80,7 @@ func (errs errArray) MarshalLogArray(arr ladcore.ArrayEncoder) error {
 		// allocating, pool the wrapper type.
 		elem := _errArrayElemPool.Get()
 		elem.error = errs[i]
+		elem.depth = depth
 		err := arr.AppendObject(elem)
 		elem.error = nil
 		_errArrayElemPool.Put(elem)
@@ -73,10 +93,139 @@ func (errs errArray) MarshalLogArray(arr ladcore.ArrayEncoder) error {
 
 type errArrayElem struct {
 	error
+	depth int
 }
 
 func (e *errArrayElem) MarshalLogObject(enc ladcore.ObjectEncoder) error {
 	// Re-use the error field's logic, which supports non-standard error types.
 	Error(e.error).AddTo(enc)
+	return addErrorChain(enc, e.error, e.depth)
+}
+
+// _maxErrorChain bounds how many wrapped errors are followed, in case an
+// error's Unwrap method returns the error itself.
+const _maxErrorChain = 32
+
+// _maxErrorDepth bounds how deeply errorCauses nest, in case a joined error
+// lists itself among its causes.
+const _maxErrorDepth = 8
+
+// addErrorChain adds what errors.Unwrap reveals about err to an element of
+// an Errors array:
+//
+//	{
+//	  "error": err.Error(),
+//	  "errorChain": [{"type": "*fmt.wrapError", "error": ...}, ...],
+//	  "errorCauses": [...],
+//	  "stacktrace": ...,
+//	}
+//
+// errorChain lists err and the errors it wraps, outermost first, and is
+// omitted if err doesn't wrap anything. If the chain ends in an error that
+// wraps several others, like those built by errors.Join, errorCauses holds
+// one element per cause, encoded the same way. stacktrace holds the stack of
+// the innermost error in the chain that records one, like those built by
+// github.com/pkg/errors.
+//
+// depth counts the errorCauses arrays that enclose the element; causes
+// nested more than _maxErrorDepth deep are omitted.
+func addErrorChain(enc ladcore.ObjectEncoder, err error, depth int) error {
+	chain := errChain{err}
+	for len(chain) < _maxErrorChain {
+		next := unwrapError(chain[len(chain)-1])
+		if next == nil {
+			break
+		}
+		chain = append(chain, next)
+	}
+
+	if len(chain) > 1 {
+		if aerr := enc.AddArray("errorChain", chain); aerr != nil {
+			return aerr
+		}
+	}
+
+	// Errors that implement errorGroup, like those from go.uber.org/multierr,
+	// already have their causes encoded.
+	last := chain[len(chain)-1]
+	if joined, ok := last.(interface{ Unwrap() []error }); ok && depth < _maxErrorDepth {
+		if _, ok := err.(interface{ Errors() []error }); !ok || len(chain) > 1 {
+			causes := errCauses{errs: joined.Unwrap(), depth: depth + 1}
+			if aerr := enc.AddArray("errorCauses", causes); aerr != nil {
+				return aerr
+			}
+		}
+	}
+
+	for i := len(chain) - 1; i >= 0; i-- {
+		if st, ok := errorStackTrace(chain[i]); ok {
+			enc.AddString("stacktrace", st)
+			break
+		}
+	}
 	return nil
 }
+
+// errChain is an error and the errors it wraps, outermost first.
+type errChain []error
+
+func (c errChain) MarshalLogArray(arr ladcore.ArrayEncoder) error {
+	for _, err := range c {
+		err := err
+		if aerr := arr.AppendObject(ladcore.ObjectMarshalerFunc(func(enc ladcore.ObjectEncoder) error {
+			msg, merr := errorMessage(err)
+			enc.AddString("type", fmt.Sprintf("%T", err))
+			enc.AddString("error", msg)
+			return merr
+		})); aerr != nil {
+			return aerr
+		}
+	}
+	return nil
+}
+
+// errorMessage calls err.Error(), recovering from panics the way the Error
+// field does: a nil pointer reads as "<nil>", and other panics are reported
+// as errors.
+func errorMessage(err error) (msg string, retErr error) {
+	defer func() {
+		if rerr := recover(); rerr != nil {
+			if v := reflect.ValueOf(err); v.Kind() == reflect.Ptr && v.IsNil() {
+				msg = "<nil>"
+				return
+			}
+			retErr = fmt.Errorf("PANIC=%v", rerr)
+		}
+	}()
+	return err.Error(), nil
+}
+
+// unwrapError is errors.Unwrap, treating an Unwrap method that panics (like
+// one called on a nil pointer) as wrapping nothing.
+func unwrapError(err error) (next error) {
+	defer func() {
+		if recover() != nil {
+			next = nil
+		}
+	}()
+	return errors.Unwrap(err)
+}
+
+// errorStackTrace formats the stack recorded by err, if it has a
+// StackTrace method like those of github.com/pkg/errors.
+func errorStackTrace(err error) (st string, ok bool) {
+	v := reflect.ValueOf(err)
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return "", false
+	}
+	m := v.MethodByName("StackTrace")
+	if !m.IsValid() || m.Type().NumIn() != 0 || m.Type().NumOut() != 1 {
+		return "", false
+	}
+	defer func() {
+		if recover() != nil {
+			st, ok = "", false
+		}
+	}()
+	return fmt.Sprintf("%+v", m.Call(nil)[0].Interface()), true
+}
@@ -29,6 +29,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/multierr"
 )
 
 func TestErrorConstructors(t *testing.T) {
@@ -96,6 +97,141 @@ func TestErrorsArraysHandleRichErrors(t *testing.T) {
 	assert.Equal(t, "egad", errMap["error"], "Unexpected standard error string.")
 }
 
+type stackError struct {
+	msg   string
+	stack string
+}
+
+func (e stackError) Error() string      { return e.msg }
+func (e stackError) StackTrace() string { return e.stack }
+
+type joinedError []error
+
+func (e joinedError) Error() string   { return "joined" }
+func (e joinedError) Unwrap() []error { return e }
+
+func TestErrorsArraysEncodeChains(t *testing.T) {
+	root := stackError{msg: "disk full", stack: "main.write\n\tmain.go:10"}
+	wrapped := fmt.Errorf("save: %w", root)
+	joined := fmt.Errorf("cleanup: %w", joinedError{wrapped, errors.New("timeout")})
+
+	enc := ladcore.NewMapObjectEncoder()
+	Errors("k", []error{errors.New("plain"), wrapped, joined}).AddTo(enc)
+
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"error": "plain"},
+		map[string]interface{}{
+			"error": "save: disk full",
+			"errorChain": []interface{}{
+				map[string]interface{}{"type": "*fmt.wrapError", "error": "save: disk full"},
+				map[string]interface{}{"type": "lad.stackError", "error": "disk full"},
+			},
+			"stacktrace": "main.write\n\tmain.go:10",
+		},
+		map[string]interface{}{
+			"error": "cleanup: joined",
+			"errorChain": []interface{}{
+				map[string]interface{}{"type": "*fmt.wrapError", "error": "cleanup: joined"},
+				map[string]interface{}{"type": "lad.joinedError", "error": "joined"},
+			},
+			"errorCauses": []interface{}{
+				map[string]interface{}{
+					"error": "save: disk full",
+					"errorChain": []interface{}{
+						map[string]interface{}{"type": "*fmt.wrapError", "error": "save: disk full"},
+						map[string]interface{}{"type": "lad.stackError", "error": "disk full"},
+					},
+					"stacktrace": "main.write\n\tmain.go:10",
+				},
+				map[string]interface{}{"error": "timeout"},
+			},
+		},
+	}, enc.Fields["k"], "Unexpected encoding of wrapped and joined errors.")
+}
+
+func TestErrorsArraysDontRepeatGroupCauses(t *testing.T) {
+	enc := ladcore.NewMapObjectEncoder()
+	Errors("k", []error{multierr.Combine(errors.New("a"), errors.New("b"))}).AddTo(enc)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"error": "a; b",
+			"errorCauses": []interface{}{
+				map[string]interface{}{"error": "a"},
+				map[string]interface{}{"error": "b"},
+			},
+		},
+	}, enc.Fields["k"], "Expected multierr causes to be encoded once.")
+}
+
+type selfWrappingError struct{}
+
+func (e *selfWrappingError) Error() string { return "loop" }
+func (e *selfWrappingError) Unwrap() error { return e }
+
+func TestErrorsArraysBoundChains(t *testing.T) {
+	enc := ladcore.NewMapObjectEncoder()
+	Errors("k", []error{&selfWrappingError{}}).AddTo(enc)
+	elem := enc.Fields["k"].([]interface{})[0].(map[string]interface{})
+	assert.Len(t, elem["errorChain"], _maxErrorChain, "Expected error chains to be bounded.")
+}
+
+type selfJoiningError struct{}
+
+func (e *selfJoiningError) Error() string   { return "loop" }
+func (e *selfJoiningError) Unwrap() []error { return []error{e} }
+
+func TestErrorsArraysBoundCauses(t *testing.T) {
+	enc := ladcore.NewMapObjectEncoder()
+	Errors("k", []error{&selfJoiningError{}}).AddTo(enc)
+
+	depth := 0
+	elem := enc.Fields["k"].([]interface{})[0].(map[string]interface{})
+	for elem["errorCauses"] != nil {
+		depth++
+		elem = elem["errorCauses"].([]interface{})[0].(map[string]interface{})
+	}
+	assert.Equal(t, _maxErrorDepth, depth, "Expected nested causes to be bounded.")
+}
+
+type nilWrapError struct{ err error }
+
+func (e *nilWrapError) Error() string { return e.err.Error() }
+func (e *nilWrapError) Unwrap() error { return e.err }
+
+func TestErrorsArraysNilErrorsInChain(t *testing.T) {
+	var nilErr *nilWrapError
+	enc := ladcore.NewMapObjectEncoder()
+	Errors("k", []error{fmt.Errorf("outer: %w", nilErr)}).AddTo(enc)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"error": "outer: <nil>",
+			"errorChain": []interface{}{
+				map[string]interface{}{"type": "*fmt.wrapError", "error": "outer: <nil>"},
+				map[string]interface{}{"type": "*lad.nilWrapError", "error": "<nil>"},
+			},
+		},
+	}, enc.Fields["k"], "Expected nil errors in a chain to be encoded safely.")
+}
+
+type derefStackError struct{ stack string }
+
+func (e *derefStackError) Error() string      { return "stack: " + e.stack }
+func (e *derefStackError) StackTrace() string { return e.stack }
+
+type panickyStackError struct{}
+
+func (panickyStackError) Error() string      { return "panicky" }
+func (panickyStackError) StackTrace() string { panic("no stack") }
+
+func TestErrorsArraysStackTracePanics(t *testing.T) {
+	enc := ladcore.NewMapObjectEncoder()
+	Errors("k", []error{(*derefStackError)(nil), panickyStackError{}}).AddTo(enc)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"error": "<nil>"},
+		map[string]interface{}{"error": "panicky"},
+	}, enc.Fields["k"], "Expected stack traces that can't be captured to be skipped.")
+}
+
 func TestErrArrayBrokenEncoder(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,200 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lad
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/auwixcom/lad/ladcore"
+)
+
+// An EventSchema describes the fields an event must carry. Fields are
+// matched by key, including those added to the Logger with With.
+type EventSchema struct {
+	// Name is the event the schema applies to, e.g. "user.login".
+	Name string
+
+	// Required lists the keys that every event with this name must carry.
+	Required []string
+
+	// Optional lists the keys that events with this name may carry. If it's
+	// non-empty, keys not listed in Required or Optional are rejected.
+	Optional []string
+}
+
+// An EventSchemaError reports an event that doesn't satisfy its schema.
+type EventSchemaError struct {
+	Event      string
+	Missing    []string // required keys that weren't present
+	Unexpected []string // keys that the schema doesn't allow
+	Unknown    bool     // no schema is registered for the event
+}
+
+func (e *EventSchemaError) Error() string {
+	if e.Unknown {
+		return fmt.Sprintf("event %q has no schema", e.Event)
+	}
+	var problems []string
+	if len(e.Missing) > 0 {
+		problems = append(problems, "missing "+strings.Join(e.Missing, ", "))
+	}
+	if len(e.Unexpected) > 0 {
+		problems = append(problems, "unexpected "+strings.Join(e.Unexpected, ", "))
+	}
+	return fmt.Sprintf("event %q doesn't match its schema: %s", e.Event, strings.Join(problems, "; "))
+}
+
+// WithEvents routes the events written with Logger.Event to core, keeping
+// business and audit events out of the diagnostic log stream. Events are
+// checked and written at InfoLevel.
+//
+// If any schemas are given, every event must match the schema with its name;
+// events without a schema are rejected.
+func WithEvents(core ladcore.Core, schemas ...EventSchema) Option {
+	return optionFunc(func(log *Logger) {
+		ev := &events{core: core}
+		if len(schemas) > 0 {
+			ev.schemas = make(map[string]EventSchema, len(schemas))
+			for _, s := range schemas {
+				ev.schemas[s.Name] = s
+			}
+		}
+		log.events = ev
+	})
+}
+
+// WithEventIDs stamps every event written with Logger.Event with a unique
+// ID under key, generated by the Logger's IDGenerator (see WithIDGenerator).
+// The ID isn't subject to the event's schema.
+func WithEventIDs(key string) Option {
+	return optionFunc(func(log *Logger) {
+		log.eventIDKey = key
+	})
+}
+
+// events is the destination of a Logger's events.
+type events struct {
+	core    ladcore.Core
+	schemas map[string]EventSchema
+	keys    []string // keys of the fields added with With
+}
+
+func (ev *events) with(fields []Field) *events {
+	return ev.withCore(ev.core.With(fields), fields)
+}
+
+// withLazy is like with, but defers evaluating the fields as WithLazy does.
+func (ev *events) withLazy(fields []Field) *events {
+	return ev.withCore(ladcore.NewLazyWith(ev.core, fields), fields)
+}
+
+func (ev *events) withCore(core ladcore.Core, fields []Field) *events {
+	clone := *ev
+	clone.core = core
+	clone.keys = ev.keys[:len(ev.keys):len(ev.keys)]
+	for _, f := range fields {
+		clone.keys = append(clone.keys, f.Key)
+	}
+	return &clone
+}
+
+func (ev *events) validate(name string, fields []Field) error {
+	if ev.schemas == nil {
+		return nil
+	}
+	schema, ok := ev.schemas[name]
+	if !ok {
+		return &EventSchemaError{Event: name, Unknown: true}
+	}
+
+	present := make(map[string]struct{}, len(ev.keys)+len(fields))
+	for _, k := range ev.keys {
+		present[k] = struct{}{}
+	}
+	for _, f := range fields {
+		present[f.Key] = struct{}{}
+	}
+
+	err := &EventSchemaError{Event: name}
+	for _, k := range schema.Required {
+		if _, ok := present[k]; !ok {
+			err.Missing = append(err.Missing, k)
+		}
+	}
+	if len(schema.Optional) > 0 {
+		allowed := make(map[string]struct{}, len(schema.Required)+len(schema.Optional))
+		for _, k := range schema.Required {
+			allowed[k] = struct{}{}
+		}
+		for _, k := range schema.Optional {
+			allowed[k] = struct{}{}
+		}
+		check := func(k string) {
+			if _, ok := allowed[k]; !ok && k != "" {
+				err.Unexpected = append(err.Unexpected, k)
+			}
+		}
+		for _, k := range ev.keys {
+			check(k)
+		}
+		for _, f := range fields {
+			check(f.Key)
+		}
+	}
+	if len(err.Missing) > 0 || len(err.Unexpected) > 0 {
+		return err
+	}
+	return nil
+}
+
+// Event records a structured business or audit event, such as a user
+// logging in, to the core registered with WithEvents. The event's name is
+// used as the entry's message. Events don't pass through the Logger's
+// diagnostic core, so they're unaffected by its level, sampling, and hooks.
+//
+//	logger.Event("user.login", lad.String("user", id), lad.String("method", "sso"))
+//
+// Event returns an *EventSchemaError, without writing anything, if the event
+// doesn't match its schema. It does nothing if no event core is registered.
+func (log *Logger) Event(name string, fields ...Field) error {
+	if log.events == nil {
+		return nil
+	}
+	if err := log.events.validate(name, fields); err != nil {
+		return err
+	}
+
+	ent := ladcore.Entry{
+		LoggerName: log.name,
+		Time:       log.clock.Now(),
+		Level:      ladcore.InfoLevel,
+		Message:    name,
+	}
+	if ce := log.events.core.Check(ent, nil); ce != nil {
+		ce.ErrorOutput = log.errorOutput
+		if log.eventIDKey != "" {
+			fields = append(fields[:len(fields):len(fields)], String(log.eventIDKey, log.idGenerator().NewID()))
+		}
+		ce.Write(fields...)
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lad
+
+import (
+	"testing"
+
+	"github.com/auwixcom/lad/internal/ztest"
+	"github.com/auwixcom/lad/ladcore"
+	"github.com/auwixcom/lad/ladtest/observer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoggerEvent(t *testing.T) {
+	eventCore, events := observer.New(ladcore.InfoLevel)
+	withLogger(t, ErrorLevel, opts(WithEvents(eventCore)), func(logger *Logger, logs *observer.ObservedLogs) {
+		logger = logger.Named("auth").With(String("request_id", "r1"))
+		require.NoError(t, logger.Event("user.login", String("user", "alice")), "Unexpected error recording event.")
+		logger.Info("diagnostics")
+
+		assert.Equal(t, 0, logs.Len(), "Expected events to bypass the diagnostic core.")
+		require.Equal(t, 1, events.Len(), "Expected event to be recorded.")
+		ev := events.All()[0]
+		assert.Equal(t, "user.login", ev.Message, "Unexpected event message.")
+		assert.Equal(t, "auth", ev.LoggerName, "Unexpected logger name.")
+		assert.Equal(t, ladcore.InfoLevel, ev.Level, "Unexpected event level.")
+		assert.Equal(t, []Field{String("request_id", "r1"), String("user", "alice")}, ev.Context, "Unexpected event fields.")
+	})
+}
+
+func TestLoggerEventNamedFields(t *testing.T) {
+	eventCore, events := observer.New(ladcore.InfoLevel)
+	logger := New(ladcore.NewNopCore(),
+		WithEvents(eventCore),
+		NamedFields(map[string][]Field{"payments": {String("component", "payments")}}),
+	)
+	require.NoError(t, logger.Named("payments").Event("charge.created", Int("cents", 500)), "Unexpected error recording event.")
+
+	require.Equal(t, 1, events.Len(), "Expected event to be recorded.")
+	assert.Equal(t, []Field{String("component", "payments"), Int("cents", 500)}, events.All()[0].Context, "Expected named fields on event.")
+}
+
+func TestLoggerEventWithoutCore(t *testing.T) {
+	withLogger(t, DebugLevel, nil, func(logger *Logger, logs *observer.ObservedLogs) {
+		assert.NoError(t, logger.Event("user.login"), "Expected events without a core to be ignored.")
+		assert.Equal(t, 0, logs.Len(), "Expected events not to reach the diagnostic core.")
+	})
+}
+
+func TestLoggerEventSchema(t *testing.T) {
+	eventCore, events := observer.New(ladcore.InfoLevel)
+	logger := New(ladcore.NewNopCore(), WithEvents(eventCore,
+		EventSchema{Name: "user.login", Required: []string{"user"}},
+		EventSchema{Name: "user.logout", Required: []string{"user"}, Optional: []string{"reason"}},
+	))
+
+	tests := []struct {
+		desc    string
+		logger  *Logger
+		name    string
+		fields  []Field
+		wantErr string
+	}{
+		{
+			desc:   "required fields present",
+			logger: logger,
+			name:   "user.login",
+			fields: []Field{String("user", "alice"), String("anything", "goes")},
+		},
+		{
+			desc:   "required field from context",
+			logger: logger.With(String("user", "alice")),
+			name:   "user.logout",
+			fields: []Field{String("reason", "idle")},
+		},
+		{
+			desc:    "missing required field",
+			logger:  logger,
+			name:    "user.login",
+			wantErr: `event "user.login" doesn't match its schema: missing user`,
+		},
+		{
+			desc:    "unexpected field",
+			logger:  logger.With(String("request_id", "r1")),
+			name:    "user.logout",
+			fields:  []Field{String("user", "alice"), String("ip", "10.0.0.1")},
+			wantErr: `event "user.logout" doesn't match its schema: unexpected request_id, ip`,
+		},
+		{
+			desc:    "missing and unexpected fields",
+			logger:  logger,
+			name:    "user.logout",
+			fields:  []Field{String("ip", "10.0.0.1")},
+			wantErr: `event "user.logout" doesn't match its schema: missing user; unexpected ip`,
+		},
+		{
+			desc:    "unknown event",
+			logger:  logger,
+			name:    "user.deleted",
+			wantErr: `event "user.deleted" has no schema`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			before := events.Len()
+			err := tt.logger.Event(tt.name, tt.fields...)
+			if tt.wantErr == "" {
+				assert.NoError(t, err, "Unexpected error.")
+				assert.Equal(t, before+1, events.Len(), "Expected event to be recorded.")
+				return
+			}
+			var schemaErr *EventSchemaError
+			require.ErrorAs(t, err, &schemaErr, "Expected an EventSchemaError.")
+			assert.Equal(t, tt.wantErr, err.Error(), "Unexpected error message.")
+			assert.Equal(t, before, events.Len(), "Expected invalid event to be dropped.")
+		})
+	}
+}
+
+func TestLoggerEventIDs(t *testing.T) {
+	t.Run("default generator", func(t *testing.T) {
+		eventCore, events := observer.New(ladcore.InfoLevel)
+		logger := New(ladcore.NewNopCore(),
+			WithEvents(eventCore, EventSchema{Name: "user.login", Required: []string{"user"}}),
+			WithEventIDs("event_id"),
+		)
+		require.NoError(t, logger.Event("user.login", String("user", "alice")), "Expected the ID to be exempt from the schema.")
+		require.NoError(t, logger.Event("user.login", String("user", "bob")), "Unexpected error recording event.")
+
+		entries := events.All()
+		require.Len(t, entries, 2, "Expected events to be recorded.")
+		first, second := entries[0].ContextMap()["event_id"], entries[1].ContextMap()["event_id"]
+		assert.Len(t, first, 26, "Expected a ULID.")
+		assert.NotEqual(t, first, second, "Expected unique event IDs.")
+	})
+
+	t.Run("custom generator", func(t *testing.T) {
+		eventCore, events := observer.New(ladcore.InfoLevel)
+		logger := New(ladcore.NewNopCore(),
+			WithEvents(eventCore),
+			WithEventIDs("id"),
+			WithIDGenerator(ladcore.IDGeneratorFunc(func() string { return "fixed" })),
+		)
+		fields := []Field{String("user", "alice")}
+		require.NoError(t, logger.Event("user.login", fields...), "Unexpected error recording event.")
+		require.Equal(t, 1, events.Len(), "Expected event to be recorded.")
+		assert.Equal(t, []Field{String("user", "alice"), String("id", "fixed")}, events.All()[0].Context, "Unexpected event fields.")
+		assert.Len(t, fields, 1, "Expected the caller's fields to be left alone.")
+	})
+}
+
+func TestLoggerSyncsEventCore(t *testing.T) {
+	sink := &ztest.Buffer{}
+	logger := New(ladcore.NewNopCore(), WithEvents(ladcore.NewCore(
+		ladcore.NewJSONEncoder(ladcore.EncoderConfig{MessageKey: "event"}), sink, ladcore.InfoLevel,
+	)))
+	require.NoError(t, logger.Event("user.login"), "Unexpected error recording event.")
+	assert.Equal(t, "{\"event\":\"user.login\"}\n", sink.String(), "Unexpected encoded event.")
+	require.NoError(t, logger.Sync(), "Unexpected error syncing.")
+	assert.True(t, sink.Called(), "Expected Sync to reach the event core.")
+}
+
+func TestLoggerEventWithLazy(t *testing.T) {
+	eventCore, events := observer.New(ladcore.InfoLevel)
+	logger := New(ladcore.NewNopCore(), WithEvents(eventCore,
+		EventSchema{Name: "user.login", Required: []string{"user"}},
+	)).WithLazy(String("user", "alice"))
+
+	require.NoError(t, logger.Event("user.login"), "Expected lazy context to satisfy the schema.")
+	require.Equal(t, 1, events.Len(), "Expected event to be recorded.")
+	assert.Equal(t, []Field{String("user", "alice")}, events.All()[0].Context, "Expected lazy context on the event.")
+}
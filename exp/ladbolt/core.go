@@ -0,0 +1,94 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladbolt
+
+import "github.com/auwixcom/lad/ladcore"
+
+// NewCore creates a Core that writes each enabled entry to the Store, along
+// with its fields, including those added with With.
+//
+// Every entry is written in its own transaction, which is durable once
+// Write returns. That's a good fit for the modest log volume of desktop and
+// command-line applications, but services logging thousands of entries a
+// second should look elsewhere.
+func NewCore(s *Store, enab ladcore.LevelEnabler) ladcore.Core {
+	return &core{
+		LevelEnabler: enab,
+		store:        s,
+	}
+}
+
+type core struct {
+	ladcore.LevelEnabler
+
+	store   *Store
+	context []ladcore.Field
+}
+
+var _ ladcore.Core = (*core)(nil)
+
+func (c *core) Level() ladcore.Level {
+	return ladcore.LevelOf(c.LevelEnabler)
+}
+
+func (c *core) With(fields []ladcore.Field) ladcore.Core {
+	return &core{
+		LevelEnabler: c.LevelEnabler,
+		store:        c.store,
+		context:      append(c.context[:len(c.context):len(c.context)], fields...),
+	}
+}
+
+func (c *core) Check(ent ladcore.Entry, ce *ladcore.CheckedEntry) *ladcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *core) Write(ent ladcore.Entry, fields []ladcore.Field) error {
+	enc := ladcore.NewMapObjectEncoder()
+	for _, f := range c.context {
+		f.AddTo(enc)
+	}
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+
+	r := &Record{
+		Time:       ent.Time,
+		Level:      ent.Level,
+		LoggerName: ent.LoggerName,
+		Message:    ent.Message,
+		Stack:      ent.Stack,
+	}
+	if ent.Caller.Defined {
+		r.Caller = ent.Caller.TrimmedPath()
+	}
+	if len(enc.Fields) > 0 {
+		r.Fields = enc.Fields
+	}
+	return c.store.add(r)
+}
+
+func (c *core) Sync() error {
+	return c.store.Sync()
+}
@@ -0,0 +1,303 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package ladbolt keeps a searchable history of lad log entries in a local
+// bbolt database. It's meant for desktop and command-line applications that
+// want to let users (or support staff) look back through recent logs
+// without shipping them anywhere.
+package ladbolt
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/auwixcom/lad/ladcore"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	_entriesBucket  = []byte("entries")
+	_timeIndex      = []byte("time")
+	_levelIndex     = []byte("level")
+	_loggerIndex    = []byte("logger")
+	_indexedBuckets = [][]byte{_timeIndex, _levelIndex, _loggerIndex}
+)
+
+// A Record is a log entry read back from a Store.
+//
+// Fields hold the entry's fields as they would be encoded to JSON, so
+// numbers are float64s and objects are maps.
+type Record struct {
+	ID         uint64                 `json:"-"`
+	Time       time.Time              `json:"time"`
+	Level      ladcore.Level          `json:"level"`
+	LoggerName string                 `json:"logger,omitempty"`
+	Message    string                 `json:"msg"`
+	Caller     string                 `json:"caller,omitempty"`
+	Stack      string                 `json:"stacktrace,omitempty"`
+	Fields     map[string]interface{} `json:"fields,omitempty"`
+}
+
+// A Query selects records from a Store. Zero-valued conditions match every
+// record.
+type Query struct {
+	// Since and Until bound the time of matching records. Since is
+	// inclusive and Until is exclusive.
+	Since, Until time.Time
+
+	// Level, if set, matches records at the levels it enables. For example,
+	// lad.WarnLevel matches records at WarnLevel and above.
+	Level ladcore.LevelEnabler
+
+	// LoggerName matches records from the logger with exactly this name.
+	LoggerName string
+
+	// Limit caps the number of records returned.
+	Limit int
+}
+
+// A Store is a log history in a bbolt database. Entries are indexed by
+// time, level and logger name. Use NewCore to write entries to it.
+//
+// A Store is safe for concurrent use.
+type Store struct {
+	db *bolt.DB
+}
+
+// Open opens the database at path, creating it if it doesn't exist. Only one
+// process can have a database open at a time.
+func Open(path string) (*Store, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range append([][]byte{_entriesBucket}, _indexedBuckets...) {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return &Store{db: db}, nil
+}
+
+// Close closes the database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// add stores a record and its index entries in a single transaction.
+func (s *Store) add(r *Record) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		entries := tx.Bucket(_entriesBucket)
+		id, err := entries.NextSequence()
+		if err != nil {
+			return err
+		}
+		r.ID = id
+
+		data, err := json.Marshal(r)
+		if err != nil {
+			return err
+		}
+		if err := entries.Put(idKey(id), data); err != nil {
+			return err
+		}
+		for i, key := range indexKeys(r) {
+			if err := tx.Bucket(_indexedBuckets[i]).Put(key, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Query returns the records matching q, oldest first.
+func (s *Store) Query(q Query) ([]Record, error) {
+	var records []Record
+	err := s.db.View(func(tx *bolt.Tx) error {
+		entries := tx.Bucket(_entriesBucket)
+		collect := func(index, prefix []byte, limit int) error {
+			var n int
+			return scan(tx.Bucket(index), prefix, q.Since, q.Until, func(id uint64) (bool, error) {
+				var r Record
+				if err := json.Unmarshal(entries.Get(idKey(id)), &r); err != nil {
+					return false, err
+				}
+				r.ID = id
+				if !q.matches(&r) {
+					return true, nil
+				}
+				records = append(records, r)
+				n++
+				return limit <= 0 || n < limit, nil
+			})
+		}
+
+		// Pick the most selective index for the query.
+		switch {
+		case q.LoggerName != "":
+			return collect(_loggerIndex, loggerPrefix(q.LoggerName), q.Limit)
+		case q.Level != nil:
+			// Each level's records are in time order, but they have to be
+			// merged, so the limit is applied again afterwards.
+			for lvl := ladcore.DebugLevel; lvl <= ladcore.FatalLevel; lvl++ {
+				if q.Level.Enabled(lvl) {
+					if err := collect(_levelIndex, levelPrefix(lvl), q.Limit); err != nil {
+						return err
+					}
+				}
+			}
+			sort.Slice(records, func(i, j int) bool {
+				if !records[i].Time.Equal(records[j].Time) {
+					return records[i].Time.Before(records[j].Time)
+				}
+				return records[i].ID < records[j].ID
+			})
+			if q.Limit > 0 && len(records) > q.Limit {
+				records = records[:q.Limit]
+			}
+			return nil
+		default:
+			return collect(_timeIndex, nil, q.Limit)
+		}
+	})
+	return records, err
+}
+
+func (q *Query) matches(r *Record) bool {
+	if q.Level != nil && !q.Level.Enabled(r.Level) {
+		return false
+	}
+	return q.LoggerName == "" || q.LoggerName == r.LoggerName
+}
+
+// DeleteBefore removes all records older than t, returning the number of
+// records removed. Applications typically call it on startup to bound the
+// size of the history.
+func (s *Store) DeleteBefore(t time.Time) (int, error) {
+	var n int
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		var ids []uint64
+		err := scan(tx.Bucket(_timeIndex), nil, time.Time{}, t, func(id uint64) (bool, error) {
+			ids = append(ids, id)
+			return true, nil
+		})
+		if err != nil {
+			return err
+		}
+
+		entries := tx.Bucket(_entriesBucket)
+		for _, id := range ids {
+			var r Record
+			if err := json.Unmarshal(entries.Get(idKey(id)), &r); err != nil {
+				return err
+			}
+			r.ID = id
+			for i, key := range indexKeys(&r) {
+				if err := tx.Bucket(_indexedBuckets[i]).Delete(key); err != nil {
+					return err
+				}
+			}
+			if err := entries.Delete(idKey(id)); err != nil {
+				return err
+			}
+			n++
+		}
+		return nil
+	})
+	return n, err
+}
+
+// Sync flushes the database to disk.
+func (s *Store) Sync() error {
+	return s.db.Sync()
+}
+
+// Index keys are a prefix identifying the indexed value (empty for the time
+// index), followed by the entry's time and ID, so that each prefix's entries
+// are in time order.
+
+func idKey(id uint64) []byte {
+	return binary.BigEndian.AppendUint64(nil, id)
+}
+
+// appendTime appends a time such that byte order matches time order, even
+// before 1970.
+func appendTime(b []byte, t time.Time) []byte {
+	return binary.BigEndian.AppendUint64(b, uint64(t.UnixNano())^(1<<63))
+}
+
+func levelPrefix(lvl ladcore.Level) []byte {
+	return []byte{uint8(lvl) ^ 0x80}
+}
+
+func loggerPrefix(name string) []byte {
+	return append([]byte(name), 0)
+}
+
+// indexKeys returns the keys of a record in each of _indexedBuckets.
+func indexKeys(r *Record) [][]byte {
+	suffix := binary.BigEndian.AppendUint64(appendTime(nil, r.Time), r.ID)
+	return [][]byte{
+		suffix,
+		append(levelPrefix(r.Level), suffix...),
+		append(loggerPrefix(r.LoggerName), suffix...),
+	}
+}
+
+var errCorruptIndex = errors.New("ladbolt: corrupt index key")
+
+// scan calls fn with the IDs of the index entries under prefix with times in
+// [since, until), in order, until fn returns false.
+func scan(index *bolt.Bucket, prefix []byte, since, until time.Time, fn func(id uint64) (bool, error)) error {
+	start := prefix
+	if !since.IsZero() {
+		start = appendTime(append([]byte(nil), prefix...), since)
+	}
+	var end []byte
+	if !until.IsZero() {
+		end = appendTime(append([]byte(nil), prefix...), until)
+	}
+
+	c := index.Cursor()
+	for k, _ := c.Seek(start); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+		if end != nil && bytes.Compare(k, end) >= 0 {
+			return nil
+		}
+		if len(k) != len(prefix)+16 {
+			return errCorruptIndex
+		}
+		more, err := fn(binary.BigEndian.Uint64(k[len(prefix)+8:]))
+		if err != nil || !more {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladbolt
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/auwixcom/lad"
+	"github.com/auwixcom/lad/ladcore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stepClock returns times one second apart, starting at epoch.
+type stepClock struct{ now time.Time }
+
+var epoch = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func (c *stepClock) Now() time.Time {
+	c.now = c.now.Add(time.Second)
+	return c.now
+}
+
+func (c *stepClock) NewTicker(d time.Duration) *time.Ticker { return time.NewTicker(d) }
+
+func openStore(t *testing.T) *Store {
+	s, err := Open(filepath.Join(t.TempDir(), "logs.db"))
+	require.NoError(t, err, "Failed to open store.")
+	t.Cleanup(func() { assert.NoError(t, s.Close(), "Failed to close store.") })
+	return s
+}
+
+func messages(records []Record) []string {
+	msgs := make([]string, 0, len(records))
+	for _, r := range records {
+		msgs = append(msgs, r.Message)
+	}
+	return msgs
+}
+
+func TestStoreQuery(t *testing.T) {
+	s := openStore(t)
+	logger := lad.New(NewCore(s, lad.DebugLevel), lad.WithClock(&stepClock{now: epoch}))
+
+	// Times are epoch+1s through epoch+6s.
+	logger.Debug("one")
+	logger.Named("db").Warn("two", lad.Int("n", 2))
+	logger.Error("three")
+	logger.Named("db").Info("four")
+	logger.With(lad.String("user", "alice")).Warn("five")
+	logger.Named("db").Error("six")
+
+	tests := []struct {
+		desc string
+		q    Query
+		want []string
+	}{
+		{"all", Query{}, []string{"one", "two", "three", "four", "five", "six"}},
+		{"limit", Query{Limit: 2}, []string{"one", "two"}},
+		{"time range", Query{Since: epoch.Add(2 * time.Second), Until: epoch.Add(4 * time.Second)}, []string{"two", "three"}},
+		{"logger", Query{LoggerName: "db"}, []string{"two", "four", "six"}},
+		{"logger and level", Query{LoggerName: "db", Level: lad.ErrorLevel}, []string{"six"}},
+		{"level", Query{Level: lad.WarnLevel}, []string{"two", "three", "five", "six"}},
+		{"level with limit", Query{Level: lad.WarnLevel, Limit: 3}, []string{"two", "three", "five"}},
+		{"level and time", Query{Level: lad.WarnLevel, Since: epoch.Add(3 * time.Second)}, []string{"three", "five", "six"}},
+		{"no match", Query{LoggerName: "missing"}, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			records, err := s.Query(tt.q)
+			require.NoError(t, err, "Unexpected error querying.")
+			assert.Equal(t, tt.want, messages(records), "Unexpected records.")
+		})
+	}
+
+	records, err := s.Query(Query{LoggerName: "db", Limit: 1})
+	require.NoError(t, err, "Unexpected error querying.")
+	require.Len(t, records, 1, "Unexpected number of records.")
+	assert.Equal(t, Record{
+		ID:         2,
+		Time:       epoch.Add(2 * time.Second),
+		Level:      ladcore.WarnLevel,
+		LoggerName: "db",
+		Message:    "two",
+		Fields:     map[string]interface{}{"n": float64(2)},
+	}, records[0], "Unexpected record.")
+}
+
+func TestStoreDeleteBefore(t *testing.T) {
+	s := openStore(t)
+	logger := lad.New(NewCore(s, lad.InfoLevel), lad.WithClock(&stepClock{now: epoch}))
+	for _, msg := range []string{"one", "two", "three"} {
+		logger.Named("app").Warn(msg)
+	}
+
+	n, err := s.DeleteBefore(epoch.Add(3 * time.Second))
+	require.NoError(t, err, "Unexpected error deleting.")
+	assert.Equal(t, 2, n, "Unexpected number of deleted records.")
+
+	for _, q := range []Query{{}, {Level: lad.WarnLevel}, {LoggerName: "app"}} {
+		records, err := s.Query(q)
+		require.NoError(t, err, "Unexpected error querying.")
+		assert.Equal(t, []string{"three"}, messages(records), "Expected old records to be gone from every index.")
+	}
+	assert.NoError(t, logger.Sync(), "Unexpected error syncing.")
+}
+
+func TestStorePersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs.db")
+	s, err := Open(path)
+	require.NoError(t, err, "Failed to open store.")
+	lad.New(NewCore(s, lad.InfoLevel)).Info("hello")
+	require.NoError(t, s.Close(), "Failed to close store.")
+
+	s, err = Open(path)
+	require.NoError(t, err, "Failed to reopen store.")
+	defer s.Close()
+	records, err := s.Query(Query{})
+	require.NoError(t, err, "Unexpected error querying.")
+	assert.Equal(t, []string{"hello"}, messages(records), "Expected records to survive reopening.")
+}
@@ -0,0 +1,243 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladparquet
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// kind is the type of a column. Columns start out with the kind of their
+// first value and fall back to stringKind when later values disagree.
+type kind uint8
+
+const (
+	boolKind kind = iota
+	int64Kind
+	float64Kind
+	timeKind
+	stringKind
+)
+
+func (k kind) node() parquet.Node {
+	switch k {
+	case boolKind:
+		return parquet.Leaf(parquet.BooleanType)
+	case int64Kind:
+		return parquet.Int(64)
+	case float64Kind:
+		return parquet.Leaf(parquet.DoubleType)
+	case timeKind:
+		return parquet.Timestamp(parquet.Nanosecond)
+	default:
+		return parquet.String()
+	}
+}
+
+// cell is a single value in a row, converted to one of the column kinds.
+type cell struct {
+	kind  kind
+	value interface{} // bool, int64, float64, time.Time, or string
+}
+
+// cellOf converts a value produced by ladcore.MapObjectEncoder.
+func cellOf(v interface{}) cell {
+	switch v := v.(type) {
+	case bool:
+		return cell{boolKind, v}
+	case int:
+		return cell{int64Kind, int64(v)}
+	case int64:
+		return cell{int64Kind, v}
+	case int32:
+		return cell{int64Kind, int64(v)}
+	case int16:
+		return cell{int64Kind, int64(v)}
+	case int8:
+		return cell{int64Kind, int64(v)}
+	case uint:
+		return cell{int64Kind, int64(v)}
+	case uint64:
+		return cell{int64Kind, int64(v)}
+	case uint32:
+		return cell{int64Kind, int64(v)}
+	case uint16:
+		return cell{int64Kind, int64(v)}
+	case uint8:
+		return cell{int64Kind, int64(v)}
+	case uintptr:
+		return cell{int64Kind, int64(v)}
+	case float64:
+		return cell{float64Kind, v}
+	case float32:
+		return cell{float64Kind, float64(v)}
+	case time.Duration:
+		return cell{int64Kind, int64(v)}
+	case time.Time:
+		return cell{timeKind, v}
+	case string:
+		return cell{stringKind, v}
+	case []byte:
+		return cell{stringKind, string(v)}
+	case complex128, complex64:
+		return cell{stringKind, fmt.Sprint(v)}
+	default:
+		// Arrays and reflected values are kept as JSON.
+		b, err := json.Marshal(v)
+		if err != nil {
+			return cell{stringKind, fmt.Sprint(v)}
+		}
+		return cell{stringKind, string(b)}
+	}
+}
+
+// flatten adds the cells of a map produced by ladcore.MapObjectEncoder to
+// row, joining the keys of nested objects and namespaces with periods.
+func flatten(row map[string]cell, prefix string, fields map[string]interface{}) {
+	for k, v := range fields {
+		if prefix != "" {
+			k = prefix + "." + k
+		}
+		if m, ok := v.(map[string]interface{}); ok {
+			flatten(row, k, m)
+			continue
+		}
+		row[k] = cellOf(v)
+	}
+}
+
+// column holds the values of one column, with nil for rows that lack it.
+type column struct {
+	kind   kind
+	values []interface{}
+}
+
+// widen converts the column's values to strings.
+func (c *column) widen() {
+	c.kind = stringKind
+	for i, v := range c.values {
+		if v != nil {
+			c.values[i] = stringOf(v)
+		}
+	}
+}
+
+func stringOf(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// batch buffers rows column by column until they're written out as a
+// Parquet file.
+type batch struct {
+	rows    int
+	columns map[string]*column
+}
+
+func newBatch() *batch {
+	return &batch{columns: make(map[string]*column)}
+}
+
+func (b *batch) add(row map[string]cell) {
+	for name, c := range row {
+		col, ok := b.columns[name]
+		if !ok {
+			col = &column{kind: c.kind, values: make([]interface{}, b.rows)}
+			b.columns[name] = col
+		}
+		v := c.value
+		if col.kind != c.kind {
+			if col.kind != stringKind {
+				col.widen()
+			}
+			v = stringOf(v)
+		}
+		col.values = append(col.values, v)
+	}
+	b.rows++
+	for _, col := range b.columns {
+		if len(col.values) < b.rows {
+			col.values = append(col.values, nil)
+		}
+	}
+}
+
+// schema derives a Parquet schema with an optional column per field.
+func (b *batch) schema() *parquet.Schema {
+	group := make(parquet.Group, len(b.columns))
+	for name, col := range b.columns {
+		group[name] = parquet.Optional(col.kind.node())
+	}
+	return parquet.NewSchema("entry", group)
+}
+
+// parquetRows assembles the buffered columns into rows. Columns appear in
+// the order of the schema, which sorts them by name.
+func (b *batch) parquetRows() []parquet.Row {
+	names := make([]string, 0, len(b.columns))
+	for name := range b.columns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	rows := make([]parquet.Row, b.rows)
+	for i := range rows {
+		row := make(parquet.Row, len(names))
+		for j, name := range names {
+			v := b.columns[name].values[i]
+			if v == nil {
+				row[j] = parquet.Value{}.Level(0, 0, j)
+			} else {
+				row[j] = valueOf(v).Level(0, 1, j)
+			}
+		}
+		rows[i] = row
+	}
+	return rows
+}
+
+func valueOf(v interface{}) parquet.Value {
+	switch v := v.(type) {
+	case bool:
+		return parquet.BooleanValue(v)
+	case int64:
+		return parquet.Int64Value(v)
+	case float64:
+		return parquet.DoubleValue(v)
+	case time.Time:
+		return parquet.Int64Value(v.UnixNano())
+	default:
+		return parquet.ByteArrayValue([]byte(v.(string)))
+	}
+}
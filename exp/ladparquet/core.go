@@ -0,0 +1,93 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladparquet
+
+import "github.com/auwixcom/lad/ladcore"
+
+// NewCore creates a Core that buffers each enabled entry as a row of the
+// Sink. Besides a column per field, including those added with With, rows
+// have time, level, logger, message, caller and stacktrace columns; these
+// take precedence over fields with the same keys. Fields in nested objects
+// and namespaces get columns named after their full, period-separated path.
+func NewCore(sink *Sink, enab ladcore.LevelEnabler) ladcore.Core {
+	return &core{
+		LevelEnabler: enab,
+		sink:         sink,
+	}
+}
+
+type core struct {
+	ladcore.LevelEnabler
+
+	sink    *Sink
+	context []ladcore.Field
+}
+
+var _ ladcore.Core = (*core)(nil)
+
+func (c *core) Level() ladcore.Level {
+	return ladcore.LevelOf(c.LevelEnabler)
+}
+
+func (c *core) With(fields []ladcore.Field) ladcore.Core {
+	return &core{
+		LevelEnabler: c.LevelEnabler,
+		sink:         c.sink,
+		context:      append(c.context[:len(c.context):len(c.context)], fields...),
+	}
+}
+
+func (c *core) Check(ent ladcore.Entry, ce *ladcore.CheckedEntry) *ladcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *core) Write(ent ladcore.Entry, fields []ladcore.Field) error {
+	enc := ladcore.NewMapObjectEncoder()
+	for _, f := range c.context {
+		f.AddTo(enc)
+	}
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+
+	row := make(map[string]cell, len(enc.Fields)+6)
+	flatten(row, "", enc.Fields)
+	row["time"] = cell{timeKind, ent.Time}
+	row["level"] = cell{stringKind, ent.Level.String()}
+	row["message"] = cell{stringKind, ent.Message}
+	if ent.LoggerName != "" {
+		row["logger"] = cell{stringKind, ent.LoggerName}
+	}
+	if ent.Caller.Defined {
+		row["caller"] = cell{stringKind, ent.Caller.TrimmedPath()}
+	}
+	if ent.Stack != "" {
+		row["stacktrace"] = cell{stringKind, ent.Stack}
+	}
+	return c.sink.add(row)
+}
+
+func (c *core) Sync() error {
+	return c.sink.Sync()
+}
@@ -0,0 +1,222 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package ladparquet writes lad log entries to Parquet files, so that logs can
+// be queried with analytical tools (DuckDB, Spark, Athena and the like)
+// without running an ingestion service.
+package ladparquet
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/auwixcom/lad/ladcore"
+	"github.com/parquet-go/parquet-go"
+	"go.uber.org/multierr"
+)
+
+const (
+	// _defaultMaxRows is the number of buffered entries that triggers a
+	// flush.
+	_defaultMaxRows = 10000
+
+	// _defaultFlushInterval specifies how often buffered entries are
+	// written out.
+	_defaultFlushInterval = time.Minute
+)
+
+// A Sink buffers entries in memory, column by column, and writes them out as
+// a Parquet file whenever MaxRows entries are buffered, at a fixed interval,
+// or when Sync is called--whichever comes first. Each file has its own
+// schema, with an optional column for every field key seen in the file.
+// Columns take the type of their values: booleans, 64-bit integers (which
+// include durations, in nanoseconds), doubles, timestamps, or strings. A
+// column whose values have mixed types is written as strings.
+//
+// A Sink is safe for concurrent use. Defer a call to Stop to write any
+// remaining entries and clean up the background goroutine:
+//
+//	sink := &ladparquet.Sink{Dir: "/var/log/myapp"}
+//	defer sink.Stop()
+//
+//	core := ladparquet.NewCore(sink, lad.InfoLevel)
+//	logger := lad.New(core)
+//
+// To write to object storage instead of the local file system, set Create to
+// a function that opens an upload.
+type Sink struct {
+	// Dir is the directory that files are created in when Create is unset.
+	//
+	// Defaults to the current directory.
+	Dir string
+
+	// Create, if specified, opens the destination for a file with the given
+	// name. The file is complete once the returned WriteCloser is closed.
+	//
+	// Defaults to creating the file in Dir.
+	Create func(name string) (io.WriteCloser, error)
+
+	// MaxRows is the number of buffered entries that triggers a flush.
+	//
+	// Defaults to 10000 if unspecified.
+	MaxRows int
+
+	// FlushInterval specifies how often buffered entries are written out.
+	//
+	// Defaults to one minute if unspecified.
+	FlushInterval time.Duration
+
+	// Clock, if specified, provides control of the source of time for the
+	// flush interval and file names.
+	//
+	// Defaults to the system clock.
+	Clock ladcore.Clock
+
+	// unexported fields for state
+	mu          sync.Mutex
+	initialized bool // whether initialize() has run
+	stopped     bool // whether Stop() has run
+	batch       *batch
+	seq         int // number of files created, for unique names
+	ticker      *time.Ticker
+	stop        chan struct{} // closed when flushLoop should stop
+	done        chan struct{} // closed when flushLoop has stopped
+}
+
+func (s *Sink) initialize() {
+	if s.MaxRows == 0 {
+		s.MaxRows = _defaultMaxRows
+	}
+	flushInterval := s.FlushInterval
+	if flushInterval == 0 {
+		flushInterval = _defaultFlushInterval
+	}
+	if s.Clock == nil {
+		s.Clock = ladcore.DefaultClock
+	}
+
+	s.batch = newBatch()
+	s.ticker = s.Clock.NewTicker(flushInterval)
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+	s.initialized = true
+	go s.flushLoop()
+}
+
+// add buffers a row, writing out the batch if it's full.
+func (s *Sink) add(row map[string]cell) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.initialized {
+		s.initialize()
+	}
+	s.batch.add(row)
+	if s.batch.rows < s.MaxRows {
+		return nil
+	}
+	return s.flush()
+}
+
+// Sync writes out all buffered entries.
+func (s *Sink) Sync() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.initialized {
+		return nil
+	}
+	return s.flush()
+}
+
+// flush writes the current batch to a new file. It must be called with s.mu
+// held.
+func (s *Sink) flush() error {
+	b := s.batch
+	if b.rows == 0 {
+		return nil
+	}
+	s.batch = newBatch()
+
+	s.seq++
+	name := fmt.Sprintf("%s-%06d.parquet", s.Clock.Now().UTC().Format("20060102T150405Z"), s.seq)
+	w, err := s.create(name)
+	if err != nil {
+		return err
+	}
+
+	pw := parquet.NewWriter(w, b.schema(), parquet.Compression(&parquet.Snappy))
+	_, err = pw.WriteRows(b.parquetRows())
+	err = multierr.Append(err, pw.Close())
+	return multierr.Append(err, w.Close())
+}
+
+func (s *Sink) create(name string) (io.WriteCloser, error) {
+	if s.Create != nil {
+		return s.Create(name)
+	}
+	return os.Create(filepath.Join(s.Dir, name))
+}
+
+// flushLoop writes out buffered entries at the configured interval until
+// Stop is called.
+func (s *Sink) flushLoop() {
+	defer close(s.done)
+
+	for {
+		select {
+		case <-s.ticker.C:
+			// Errors are dropped here since there's nobody to report them
+			// to; the entries are lost either way.
+			_ = s.Sync()
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+// Stop cleans up the background goroutine and writes out any remaining
+// entries.
+func (s *Sink) Stop() error {
+	stopped := func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if !s.initialized || s.stopped {
+			return false
+		}
+		s.stopped = true
+
+		s.ticker.Stop()
+		close(s.stop)
+		return true
+	}()
+
+	if !stopped {
+		return nil
+	}
+
+	<-s.done
+	return s.Sync()
+}
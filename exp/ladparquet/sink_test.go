@@ -0,0 +1,207 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladparquet
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/auwixcom/lad"
+	"github.com/auwixcom/lad/ladcore"
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readParquet returns the kinds of the columns in a Parquet file along with
+// its rows, leaving out null values.
+func readParquet(t *testing.T, data []byte) (map[string]parquet.Kind, []map[string]interface{}) {
+	f, err := parquet.OpenFile(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err, "Failed to open Parquet file.")
+
+	kinds := make(map[string]parquet.Kind)
+	names := make(map[int]string)
+	for i, path := range f.Schema().Columns() {
+		leaf, ok := f.Schema().Lookup(path...)
+		require.True(t, ok, "Failed to look up column %v.", path)
+		kinds[path[0]] = leaf.Node.Type().Kind()
+		names[i] = path[0]
+	}
+
+	r := parquet.NewReader(f)
+	defer r.Close()
+	buf := make([]parquet.Row, f.NumRows())
+	n, err := r.ReadRows(buf)
+	if !errors.Is(err, io.EOF) {
+		require.NoError(t, err, "Failed to read rows.")
+	}
+
+	var rows []map[string]interface{}
+	for _, row := range buf[:n] {
+		m := make(map[string]interface{})
+		for _, v := range row {
+			if v.IsNull() {
+				continue
+			}
+			var val interface{}
+			switch v.Kind() {
+			case parquet.Boolean:
+				val = v.Boolean()
+			case parquet.Int64:
+				val = v.Int64()
+			case parquet.Double:
+				val = v.Double()
+			default:
+				val = string(v.ByteArray())
+			}
+			m[names[v.Column()]] = val
+		}
+		rows = append(rows, m)
+	}
+	return kinds, rows
+}
+
+// memoryFiles collects files created by a Sink in memory.
+type memoryFiles struct {
+	mu    sync.Mutex
+	files map[string]*bytes.Buffer
+}
+
+type nopCloser struct{ *bytes.Buffer }
+
+func (nopCloser) Close() error { return nil }
+
+func (m *memoryFiles) Create(name string) (io.WriteCloser, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.files == nil {
+		m.files = make(map[string]*bytes.Buffer)
+	}
+	buf := new(bytes.Buffer)
+	m.files[name] = buf
+	return nopCloser{buf}, nil
+}
+
+func (m *memoryFiles) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.files)
+}
+
+func TestSinkSchemaFromFields(t *testing.T) {
+	var files memoryFiles
+	sink := &Sink{Create: files.Create}
+	defer func() { assert.NoError(t, sink.Stop(), "Unexpected error stopping Sink.") }()
+
+	ts := time.Date(2026, 1, 2, 3, 4, 5, 6, time.UTC)
+	logger := lad.New(NewCore(sink, lad.InfoLevel), lad.WithClock(fixedClock{ts})).With(lad.String("service", "api"))
+	logger.Info("first", lad.Int("n", 1), lad.Bool("ok", true), lad.Duration("took", time.Second))
+	logger.Named("db").Warn("second",
+		lad.Float64("ratio", 0.5),
+		lad.String("n", "two"),
+		lad.Namespace("req"),
+		lad.Strings("tags", []string{"a", "b"}),
+	)
+	assert.Zero(t, files.Len(), "Expected entries to be buffered.")
+
+	require.NoError(t, logger.Sync(), "Unexpected error syncing.")
+	require.Equal(t, 1, files.Len(), "Expected Sync to write a file.")
+	for _, data := range files.files {
+		kinds, rows := readParquet(t, data.Bytes())
+		assert.Equal(t, map[string]parquet.Kind{
+			"time":     parquet.Int64,
+			"level":    parquet.ByteArray,
+			"logger":   parquet.ByteArray,
+			"message":  parquet.ByteArray,
+			"service":  parquet.ByteArray,
+			"n":        parquet.ByteArray, // mixed types fall back to strings
+			"ok":       parquet.Boolean,
+			"took":     parquet.Int64,
+			"ratio":    parquet.Double,
+			"req.tags": parquet.ByteArray,
+		}, kinds, "Unexpected schema.")
+		assert.Equal(t, []map[string]interface{}{
+			{
+				"time":    ts.UnixNano(),
+				"level":   "info",
+				"message": "first",
+				"service": "api",
+				"n":       "1",
+				"ok":      true,
+				"took":    int64(time.Second),
+			},
+			{
+				"time":     ts.UnixNano(),
+				"level":    "warn",
+				"logger":   "db",
+				"message":  "second",
+				"service":  "api",
+				"n":        "two",
+				"ratio":    0.5,
+				"req.tags": `["a","b"]`,
+			},
+		}, rows, "Unexpected rows.")
+	}
+}
+
+func TestSinkMaxRows(t *testing.T) {
+	dir := t.TempDir()
+	sink := &Sink{Dir: dir, MaxRows: 2}
+	defer func() { assert.NoError(t, sink.Stop(), "Unexpected error stopping Sink.") }()
+
+	logger := lad.New(NewCore(sink, lad.InfoLevel))
+	for i := 0; i < 5; i++ {
+		logger.Info("hello", lad.Int("i", i))
+	}
+
+	names, err := filepath.Glob(filepath.Join(dir, "*.parquet"))
+	require.NoError(t, err, "Unexpected error listing files.")
+	require.Len(t, names, 2, "Expected a file per full batch.")
+
+	data, err := os.ReadFile(names[1])
+	require.NoError(t, err, "Unexpected error reading file.")
+	_, rows := readParquet(t, data)
+	require.Len(t, rows, 2, "Unexpected number of rows.")
+	assert.Equal(t, int64(3), rows[1]["i"], "Unexpected value.")
+}
+
+func TestSinkCreateError(t *testing.T) {
+	errCreate := errors.New("create failed")
+	sink := &Sink{
+		MaxRows: 1,
+		Create:  func(string) (io.WriteCloser, error) { return nil, errCreate },
+	}
+	defer func() { assert.NoError(t, sink.Stop(), "Unexpected error stopping Sink.") }()
+
+	err := NewCore(sink, lad.InfoLevel).Write(ladcore.Entry{Message: "hello"}, nil)
+	assert.ErrorIs(t, err, errCreate, "Expected errors creating files to propagate.")
+}
+
+type fixedClock struct{ t time.Time }
+
+func (c fixedClock) Now() time.Time                         { return c.t }
+func (c fixedClock) NewTicker(d time.Duration) *time.Ticker { return time.NewTicker(d) }
@@ -0,0 +1,160 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladzstd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/auwixcom/lad"
+	"github.com/auwixcom/lad/ladcore"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sampleLogs returns n JSON log lines that look like a service's output.
+func sampleLogs(t testing.TB, n int) []byte {
+	var buf bytes.Buffer
+	enc := lad.NewProductionEncoderConfig()
+	core := ladcore.NewCore(ladcore.NewJSONEncoder(enc), ladcore.AddSync(&buf), lad.DebugLevel)
+	logger := lad.New(core).Named("api").With(lad.String("region", "us-east-1"))
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < n; i++ {
+		logger.Info("handled request",
+			lad.Time("at", start.Add(time.Duration(i)*time.Millisecond)),
+			lad.String("method", []string{"GET", "POST", "DELETE"}[i%3]),
+			lad.String("path", fmt.Sprintf("/v1/users/%d/orders", i*7919%10007)),
+			lad.Int("status", []int{200, 201, 404, 500}[i%4]),
+			lad.Duration("latency", time.Duration(i%97)*time.Millisecond),
+		)
+	}
+	require.NoError(t, logger.Sync(), "Unexpected error syncing.")
+	return buf.Bytes()
+}
+
+// compressLines compresses each line as an independent entry and returns
+// the total compressed size.
+func compressLines(t testing.TB, logs []byte, opts ...Option) (int, []byte) {
+	var out bytes.Buffer
+	w, err := NewWriter(&out, append(opts, IndependentEntries())...)
+	require.NoError(t, err, "Unexpected error creating writer.")
+	for _, line := range bytes.SplitAfter(logs, []byte("\n")) {
+		if len(line) > 0 {
+			_, err := w.Write(line)
+			require.NoError(t, err, "Unexpected error writing.")
+		}
+	}
+	require.NoError(t, w.Close(), "Unexpected error closing.")
+	return out.Len(), out.Bytes()
+}
+
+func TestTrainDictionary(t *testing.T) {
+	dict, err := TrainDictionary(bytes.NewReader(sampleLogs(t, 2000)), DictionarySize(8<<10))
+	require.NoError(t, err, "Unexpected error training.")
+
+	id, err := zstd.InspectDictionary(dict)
+	require.NoError(t, err, "Expected a valid dictionary.")
+	assert.GreaterOrEqual(t, id.ID(), uint32(_minDictionaryID), "Expected an unreserved ID.")
+	assert.LessOrEqual(t, id.ContentSize(), 8<<10, "Expected the dictionary to fit the requested size.")
+
+	logs := sampleLogs(t, 500)
+	plain, _ := compressLines(t, logs)
+	withDict, compressed := compressLines(t, logs, Dictionary(dict))
+	assert.Less(t, withDict*2, plain, "Expected the dictionary to at least halve the size of short entries.")
+
+	r, err := NewReader(bytes.NewReader(compressed), dict)
+	require.NoError(t, err, "Unexpected error creating reader.")
+	defer r.Close()
+	got, err := io.ReadAll(r)
+	require.NoError(t, err, "Unexpected error decompressing.")
+	assert.Equal(t, string(logs), string(got), "Expected entries to round-trip.")
+}
+
+func TestTrainDictionaryOptions(t *testing.T) {
+	dict, err := TrainDictionary(bytes.NewReader(sampleLogs(t, 200)), DictionaryID(1<<20))
+	require.NoError(t, err, "Unexpected error training.")
+	id, err := zstd.InspectDictionary(dict)
+	require.NoError(t, err, "Expected a valid dictionary.")
+	assert.Equal(t, uint32(1<<20), id.ID(), "Unexpected dictionary ID.")
+
+	_, err = TrainDictionary(strings.NewReader("short\n\n"))
+	assert.ErrorIs(t, err, ErrNoSamples, "Expected an error without enough samples.")
+}
+
+// syncBuffer is a bytes.Buffer that counts calls to Sync and Close.
+type syncBuffer struct {
+	bytes.Buffer
+
+	syncs, closes int
+}
+
+func (b *syncBuffer) Sync() error {
+	b.syncs++
+	return nil
+}
+
+func (b *syncBuffer) Close() error {
+	b.closes++
+	return nil
+}
+
+func TestWriterStream(t *testing.T) {
+	var out syncBuffer
+	w, err := NewWriter(&out, Level(zstd.SpeedBestCompression))
+	require.NoError(t, err, "Unexpected error creating writer.")
+
+	logger := lad.New(ladcore.NewCore(
+		ladcore.NewJSONEncoder(lad.NewProductionEncoderConfig()), w, lad.DebugLevel,
+	))
+	logger.Info("first")
+	require.NoError(t, logger.Sync(), "Unexpected error syncing.")
+	assert.Equal(t, 1, out.syncs, "Expected Sync to sync the underlying writer.")
+
+	// Everything written before Sync can be read back without closing.
+	r, err := NewReader(bytes.NewReader(out.Bytes()))
+	require.NoError(t, err, "Unexpected error creating reader.")
+	got, _ := io.ReadAll(r) // the frame isn't finished yet
+	r.Close()
+	assert.Contains(t, string(got), `"msg":"first"`, "Expected synced entries to be readable.")
+
+	logger.Info("second")
+	require.NoError(t, w.Close(), "Unexpected error closing.")
+	require.NoError(t, w.Close(), "Expected closing twice to succeed.")
+	assert.Equal(t, 1, out.closes, "Expected Close to close the underlying writer once.")
+
+	_, err = w.Write([]byte("late\n"))
+	assert.Error(t, err, "Expected writes after Close to fail.")
+	assert.Error(t, w.Sync(), "Expected syncs after Close to fail.")
+
+	r, err = NewReader(bytes.NewReader(out.Bytes()))
+	require.NoError(t, err, "Unexpected error creating reader.")
+	defer r.Close()
+	got, err = io.ReadAll(r)
+	require.NoError(t, err, "Unexpected error decompressing.")
+	assert.Equal(t, 2, strings.Count(string(got), "\n"), "Expected both entries.")
+	assert.Contains(t, string(got), `"msg":"second"`, "Expected entries written before Close.")
+}
@@ -0,0 +1,231 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladzstd
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"hash/fnv"
+	"io"
+	"sort"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// _defaultDictionarySize is the size of trained dictionaries, unless
+	// DictionarySize says otherwise.
+	_defaultDictionarySize = 32 << 10
+
+	// Dictionaries are built from segments of _segmentSize bytes, scored by
+	// how common their _dmerSize-byte substrings are across the samples.
+	_segmentSize = 64
+	_dmerSize    = 8
+
+	// Dictionary IDs below 32768 are reserved for registered dictionaries.
+	_minDictionaryID = 1 << 15
+	_maxDictionaryID = 1<<31 - 1
+)
+
+// ErrNoSamples is returned by TrainDictionary if the sample logs are too
+// short to build a dictionary from.
+var ErrNoSamples = errors.New("ladzstd: not enough sample logs to train a dictionary")
+
+// A TrainOption configures TrainDictionary.
+type TrainOption interface {
+	apply(*trainOptions)
+}
+
+type trainOptionFunc func(*trainOptions)
+
+func (f trainOptionFunc) apply(o *trainOptions) {
+	f(o)
+}
+
+type trainOptions struct {
+	size int
+	id   uint32
+}
+
+// DictionarySize sets the maximum size of the dictionary, in bytes. Defaults
+// to 32KiB. Larger dictionaries help with more varied logs, at the cost of
+// memory in every Writer and reader that uses them.
+func DictionarySize(size int) TrainOption {
+	return trainOptionFunc(func(o *trainOptions) {
+		o.size = size
+	})
+}
+
+// DictionaryID sets the ID recorded in the dictionary and in the frames
+// compressed with it. Defaults to an ID derived from the dictionary's
+// content, so different dictionaries are unlikely to share one.
+func DictionaryID(id uint32) TrainOption {
+	return trainOptionFunc(func(o *trainOptions) {
+		o.id = id
+	})
+}
+
+// TrainDictionary builds a Zstandard dictionary from sample logs, read from r
+// one entry per line. Pass it to Writers with the Dictionary option. Use a
+// few thousand entries that are representative of what will be compressed,
+// such as an hour of an existing log file:
+//
+//	f, err := os.Open("app.log")
+//	// ...
+//	dict, err := ladzstd.TrainDictionary(f)
+//
+// The dictionary is built from the substrings most common across the
+// samples--keys, messages, and the boilerplate between them--and works best
+// with entries compressed individually; see IndependentEntries.
+func TrainDictionary(r io.Reader, opts ...TrainOption) ([]byte, error) {
+	o := trainOptions{size: _defaultDictionarySize}
+	for _, opt := range opts {
+		opt.apply(&o)
+	}
+
+	var (
+		samples [][]byte
+		corpus  []byte
+	)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		start := len(corpus)
+		corpus = append(append(corpus, line...), '\n')
+		samples = append(samples, corpus[start:len(corpus):len(corpus)])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(corpus) < _segmentSize {
+		return nil, ErrNoSamples
+	}
+
+	history := selectSegments(corpus, o.size)
+	if o.id == 0 {
+		o.id = contentID(history)
+	}
+	return zstd.BuildDict(zstd.BuildDictOptions{
+		ID:       o.id,
+		Contents: samples,
+		History:  history,
+		Offsets:  [3]int{1, 4, 8},
+		Level:    zstd.SpeedDefault,
+	})
+}
+
+// A segment is a candidate piece of dictionary content.
+type segment struct {
+	start, score int
+}
+
+// selectSegments picks dictionary content from the corpus, in the manner of
+// zstd's COVER algorithm: it splits the corpus into one epoch for each
+// segment that fits in the dictionary, takes the segment from each epoch
+// whose d-mers are most frequent in the whole corpus, and orders them with
+// the best last, where they're cheapest to refer to.
+func selectSegments(corpus []byte, size int) []byte {
+	if len(corpus) <= size {
+		return corpus
+	}
+
+	freq := make(map[uint64]int)
+	for i := 0; i+_dmerSize <= len(corpus); i++ {
+		freq[dmer(corpus, i)]++
+	}
+
+	epochs := size / _segmentSize
+	if epochs < 1 {
+		epochs = 1
+	}
+	epochSize := len(corpus) / epochs
+	if epochSize < _segmentSize {
+		epochSize = _segmentSize
+	}
+
+	var chosen []segment
+	for start := 0; start+_segmentSize <= len(corpus); start += epochSize {
+		end := start + epochSize
+		if end > len(corpus) {
+			end = len(corpus)
+		}
+		best, ok := bestSegment(corpus, start, end, freq)
+		if !ok {
+			continue
+		}
+		chosen = append(chosen, best)
+		// Don't reward later segments for the same content.
+		for i := best.start; i+_dmerSize <= best.start+_segmentSize; i++ {
+			freq[dmer(corpus, i)] = 0
+		}
+	}
+
+	sort.SliceStable(chosen, func(i, j int) bool { return chosen[i].score < chosen[j].score })
+	content := make([]byte, 0, len(chosen)*_segmentSize)
+	for _, s := range chosen {
+		content = append(content, corpus[s.start:s.start+_segmentSize]...)
+	}
+	if len(content) > size {
+		content = content[len(content)-size:]
+	}
+	return content
+}
+
+// bestSegment finds the segment within corpus[start:end] whose d-mers are
+// most frequent, sliding a window over the epoch.
+func bestSegment(corpus []byte, start, end int, freq map[uint64]int) (segment, bool) {
+	if end-start < _segmentSize {
+		return segment{}, false
+	}
+
+	// The window [i, i+_segmentSize) holds the d-mers starting in
+	// [i, i+_segmentSize-_dmerSize].
+	const dmers = _segmentSize - _dmerSize + 1
+	score := 0
+	for j := start; j < start+dmers; j++ {
+		score += freq[dmer(corpus, j)]
+	}
+	best := segment{start: start, score: score}
+	for i := start + 1; i+_segmentSize <= end; i++ {
+		score -= freq[dmer(corpus, i-1)]
+		score += freq[dmer(corpus, i+dmers-1)]
+		if score > best.score {
+			best = segment{start: i, score: score}
+		}
+	}
+	return best, best.score > 0
+}
+
+func dmer(b []byte, i int) uint64 {
+	return binary.LittleEndian.Uint64(b[i : i+_dmerSize])
+}
+
+// contentID derives a dictionary ID from its content.
+func contentID(content []byte) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write(content)
+	return _minDictionaryID + h.Sum32()%(_maxDictionaryID-_minDictionaryID+1)
+}
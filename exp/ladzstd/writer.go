@@ -0,0 +1,193 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package ladzstd compresses logs with Zstandard, for archiving them. Short,
+// similar log lines compress poorly on their own, so the package can also
+// train a dictionary from sample logs and compress with it.
+package ladzstd
+
+import (
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/auwixcom/lad/ladcore"
+	"github.com/klauspost/compress/zstd"
+	"go.uber.org/multierr"
+)
+
+var errClosed = errors.New("ladzstd: writer is closed")
+
+// An Option configures a Writer.
+type Option interface {
+	apply(*writerOptions)
+}
+
+type optionFunc func(*writerOptions)
+
+func (f optionFunc) apply(o *writerOptions) {
+	f(o)
+}
+
+type writerOptions struct {
+	dict        []byte
+	level       zstd.EncoderLevel
+	independent bool
+}
+
+// Dictionary compresses with a dictionary, such as one built by
+// TrainDictionary. The same dictionary must be passed to NewReader to read
+// the output back.
+func Dictionary(dict []byte) Option {
+	return optionFunc(func(o *writerOptions) {
+		o.dict = dict
+	})
+}
+
+// Level sets the compression level. Defaults to zstd.SpeedDefault.
+func Level(level zstd.EncoderLevel) Option {
+	return optionFunc(func(o *writerOptions) {
+		o.level = level
+	})
+}
+
+// IndependentEntries compresses each write, which is one entry when the
+// Writer backs a ladcore.Core, as a frame of its own, so that entries can be
+// decompressed individually. Without a dictionary, this compresses short
+// entries poorly; with one, it's the archival mode dictionaries are meant
+// for.
+func IndependentEntries() Option {
+	return optionFunc(func(o *writerOptions) {
+		o.independent = true
+	})
+}
+
+// A Writer is a ladcore.WriteSyncer that compresses everything written to it
+// before passing it on. Sync flushes the compressed data written so far to
+// the underlying writer, and syncs that too if it's a ladcore.WriteSyncer.
+// Close must be called to finish the output. A Writer is safe for concurrent
+// use.
+//
+//	f, err := os.Create("app.log.zst")
+//	// ...
+//	w, err := ladzstd.NewWriter(f, ladzstd.Dictionary(dict))
+//	// ...
+//	defer w.Close()
+//	core := ladcore.NewCore(enc, w, lad.InfoLevel)
+type Writer struct {
+	mu          sync.Mutex
+	w           io.Writer
+	enc         *zstd.Encoder
+	independent bool
+	buf         []byte // for independent frames
+	closed      bool
+}
+
+var _ ladcore.WriteSyncer = (*Writer)(nil)
+
+// NewWriter builds a Writer that compresses to w.
+func NewWriter(w io.Writer, opts ...Option) (*Writer, error) {
+	o := writerOptions{level: zstd.SpeedDefault}
+	for _, opt := range opts {
+		opt.apply(&o)
+	}
+
+	eopts := []zstd.EOption{
+		zstd.WithEncoderLevel(o.level),
+		zstd.WithEncoderConcurrency(1),
+	}
+	if o.dict != nil {
+		eopts = append(eopts, zstd.WithEncoderDict(o.dict))
+	}
+	dst := w
+	if o.independent {
+		// Frames are built with EncodeAll, so the streaming side is unused.
+		dst = nil
+	}
+	enc, err := zstd.NewWriter(dst, eopts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Writer{w: w, enc: enc, independent: o.independent}, nil
+}
+
+// Write compresses p.
+func (w *Writer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return 0, errClosed
+	}
+	if !w.independent {
+		return w.enc.Write(p)
+	}
+
+	w.buf = w.enc.EncodeAll(p, w.buf[:0])
+	if _, err := w.w.Write(w.buf); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Sync flushes compressed data to the underlying writer and syncs it.
+func (w *Writer) Sync() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return errClosed
+	}
+	var err error
+	if !w.independent {
+		err = w.enc.Flush()
+	}
+	if ws, ok := w.w.(ladcore.WriteSyncer); ok {
+		err = multierr.Append(err, ws.Sync())
+	}
+	return err
+}
+
+// Close finishes the compressed output and closes the underlying writer if
+// it's an io.Closer.
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	err := w.enc.Close()
+	if c, ok := w.w.(io.Closer); ok {
+		err = multierr.Append(err, c.Close())
+	}
+	return err
+}
+
+// NewReader decompresses output written by a Writer, using any dictionaries
+// it was compressed with.
+func NewReader(r io.Reader, dicts ...[]byte) (io.ReadCloser, error) {
+	dec, err := zstd.NewReader(r, zstd.WithDecoderDicts(dicts...), zstd.WithDecoderConcurrency(1))
+	if err != nil {
+		return nil, err
+	}
+	return dec.IOReadCloser(), nil
+}
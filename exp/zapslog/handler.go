@@ -39,6 +39,7 @@ type Handler struct {
 	addCaller  bool
 	addStackAt slog.Level
 	callerSkip int
+	levelOf    func(slog.Level) ladcore.Level
 
 	// List of unapplied groups.
 	//
@@ -54,6 +55,7 @@ func NewHandler(core ladcore.Core, opts ...HandlerOption) *Handler {
 	h := &Handler{
 		core:       core,
 		addStackAt: slog.LevelError,
+		levelOf:    convertSlogLevel,
 	}
 	for _, v := range opts {
 		v.apply(h)
@@ -131,13 +133,13 @@ func convertSlogLevel(l slog.Level) ladcore.Level {
 
 // Enabled reports whether the handler handles records at the given level.
 func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
-	return h.core.Enabled(convertSlogLevel(level))
+	return h.core.Enabled(h.levelOf(level))
 }
 
 // Handle handles the Record.
 func (h *Handler) Handle(ctx context.Context, record slog.Record) error {
 	ent := ladcore.Entry{
-		Level:      convertSlogLevel(record.Level),
+		Level:      h.levelOf(record.Level),
 		Time:       record.Time,
 		Message:    record.Message,
 		LoggerName: h.name,
@@ -24,6 +24,7 @@ package ladslog
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"log/slog"
 	"sync"
@@ -155,6 +156,36 @@ func TestWithName(t *testing.T) {
 	})
 }
 
+func TestWithLevelMapper(t *testing.T) {
+	fac, observedLogs := observer.New(ladcore.InfoLevel)
+
+	t.Run("default", func(t *testing.T) {
+		sl := slog.New(NewHandler(fac))
+		sl.Log(context.Background(), slog.LevelInfo-2, "below info")
+		sl.Log(context.Background(), slog.LevelError+4, "above error")
+
+		logs := observedLogs.TakeAll()
+		require.Len(t, logs, 1, "Expected exactly one entry to be logged.")
+		assert.Equal(t, ladcore.ErrorLevel, logs[0].Level, "Unexpected level.")
+	})
+	t.Run("custom", func(t *testing.T) {
+		mapper := func(l slog.Level) ladcore.Level {
+			if l > slog.LevelError {
+				return ladcore.DPanicLevel
+			}
+			return ladcore.InfoLevel
+		}
+		sl := slog.New(NewHandler(fac, WithLevelMapper(mapper)))
+		sl.Log(context.Background(), slog.LevelInfo-2, "below info")
+		sl.Log(context.Background(), slog.LevelError+4, "above error")
+
+		logs := observedLogs.TakeAll()
+		require.Len(t, logs, 2, "Expected both entries to be logged.")
+		assert.Equal(t, ladcore.InfoLevel, logs[0].Level, "Unexpected level.")
+		assert.Equal(t, ladcore.DPanicLevel, logs[1].Level, "Unexpected level.")
+	})
+}
+
 func TestInlineGroup(t *testing.T) {
 	fac, observedLogs := observer.New(ladcore.DebugLevel)
 
@@ -22,7 +22,11 @@
 
 package ladslog
 
-import "log/slog"
+import (
+	"log/slog"
+
+	"github.com/auwixcom/lad/ladcore"
+)
 
 // A HandlerOption configures a slog Handler.
 type HandlerOption interface {
@@ -70,3 +74,15 @@ func AddStacktraceAt(lvl slog.Level) HandlerOption {
 		log.addStackAt = lvl
 	})
 }
+
+// WithLevelMapper configures how the Handler translates slog levels into lad
+// levels. By default, levels are rounded down to the nearest of Debug, Info,
+// Warn and Error. A nil mapper restores the default.
+func WithLevelMapper(f func(slog.Level) ladcore.Level) HandlerOption {
+	return handlerOptionFunc(func(handler *Handler) {
+		if f == nil {
+			f = convertSlogLevel
+		}
+		handler.levelOf = f
+	})
+}
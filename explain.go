@@ -0,0 +1,289 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lad
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/auwixcom/lad/ladcore"
+)
+
+// Explain describes the logging pipeline that Build would construct from the
+// Config: levels, encoding, outputs, sampling, and filters, in the order
+// entries pass through them. It doesn't open any outputs, so it's safe to
+// call on configurations that can't be built; problems Build would report
+// are included in the description.
+func (cfg Config) Explain() string {
+	var b strings.Builder
+	line := func(format string, args ...interface{}) {
+		fmt.Fprintf(&b, format+"\n", args...)
+	}
+
+	if cfg.Level == (AtomicLevel{}) {
+		line("level: missing (Build will fail)")
+	} else {
+		line("level: %v and above (dynamic)", cfg.Level.Level())
+	}
+	line("development: %v", cfg.Development)
+	if cfg.DisableCaller {
+		line("caller: disabled")
+	} else {
+		line("caller: enabled")
+	}
+	if cfg.DisableStacktrace {
+		line("stacktraces: disabled")
+	} else {
+		line("stacktraces: %v and above", cfg.stacktraceLevel())
+	}
+
+	if cfg.DynamicFilters != (AtomicFilters{}) {
+		rules := cfg.DynamicFilters.Rules()
+		line("dynamic filters: %d drop rule(s), replaceable at runtime", len(rules))
+		for i, r := range rules {
+			line("  %d. drop if %s", i+1, r.Match)
+		}
+	}
+	if s := cfg.Sampling; s != nil {
+		line("sampling: %s", describeSampling(*s))
+	} else {
+		line("sampling: disabled")
+	}
+	if len(cfg.Filters) > 0 {
+		line("filters (first match wins):")
+		for i, f := range cfg.Filters {
+			line("  %d. %s", i+1, describeFilter(f))
+		}
+	}
+
+	enc := cfg.Encoding
+	if enc == "" {
+		enc = "missing (Build will fail)"
+	}
+	line("encoding: %s", enc)
+	if keys := encoderKeys(cfg.EncoderConfig); len(keys) > 0 {
+		line("  keys: %s", strings.Join(keys, ", "))
+	}
+	if len(cfg.InitialFields) > 0 {
+		line("initial fields: %s", strings.Join(sortedKeys(cfg.InitialFields), ", "))
+	}
+	if cfg.SchemaVersion != "" {
+		line("schema version: %s (stamped as %q)", cfg.SchemaVersion, SchemaVersionKey)
+	}
+	if len(cfg.NamedFields) > 0 {
+		names := make([]string, 0, len(cfg.NamedFields))
+		for name := range cfg.NamedFields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		line("named fields:")
+		for _, name := range names {
+			line("  %s: %s", name, strings.Join(sortedKeys(cfg.NamedFields[name]), ", "))
+		}
+	}
+	line("outputs: %s", cfg.describeOutputs())
+	line("error outputs: %s", describePaths(cfg.ErrorOutputPaths))
+	return b.String()
+}
+
+func (cfg Config) stacktraceLevel() ladcore.Level {
+	if cfg.Development {
+		return WarnLevel
+	}
+	return ErrorLevel
+}
+
+func describeSampling(s SamplingConfig) string {
+	per := "per level and message"
+	if len(s.KeyFields) > 0 {
+		per = fmt.Sprintf("per level, message, and value of %s", strings.Join(s.KeyFields, ", "))
+	}
+	rest := fmt.Sprintf("then every %dth", s.Thereafter)
+	switch s.Thereafter {
+	case 0:
+		rest = "then drop the rest"
+	case 1:
+		rest = "then every entry"
+	}
+	return fmt.Sprintf("%s, each second log the first %d entries, %s", per, s.Initial, rest)
+}
+
+func describeFilter(f FilterConfig) string {
+	desc := fmt.Sprintf("drop if %s", f.Match)
+	switch f.Action {
+	case "", _filterActionDrop:
+	case _filterActionRoute:
+		desc = fmt.Sprintf("route to %s if %s", describePaths(f.OutputPaths), f.Match)
+	default:
+		desc = fmt.Sprintf("%q if %s", f.Action, f.Match)
+	}
+	if _, err := parseFilter(f.Match); err != nil {
+		desc += fmt.Sprintf(" (Build will fail: %v)", err)
+	}
+	return desc
+}
+
+func encoderKeys(ec ladcore.EncoderConfig) []string {
+	var keys []string
+	for _, k := range []struct{ name, key string }{
+		{"time", ec.TimeKey},
+		{"level", ec.LevelKey},
+		{"name", ec.NameKey},
+		{"caller", ec.CallerKey},
+		{"function", ec.FunctionKey},
+		{"message", ec.MessageKey},
+		{"stacktrace", ec.StacktraceKey},
+	} {
+		if k.key != ladcore.OmitKey {
+			keys = append(keys, fmt.Sprintf("%s=%q", k.name, k.key))
+		}
+	}
+	return keys
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// describeOutputs lists the OutputPaths, with their levels, if any.
+func (cfg Config) describeOutputs() string {
+	paths := make([]string, len(cfg.OutputPaths))
+	for i, path := range cfg.OutputPaths {
+		paths[i] = path
+		if lvl, ok := cfg.OutputLevels[path]; ok {
+			paths[i] += fmt.Sprintf(" (%v and above)", lvl)
+		}
+	}
+	if err := cfg.checkOutputLevels(); err != nil {
+		paths = append(paths, fmt.Sprintf("(Build will fail: %v)", err))
+	}
+	return describePaths(paths)
+}
+
+func describePaths(paths []string) string {
+	if len(paths) == 0 {
+		return "none"
+	}
+	return strings.Join(paths, ", ")
+}
+
+// EntryExplanation reports what a logger built from a Config would do with an
+// entry. See Config.ExplainEntry.
+type EntryExplanation struct {
+	// Emitted reports whether the entry would be written.
+	Emitted bool
+	// Outputs are the paths the entry would be written to.
+	Outputs []string
+	// Reason explains the outcome.
+	Reason string
+	// Sampled reports whether the entry would be subject to sampling, and
+	// so might be dropped if many similar entries are logged.
+	Sampled bool
+}
+
+// String returns a one-line summary of the explanation.
+func (e EntryExplanation) String() string {
+	var b strings.Builder
+	if e.Emitted {
+		fmt.Fprintf(&b, "emitted to %s: %s", describePaths(e.Outputs), e.Reason)
+	} else {
+		fmt.Fprintf(&b, "dropped: %s", e.Reason)
+	}
+	if e.Sampled {
+		b.WriteString(" (subject to sampling)")
+	}
+	return b.String()
+}
+
+// ExplainEntry reports whether, and where, a logger built from the Config
+// would write an entry with the given level and logger name. Since there's no
+// message, filters that look at messages are evaluated against an empty one.
+// It returns an error if the Config has no level or has invalid filters.
+func (cfg Config) ExplainEntry(lvl ladcore.Level, loggerName string) (EntryExplanation, error) {
+	if cfg.Level == (AtomicLevel{}) {
+		return EntryExplanation{}, errMissingLevel
+	}
+	if !cfg.Level.Enabled(lvl) {
+		return EntryExplanation{
+			Reason: fmt.Sprintf("%v is below the minimum level %v", lvl, cfg.Level.Level()),
+		}, nil
+	}
+
+	ent := ladcore.Entry{Level: lvl, LoggerName: loggerName}
+	if cfg.DynamicFilters != (AtomicFilters{}) {
+		for i, r := range cfg.DynamicFilters.Rules() {
+			match, err := parseFilter(r.Match)
+			if err != nil {
+				return EntryExplanation{}, err
+			}
+			if match(ent) {
+				return EntryExplanation{
+					Reason: fmt.Sprintf("matches dynamic filter %d (%s)", i+1, r.Match),
+				}, nil
+			}
+		}
+	}
+
+	sampled := cfg.Sampling != nil
+	for i, f := range cfg.Filters {
+		match, err := parseFilter(f.Match)
+		if err != nil {
+			return EntryExplanation{}, err
+		}
+		if !match(ent) {
+			continue
+		}
+		if f.Action == _filterActionRoute {
+			return EntryExplanation{
+				Emitted: true,
+				Outputs: f.OutputPaths,
+				Reason:  fmt.Sprintf("routed by filter %d (%s)", i+1, f.Match),
+				Sampled: sampled,
+			}, nil
+		}
+		return EntryExplanation{
+			Reason: fmt.Sprintf("matches filter %d (%s)", i+1, f.Match),
+		}, nil
+	}
+
+	var outputs []string
+	for _, path := range cfg.OutputPaths {
+		if min, ok := cfg.OutputLevels[path]; !ok || lvl >= min {
+			outputs = append(outputs, path)
+		}
+	}
+	reason := "no filter matches"
+	if len(outputs) < len(cfg.OutputPaths) {
+		reason += "; some outputs require a higher level"
+	}
+	return EntryExplanation{
+		Emitted: len(outputs) > 0,
+		Outputs: outputs,
+		Reason:  reason,
+		Sampled: sampled,
+	}, nil
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lad
+
+import (
+	"testing"
+
+	"github.com/auwixcom/lad/ladcore"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func explainTestConfig() Config {
+	cfg := NewProductionConfig()
+	cfg.InitialFields = map[string]interface{}{"service": "api", "env": "prod"}
+	cfg.NamedFields = map[string]map[string]interface{}{
+		"payments.db": {"db": "primary"},
+		"payments":    {"team": 7, "component": "payments"},
+	}
+	cfg.Filters = []FilterConfig{
+		{Match: `level < warn AND logger prefix "vendor."`},
+		{Match: `logger = audit`, Action: "route", OutputPaths: []string{"audit.log"}},
+	}
+	return cfg
+}
+
+func TestConfigExplain(t *testing.T) {
+	cfg := explainTestConfig()
+	cfg.DynamicFilters = NewAtomicFilters()
+	require.NoError(t, cfg.DynamicFilters.SetRules(FilterConfig{Match: `logger = noisy`}), "Unexpected error setting rules.")
+
+	assert.Equal(t, `level: info and above (dynamic)
+development: false
+caller: enabled
+stacktraces: error and above
+dynamic filters: 1 drop rule(s), replaceable at runtime
+  1. drop if logger = noisy
+sampling: per level and message, each second log the first 100 entries, then every 100th
+filters (first match wins):
+  1. drop if level < warn AND logger prefix "vendor."
+  2. route to audit.log if logger = audit
+encoding: json
+  keys: time="ts", level="level", name="logger", caller="caller", message="msg", stacktrace="stacktrace"
+initial fields: env, service
+named fields:
+  payments: component, team
+  payments.db: db
+outputs: stderr
+error outputs: stderr
+`, cfg.Explain(), "Unexpected explanation.")
+}
+
+func TestConfigExplainSampling(t *testing.T) {
+	tests := []struct {
+		cfg  SamplingConfig
+		want string
+	}{
+		{
+			SamplingConfig{Initial: 10, Thereafter: 0},
+			"per level and message, each second log the first 10 entries, then drop the rest",
+		},
+		{
+			SamplingConfig{Initial: 10, Thereafter: 1},
+			"per level and message, each second log the first 10 entries, then every entry",
+		},
+		{
+			SamplingConfig{Initial: 5, Thereafter: 50, KeyFields: []string{"endpoint", "status"}},
+			"per level, message, and value of endpoint, status, each second log the first 5 entries, then every 50th",
+		},
+	}
+	for _, tt := range tests {
+		cfg := NewProductionConfig()
+		cfg.Sampling = &tt.cfg
+		assert.Contains(t, cfg.Explain(), "\nsampling: "+tt.want+"\n", "Unexpected sampling explanation for %+v.", tt.cfg)
+	}
+}
+
+func TestConfigExplainProblems(t *testing.T) {
+	cfg := Config{
+		DisableCaller:     true,
+		DisableStacktrace: true,
+		Filters:           []FilterConfig{{Match: "level <", Action: "shout"}},
+	}
+	assert.Equal(t, `level: missing (Build will fail)
+development: false
+caller: disabled
+stacktraces: disabled
+sampling: disabled
+filters (first match wins):
+  1. "shout" if level < (Build will fail: invalid filter "level <": unexpected end of filter)
+encoding: missing (Build will fail)
+outputs: none
+error outputs: none
+`, cfg.Explain(), "Unexpected explanation.")
+}
+
+func TestConfigExplainEntry(t *testing.T) {
+	cfg := explainTestConfig()
+	cfg.DynamicFilters = NewAtomicFilters()
+	require.NoError(t, cfg.DynamicFilters.SetRules(FilterConfig{Match: `logger = noisy`}), "Unexpected error setting rules.")
+
+	tests := []struct {
+		lvl    ladcore.Level
+		logger string
+		want   string
+	}{
+		{DebugLevel, "", "dropped: debug is below the minimum level info"},
+		{InfoLevel, "noisy", "dropped: matches dynamic filter 1 (logger = noisy)"},
+		{InfoLevel, "vendor.sql", `dropped: matches filter 1 (level < warn AND logger prefix "vendor.")`},
+		{ErrorLevel, "vendor.sql", "emitted to stderr: no filter matches (subject to sampling)"},
+		{InfoLevel, "audit", "emitted to audit.log: routed by filter 2 (logger = audit) (subject to sampling)"},
+	}
+	for _, tt := range tests {
+		got, err := cfg.ExplainEntry(tt.lvl, tt.logger)
+		require.NoError(t, err, "Unexpected error explaining entry.")
+		assert.Equal(t, tt.want, got.String(), "Unexpected explanation for %v entry from %q.", tt.lvl, tt.logger)
+	}
+
+	cfg.Sampling = nil
+	got, err := cfg.ExplainEntry(InfoLevel, "app")
+	require.NoError(t, err, "Unexpected error explaining entry.")
+	assert.Equal(t, EntryExplanation{
+		Emitted: true,
+		Outputs: []string{"stderr"},
+		Reason:  "no filter matches",
+	}, got, "Unexpected explanation.")
+
+	cfg.OutputPaths = []string{"stderr", "error.log"}
+	cfg.OutputLevels = map[string]ladcore.Level{"error.log": ErrorLevel}
+	got, err = cfg.ExplainEntry(InfoLevel, "app")
+	require.NoError(t, err, "Unexpected error explaining entry.")
+	assert.Equal(t, "emitted to stderr: no filter matches; some outputs require a higher level", got.String(),
+		"Expected outputs at higher levels to be left out.")
+	assert.Contains(t, cfg.Explain(), "outputs: stderr, error.log (error and above)\n", "Expected output levels in the description.")
+
+	_, err = Config{}.ExplainEntry(InfoLevel, "")
+	assert.ErrorIs(t, err, errMissingLevel, "Expected an error without a level.")
+
+	cfg.Filters = []FilterConfig{{Match: "level <"}}
+	_, err = cfg.ExplainEntry(InfoLevel, "")
+	assert.ErrorContains(t, err, "invalid filter", "Expected an error for invalid filters.")
+}
@@ -23,6 +23,12 @@ package lad
 import (
 	"fmt"
 	"math"
+	"math/big"
+	"net/netip"
+	"net/url"
+	"runtime"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/auwixcom/lad/internal/stacktrace"
@@ -223,6 +229,20 @@ func String(key string, val string) Field {
 	return Field{Key: key, Type: ladcore.StringType, String: val}
 }
 
+// Redacted constructs a field that records the presence of a sensitive
+// value without revealing it; the value is encoded as "<redacted>". See
+// ladcore.NewRedactionCore to redact fields by key or pattern instead.
+func Redacted(key string, val string) Field {
+	return String(key, ladcore.RedactMask.Redact(val))
+}
+
+// RedactedHash constructs a field that carries a truncated SHA-256 digest of
+// a sensitive value instead of the value itself, so that entries about the
+// same value can be correlated. See ladcore.RedactHash for its limits.
+func RedactedHash(key string, val string) Field {
+	return String(key, ladcore.RedactHash.Redact(val))
+}
+
 // Stringp constructs a field that carries a *string. The returned Field will safely
 // and explicitly represent `nil` when appropriate.
 func Stringp(key string, val *string) Field {
@@ -316,6 +336,97 @@ func Uintptrp(key string, val *uintptr) Field {
 	return Uintptr(key, *val)
 }
 
+// Count constructs a field that carries an increment to the counter named by
+// key. Encoders log it like Int64, but metrics cores (see
+// ladcore.NewMetricsCore) also report it as a counter metric. Pass it at the
+// log site; metrics cores ignore counters added with With.
+func Count(key string, n int64) Field {
+	return Field{Key: key, Type: ladcore.Int64Type, Integer: n, Interface: ladcore.CounterMetric}
+}
+
+// Gauge constructs a field that carries the current value of the gauge named
+// by key. Encoders log it like Float64, but metrics cores (see
+// ladcore.NewMetricsCore) also report it as a gauge metric.
+func Gauge(key string, val float64) Field {
+	return Field{Key: key, Type: ladcore.Float64Type, Integer: int64(math.Float64bits(val)), Interface: ladcore.GaugeMetric}
+}
+
+// Money constructs a field that carries a monetary amount, encoded as an
+// object with an integer "amount" in the currency's minor units (cents, for
+// example) and a "currency" code, typically ISO 4217. Keeping amounts in
+// minor units avoids the rounding errors of floating point:
+//
+//	lad.Money("total", 1999, "USD") // {"amount": 1999, "currency": "USD"}
+func Money(key string, minorUnits int64, currency string) Field {
+	return Object(key, moneyObject{amount: minorUnits, currency: currency})
+}
+
+type moneyObject struct {
+	amount   int64
+	currency string
+}
+
+func (m moneyObject) MarshalLogObject(enc ladcore.ObjectEncoder) error {
+	enc.AddInt64("amount", m.amount)
+	enc.AddString("currency", m.currency)
+	return nil
+}
+
+// LatLon constructs a field that carries a geographic coordinate in degrees,
+// encoded as a GeoJSON Point. Note that GeoJSON orders coordinates longitude
+// first:
+//
+//	lad.LatLon("location", 40.7, -74.0) // {"type": "Point", "coordinates": [-74.0, 40.7]}
+func LatLon(key string, lat, lon float64) Field {
+	return Object(key, geoPoint{lat: lat, lon: lon})
+}
+
+type geoPoint struct {
+	lat, lon float64
+}
+
+func (p geoPoint) MarshalLogObject(enc ladcore.ObjectEncoder) error {
+	enc.AddString("type", "Point")
+	return enc.AddArray("coordinates", p)
+}
+
+func (p geoPoint) MarshalLogArray(arr ladcore.ArrayEncoder) error {
+	arr.AppendFloat64(p.lon)
+	arr.AppendFloat64(p.lat)
+	return nil
+}
+
+// Flags constructs a field that carries a bitset, such as a permission mask,
+// encoded as an object with the raw "value" and the "set" bits as an array of
+// names. names[i] names bit i; set bits without a name are logged as "bit<i>":
+//
+//	lad.Flags("perms", 0b101, []string{"read", "write", "exec"}) // {"value": 5, "set": ["read", "exec"]}
+func Flags(key string, value uint64, names []string) Field {
+	return Object(key, flagsObject{value: value, names: names})
+}
+
+type flagsObject struct {
+	value uint64
+	names []string
+}
+
+func (f flagsObject) MarshalLogObject(enc ladcore.ObjectEncoder) error {
+	enc.AddUint64("value", f.value)
+	return enc.AddArray("set", ladcore.ArrayMarshalerFunc(func(arr ladcore.ArrayEncoder) error {
+		for i := 0; i < 64; i++ {
+			if f.value&(1<<uint(i)) == 0 {
+				continue
+			}
+			if i < len(f.names) && f.names[i] != "" {
+				arr.AppendString(f.names[i])
+			} else {
+				arr.AppendString("bit" + strconv.Itoa(i))
+			}
+		}
+		return nil
+	}))
+}
+
 // Reflect constructs a field with the given key and an arbitrary object. It uses
 // an encoding-appropriate, reflection-based function to lazily serialize nearly
 // any object into the logging context, but it's relatively slow and
@@ -342,6 +453,40 @@ func Stringer(key string, val fmt.Stringer) Field {
 	return Field{Key: key, Type: ladcore.StringerType, Interface: val}
 }
 
+// UUID constructs a field that carries a UUID, encoded in its canonical
+// form (for example, "6ba7b810-9dad-11d1-80b4-00c04fd430c8"). Since popular
+// UUID packages define their UUID types as [16]byte, their values can be
+// passed directly. Constructing the field doesn't allocate, and UUIDs are
+// formatted without fmt or intermediate strings.
+func UUID(key string, val [16]byte) Field {
+	return ladcore.UUIDField(key, val)
+}
+
+// NetIPAddr constructs a field that carries a netip.Addr, encoded in its
+// standard form (for example, "192.0.2.1" or "2001:db8::1"). Addresses are
+// formatted without fmt or intermediate strings.
+func NetIPAddr(key string, val netip.Addr) Field {
+	return Field{Key: key, Type: ladcore.NetIPAddrType, Interface: val}
+}
+
+// NetIPPrefix constructs a field that carries a netip.Prefix, encoded in CIDR
+// notation (for example, "192.0.2.0/24"). Like NetIPAddr, it doesn't format
+// through fmt or intermediate strings.
+func NetIPPrefix(key string, val netip.Prefix) Field {
+	return Field{Key: key, Type: ladcore.NetIPPrefixType, Interface: val}
+}
+
+// URL constructs a field that carries a URL. The URL is formatted lazily,
+// with any password replaced by "xxxxx" as url.URL's Redacted method does,
+// so credentials embedded in connection strings aren't logged. The returned
+// Field will safely and explicitly represent `nil` when appropriate.
+func URL(key string, val *url.URL) Field {
+	if val == nil {
+		return nilField(key)
+	}
+	return Field{Key: key, Type: ladcore.URLType, Interface: val}
+}
+
 // Time constructs a Field with the given key and value. The encoder
 // controls how the time is serialized.
 func Time(key string, val time.Time) Field {
@@ -351,6 +496,24 @@ func Time(key string, val time.Time) Field {
 	return Field{Key: key, Type: ladcore.TimeType, Integer: val.UnixNano(), Interface: val.Location()}
 }
 
+// Timestamp constructs a field that replaces the entry's timestamp with t,
+// for replaying historical events or logging on behalf of delayed batch
+// items. The field itself isn't encoded, and it only takes effect when
+// passed at the log site; it's ignored in With.
+func Timestamp(t time.Time) Field {
+	return Field{Type: ladcore.TimestampType, Interface: t}
+}
+
+// TimestampWithEmitTime is like Timestamp, but preserves the time the entry
+// was actually logged under the given key.
+//
+//	logger.Info("order shipped",
+//	  lad.TimestampWithEmitTime(order.ShippedAt, "logged_at"),
+//	)
+func TimestampWithEmitTime(t time.Time, key string) Field {
+	return Field{Key: key, Type: ladcore.TimestampType, Interface: t}
+}
+
 // Timep constructs a field that carries a *time.Time. The returned Field will safely
 // and explicitly represent `nil` when appropriate.
 func Timep(key string, val *time.Time) Field {
@@ -378,6 +541,45 @@ func StackSkip(key string, skip int) Field {
 	return String(key, stacktrace.Take(skip+1)) // skip StackSkip
 }
 
+// Frame constructs a field that carries a runtime.Frame, such as one obtained
+// from runtime.CallersFrames. The frame is encoded as an object with
+// "function", "file", and "line" keys.
+func Frame(key string, frame runtime.Frame) Field {
+	return Object(key, frameObject(frame))
+}
+
+// Caller constructs a field that records the call frame skip levels above the
+// caller of Caller. skip=0 identifies the function calling Caller.
+//
+// Unlike the AddCaller option, which annotates an entry with the site of the
+// logging call, Caller lets helper libraries attach the origin of an
+// operation even when the entry itself is emitted elsewhere. If the frame
+// can't be determined, the field is a no-op.
+func Caller(key string, skip int) Field {
+	stack := stacktrace.Capture(skip+1, stacktrace.First) // skip Caller
+	defer stack.Free()
+
+	if stack.Count() == 0 {
+		return Skip()
+	}
+	frame, _ := stack.Next()
+	return Frame(key, frame)
+}
+
+// CallerAt is shorthand for Caller("caller", skip).
+func CallerAt(skip int) Field {
+	return Caller("caller", skip+1) // skip CallerAt
+}
+
+type frameObject runtime.Frame
+
+func (f frameObject) MarshalLogObject(enc ladcore.ObjectEncoder) error {
+	enc.AddString("function", f.Function)
+	enc.AddString("file", f.File)
+	enc.AddInt("line", f.Line)
+	return nil
+}
+
 // Duration constructs a field with the given key and value. The encoder
 // controls how the duration is serialized.
 func Duration(key string, val time.Duration) Field {
@@ -393,6 +595,12 @@ func Durationp(key string, val *time.Duration) Field {
 	return Duration(key, *val)
 }
 
+// Since constructs a field that carries the time elapsed since t, encoded like
+// any other Duration. It's shorthand for Duration(key, time.Since(t)).
+func Since(key string, t time.Time) Field {
+	return Duration(key, time.Since(t))
+}
+
 // Object constructs a field with the given key and ObjectMarshaler. It
 // provides a flexible, but still type-safe and efficient, way to add map- or
 // struct-like user-defined types to the logging context. The struct's
@@ -411,6 +619,47 @@ func Inline(val ladcore.ObjectMarshaler) Field {
 	}
 }
 
+// Lazy constructs a field whose value is computed by fn only when the entry
+// is written, that is, after it's passed the level and sampling checks. The
+// field returned by fn is logged under key. Use it for values that are
+// expensive to compute, such as serialized payloads.
+//
+// fn is called at most once, even if the entry is written to several cores,
+// and the computed field is subject to redaction like any other.
+//
+// Fields added with Logger.With are encoded right away; use Logger.WithLazy
+// to defer those too.
+func Lazy(key string, fn func() Field) Field {
+	return Field{
+		Key:       key,
+		Type:      ladcore.InlineMarshalerType,
+		Interface: &lazyField{key: key, fn: fn},
+	}
+}
+
+type lazyField struct {
+	key string
+	fn  func() Field
+
+	once  sync.Once
+	field Field
+}
+
+var _ ladcore.LazyFieldMarshaler = (*lazyField)(nil)
+
+func (l *lazyField) ResolveField() Field {
+	l.once.Do(func() {
+		l.field = l.fn()
+		l.field.Key = l.key
+	})
+	return l.field
+}
+
+func (l *lazyField) MarshalLogObject(enc ladcore.ObjectEncoder) error {
+	l.ResolveField().AddTo(enc)
+	return nil
+}
+
 // Dict constructs a field containing the provided key-value pairs.
 // It acts similar to [Object], but with the fields specified as arguments.
 func Dict(key string, val ...Field) Field {
@@ -608,6 +857,18 @@ func Any(key string, value interface{}) Field {
 		c = anyFieldC[*time.Duration](Durationp)
 	case []time.Duration:
 		c = anyFieldC[[]time.Duration](Durations)
+	case *big.Int:
+		c = anyFieldC[*big.Int](BigInt)
+	case *big.Float:
+		c = anyFieldC[*big.Float](BigFloat)
+	case DecimalValue:
+		c = anyFieldC[DecimalValue](Decimal)
+	case netip.Addr:
+		c = anyFieldC[netip.Addr](NetIPAddr)
+	case netip.Prefix:
+		c = anyFieldC[netip.Prefix](NetIPPrefix)
+	case *url.URL:
+		c = anyFieldC[*url.URL](URL)
 	case error:
 		c = anyFieldC[error](NamedError)
 	case []error:
@@ -23,14 +23,19 @@ package lad
 import (
 	"math"
 	"net"
+	"net/netip"
+	"net/url"
 	"regexp"
+	"runtime"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/auwixcom/lad/internal/stacktrace"
+	"github.com/auwixcom/lad/internal/ztest"
 	"github.com/auwixcom/lad/ladcore"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type username string
@@ -63,6 +68,9 @@ func assertCanBeReused(t testing.TB, field Field) {
 func TestFieldConstructors(t *testing.T) {
 	// Interface types.
 	addr := net.ParseIP("1.2.3.4")
+	ipAddr := netip.MustParseAddr("1.2.3.4")
+	ipPrefix := netip.MustParsePrefix("1.2.3.0/24")
+	u := &url.URL{Scheme: "https", Host: "example.com"}
 	name := username("phil")
 	ints := []int{5, 6}
 
@@ -109,27 +117,41 @@ func TestFieldConstructors(t *testing.T) {
 		{"Int16", Field{Key: "k", Type: ladcore.Int16Type, Integer: 1}, Int16("k", 1)},
 		{"Int8", Field{Key: "k", Type: ladcore.Int8Type, Integer: 1}, Int8("k", 1)},
 		{"String", Field{Key: "k", Type: ladcore.StringType, String: "foo"}, String("k", "foo")},
+		{"Redacted", Field{Key: "k", Type: ladcore.StringType, String: "<redacted>"}, Redacted("k", "foo")},
+		{"RedactedHash", Field{Key: "k", Type: ladcore.StringType, String: "sha256:2c26b46b68ffc68f"}, RedactedHash("k", "foo")},
 		{"Time", Field{Key: "k", Type: ladcore.TimeType, Integer: 0, Interface: time.UTC}, Time("k", time.Unix(0, 0).In(time.UTC))},
 		{"Time", Field{Key: "k", Type: ladcore.TimeType, Integer: 1000, Interface: time.UTC}, Time("k", time.Unix(0, 1000).In(time.UTC))},
 		{"Time", Field{Key: "k", Type: ladcore.TimeType, Integer: math.MinInt64, Interface: time.UTC}, Time("k", time.Unix(0, math.MinInt64).In(time.UTC))},
 		{"Time", Field{Key: "k", Type: ladcore.TimeType, Integer: math.MaxInt64, Interface: time.UTC}, Time("k", time.Unix(0, math.MaxInt64).In(time.UTC))},
 		{"Time", Field{Key: "k", Type: ladcore.TimeFullType, Interface: time.Time{}}, Time("k", time.Time{})},
 		{"Time", Field{Key: "k", Type: ladcore.TimeFullType, Interface: time.Unix(math.MaxInt64, 0)}, Time("k", time.Unix(math.MaxInt64, 0))},
+		{"Timestamp", Field{Type: ladcore.TimestampType, Interface: time.Unix(1, 0)}, Timestamp(time.Unix(1, 0))},
+		{"TimestampWithEmitTime", Field{Key: "k", Type: ladcore.TimestampType, Interface: time.Unix(1, 0)}, TimestampWithEmitTime(time.Unix(1, 0), "k")},
 		{"Uint", Field{Key: "k", Type: ladcore.Uint64Type, Integer: 1}, Uint("k", 1)},
 		{"Uint64", Field{Key: "k", Type: ladcore.Uint64Type, Integer: 1}, Uint64("k", 1)},
 		{"Uint32", Field{Key: "k", Type: ladcore.Uint32Type, Integer: 1}, Uint32("k", 1)},
 		{"Uint16", Field{Key: "k", Type: ladcore.Uint16Type, Integer: 1}, Uint16("k", 1)},
 		{"Uint8", Field{Key: "k", Type: ladcore.Uint8Type, Integer: 1}, Uint8("k", 1)},
 		{"Uintptr", Field{Key: "k", Type: ladcore.UintptrType, Integer: 10}, Uintptr("k", 0xa)},
+		{"Count", Field{Key: "k", Type: ladcore.Int64Type, Integer: 3, Interface: ladcore.CounterMetric}, Count("k", 3)},
+		{"Gauge", Field{Key: "k", Type: ladcore.Float64Type, Integer: int64(math.Float64bits(1.5)), Interface: ladcore.GaugeMetric}, Gauge("k", 1.5)},
 		{"Reflect", Field{Key: "k", Type: ladcore.ReflectType, Interface: ints}, Reflect("k", ints)},
 		{"Reflect", Field{Key: "k", Type: ladcore.ReflectType}, Reflect("k", nil)},
 		{"Stringer", Field{Key: "k", Type: ladcore.StringerType, Interface: addr}, Stringer("k", addr)},
+		{"UUID", ladcore.UUIDField("k", [16]byte{1}), UUID("k", [16]byte{1})},
+		{"NetIPAddr", Field{Key: "k", Type: ladcore.NetIPAddrType, Interface: ipAddr}, NetIPAddr("k", ipAddr)},
+		{"NetIPPrefix", Field{Key: "k", Type: ladcore.NetIPPrefixType, Interface: ipPrefix}, NetIPPrefix("k", ipPrefix)},
+		{"URL", Field{Key: "k", Type: ladcore.URLType, Interface: u}, URL("k", u)},
+		{"URL", nilField("k"), URL("k", nil)},
 		{"Object", Field{Key: "k", Type: ladcore.ObjectMarshalerType, Interface: name}, Object("k", name)},
 		{"Inline", Field{Type: ladcore.InlineMarshalerType, Interface: name}, Inline(name)},
 		{"Any:ObjectMarshaler", Any("k", name), Object("k", name)},
 		{"Any:ArrayMarshaler", Any("k", bools([]bool{true})), Array("k", bools([]bool{true}))},
 		{"Any:Dict", Any("k", []Field{String("k", "v")}), Dict("k", String("k", "v"))},
 		{"Any:Stringer", Any("k", addr), Stringer("k", addr)},
+		{"Any:NetIPAddr", Any("k", ipAddr), NetIPAddr("k", ipAddr)},
+		{"Any:NetIPPrefix", Any("k", ipPrefix), NetIPPrefix("k", ipPrefix)},
+		{"Any:URL", Any("k", u), URL("k", u)},
 		{"Any:Bool", Any("k", true), Bool("k", true)},
 		{"Any:Bools", Any("k", []bool{true}), Bools("k", []bool{true})},
 		{"Any:Byte", Any("k", byte(1)), Uint8("k", 1)},
@@ -291,6 +313,123 @@ func TestStackSkipFieldWithSkip(t *testing.T) {
 	assertCanBeReused(t, f)
 }
 
+func TestFrameField(t *testing.T) {
+	frame := runtime.Frame{Function: "foo.Bar", File: "/src/foo/bar.go", Line: 42}
+	f := Frame("frame", frame)
+
+	enc := ladcore.NewMapObjectEncoder()
+	f.AddTo(enc)
+	assert.Equal(t, map[string]interface{}{
+		"function": "foo.Bar",
+		"file":     "/src/foo/bar.go",
+		"line":     42,
+	}, enc.Fields["frame"], "Unexpected frame encoding.")
+	assertCanBeReused(t, f)
+}
+
+func TestMoneyField(t *testing.T) {
+	f := Money("total", -1999, "USD")
+
+	enc := ladcore.NewMapObjectEncoder()
+	f.AddTo(enc)
+	assert.Equal(t, map[string]interface{}{
+		"amount":   int64(-1999),
+		"currency": "USD",
+	}, enc.Fields["total"], "Unexpected money encoding.")
+	assertCanBeReused(t, f)
+}
+
+func TestLatLonField(t *testing.T) {
+	f := LatLon("location", 40.7, -74.0)
+
+	enc := ladcore.NewMapObjectEncoder()
+	f.AddTo(enc)
+	assert.Equal(t, map[string]interface{}{
+		"type":        "Point",
+		"coordinates": []interface{}{-74.0, 40.7},
+	}, enc.Fields["location"], "Unexpected coordinate encoding.")
+	assertCanBeReused(t, f)
+}
+
+func TestFlagsField(t *testing.T) {
+	names := []string{"read", "write", "", "exec"}
+	tests := []struct {
+		desc  string
+		value uint64
+		want  []interface{}
+	}{
+		{"none", 0, []interface{}{}},
+		{"named", 0b1001, []interface{}{"read", "exec"}},
+		{"unnamed", 0b0110, []interface{}{"write", "bit2"}},
+		{"out of range", 1<<63 | 1, []interface{}{"read", "bit63"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			f := Flags("perms", tt.value, names)
+
+			enc := ladcore.NewMapObjectEncoder()
+			f.AddTo(enc)
+			assert.Equal(t, map[string]interface{}{
+				"value": tt.value,
+				"set":   tt.want,
+			}, enc.Fields["perms"], "Unexpected flags encoding.")
+			assertCanBeReused(t, f)
+		})
+	}
+}
+
+func TestCallerField(t *testing.T) {
+	pc, file, line, ok := runtime.Caller(0)
+	f := Caller("origin", 0)
+	require.True(t, ok, "Failed to get caller.")
+
+	enc := ladcore.NewMapObjectEncoder()
+	f.AddTo(enc)
+	assert.Equal(t, map[string]interface{}{
+		"function": runtime.FuncForPC(pc).Name(),
+		"file":     file,
+		"line":     line + 1,
+	}, enc.Fields["origin"], "Unexpected caller encoding.")
+	assertCanBeReused(t, f)
+}
+
+func TestCallerAtField(t *testing.T) {
+	helper := func() Field {
+		return CallerAt(1)
+	}
+
+	_, file, line, ok := runtime.Caller(0)
+	f := helper()
+	require.True(t, ok, "Failed to get caller.")
+
+	enc := ladcore.NewMapObjectEncoder()
+	f.AddTo(enc)
+	require.Contains(t, enc.Fields, "caller", "Expected a caller key.")
+	caller := enc.Fields["caller"].(map[string]interface{})
+	assert.Equal(t, file, caller["file"], "Unexpected file.")
+	assert.Equal(t, line+1, caller["line"], "Expected the helper's caller, not the helper.")
+	assert.Equal(t, "github.com/auwixcom/lad.TestCallerAtField", caller["function"], "Unexpected function.")
+}
+
+func TestCallerFieldSkipTooLarge(t *testing.T) {
+	assert.Equal(t, Skip(), Caller("caller", 1<<20), "Expected a no-op field for an out-of-range skip.")
+}
+
+func TestMetricFieldsEncodeAsNumbers(t *testing.T) {
+	enc := ladcore.NewMapObjectEncoder()
+	Count("requests", 3).AddTo(enc)
+	Gauge("queue_depth", 1.5).AddTo(enc)
+	assert.Equal(t, map[string]interface{}{
+		"requests":    int64(3),
+		"queue_depth": 1.5,
+	}, enc.Fields, "Expected metric fields to encode like plain numbers.")
+
+	assert.Equal(t, ladcore.CounterMetric, ladcore.MetricTypeOf(Count("k", 1)), "Unexpected metric type.")
+	assert.Equal(t, ladcore.GaugeMetric, ladcore.MetricTypeOf(Gauge("k", 1)), "Unexpected metric type.")
+	assert.Equal(t, ladcore.NotMetric, ladcore.MetricTypeOf(Int64("k", 1)), "Unexpected metric type.")
+}
+
 func TestDict(t *testing.T) {
 	tests := []struct {
 		desc     string
@@ -356,3 +495,57 @@ func TestDictObject(t *testing.T) {
 		})
 	}
 }
+
+func TestLazy(t *testing.T) {
+	var calls int
+	field := Lazy("payload", func() Field {
+		calls++
+		return Int("size", 42)
+	})
+	assert.Equal(t, 0, calls, "Expected constructing the field not to compute its value.")
+
+	enc := ladcore.NewMapObjectEncoder()
+	field.AddTo(enc)
+	assert.Equal(t, map[string]any{"payload": int64(42)}, enc.Fields, "Unexpected encoded fields.")
+	assert.Equal(t, 1, calls, "Expected encoding the field to compute its value once.")
+	assertCanBeReused(t, field)
+}
+
+func TestLazySkippedWhenDisabled(t *testing.T) {
+	buf := &ztest.Buffer{}
+	logger := New(ladcore.NewCore(
+		ladcore.NewJSONEncoder(ladcore.EncoderConfig{MessageKey: "msg"}),
+		buf,
+		InfoLevel,
+	))
+
+	var calls int
+	payload := func() Field {
+		calls++
+		return String("v", "expensive")
+	}
+
+	logger.Debug("debug", Lazy("payload", payload))
+	assert.Equal(t, 0, calls, "Expected disabled entries not to compute lazy fields.")
+	assert.Empty(t, buf.Lines(), "Expected disabled entries not to be written.")
+
+	logger.Info("info", Lazy("payload", payload))
+	assert.Equal(t, 1, calls, "Expected enabled entries to compute lazy fields.")
+	assert.Equal(t, []string{`{"msg":"info","payload":"expensive"}`}, buf.Lines(), "Unexpected output.")
+}
+
+var _uuidSink Field
+
+func TestUUIDAllocs(t *testing.T) {
+	ztest.SkipIfRace(t)
+
+	u := [16]byte{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+	allocs := testing.AllocsPerRun(100, func() {
+		_uuidSink = UUID("id", u)
+	})
+	assert.Zero(t, allocs, "Constructing a UUID field shouldn't allocate.")
+
+	enc := ladcore.NewMapObjectEncoder()
+	_uuidSink.AddTo(enc)
+	assert.Equal(t, "6ba7b810-9dad-11d1-80b4-00c04fd430c8", enc.Fields["id"], "Unexpected UUID encoding.")
+}
@@ -0,0 +1,551 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lad
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/auwixcom/lad/ladcore"
+)
+
+// FilterConfig is a declarative rule that drops or reroutes the log entries
+// matching a predicate. Rules are part of Config, so filtering policy can
+// live alongside the rest of the logging configuration.
+//
+// Match is a predicate over an entry's level, logger name, and message.
+// Comparisons are combined with AND, OR, and NOT (in decreasing order of
+// precedence) and grouped with parentheses:
+//
+//	level < info AND logger prefix "vendor."
+//	NOT (level >= warn OR message contains "retry")
+//
+// Levels support <, <=, >, >=, = and !=. Logger names and messages support
+// = and != as well as prefix, suffix, contains, and matches, which takes a
+// regular expression. Strings are double-quoted, with Go escapes. Keywords
+// and level names are case-insensitive.
+//
+// Fields aren't available to predicates: rules are evaluated before the
+// fields of an entry are gathered, so dropped entries cost next to nothing.
+type FilterConfig struct {
+	// Match is the predicate that selects entries.
+	Match string `json:"match" yaml:"match"`
+	// Action is what happens to matching entries: "drop" (the default)
+	// discards them, and "route" writes them to OutputPaths instead of the
+	// logger's outputs.
+	Action string `json:"action,omitempty" yaml:"action,omitempty"`
+	// OutputPaths are the destinations of a route rule. See Open for
+	// details.
+	OutputPaths []string `json:"outputPaths,omitempty" yaml:"outputPaths,omitempty"`
+}
+
+const (
+	_filterActionDrop  = "drop"
+	_filterActionRoute = "route"
+)
+
+// entryPredicate reports whether an entry matches a filter.
+type entryPredicate func(ladcore.Entry) bool
+
+// filterRule is a compiled FilterConfig.
+type filterRule struct {
+	match entryPredicate
+	route int // index of the route core, or -1 to drop
+}
+
+// filterCore sends each entry to the Core selected by the first matching
+// rule: nowhere, one of routes, or, if no rule matches, main.
+type filterCore struct {
+	main   ladcore.Core
+	routes []ladcore.Core
+	rules  []filterRule
+}
+
+var _ ladcore.Core = (*filterCore)(nil)
+
+func (c *filterCore) Enabled(lvl ladcore.Level) bool {
+	if c.main.Enabled(lvl) {
+		return true
+	}
+	for _, r := range c.routes {
+		if r.Enabled(lvl) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *filterCore) With(fields []Field) ladcore.Core {
+	clone := &filterCore{
+		main:   c.main.With(fields),
+		routes: make([]ladcore.Core, len(c.routes)),
+		rules:  c.rules,
+	}
+	for i, r := range c.routes {
+		clone.routes[i] = r.With(fields)
+	}
+	return clone
+}
+
+// target returns the Core that should handle the entry, or nil if it's
+// dropped.
+func (c *filterCore) target(ent ladcore.Entry) ladcore.Core {
+	for _, rule := range c.rules {
+		if !rule.match(ent) {
+			continue
+		}
+		if rule.route < 0 {
+			return nil
+		}
+		return c.routes[rule.route]
+	}
+	return c.main
+}
+
+func (c *filterCore) Check(ent ladcore.Entry, ce *ladcore.CheckedEntry) *ladcore.CheckedEntry {
+	if t := c.target(ent); t != nil {
+		return t.Check(ent, ce)
+	}
+	return ce
+}
+
+func (c *filterCore) Write(ent ladcore.Entry, fields []Field) error {
+	if t := c.target(ent); t != nil {
+		return t.Write(ent, fields)
+	}
+	return nil
+}
+
+func (c *filterCore) Sync() error {
+	return ladcore.NewTee(append([]ladcore.Core{c.main}, c.routes...)...).Sync()
+}
+
+// buildFilterCore wraps the core built from the rest of the Config with its
+// Filters. Route rules get Cores of their own that share the Config's
+// encoding and level.
+//
+// If it fails, the route outputs it opened are closed. Otherwise, like the
+// Config's OutputPaths, they stay open for the life of the process: the
+// Logger writes to them but never closes them.
+func (cfg Config) buildFilterCore(main ladcore.Core) (_ ladcore.Core, err error) {
+	rules := make([]filterRule, len(cfg.Filters))
+	for i, f := range cfg.Filters {
+		match, err := parseFilter(f.Match)
+		if err != nil {
+			return nil, err
+		}
+		rules[i] = filterRule{match: match, route: -1}
+
+		switch f.Action {
+		case "", _filterActionDrop:
+		case _filterActionRoute:
+			if len(f.OutputPaths) == 0 {
+				return nil, fmt.Errorf("filter %q routes to no outputPaths", f.Match)
+			}
+		default:
+			return nil, fmt.Errorf("unknown filter action %q: expected %q or %q", f.Action, _filterActionDrop, _filterActionRoute)
+		}
+	}
+
+	var closers []func()
+	defer func() {
+		if err != nil {
+			for _, closeSink := range closers {
+				closeSink()
+			}
+		}
+	}()
+
+	fc := &filterCore{main: main, rules: rules}
+	for i, f := range cfg.Filters {
+		if f.Action != _filterActionRoute {
+			continue
+		}
+		enc, err := cfg.buildEncoder()
+		if err != nil {
+			return nil, err
+		}
+		sink, closeSink, err := Open(f.OutputPaths...)
+		if err != nil {
+			return nil, err
+		}
+		closers = append(closers, closeSink)
+		fc.rules[i].route = len(fc.routes)
+		fc.routes = append(fc.routes, ladcore.NewCore(enc, sink, cfg.Level))
+	}
+	return fc, nil
+}
+
+// AtomicFilters is a set of drop rules that can be replaced atomically at
+// runtime, for example to silence a noisy message fleet-wide during an
+// incident without redeploying. Apply it to a logger with the AddFilters
+// option or Config.DynamicFilters.
+//
+// Rules use the language described by FilterConfig, but only the "drop"
+// action is supported: routes need outputs, which are opened once when a
+// Config is built.
+//
+// The AtomicFilters itself is an http.Handler that serves a JSON endpoint to
+// view and replace its rules. AtomicFilters must be created with the
+// NewAtomicFilters constructor to allocate their internal atomic pointer.
+type AtomicFilters struct {
+	p *atomic.Pointer[filterSet]
+}
+
+// filterSet is an immutable, compiled set of drop rules.
+type filterSet struct {
+	configs []FilterConfig
+	rules   []entryPredicate
+}
+
+// NewAtomicFilters creates an AtomicFilters with no rules.
+func NewAtomicFilters() AtomicFilters {
+	f := AtomicFilters{p: new(atomic.Pointer[filterSet])}
+	f.p.Store(&filterSet{})
+	return f
+}
+
+// Rules returns the current rules.
+func (f AtomicFilters) Rules() []FilterConfig {
+	configs := f.p.Load().configs
+	return append(make([]FilterConfig, 0, len(configs)), configs...)
+}
+
+// SetRules validates the rules and, if they're all valid, replaces the
+// current rules with them. Otherwise, the current rules are left in place.
+func (f AtomicFilters) SetRules(rules ...FilterConfig) error {
+	set := &filterSet{
+		configs: make([]FilterConfig, len(rules)),
+		rules:   make([]entryPredicate, len(rules)),
+	}
+	for i, r := range rules {
+		if r.Action != "" && r.Action != _filterActionDrop {
+			return fmt.Errorf("filter %q: only %q rules can be set at runtime", r.Match, _filterActionDrop)
+		}
+		pred, err := parseFilter(r.Match)
+		if err != nil {
+			return err
+		}
+		set.configs[i] = FilterConfig{Match: r.Match, Action: _filterActionDrop}
+		set.rules[i] = pred
+	}
+	f.p.Store(set)
+	return nil
+}
+
+// drops reports whether any of the current rules matches the entry.
+func (f AtomicFilters) drops(ent ladcore.Entry) bool {
+	for _, match := range f.p.Load().rules {
+		if match(ent) {
+			return true
+		}
+	}
+	return false
+}
+
+// AddFilters configures the Logger to drop entries matching the current rules
+// of the AtomicFilters. Rules are evaluated for every entry, so changes take
+// effect immediately for all loggers descended from this one.
+func AddFilters(f AtomicFilters) Option {
+	return WrapCore(func(core ladcore.Core) ladcore.Core {
+		return &atomicFilterCore{Core: core, filters: f}
+	})
+}
+
+type atomicFilterCore struct {
+	ladcore.Core
+
+	filters AtomicFilters
+}
+
+func (c *atomicFilterCore) Level() ladcore.Level {
+	return ladcore.LevelOf(c.Core)
+}
+
+func (c *atomicFilterCore) With(fields []Field) ladcore.Core {
+	return &atomicFilterCore{
+		Core:    c.Core.With(fields),
+		filters: c.filters,
+	}
+}
+
+func (c *atomicFilterCore) Check(ent ladcore.Entry, ce *ladcore.CheckedEntry) *ladcore.CheckedEntry {
+	if c.filters.drops(ent) {
+		return ce
+	}
+	return c.Core.Check(ent, ce)
+}
+
+func (c *atomicFilterCore) Write(ent ladcore.Entry, fields []Field) error {
+	if c.filters.drops(ent) {
+		return nil
+	}
+	return c.Core.Write(ent, fields)
+}
+
+// parseFilter compiles a predicate written in the language described by
+// FilterConfig.
+func parseFilter(src string) (entryPredicate, error) {
+	toks, err := tokenizeFilter(src)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter %q: %v", src, err)
+	}
+	p := &filterParser{toks: toks}
+	pred, err := p.parseOr()
+	if err == nil && p.pos < len(p.toks) {
+		err = fmt.Errorf("unexpected %q", p.toks[p.pos].text)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter %q: %v", src, err)
+	}
+	return pred, nil
+}
+
+type filterTokenKind int
+
+const (
+	filterIdent filterTokenKind = iota
+	filterString
+	filterOperator
+	filterParen
+)
+
+type filterToken struct {
+	kind filterTokenKind
+	text string // unquoted for strings
+}
+
+func tokenizeFilter(src string) ([]filterToken, error) {
+	var toks []filterToken
+	for i := 0; i < len(src); {
+		switch c := src[i]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(' || c == ')':
+			toks = append(toks, filterToken{filterParen, src[i : i+1]})
+			i++
+		case c == '"':
+			j := i + 1
+			for ; j < len(src) && src[j] != '"'; j++ {
+				if src[j] == '\\' {
+					j++
+				}
+			}
+			if j >= len(src) {
+				return nil, errors.New("unterminated string")
+			}
+			s, err := strconv.Unquote(src[i : j+1])
+			if err != nil {
+				return nil, fmt.Errorf("bad string %s: %v", src[i:j+1], err)
+			}
+			toks = append(toks, filterToken{filterString, s})
+			i = j + 1
+		case strings.IndexByte("<>=!", c) >= 0:
+			j := i + 1
+			if j < len(src) && src[j] == '=' {
+				j++
+			}
+			op := src[i:j]
+			if op == "!" {
+				return nil, errors.New(`unexpected "!"`)
+			}
+			toks = append(toks, filterToken{filterOperator, op})
+			i = j
+		case isFilterIdentByte(c):
+			j := i
+			for j < len(src) && isFilterIdentByte(src[j]) {
+				j++
+			}
+			toks = append(toks, filterToken{filterIdent, src[i:j]})
+			i = j
+		default:
+			return nil, fmt.Errorf("unexpected %q", c)
+		}
+	}
+	return toks, nil
+}
+
+func isFilterIdentByte(c byte) bool {
+	return c == '_' || c == '.' || c == '-' ||
+		('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+}
+
+type filterParser struct {
+	toks []filterToken
+	pos  int
+}
+
+func (p *filterParser) peekKeyword(kw string) bool {
+	return p.pos < len(p.toks) &&
+		p.toks[p.pos].kind == filterIdent &&
+		strings.EqualFold(p.toks[p.pos].text, kw)
+}
+
+func (p *filterParser) next() (filterToken, error) {
+	if p.pos >= len(p.toks) {
+		return filterToken{}, errors.New("unexpected end of filter")
+	}
+	p.pos++
+	return p.toks[p.pos-1], nil
+}
+
+func (p *filterParser) parseOr() (entryPredicate, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword("or") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(ent ladcore.Entry) bool { return l(ent) || right(ent) }
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (entryPredicate, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword("and") {
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(ent ladcore.Entry) bool { return l(ent) && right(ent) }
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseUnary() (entryPredicate, error) {
+	if p.peekKeyword("not") {
+		p.pos++
+		pred, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return func(ent ladcore.Entry) bool { return !pred(ent) }, nil
+	}
+
+	tok, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	if tok.kind == filterParen && tok.text == "(" {
+		pred, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if tok, err := p.next(); err != nil || tok.text != ")" {
+			return nil, errors.New(`missing ")"`)
+		}
+		return pred, nil
+	}
+	if tok.kind != filterIdent {
+		return nil, fmt.Errorf("unexpected %q", tok.text)
+	}
+	return p.parseComparison(strings.ToLower(tok.text))
+}
+
+func (p *filterParser) parseComparison(subject string) (entryPredicate, error) {
+	opTok, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	op := strings.ToLower(opTok.text)
+	if op == "==" {
+		op = "="
+	}
+	valTok, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	if valTok.kind != filterString && valTok.kind != filterIdent {
+		return nil, fmt.Errorf("expected a value after %q, got %q", opTok.text, valTok.text)
+	}
+
+	switch subject {
+	case "level":
+		return levelPredicate(op, valTok.text)
+	case "logger":
+		return stringPredicate(op, valTok.text, func(ent ladcore.Entry) string { return ent.LoggerName })
+	case "message", "msg":
+		return stringPredicate(op, valTok.text, func(ent ladcore.Entry) string { return ent.Message })
+	default:
+		return nil, fmt.Errorf("unknown attribute %q: expected level, logger, or message", subject)
+	}
+}
+
+func levelPredicate(op, val string) (entryPredicate, error) {
+	var lvl ladcore.Level
+	if err := lvl.UnmarshalText([]byte(val)); err != nil {
+		return nil, err
+	}
+	switch op {
+	case "<":
+		return func(ent ladcore.Entry) bool { return ent.Level < lvl }, nil
+	case "<=":
+		return func(ent ladcore.Entry) bool { return ent.Level <= lvl }, nil
+	case ">":
+		return func(ent ladcore.Entry) bool { return ent.Level > lvl }, nil
+	case ">=":
+		return func(ent ladcore.Entry) bool { return ent.Level >= lvl }, nil
+	case "=":
+		return func(ent ladcore.Entry) bool { return ent.Level == lvl }, nil
+	case "!=":
+		return func(ent ladcore.Entry) bool { return ent.Level != lvl }, nil
+	default:
+		return nil, fmt.Errorf("operator %q doesn't apply to levels", op)
+	}
+}
+
+func stringPredicate(op, val string, get func(ladcore.Entry) string) (entryPredicate, error) {
+	switch op {
+	case "=":
+		return func(ent ladcore.Entry) bool { return get(ent) == val }, nil
+	case "!=":
+		return func(ent ladcore.Entry) bool { return get(ent) != val }, nil
+	case "prefix":
+		return func(ent ladcore.Entry) bool { return strings.HasPrefix(get(ent), val) }, nil
+	case "suffix":
+		return func(ent ladcore.Entry) bool { return strings.HasSuffix(get(ent), val) }, nil
+	case "contains":
+		return func(ent ladcore.Entry) bool { return strings.Contains(get(ent), val) }, nil
+	case "matches":
+		re, err := regexp.Compile(val)
+		if err != nil {
+			return nil, err
+		}
+		return func(ent ladcore.Entry) bool { return re.MatchString(get(ent)) }, nil
+	default:
+		return nil, fmt.Errorf("operator %q doesn't apply to strings", op)
+	}
+}
@@ -0,0 +1,264 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lad
+
+import (
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/auwixcom/lad/ladcore"
+	"github.com/auwixcom/lad/ladtest/observer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFilter(t *testing.T) {
+	vendorDebug := ladcore.Entry{Level: DebugLevel, LoggerName: "vendor.sql", Message: "query took 3ms"}
+	appWarn := ladcore.Entry{Level: WarnLevel, LoggerName: "app", Message: "retrying request"}
+
+	tests := []struct {
+		filter string
+		want   []bool // matches vendorDebug, appWarn
+	}{
+		{`level < info AND logger prefix "vendor."`, []bool{true, false}},
+		{`level<info`, []bool{true, false}},
+		{`level >= WARN`, []bool{false, true}},
+		{`level = debug or level == warn`, []bool{true, true}},
+		{`level != debug`, []bool{false, true}},
+		{`level <= info`, []bool{true, false}},
+		{`level > info`, []bool{false, true}},
+		{`logger = app`, []bool{false, true}},
+		{`logger != "app"`, []bool{true, false}},
+		{`logger suffix ".sql"`, []bool{true, false}},
+		{`message contains "retry"`, []bool{false, true}},
+		{`msg matches "^query took \\d+ms$"`, []bool{true, false}},
+		{`NOT level >= warn`, []bool{true, false}},
+		{`not (level >= warn or logger prefix "vendor.")`, []bool{false, false}},
+		{`level >= warn OR logger = "vendor.sql" AND message contains "nope"`, []bool{false, true}},
+		{`(level >= warn OR logger = "vendor.sql") AND message contains "query"`, []bool{true, false}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			pred, err := parseFilter(tt.filter)
+			require.NoError(t, err, "Unexpected error parsing filter.")
+			assert.Equal(t, tt.want, []bool{pred(vendorDebug), pred(appWarn)}, "Unexpected matches.")
+		})
+	}
+}
+
+func TestParseFilterErrors(t *testing.T) {
+	tests := []struct {
+		filter  string
+		wantErr string
+	}{
+		{``, "unexpected end of filter"},
+		{`level <`, "unexpected end of filter"},
+		{`level < bogus`, `unrecognized level: "bogus"`},
+		{`level prefix info`, `operator "prefix" doesn't apply to levels`},
+		{`logger < "a"`, `operator "<" doesn't apply to strings`},
+		{`time > 5`, `unknown attribute "time"`},
+		{`logger = "app`, "unterminated string"},
+		{`logger = "\q"`, "bad string"},
+		{`(level < info`, `missing ")"`},
+		{`level < info)`, `unexpected ")"`},
+		{`level < info logger = app`, `unexpected "logger"`},
+		{`level ! info`, `unexpected "!"`},
+		{`level < info AND`, "unexpected end of filter"},
+		{`logger = (`, `expected a value after "=", got "("`},
+		{`message matches "("`, "missing closing )"},
+		{`level < info ; drop`, "unexpected ';'"},
+		{`= info`, `unexpected "="`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			_, err := parseFilter(tt.filter)
+			require.Error(t, err, "Expected an error parsing filter.")
+			assert.Contains(t, err.Error(), tt.wantErr, "Unexpected error message.")
+		})
+	}
+}
+
+func TestConfigFilters(t *testing.T) {
+	dir := t.TempDir()
+	mainOut := filepath.Join(dir, "main.log")
+	auditOut := filepath.Join(dir, "audit.log")
+
+	cfg := NewDevelopmentConfig()
+	cfg.Encoding = "json"
+	cfg.EncoderConfig = ladcore.EncoderConfig{MessageKey: "msg", NameKey: "logger"}
+	cfg.OutputPaths = []string{mainOut}
+	cfg.Filters = []FilterConfig{
+		{Match: `level < info AND logger prefix "vendor."`},
+		{Match: `logger = audit`, Action: "route", OutputPaths: []string{auditOut}},
+	}
+
+	logger, err := cfg.Build()
+	require.NoError(t, err, "Unexpected error building logger.")
+	logger = logger.With(String("k", "v"))
+
+	logger.Named("vendor").Named("sql").Debug("dropped")
+	logger.Named("vendor").Named("sql").Info("kept")
+	logger.Named("audit").Info("routed")
+	logger.Debug("debug")
+	require.NoError(t, logger.Sync(), "Unexpected error syncing.")
+
+	readLog := func(path string) string {
+		out, err := os.ReadFile(path)
+		require.NoError(t, err, "Couldn't read log output.")
+		return string(out)
+	}
+	assert.Equal(t,
+		`{"logger":"vendor.sql","msg":"kept","k":"v"}`+"\n"+
+			`{"msg":"debug","k":"v"}`+"\n",
+		readLog(mainOut), "Unexpected main output.")
+	assert.Equal(t, `{"logger":"audit","msg":"routed","k":"v"}`+"\n", readLog(auditOut), "Unexpected routed output.")
+}
+
+func TestConfigFilterErrors(t *testing.T) {
+	tests := []struct {
+		desc    string
+		filter  FilterConfig
+		wantErr string
+	}{
+		{"bad predicate", FilterConfig{Match: "level <"}, "invalid filter"},
+		{"unknown action", FilterConfig{Match: "level < info", Action: "shout"}, `unknown filter action "shout"`},
+		{"route without outputs", FilterConfig{Match: "level < info", Action: "route"}, "routes to no outputPaths"},
+		{"bad route output", FilterConfig{Match: "level < info", Action: "route", OutputPaths: []string{"/tmp/not-there/foo.log"}}, "no such file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			cfg := NewProductionConfig()
+			cfg.OutputPaths = []string{"stdout"}
+			cfg.Filters = []FilterConfig{tt.filter}
+			_, err := cfg.Build()
+			require.Error(t, err, "Expected an error building logger.")
+			assert.Contains(t, err.Error(), tt.wantErr, "Unexpected error message.")
+		})
+	}
+}
+
+// closeCountingSink counts how many times it's closed.
+type closeCountingSink struct {
+	ladcore.WriteSyncer
+
+	closed *int
+}
+
+func (s closeCountingSink) Close() error {
+	*s.closed++
+	return nil
+}
+
+func TestConfigFilterErrorClosesRoutes(t *testing.T) {
+	r := stubSinkRegistry(t)
+	var closed int
+	require.NoError(t, r.RegisterSink("counting", func(*url.URL) (Sink, error) {
+		return closeCountingSink{WriteSyncer: ladcore.AddSync(io.Discard), closed: &closed}, nil
+	}), "Unexpected error registering sink.")
+
+	cfg := NewProductionConfig()
+	cfg.OutputPaths = []string{"stdout"}
+	cfg.Filters = []FilterConfig{
+		{Match: "level < info", Action: "route", OutputPaths: []string{"counting://a"}},
+		{Match: "level < warn", Action: "route", OutputPaths: []string{"/tmp/not-there/foo.log"}},
+	}
+	_, err := cfg.Build()
+	require.Error(t, err, "Expected an error building logger.")
+	assert.Equal(t, 1, closed, "Expected routes opened before the error to be closed.")
+}
+
+func TestConfigBuildErrorClosesOutputs(t *testing.T) {
+	r := stubSinkRegistry(t)
+	var closed int
+	require.NoError(t, r.RegisterSink("counting", func(*url.URL) (Sink, error) {
+		return closeCountingSink{WriteSyncer: ladcore.AddSync(io.Discard), closed: &closed}, nil
+	}), "Unexpected error registering sink.")
+
+	tests := []struct {
+		desc   string
+		modify func(*Config)
+	}{
+		{"missing level", func(cfg *Config) { cfg.Level = AtomicLevel{} }},
+		{"invalid filter", func(cfg *Config) { cfg.Filters = []FilterConfig{{Match: "level <"}} }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			closed = 0
+			cfg := NewProductionConfig()
+			cfg.OutputPaths = []string{"counting://out"}
+			cfg.ErrorOutputPaths = []string{"counting://err"}
+			tt.modify(&cfg)
+			_, err := cfg.Build()
+			require.Error(t, err, "Expected an error building logger.")
+			assert.Equal(t, 2, closed, "Expected outputs and error outputs to be closed.")
+		})
+	}
+}
+
+func TestAtomicFilters(t *testing.T) {
+	filters := NewAtomicFilters()
+	withLogger(t, DebugLevel, opts(AddFilters(filters)), func(logger *Logger, logs *observer.ObservedLogs) {
+		vendor := logger.Named("vendor").With(String("k", "v"))
+
+		vendor.Debug("before")
+		require.NoError(t, filters.SetRules(FilterConfig{Match: `logger prefix "vendor"`}), "Unexpected error setting rules.")
+		vendor.Debug("during")
+		logger.Debug("unaffected")
+		require.NoError(t, vendor.Core().Write(ladcore.Entry{LoggerName: "vendor", Message: "direct"}, nil), "Unexpected error writing.")
+
+		assert.Error(t, filters.SetRules(FilterConfig{Match: "level <"}), "Expected invalid rules to be rejected.")
+		vendor.Debug("still dropped")
+
+		require.NoError(t, filters.SetRules(), "Unexpected error clearing rules.")
+		vendor.Debug("after")
+
+		var msgs []string
+		for _, e := range logs.AllUntimed() {
+			msgs = append(msgs, e.Message)
+		}
+		assert.Equal(t, []string{"before", "unaffected", "after"}, msgs, "Unexpected logged messages.")
+	})
+}
+
+func TestConfigDynamicFilters(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "out.log")
+
+	cfg := NewProductionConfig()
+	cfg.EncoderConfig = ladcore.EncoderConfig{MessageKey: "msg"}
+	cfg.OutputPaths = []string{out}
+	cfg.DynamicFilters = NewAtomicFilters()
+
+	logger, err := cfg.Build()
+	require.NoError(t, err, "Unexpected error building logger.")
+	require.NoError(t, cfg.DynamicFilters.SetRules(FilterConfig{Match: `message = "noisy"`}), "Unexpected error setting rules.")
+	logger.Info("noisy")
+	logger.Info("quiet")
+
+	contents, err := os.ReadFile(out)
+	require.NoError(t, err, "Couldn't read log output.")
+	assert.Equal(t, `{"msg":"quiet"}`+"\n", string(contents), "Unexpected log output.")
+}
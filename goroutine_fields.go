@@ -0,0 +1,199 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lad
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+	"sync"
+
+	"github.com/auwixcom/lad/ladcore"
+)
+
+// _goroutineFields holds the fields pushed by each goroutine, keyed by
+// goroutine ID.
+var _goroutineFields = newGoroutineFieldStore()
+
+// PushFields adds fields to the current goroutine's implicit context. Loggers
+// built with the AddGoroutineFields option attach these fields to every entry
+// logged from this goroutine, until a matching PopFields call removes them.
+//
+//	func handle(req *Request) {
+//	  lad.PushFields(lad.String("request_id", req.ID))
+//	  defer lad.PopFields()
+//
+//	  process(req) // logs from here carry request_id
+//	}
+//
+// Goroutine-local state is a deliberate departure from Go's usual style, so
+// keep the tradeoffs in mind before reaching for it:
+//
+//   - Fields aren't inherited by goroutines started after the push. Work
+//     handed to another goroutine (including worker pools) must push its own
+//     fields.
+//   - Every PushFields must be paired with a PopFields on the same goroutine.
+//     Fields left behind leak memory and may reappear if the runtime re-uses
+//     the goroutine's ID.
+//   - Looking up the current goroutine costs roughly a microsecond, which is
+//     paid on every enabled entry by opted-in loggers, and entries with
+//     implicit fields encode them on each write rather than once.
+//   - If the current goroutine can't be identified, which would take a
+//     change to the runtime's stack trace format, PushFields and PopFields
+//     do nothing.
+//
+// Where possible, prefer passing a Logger built with With, or a
+// context.Context, through the call stack.
+func PushFields(fields ...Field) {
+	if len(fields) == 0 {
+		// Keep pushes and pops balanced even if nothing was added.
+		fields = []Field{}
+	}
+	if id, ok := goroutineID(); ok {
+		_goroutineFields.push(id, fields)
+	}
+}
+
+// PopFields removes the fields added by the most recent PushFields call on the
+// current goroutine. It's a no-op if there are none.
+func PopFields() {
+	if id, ok := goroutineID(); ok {
+		_goroutineFields.pop(id)
+	}
+}
+
+// AddGoroutineFields configures the Logger to attach the fields added with
+// PushFields on the logging goroutine to each entry. See PushFields for the
+// tradeoffs involved.
+func AddGoroutineFields() Option {
+	return WrapCore(func(core ladcore.Core) ladcore.Core {
+		return &goroutineFieldsCore{Core: core}
+	})
+}
+
+type goroutineFieldStore struct {
+	mu     sync.RWMutex
+	fields map[uint64][][]Field // goroutine ID to stack of pushes
+}
+
+func newGoroutineFieldStore() *goroutineFieldStore {
+	return &goroutineFieldStore{
+		fields: make(map[uint64][][]Field),
+	}
+}
+
+func (s *goroutineFieldStore) push(id uint64, fields []Field) {
+	s.mu.Lock()
+	s.fields[id] = append(s.fields[id], fields)
+	s.mu.Unlock()
+}
+
+func (s *goroutineFieldStore) pop(id uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pushes := s.fields[id]
+	switch len(pushes) {
+	case 0:
+		return
+	case 1:
+		delete(s.fields, id)
+	default:
+		pushes[len(pushes)-1] = nil // don't retain popped fields
+		s.fields[id] = pushes[:len(pushes)-1]
+	}
+}
+
+// get returns all fields currently pushed by the given goroutine, oldest
+// first.
+func (s *goroutineFieldStore) get(id uint64) []Field {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	pushes := s.fields[id]
+	switch len(pushes) {
+	case 0:
+		return nil
+	case 1:
+		return pushes[0]
+	}
+
+	var all []Field
+	for _, fs := range pushes {
+		all = append(all, fs...)
+	}
+	return all
+}
+
+var _goroutinePrefix = []byte("goroutine ")
+
+// goroutineID returns the ID of the current goroutine, and false if it can't
+// be determined. The runtime doesn't expose IDs directly, so we parse them
+// from the header of the goroutine's stack trace.
+func goroutineID() (uint64, bool) {
+	var buf [64]byte
+	return parseGoroutineID(buf[:runtime.Stack(buf[:], false)])
+}
+
+// parseGoroutineID parses the ID from a stack trace header, like
+// "goroutine 42 [running]:".
+func parseGoroutineID(b []byte) (uint64, bool) {
+	if !bytes.HasPrefix(b, _goroutinePrefix) {
+		return 0, false
+	}
+	b = b[len(_goroutinePrefix):]
+	i := bytes.IndexByte(b, ' ')
+	if i <= 0 {
+		return 0, false
+	}
+	id, err := strconv.ParseUint(string(b[:i]), 10, 64)
+	if err != nil || id == 0 {
+		return 0, false
+	}
+	return id, true
+}
+
+// goroutineFieldsCore adds the logging goroutine's implicit fields to entries.
+// Check always runs on the logging goroutine, so that's where the lookup
+// happens.
+type goroutineFieldsCore struct {
+	ladcore.Core
+}
+
+func (c *goroutineFieldsCore) Level() ladcore.Level {
+	return ladcore.LevelOf(c.Core)
+}
+
+func (c *goroutineFieldsCore) With(fields []Field) ladcore.Core {
+	return &goroutineFieldsCore{Core: c.Core.With(fields)}
+}
+
+func (c *goroutineFieldsCore) Check(ent ladcore.Entry, ce *ladcore.CheckedEntry) *ladcore.CheckedEntry {
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+	if id, ok := goroutineID(); ok {
+		if fields := _goroutineFields.get(id); len(fields) > 0 {
+			return c.Core.With(fields).Check(ent, ce)
+		}
+	}
+	return c.Core.Check(ent, ce)
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lad
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/auwixcom/lad/ladcore"
+	"github.com/auwixcom/lad/ladtest/observer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoroutineFields(t *testing.T) {
+	withLogger(t, InfoLevel, opts(AddGoroutineFields()), func(logger *Logger, logs *observer.ObservedLogs) {
+		logger.Info("none")
+
+		PushFields(String("request", "r1"))
+		logger.Info("one", Int("n", 1))
+
+		PushFields(String("user", "u1"))
+		logger.With(String("ctx", "c")).Info("two")
+		logger.Debug("disabled")
+
+		PopFields()
+		logger.Info("popped")
+
+		PopFields()
+		PopFields() // extra pops are no-ops
+		logger.Info("empty")
+
+		entries := logs.AllUntimed()
+		require.Len(t, entries, 5, "Unexpected number of entries.")
+		assert.Empty(t, entries[0].Context, "Expected no implicit fields before a push.")
+		assert.Equal(t, []Field{String("request", "r1"), Int("n", 1)}, entries[1].Context, "Unexpected fields.")
+		assert.Equal(t, []Field{
+			String("ctx", "c"),
+			String("request", "r1"),
+			String("user", "u1"),
+		}, entries[2].Context, "Unexpected fields after a nested push.")
+		assert.Equal(t, []Field{String("request", "r1")}, entries[3].Context, "Unexpected fields after a pop.")
+		assert.Empty(t, entries[4].Context, "Expected no implicit fields after popping everything.")
+	})
+	id, ok := goroutineID()
+	require.True(t, ok, "Expected a goroutine ID.")
+	assert.Empty(t, _goroutineFields.get(id), "Expected the goroutine's fields to be released.")
+}
+
+func TestGoroutineFieldsOptIn(t *testing.T) {
+	PushFields(String("request", "r1"))
+	defer PopFields()
+
+	withLogger(t, InfoLevel, nil, func(logger *Logger, logs *observer.ObservedLogs) {
+		logger.Info("hello")
+		require.Equal(t, 1, logs.Len(), "Expected one entry.")
+		assert.Empty(t, logs.All()[0].Context, "Expected loggers to ignore implicit fields by default.")
+	})
+}
+
+func TestGoroutineFieldsIsolation(t *testing.T) {
+	withLogger(t, InfoLevel, opts(AddGoroutineFields()), func(logger *Logger, logs *observer.ObservedLogs) {
+		PushFields(String("goroutine", "parent"))
+		defer PopFields()
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			logger.Info("child")
+		}()
+		wg.Wait()
+
+		require.Equal(t, 1, logs.Len(), "Expected one entry.")
+		assert.Empty(t, logs.All()[0].Context, "Expected fields not to leak into other goroutines.")
+	})
+}
+
+func TestGoroutineFieldsCoreLevel(t *testing.T) {
+	core, _ := observer.New(WarnLevel)
+	logger := New(core, AddGoroutineFields())
+	assert.Equal(t, WarnLevel, logger.Level(), "Expected the wrapped core's level.")
+	assert.Equal(t, ladcore.WarnLevel, ladcore.LevelOf(logger.Core()), "Expected the wrapped core's level.")
+}
+
+func TestGoroutineID(t *testing.T) {
+	id, ok := goroutineID()
+	require.True(t, ok, "Expected a goroutine ID.")
+	assert.NotZero(t, id, "Expected a goroutine ID.")
+	again, _ := goroutineID()
+	assert.Equal(t, id, again, "Expected a stable goroutine ID.")
+
+	var other uint64
+	done := make(chan struct{})
+	go func() {
+		other, _ = goroutineID()
+		close(done)
+	}()
+	<-done
+	assert.NotEqual(t, id, other, "Expected distinct goroutine IDs.")
+}
+
+func TestParseGoroutineID(t *testing.T) {
+	tests := []struct {
+		give   string
+		wantID uint64
+		wantOK bool
+	}{
+		{"goroutine 42 [running]:\n", 42, true},
+		{"goroutine 1 [running]:", 1, true},
+		{"goroutine 0 [running]:", 0, false},
+		{"goroutine [running]:", 0, false},
+		{"goroutine 42", 0, false},
+		{"goroutine x [running]:", 0, false},
+		{"thread 42 [running]:", 0, false},
+		{"", 0, false},
+	}
+	for _, tt := range tests {
+		id, ok := parseGoroutineID([]byte(tt.give))
+		assert.Equal(t, tt.wantOK, ok, "Unexpected success parsing %q.", tt.give)
+		assert.Equal(t, tt.wantID, id, "Unexpected ID parsed from %q.", tt.give)
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lad
+
+import (
+	"sync"
+	"time"
+
+	"github.com/auwixcom/lad/ladcore"
+)
+
+// A Group collects related entries, such as those logged while processing one
+// unit of work in a batch job, and emits them as a single structured record
+// when closed.
+//
+//	g := lad.NewGroup(logger, "processed batch", lad.String("batch", id))
+//	defer g.Close()
+//
+//	glog := g.Logger()
+//	glog.Info("fetched rows", lad.Int("rows", n))
+//	glog.Warn("skipped malformed row", lad.Int("row", i))
+//
+// On Close, the group writes one entry with the group's message and fields, a
+// "duration" field, and an "events" array. Each event records its level,
+// message, and fields, along with the time elapsed since the group was opened.
+// The summary entry is logged at InfoLevel, or at the level of the most severe
+// event if that's higher (capped at ErrorLevel).
+//
+// Groups keep every collected entry in memory until they're closed, and
+// encode the entries' fields only when the summary is written. Entries at
+// DPanicLevel and above close the group immediately, so that the summary is
+// written before the program panics or exits.
+type Group struct {
+	log    *Logger
+	msg    string
+	fields []Field
+	start  time.Time
+
+	mu     sync.Mutex
+	closed bool
+	level  ladcore.Level
+	events []groupEvent
+}
+
+// NewGroup opens a Group that summarizes entries onto the given Logger.
+func NewGroup(log *Logger, msg string, fields ...Field) *Group {
+	return &Group{
+		// Skip Group.Close when annotating the summary with its caller.
+		log:    log.WithOptions(AddCallerSkip(1)),
+		msg:    msg,
+		fields: fields,
+		start:  log.clock.Now(),
+		level:  InfoLevel,
+	}
+}
+
+// Logger returns a Logger whose entries are collected into the group instead
+// of being written individually. Entries logged after the group is closed are
+// written directly.
+func (g *Group) Logger() *Logger {
+	return g.log.WithOptions(
+		AddCallerSkip(-1),
+		WrapCore(func(core ladcore.Core) ladcore.Core {
+			return &groupCore{core: core, group: g}
+		}),
+	)
+}
+
+// Close writes the group's summary entry. Subsequent calls are no-ops.
+func (g *Group) Close() {
+	g.mu.Lock()
+	if g.closed {
+		g.mu.Unlock()
+		return
+	}
+	g.closed = true
+	events, level := g.events, g.level
+	g.events = nil
+	g.mu.Unlock()
+
+	if ce := g.log.Check(level, g.msg); ce != nil {
+		fields := make([]Field, 0, len(g.fields)+2)
+		fields = append(fields, g.fields...)
+		fields = append(fields,
+			Duration("duration", ce.Time.Sub(g.start)),
+			Array("events", groupEvents{start: g.start, events: events}),
+		)
+		ce.Write(fields...)
+	}
+}
+
+func (g *Group) isClosed() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.closed
+}
+
+// add records an event, reporting false if the group was already closed.
+func (g *Group) add(ent ladcore.Entry, fields []Field) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.closed {
+		return false
+	}
+	g.events = append(g.events, groupEvent{Entry: ent, fields: fields})
+	if ent.Level > g.level {
+		g.level = ent.Level
+		if g.level > ErrorLevel {
+			// Don't let the summary itself panic or exit.
+			g.level = ErrorLevel
+		}
+	}
+	return true
+}
+
+type groupEvent struct {
+	ladcore.Entry
+
+	fields []Field
+}
+
+type groupEvents struct {
+	start  time.Time
+	events []groupEvent
+}
+
+func (es groupEvents) MarshalLogArray(arr ladcore.ArrayEncoder) error {
+	for i := range es.events {
+		if err := arr.AppendObject(groupEventObject{es.start, &es.events[i]}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type groupEventObject struct {
+	start time.Time
+	event *groupEvent
+}
+
+func (o groupEventObject) MarshalLogObject(enc ladcore.ObjectEncoder) error {
+	enc.AddString("level", o.event.Level.String())
+	enc.AddDuration("elapsed", o.event.Time.Sub(o.start))
+	enc.AddString("msg", o.event.Message)
+	for i := range o.event.fields {
+		o.event.fields[i].AddTo(enc)
+	}
+	return nil
+}
+
+// groupCore collects entries into a Group until it's closed, and then
+// forwards them to the wrapped Core.
+type groupCore struct {
+	core    ladcore.Core
+	group   *Group
+	context []Field
+}
+
+var _ ladcore.Core = (*groupCore)(nil)
+
+func (c *groupCore) Enabled(lvl ladcore.Level) bool {
+	return c.core.Enabled(lvl)
+}
+
+func (c *groupCore) Level() ladcore.Level {
+	return ladcore.LevelOf(c.core)
+}
+
+func (c *groupCore) With(fields []Field) ladcore.Core {
+	return &groupCore{
+		core:    c.core.With(fields),
+		group:   c.group,
+		context: append(c.context[:len(c.context):len(c.context)], fields...),
+	}
+}
+
+func (c *groupCore) Check(ent ladcore.Entry, ce *ladcore.CheckedEntry) *ladcore.CheckedEntry {
+	if c.group.isClosed() {
+		return c.core.Check(ent, ce)
+	}
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *groupCore) Write(ent ladcore.Entry, fields []Field) error {
+	all := make([]Field, 0, len(c.context)+len(fields))
+	all = append(all, c.context...)
+	all = append(all, fields...)
+	if !c.group.add(ent, all) {
+		// The group was closed between Check and Write, so write the entry
+		// directly. The wrapped core didn't get to Check it, and may still
+		// decide to drop it, for example if it samples.
+		if ce := c.core.Check(ent, nil); ce != nil {
+			ce.ErrorOutput = c.group.log.errorOutput
+			ce.Write(fields...)
+		}
+		return nil
+	}
+	if ent.Level > ErrorLevel {
+		// We may be about to crash, so don't wait for Close.
+		c.group.Close()
+	}
+	return nil
+}
+
+func (c *groupCore) Sync() error {
+	return c.core.Sync()
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lad
+
+import (
+	"testing"
+	"time"
+
+	"github.com/auwixcom/lad/internal/ztest"
+	"github.com/auwixcom/lad/ladcore"
+	"github.com/auwixcom/lad/ladtest/observer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroup(t *testing.T) {
+	clock := ztest.NewMockClock()
+	withLogger(t, InfoLevel, opts(WithClock(clock)), func(logger *Logger, logs *observer.ObservedLogs) {
+		g := NewGroup(logger, "batch done", String("batch", "b1"))
+		glog := g.Logger()
+
+		glog.Info("fetched", Int("rows", 3))
+		clock.Add(time.Second)
+		glog.With(String("stage", "parse")).Warn("skipped row", Int("row", 2))
+		glog.Debug("disabled")
+		assert.Zero(t, logs.Len(), "Expected entries to be collected until Close.")
+
+		clock.Add(time.Second)
+		g.Close()
+		g.Close()
+		glog.Info("after close")
+
+		entries := logs.AllUntimed()
+		require.Len(t, entries, 2, "Expected a summary and one direct entry.")
+
+		summary := entries[0]
+		assert.Equal(t, "batch done", summary.Message, "Unexpected summary message.")
+		assert.Equal(t, WarnLevel, summary.Level, "Expected the most severe event's level.")
+		assert.Equal(t, map[string]interface{}{
+			"batch":    "b1",
+			"duration": 2 * time.Second,
+			"events": []interface{}{
+				map[string]interface{}{
+					"level":   "info",
+					"elapsed": time.Duration(0),
+					"msg":     "fetched",
+					"rows":    int64(3),
+				},
+				map[string]interface{}{
+					"level":   "warn",
+					"elapsed": time.Second,
+					"msg":     "skipped row",
+					"stage":   "parse",
+					"row":     int64(2),
+				},
+			},
+		}, summary.ContextMap(), "Unexpected summary fields.")
+
+		assert.Equal(t, "after close", entries[1].Message, "Expected entries after Close to be written directly.")
+	})
+}
+
+func TestGroupEmpty(t *testing.T) {
+	withLogger(t, DebugLevel, nil, func(logger *Logger, logs *observer.ObservedLogs) {
+		NewGroup(logger, "nothing").Close()
+
+		entries := logs.AllUntimed()
+		require.Len(t, entries, 1, "Expected a summary entry.")
+		assert.Equal(t, InfoLevel, entries[0].Level, "Expected summaries to default to InfoLevel.")
+		assert.Equal(t, []interface{}{}, entries[0].ContextMap()["events"], "Expected no events.")
+	})
+}
+
+func TestGroupClosesOnPanic(t *testing.T) {
+	withLogger(t, DebugLevel, nil, func(logger *Logger, logs *observer.ObservedLogs) {
+		g := NewGroup(logger, "unit of work")
+		glog := g.Logger()
+		glog.Info("started")
+
+		assert.Panics(t, func() { glog.Panic("boom") }, "Expected Panic to panic.")
+
+		entries := logs.AllUntimed()
+		require.Len(t, entries, 1, "Expected the group to be summarized before panicking.")
+		assert.Equal(t, ErrorLevel, entries[0].Level, "Expected the summary level to be capped.")
+		events := entries[0].ContextMap()["events"].([]interface{})
+		assert.Len(t, events, 2, "Expected both events in the summary.")
+	})
+}
+
+func TestGroupCaller(t *testing.T) {
+	withLogger(t, DebugLevel, opts(AddCaller()), func(logger *Logger, logs *observer.ObservedLogs) {
+		g := NewGroup(logger, "work")
+		g.Logger().Info("event")
+		g.Close()
+
+		entries := logs.AllUntimed()
+		require.Len(t, entries, 1, "Expected a summary entry.")
+		assert.Regexp(t, `group_test.go:\d+$`, entries[0].Caller.String(), "Expected the summary to report Close's caller.")
+	})
+}
+
+func TestGroupClosedBetweenCheckAndWrite(t *testing.T) {
+	core, logs := observer.New(DebugLevel)
+	logger := New(ladcore.NewSamplerWithOptions(core, time.Minute, 1, 0))
+	logger.Info("repeated")
+
+	g := NewGroup(logger, "work")
+	ce := g.Logger().Check(InfoLevel, "repeated")
+	require.NotNil(t, ce, "Expected the entry to be collected by the open group.")
+	g.Close()
+	ce.Write()
+
+	var msgs []string
+	for _, e := range logs.AllUntimed() {
+		msgs = append(msgs, e.Message)
+	}
+	assert.Equal(t, []string{"repeated", "work"}, msgs, "Expected the late entry to go through the wrapped core's sampling.")
+}
+
+func TestGroupCoreLevel(t *testing.T) {
+	core, _ := observer.New(WarnLevel)
+	g := NewGroup(New(core), "work")
+	assert.Equal(t, ladcore.WarnLevel, g.Logger().Level(), "Expected the wrapped core's level.")
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lad
+
+import "strings"
+
+var _statusClasses = [...]string{"1xx", "2xx", "3xx", "4xx", "5xx"}
+
+// HTTPStatusClass constructs a field that carries the class of an HTTP
+// status code, like "2xx" or "5xx", so dashboards can group responses
+// without parsing codes themselves. Codes outside 100-599 are logged as
+// "unknown". By convention, the key is "http.status_class".
+func HTTPStatusClass(key string, code int) Field {
+	if code < 100 || code > 599 {
+		return String(key, "unknown")
+	}
+	return String(key, _statusClasses[code/100-1])
+}
+
+// _userAgentFamilies lists substrings that identify a user agent's family,
+// in the order they're checked. Order matters: most browsers claim to be
+// Safari, and Chromium-based browsers also claim to be Chrome.
+var _userAgentFamilies = []struct {
+	token, family string
+}{
+	{"bot", "bot"},
+	{"crawler", "bot"},
+	{"spider", "bot"},
+	{"curl/", "curl"},
+	{"wget/", "wget"},
+	{"go-http-client/", "go"},
+	{"python-requests/", "python"},
+	{"python-urllib/", "python"},
+	{"okhttp/", "okhttp"},
+	{"java/", "java"},
+	{"edg/", "edge"},
+	{"edge/", "edge"},
+	{"opr/", "opera"},
+	{"opera", "opera"},
+	{"firefox/", "firefox"},
+	{"fxios/", "firefox"},
+	{"chrome/", "chrome"},
+	{"crios/", "chrome"},
+	{"msie ", "ie"},
+	{"trident/", "ie"},
+	{"safari/", "safari"},
+}
+
+// UserAgentFamily constructs a field that carries the coarse family of an
+// HTTP User-Agent header, like "chrome", "firefox", "curl", or "bot". It's
+// meant for grouping traffic, not for precise client identification; agents
+// that aren't recognized are logged as "other", and an empty header as
+// "unknown". By convention, the key is "http.user_agent.family".
+func UserAgentFamily(key string, userAgent string) Field {
+	return String(key, userAgentFamily(userAgent))
+}
+
+func userAgentFamily(ua string) string {
+	if ua == "" {
+		return "unknown"
+	}
+	ua = strings.ToLower(ua)
+	for _, f := range _userAgentFamilies {
+		if strings.Contains(ua, f.token) {
+			return f.family
+		}
+	}
+	return "other"
+}
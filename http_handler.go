@@ -138,3 +138,69 @@ func decodePutJSON(body io.Reader) (ladcore.Level, error) {
 	}
 	return *pld.Level, nil
 }
+
+// ServeHTTP is a simple JSON endpoint that can report on or replace the
+// current filter rules.
+//
+// # GET
+//
+// The GET request returns a JSON description of the current rules like:
+//
+//	{"filters":[{"match":"logger prefix \"vendor.\"","action":"drop"}]}
+//
+// # PUT
+//
+// The PUT request replaces the rules with those in a JSON payload of the same
+// shape. The rules are validated first; if any is invalid, the request fails
+// and the current rules stay in place. An example curl request that drops
+// debug logs from the "vendor" logger's descendants could look like this:
+//
+//	curl -X PUT localhost:8080/log/filters -d '{"filters":[{"match":"level < info AND logger prefix \"vendor.\""}]}'
+//
+// An empty list removes all rules.
+func (f AtomicFilters) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := f.serveHTTP(w, r); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = fmt.Fprintf(w, "internal error: %v", err)
+	}
+}
+
+func (f AtomicFilters) serveHTTP(w http.ResponseWriter, r *http.Request) error {
+	type errorResponse struct {
+		Error string `json:"error"`
+	}
+	type payload struct {
+		Filters []FilterConfig `json:"filters"`
+	}
+
+	enc := json.NewEncoder(w)
+
+	switch r.Method {
+	case http.MethodGet:
+		return enc.Encode(payload{Filters: f.Rules()})
+
+	case http.MethodPut:
+		var pld struct {
+			Filters *[]FilterConfig `json:"filters"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&pld); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return enc.Encode(errorResponse{Error: fmt.Sprintf("malformed request body: %v", err)})
+		}
+		if pld.Filters == nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return enc.Encode(errorResponse{Error: "must specify filters"})
+		}
+		if err := f.SetRules(*pld.Filters...); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return enc.Encode(errorResponse{Error: err.Error()})
+		}
+		return enc.Encode(payload{Filters: f.Rules()})
+
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return enc.Encode(errorResponse{
+			Error: "Only GET and PUT are supported.",
+		})
+	}
+}
@@ -257,3 +257,58 @@ func FuzzAtomicLevelServeHTTP(f *testing.F) {
 		assert.NotRegexp(t, `<[^>]+>`, resw.Body.String(), "Unexpected HTML tag in response body.")
 	})
 }
+
+func TestAtomicFiltersServeHTTP(t *testing.T) {
+	filters := lad.NewAtomicFilters()
+	srv := httptest.NewServer(filters)
+	defer srv.Close()
+
+	do := func(method, body string) (int, string) {
+		req, err := http.NewRequest(method, srv.URL, strings.NewReader(body))
+		require.NoError(t, err, "Error constructing request.")
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err, "Error making request.")
+		defer func() {
+			assert.NoError(t, res.Body.Close(), "Error closing response body.")
+		}()
+		resBody, err := io.ReadAll(res.Body)
+		require.NoError(t, err, "Error reading response body.")
+		return res.StatusCode, strings.TrimSpace(string(resBody))
+	}
+
+	code, body := do(http.MethodGet, "")
+	assert.Equal(t, http.StatusOK, code, "Unexpected status code.")
+	assert.Equal(t, `{"filters":[]}`, body, "Unexpected rules.")
+
+	code, body = do(http.MethodPut, `{"filters":[{"match":"logger prefix \"vendor.\""}]}`)
+	assert.Equal(t, http.StatusOK, code, "Unexpected status code.")
+	assert.Equal(t, `{"filters":[{"match":"logger prefix \"vendor.\"","action":"drop"}]}`, body, "Unexpected rules.")
+
+	tests := []struct {
+		desc    string
+		method  string
+		body    string
+		code    int
+		wantErr string
+	}{
+		{"malformed JSON", http.MethodPut, `{`, http.StatusBadRequest, "malformed request body"},
+		{"missing filters", http.MethodPut, `{}`, http.StatusBadRequest, "must specify filters"},
+		{"invalid rule", http.MethodPut, `{"filters":[{"match":"level <"}]}`, http.StatusBadRequest, "invalid filter"},
+		{"route rule", http.MethodPut, `{"filters":[{"match":"level < info","action":"route"}]}`, http.StatusBadRequest, "only \\\"drop\\\" rules"},
+		{"bad method", http.MethodPost, ``, http.StatusMethodNotAllowed, "Only GET and PUT"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			code, body := do(tt.method, tt.body)
+			assert.Equal(t, tt.code, code, "Unexpected status code.")
+			assert.Contains(t, body, tt.wantErr, "Unexpected error message.")
+		})
+	}
+	assert.Equal(t,
+		[]lad.FilterConfig{{Match: `logger prefix "vendor."`, Action: "drop"}},
+		filters.Rules(), "Expected failed requests to leave the rules in place.")
+
+	code, body = do(http.MethodPut, `{"filters":[]}`)
+	assert.Equal(t, http.StatusOK, code, "Unexpected status code.")
+	assert.Equal(t, `{"filters":[]}`, body, "Expected an empty list to remove all rules.")
+}
@@ -24,6 +24,7 @@ package stacktrace
 
 import (
 	"runtime"
+	"strings"
 
 	"github.com/auwixcom/lad/buffer"
 	"github.com/auwixcom/lad/internal/bufferpool"
@@ -144,6 +145,21 @@ func Take(skip int) string {
 	return buffer.String()
 }
 
+// PackagePath returns the import path of the package that defines the
+// function with the given fully-qualified name, as reported by
+// runtime.Frame.Function. For example, it returns "github.com/a/b" for
+// "github.com/a/b.(*T).Method.func1".
+func PackagePath(function string) string {
+	// The package path ends at the first dot after the last slash; the
+	// runtime escapes any dots in the last path element as %2e.
+	pkg := function
+	slash := strings.LastIndexByte(function, '/')
+	if dot := strings.IndexByte(function[slash+1:], '.'); dot >= 0 {
+		pkg = function[:slash+1+dot]
+	}
+	return strings.ReplaceAll(pkg, "%2e", ".")
+}
+
 // Formatter formats a stack trace into a readable string representation.
 type Formatter struct {
 	b        *buffer.Buffer
@@ -104,3 +104,21 @@ func withStackDepth(depth int, f func()) {
 	}
 	recurse(rune(depth))
 }
+
+func TestPackagePath(t *testing.T) {
+	tests := []struct {
+		give string
+		want string
+	}{
+		{"main.main", "main"},
+		{"runtime.goexit", "runtime"},
+		{"github.com/a/b.F", "github.com/a/b"},
+		{"github.com/a/b.(*T).Method.func1", "github.com/a/b"},
+		{"gopkg.in/yaml%2ev3.Marshal", "gopkg.in/yaml.v3"},
+		{"example.com/a/b.G[...]", "example.com/a/b"},
+		{"nodots", "nodots"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, PackagePath(tt.give), "Unexpected package for %q.", tt.give)
+	}
+}
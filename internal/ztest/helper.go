@@ -0,0 +1,48 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ztest
+
+import "testing"
+
+// Infoer is satisfied by lad's SugaredLogger.
+type Infoer interface {
+	Info(args ...interface{})
+}
+
+// InfoFromHelper logs args through l from within this package, standing in
+// for a logging helper package in caller-skipping tests. It calls itself
+// depth times first to simulate nested helpers.
+func InfoFromHelper(l Infoer, depth int, args ...interface{}) {
+	if depth > 0 {
+		InfoFromHelper(l, depth-1, args...)
+		return
+	}
+	l.Info(args...)
+}
+
+// SkipIfRace skips a test that counts allocations when the race detector is
+// on.
+func SkipIfRace(t testing.TB) {
+	t.Helper()
+	if RaceEnabled {
+		t.Skip("allocation counts are unreliable under the race detector")
+	}
+}
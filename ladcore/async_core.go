@@ -0,0 +1,341 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore
+
+import (
+	"fmt"
+	"sync"
+
+	"go.uber.org/multierr"
+)
+
+// _defaultAsyncQueueSize is the number of entries an AsyncCore buffers if
+// AsyncQueueSize isn't used.
+const _defaultAsyncQueueSize = 1024
+
+// AsyncOption configures a Core created with NewAsyncCore.
+type AsyncOption interface {
+	apply(*asyncQueue)
+}
+
+// asyncOptionFunc wraps a func so it satisfies the AsyncOption interface.
+type asyncOptionFunc func(*asyncQueue)
+
+func (f asyncOptionFunc) apply(q *asyncQueue) {
+	f(q)
+}
+
+// AsyncQueueSize sets the maximum number of entries waiting to be written.
+// Defaults to 1024 if unspecified or non-positive.
+func AsyncQueueSize(size int) AsyncOption {
+	return asyncOptionFunc(func(q *asyncQueue) {
+		if size > 0 {
+			q.size = size
+		}
+	})
+}
+
+// AsyncDropOldest makes the Core discard the oldest queued entry to make room
+// for a new one when the queue is full, instead of blocking the logging
+// goroutine until the background writer catches up. Discarded entries are
+// reported by DroppedCount.
+func AsyncDropOldest() AsyncOption {
+	return asyncOptionFunc(func(q *asyncQueue) {
+		q.dropOldest = true
+	})
+}
+
+// AsyncErrorOutput sets the destination for errors returned by the wrapped
+// Core while writing in the background. By default, such errors are
+// discarded.
+func AsyncErrorOutput(ws WriteSyncer) AsyncOption {
+	return asyncOptionFunc(func(q *asyncQueue) {
+		q.errorOutput = ws
+	})
+}
+
+// AsyncCore is a Core that hands entries off to a bounded queue and writes
+// them to another Core from a background goroutine, keeping slow sinks off
+// the logging goroutine.
+//
+// Entries at DPanicLevel and above are written before Write returns, along
+// with everything queued ahead of them, since the logger may panic or exit
+// right afterwards.
+//
+// Call Stop to flush the queue and release the background goroutine. Cores
+// derived with With share their parent's queue.
+type AsyncCore struct {
+	core Core
+	q    *asyncQueue
+}
+
+var (
+	_ Core           = (*AsyncCore)(nil)
+	_ leveledEnabler = (*AsyncCore)(nil)
+)
+
+// NewAsyncCore wraps core so that its writes happen asynchronously. The
+// background goroutine starts immediately.
+func NewAsyncCore(core Core, opts ...AsyncOption) *AsyncCore {
+	q := &asyncQueue{size: _defaultAsyncQueueSize}
+	for _, opt := range opts {
+		opt.apply(q)
+	}
+	q.cond = sync.NewCond(&q.mu)
+	q.buf = make([]asyncItem, q.size)
+	q.stopped = make(chan struct{})
+	go q.run()
+	return &AsyncCore{core: core, q: q}
+}
+
+// Enabled reports whether the wrapped Core is enabled at the given level.
+func (c *AsyncCore) Enabled(lvl Level) bool {
+	return c.core.Enabled(lvl)
+}
+
+// Level returns the minimum enabled level of the wrapped Core.
+func (c *AsyncCore) Level() Level {
+	return LevelOf(c.core)
+}
+
+// With adds structured context to the wrapped Core. The returned Core shares
+// this one's queue.
+func (c *AsyncCore) With(fields []Field) Core {
+	return &AsyncCore{core: c.core.With(fields), q: c.q}
+}
+
+// Check runs the wrapped Core's Check on the calling goroutine, so that
+// decisions like sampling are made once and in order, and adds the AsyncCore
+// to the CheckedEntry if any of the wrapped cores accepted the entry. The
+// background goroutine writes to those cores without checking again.
+func (c *AsyncCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+	if cores := checkedCores(c.core, ent); len(cores) > 0 {
+		return ce.AddCore(ent, &asyncCheckedCore{AsyncCore: c, cores: cores})
+	}
+	return ce
+}
+
+// Write queues the entry for the background goroutine. After Stop, entries
+// are written synchronously.
+//
+// Write runs the wrapped Core's Check first, since it's called on an
+// AsyncCore only when the entry didn't go through Check.
+func (c *AsyncCore) Write(ent Entry, fields []Field) error {
+	return c.write(checkedCores(c.core, ent), ent, fields)
+}
+
+func (c *AsyncCore) write(cores []Core, ent Entry, fields []Field) error {
+	if len(cores) == 0 {
+		return nil
+	}
+	// The caller owns fields, so queue a copy.
+	item := asyncItem{
+		cores:  cores,
+		ent:    ent,
+		fields: append([]Field(nil), fields...),
+	}
+	seq, ok := c.q.push(item)
+	if !ok {
+		return item.write()
+	}
+	if ent.Level > ErrorLevel {
+		c.q.wait(seq)
+	}
+	return nil
+}
+
+// Sync waits for every entry queued before the call to be written, then
+// syncs the wrapped Core.
+func (c *AsyncCore) Sync() error {
+	c.q.flush()
+	return c.core.Sync()
+}
+
+// Stop writes any queued entries, stops the background goroutine, and
+// syncs the wrapped Core. Stop is safe to call more than once.
+func (c *AsyncCore) Stop() error {
+	c.q.stop()
+	return c.core.Sync()
+}
+
+// DroppedCount reports how many entries have been discarded because the
+// queue was full. It's always zero unless AsyncDropOldest is used.
+func (c *AsyncCore) DroppedCount() uint64 {
+	c.q.mu.Lock()
+	defer c.q.mu.Unlock()
+	return c.q.dropped
+}
+
+// asyncCheckedCore is added to a CheckedEntry by AsyncCore.Check, and
+// carries the wrapped cores that accepted the entry to Write.
+type asyncCheckedCore struct {
+	*AsyncCore
+
+	cores []Core
+}
+
+func (c *asyncCheckedCore) Write(ent Entry, fields []Field) error {
+	return c.write(c.cores, ent, fields)
+}
+
+// checkedCores runs core's Check for an entry and returns the cores that
+// accepted it.
+func checkedCores(core Core, ent Entry) []Core {
+	ce := core.Check(ent, nil)
+	if ce == nil {
+		return nil
+	}
+	defer putCheckedEntry(ce)
+	return append([]Core(nil), ce.cores...)
+}
+
+type asyncItem struct {
+	cores  []Core // the wrapped cores that accepted the entry
+	ent    Entry
+	fields []Field
+}
+
+func (item asyncItem) write() error {
+	var err error
+	for _, c := range item.cores {
+		err = multierr.Append(err, c.Write(item.ent, item.fields))
+	}
+	return err
+}
+
+// asyncQueue is a ring buffer of entries shared by an AsyncCore and every
+// Core derived from it.
+type asyncQueue struct {
+	size        int
+	dropOldest  bool
+	errorOutput WriteSyncer
+
+	mu   sync.Mutex
+	cond *sync.Cond // broadcast whenever the fields below change
+
+	buf   []asyncItem
+	head  int // index of the oldest queued item
+	count int // number of queued items
+
+	pushed   uint64 // entries ever queued, so also the last sequence number
+	inflight uint64 // sequence number of the entry being written, if any
+	dropped  uint64
+
+	stopping bool
+	stopped  chan struct{} // closed when run returns
+}
+
+// push queues an item and returns its sequence number. It returns false if
+// the queue has been stopped.
+func (q *asyncQueue) push(item asyncItem) (seq uint64, ok bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for q.count == len(q.buf) && !q.dropOldest && !q.stopping {
+		q.cond.Wait()
+	}
+	if q.stopping {
+		return 0, false
+	}
+
+	if q.count == len(q.buf) {
+		q.buf[q.head] = asyncItem{}
+		q.head = (q.head + 1) % len(q.buf)
+		q.count--
+		q.dropped++
+	}
+	q.buf[(q.head+q.count)%len(q.buf)] = item
+	q.count++
+	q.pushed++
+	q.cond.Broadcast()
+	return q.pushed, true
+}
+
+// unfinished returns the sequence number of the oldest item that hasn't
+// been written or dropped yet. Items are written in order, but the oldest
+// queued items may be dropped while an earlier one is being written.
+func (q *asyncQueue) unfinished() uint64 {
+	if q.inflight != 0 {
+		return q.inflight
+	}
+	return q.pushed - uint64(q.count) + 1
+}
+
+// wait blocks until the item with the given sequence number, and every item
+// before it, has been written or dropped.
+func (q *asyncQueue) wait(seq uint64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for q.unfinished() <= seq {
+		q.cond.Wait()
+	}
+}
+
+func (q *asyncQueue) flush() {
+	q.mu.Lock()
+	seq := q.pushed
+	q.mu.Unlock()
+	q.wait(seq)
+}
+
+func (q *asyncQueue) stop() {
+	q.mu.Lock()
+	q.stopping = true
+	q.cond.Broadcast()
+	q.mu.Unlock()
+	<-q.stopped
+}
+
+func (q *asyncQueue) run() {
+	defer close(q.stopped)
+
+	for {
+		q.mu.Lock()
+		for q.count == 0 && !q.stopping {
+			q.cond.Wait()
+		}
+		if q.count == 0 {
+			q.mu.Unlock()
+			return
+		}
+		q.inflight = q.unfinished()
+		item := q.buf[q.head]
+		q.buf[q.head] = asyncItem{}
+		q.head = (q.head + 1) % len(q.buf)
+		q.count--
+		q.cond.Broadcast()
+		q.mu.Unlock()
+
+		if err := item.write(); err != nil && q.errorOutput != nil {
+			_, _ = fmt.Fprintf(q.errorOutput, "%v write error: %v\n", item.ent.Time, err)
+			_ = q.errorOutput.Sync() // ignore error
+		}
+
+		q.mu.Lock()
+		q.inflight = 0
+		q.cond.Broadcast()
+		q.mu.Unlock()
+	}
+}
@@ -0,0 +1,223 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/auwixcom/lad/internal/ztest"
+	//revive:disable:dot-imports
+	. "github.com/auwixcom/lad/ladcore"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// collectAsync receives from ch until it's closed.
+func collectAsync(ch <-chan EntryWithFields) <-chan []string {
+	done := make(chan []string, 1)
+	go func() {
+		var msgs []string
+		for ewf := range ch {
+			msgs = append(msgs, ewf.Message)
+		}
+		done <- msgs
+	}()
+	return done
+}
+
+func TestAsyncCore(t *testing.T) {
+	ch := make(chan EntryWithFields, 4)
+	core := NewAsyncCore(NewChannelCore(ch, InfoLevel))
+	assert.Equal(t, InfoLevel, LevelOf(core), "Unexpected level.")
+
+	child := core.With([]Field{makeInt64Field("a", 1)})
+	fields := []Field{makeInt64Field("b", 2)}
+	for _, lvl := range []Level{DebugLevel, WarnLevel} {
+		if ce := child.Check(Entry{Level: lvl, Message: "msg"}, nil); ce != nil {
+			ce.Write(fields...)
+		}
+	}
+	fields[0] = makeInt64Field("c", 3)
+
+	require.NoError(t, core.Sync(), "Unexpected error syncing.")
+	require.Len(t, ch, 1, "Expected only enabled entries to be written.")
+	assert.Equal(t, EntryWithFields{
+		Entry:  Entry{Level: WarnLevel, Message: "msg"},
+		Fields: []Field{makeInt64Field("a", 1), makeInt64Field("b", 2)},
+	}, <-ch, "Unexpected entry.")
+
+	require.NoError(t, core.Stop(), "Unexpected error stopping.")
+	require.NoError(t, core.Stop(), "Expected Stop to be idempotent.")
+	require.NoError(t, core.Write(Entry{Message: "late"}, nil), "Unexpected error writing.")
+	require.Len(t, ch, 1, "Expected writes after Stop to be synchronous.")
+	assert.Equal(t, "late", (<-ch).Message, "Unexpected entry.")
+	assert.Zero(t, core.DroppedCount(), "Unexpected dropped entries.")
+}
+
+func TestAsyncCoreBlocksWhenFull(t *testing.T) {
+	ch := make(chan EntryWithFields)
+	core := NewAsyncCore(NewChannelCore(ch, InfoLevel), AsyncQueueSize(1))
+
+	written := make(chan struct{})
+	go func() {
+		for _, msg := range []string{"1", "2", "3", "4"} {
+			assert.NoError(t, core.Write(Entry{Message: msg}, nil), "Unexpected error writing.")
+		}
+		close(written)
+	}()
+
+	var msgs []string
+	for len(msgs) < 4 {
+		msgs = append(msgs, (<-ch).Message)
+	}
+	<-written
+	require.NoError(t, core.Stop(), "Unexpected error stopping.")
+	assert.Equal(t, []string{"1", "2", "3", "4"}, msgs, "Expected every entry, in order.")
+	assert.Zero(t, core.DroppedCount(), "Unexpected dropped entries.")
+}
+
+func TestAsyncCoreDropOldest(t *testing.T) {
+	ch := make(chan EntryWithFields)
+	core := NewAsyncCore(NewChannelCore(ch, InfoLevel), AsyncQueueSize(2), AsyncDropOldest())
+
+	// Nothing is receiving, so at most one entry leaves the queue.
+	for _, msg := range []string{"1", "2", "3", "4", "5"} {
+		require.NoError(t, core.Write(Entry{Message: msg}, nil), "Unexpected error writing.")
+	}
+
+	done := collectAsync(ch)
+	require.NoError(t, core.Stop(), "Unexpected error stopping.")
+	close(ch)
+	msgs := <-done
+
+	require.GreaterOrEqual(t, len(msgs), 2, "Expected the newest entries to be written.")
+	assert.Equal(t, []string{"4", "5"}, msgs[len(msgs)-2:], "Expected the newest entries to be kept.")
+	assert.Equal(t, uint64(5-len(msgs)), core.DroppedCount(), "Unexpected dropped count.")
+}
+
+func TestAsyncCoreWritesPanicsSynchronously(t *testing.T) {
+	ch := make(chan EntryWithFields, 2)
+	core := NewAsyncCore(NewChannelCore(ch, InfoLevel))
+	defer core.Stop()
+
+	require.NoError(t, core.Write(Entry{Level: InfoLevel, Message: "before"}, nil), "Unexpected error writing.")
+	require.NoError(t, core.Write(Entry{Level: DPanicLevel, Message: "panic"}, nil), "Unexpected error writing.")
+	require.Len(t, ch, 2, "Expected queued entries to be written before returning.")
+	assert.Equal(t, "before", (<-ch).Message, "Unexpected entry.")
+	assert.Equal(t, "panic", (<-ch).Message, "Unexpected entry.")
+}
+
+func TestAsyncCoreErrorOutput(t *testing.T) {
+	errOut := &ztest.Buffer{}
+	inner := NewChannelCore(make(chan EntryWithFields), InfoLevel, ChannelDropWhenFull())
+	core := NewAsyncCore(inner, AsyncErrorOutput(errOut))
+	defer core.Stop()
+
+	require.NoError(t, core.Write(Entry{Message: "dropped"}, nil), "Unexpected error writing.")
+	require.NoError(t, core.Sync(), "Unexpected error syncing.")
+	assert.Contains(t, errOut.String(), ErrChannelFull.Error(), "Expected write errors to be reported.")
+}
+
+func TestAsyncCoreChecksOnce(t *testing.T) {
+	ch := make(chan EntryWithFields, 4)
+	var checks atomic.Int32
+	sampled := NewSamplerWithOptions(NewChannelCore(ch, InfoLevel), time.Hour, 1, 0, SamplerHook(func(Entry, SamplingDecision) {
+		checks.Add(1)
+	}))
+	core := NewAsyncCore(sampled)
+	defer core.Stop()
+
+	for _, msg := range []string{"kept", "kept", "other"} {
+		ce := core.Check(Entry{Level: InfoLevel, Message: msg}, nil)
+		assert.Equal(t, int32(1), checks.Swap(0), "Expected the wrapped Check to run once, before Write.")
+		if ce != nil {
+			ce.Write()
+		}
+	}
+	require.NoError(t, core.Sync(), "Unexpected error syncing.")
+	assert.Zero(t, checks.Load(), "Expected the background goroutine not to check again.")
+	require.Len(t, ch, 2, "Expected the sampler's decisions to stand.")
+	assert.Equal(t, "kept", (<-ch).Message, "Unexpected entry.")
+	assert.Equal(t, "other", (<-ch).Message, "Unexpected entry.")
+}
+
+// gatedCore announces each write on started, then blocks it until release
+// is closed.
+type gatedCore struct {
+	started chan string
+	release chan struct{}
+}
+
+func (c *gatedCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	return ce.AddCore(ent, c)
+}
+
+func (c *gatedCore) Write(ent Entry, _ []Field) error {
+	c.started <- ent.Message
+	<-c.release
+	return nil
+}
+
+func (c *gatedCore) With([]Field) Core { return c }
+func (*gatedCore) Enabled(Level) bool  { return true }
+func (*gatedCore) Sync() error         { return nil }
+
+func TestAsyncCoreSyncWaitsForInflightWrite(t *testing.T) {
+	inner := &gatedCore{started: make(chan string, 4), release: make(chan struct{})}
+	core := NewAsyncCore(inner, AsyncQueueSize(1), AsyncDropOldest())
+	var once sync.Once
+	unblock := func() { once.Do(func() { close(inner.release) }) }
+	defer core.Stop()
+	defer unblock()
+
+	require.NoError(t, core.Write(Entry{Message: "slow"}, nil), "Unexpected error writing.")
+	assert.Equal(t, "slow", <-inner.started, "Unexpected entry.")
+	synced := make(chan struct{})
+	go func() {
+		assert.NoError(t, core.Sync(), "Unexpected error syncing.")
+		close(synced)
+	}()
+
+	time.Sleep(10 * time.Millisecond) // let Sync start waiting
+
+	// Drop entries queued behind the one in flight.
+	for _, msg := range []string{"1", "2", "3"} {
+		require.NoError(t, core.Write(Entry{Message: msg}, nil), "Unexpected error writing.")
+	}
+	assert.Equal(t, uint64(2), core.DroppedCount(), "Unexpected dropped count.")
+
+	select {
+	case <-synced:
+		t.Fatal("Expected Sync to wait for the entry in flight.")
+	case <-time.After(10 * time.Millisecond):
+	}
+	unblock()
+	select {
+	case <-synced:
+	case <-time.After(time.Second):
+		t.Fatal("Expected Sync to return once the entry in flight was written.")
+	}
+	assert.Equal(t, "3", <-inner.started, "Expected the newest entry to be kept.")
+}
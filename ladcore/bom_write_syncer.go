@@ -0,0 +1,74 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore
+
+import (
+	"os"
+	"sync"
+)
+
+// UTF8BOM is the UTF-8 encoding of the byte order mark, which some Windows
+// tools require at the start of a UTF-8 file.
+const UTF8BOM = "\xef\xbb\xbf"
+
+// AddBOM wraps ws so that UTF8BOM is written ahead of the first write. If ws
+// reports its size, like an *os.File does, and it isn't empty, the mark is
+// omitted, so reopening a log file for appending doesn't put one in the
+// middle of the file.
+func AddBOM(ws WriteSyncer) WriteSyncer {
+	return &bomWriteSyncer{WriteSyncer: ws}
+}
+
+type bomWriteSyncer struct {
+	WriteSyncer
+
+	mu      sync.Mutex
+	started bool // whether the first write has happened
+}
+
+func (s *bomWriteSyncer) Write(bs []byte) (int, error) {
+	s.mu.Lock()
+	if s.started {
+		s.mu.Unlock()
+		return s.WriteSyncer.Write(bs)
+	}
+	defer s.mu.Unlock()
+	s.started = true
+
+	if f, ok := s.WriteSyncer.(interface{ Stat() (os.FileInfo, error) }); ok {
+		if fi, err := f.Stat(); err == nil && fi.Size() > 0 {
+			return s.WriteSyncer.Write(bs)
+		}
+	}
+
+	// Write the mark and the first entry together, so they can't be split
+	// by another writer.
+	n, err := s.WriteSyncer.Write(append([]byte(UTF8BOM), bs...))
+	n -= len(UTF8BOM)
+	if n < 0 {
+		n = 0
+	}
+	return n, err
+}
+
+func (s *bomWriteSyncer) Close() error {
+	return CloseWriteSyncer(s.WriteSyncer)
+}
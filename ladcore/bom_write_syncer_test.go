@@ -0,0 +1,80 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/auwixcom/lad/internal/ztest"
+	//revive:disable:dot-imports
+	. "github.com/auwixcom/lad/ladcore"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddBOM(t *testing.T) {
+	buf := &ztest.Buffer{}
+	ws := AddBOM(buf)
+
+	for _, line := range []string{"first\n", "second\n"} {
+		n, err := ws.Write([]byte(line))
+		require.NoError(t, err, "Unexpected error writing.")
+		assert.Equal(t, len(line), n, "Unexpected byte count.")
+	}
+	assert.Equal(t, UTF8BOM+"first\nsecond\n", buf.String(), "Expected BOM before the first write only.")
+
+	require.NoError(t, ws.Sync(), "Unexpected error syncing.")
+	assert.True(t, buf.Called(), "Expected Sync to be forwarded.")
+}
+
+func TestAddBOMFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log")
+
+	for _, line := range []string{"first\n", "second\n"} {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		require.NoError(t, err, "Unexpected error opening file.")
+		ws := AddBOM(f)
+		_, err = ws.Write([]byte(line))
+		require.NoError(t, err, "Unexpected error writing.")
+		require.NoError(t, CloseWriteSyncer(ws), "Unexpected error closing file.")
+	}
+
+	out, err := os.ReadFile(path)
+	require.NoError(t, err, "Unexpected error reading file.")
+	assert.Equal(t, UTF8BOM+"first\nsecond\n", string(out), "Expected BOM only at the start of the file.")
+}
+
+func TestAddBOMShortWrite(t *testing.T) {
+	n, err := AddBOM(&ztest.FailWriter{}).Write([]byte("x"))
+	assert.Error(t, err, "Expected write error.")
+	assert.Equal(t, 1, n, "Unexpected byte count.")
+
+	n, err = AddBOM(&shortBOMWriter{}).Write([]byte("x"))
+	assert.NoError(t, err, "Unexpected error.")
+	assert.Equal(t, 0, n, "Expected partial BOM writes to count as nothing written.")
+}
+
+type shortBOMWriter struct{ ztest.Syncer }
+
+func (*shortBOMWriter) Write([]byte) (int, error) { return 2, nil }
@@ -185,6 +185,12 @@ func (s *BufferedWriteSyncer) flushLoop() {
 	}
 }
 
+// Close stops the BufferedWriteSyncer, flushing remaining unwritten data,
+// then closes the wrapped WriteSyncer if it implements io.Closer.
+func (s *BufferedWriteSyncer) Close() error {
+	return multierr.Append(s.Stop(), CloseWriteSyncer(s.WS))
+}
+
 // Stop closes the buffer, cleans up background goroutines, and flushes
 // remaining unwritten data.
 func (s *BufferedWriteSyncer) Stop() (err error) {
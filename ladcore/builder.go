@@ -0,0 +1,164 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore
+
+import (
+	"errors"
+	"time"
+
+	"go.uber.org/multierr"
+)
+
+var (
+	errBuilderNoEncoder = errors.New("core builder: no Encoder specified")
+	errBuilderNoSink    = errors.New("core builder: no Sink specified")
+)
+
+// A CoreBuilder assembles a Core from an Encoder, sinks, and the usual
+// wrappers, as an alternative to composing NewCore, NewMultiWriteSyncer,
+// NewSamplerWithOptions, and friends by hand:
+//
+//	core, err := ladcore.Build().
+//	  Encoder(ladcore.NewJSONEncoder(cfg)).
+//	  Sink(ladcore.Lock(os.Stderr)).
+//	  Sink(file).
+//	  MinLevel(ladcore.InfoLevel).
+//	  Sampled(time.Second, 100, 100).
+//	  Async(0, 0).
+//	  Core()
+//
+// Each method records a setting and returns the builder; the order of the
+// calls doesn't matter. A CoreBuilder isn't safe for concurrent use.
+type CoreBuilder struct {
+	enc   Encoder
+	sinks []WriteSyncer
+	enab  LevelEnabler
+	hooks []func(Entry) error
+
+	sampled           bool
+	tick              time.Duration
+	first, thereafter int
+	samplerOpts       []SamplerOption
+	async             bool
+	asyncSize         int
+	asyncInterval     time.Duration
+	buffered          []*BufferedWriteSyncer
+}
+
+// Build starts building a Core.
+func Build() *CoreBuilder {
+	return &CoreBuilder{}
+}
+
+// Encoder sets the Encoder for the Core. It's required.
+func (b *CoreBuilder) Encoder(enc Encoder) *CoreBuilder {
+	b.enc = enc
+	return b
+}
+
+// Sink adds a destination for the Core's output. At least one is required;
+// with several, each entry is written to all of them.
+func (b *CoreBuilder) Sink(ws WriteSyncer) *CoreBuilder {
+	b.sinks = append(b.sinks, ws)
+	return b
+}
+
+// MinLevel sets the levels the Core logs. Defaults to InfoLevel and above.
+func (b *CoreBuilder) MinLevel(enab LevelEnabler) *CoreBuilder {
+	b.enab = enab
+	return b
+}
+
+// Sampled samples the Core's entries. See NewSamplerWithOptions for the
+// meaning of the arguments.
+func (b *CoreBuilder) Sampled(tick time.Duration, first, thereafter int, opts ...SamplerOption) *CoreBuilder {
+	b.sampled = true
+	b.tick, b.first, b.thereafter = tick, first, thereafter
+	b.samplerOpts = opts
+	return b
+}
+
+// Async buffers writes to the sinks in memory, flushing them when size bytes
+// are buffered, every flushInterval, and when the Core is synced. Zero values
+// use the defaults of BufferedWriteSyncer. Call Stop when the Core is no
+// longer needed to flush it and stop the background goroutine.
+func (b *CoreBuilder) Async(size int, flushInterval time.Duration) *CoreBuilder {
+	b.async = true
+	b.asyncSize, b.asyncInterval = size, flushInterval
+	return b
+}
+
+// Hooks registers functions to call each time the Core writes an entry. See
+// RegisterHooks for details.
+func (b *CoreBuilder) Hooks(hooks ...func(Entry) error) *CoreBuilder {
+	b.hooks = append(b.hooks, hooks...)
+	return b
+}
+
+// Core builds the Core. It returns an error if a required setting is
+// missing.
+func (b *CoreBuilder) Core() (Core, error) {
+	if b.enc == nil {
+		return nil, errBuilderNoEncoder
+	}
+	if len(b.sinks) == 0 {
+		return nil, errBuilderNoSink
+	}
+
+	ws := b.sinks[0]
+	if len(b.sinks) > 1 {
+		ws = NewMultiWriteSyncer(b.sinks...)
+	}
+	if b.async {
+		bws := &BufferedWriteSyncer{
+			WS:            ws,
+			Size:          b.asyncSize,
+			FlushInterval: b.asyncInterval,
+		}
+		b.buffered = append(b.buffered, bws)
+		ws = bws
+	}
+
+	enab := b.enab
+	if enab == nil {
+		enab = InfoLevel
+	}
+
+	core := NewCore(b.enc, ws, enab)
+	if len(b.hooks) > 0 {
+		core = RegisterHooks(core, b.hooks...)
+	}
+	if b.sampled {
+		core = NewSamplerWithOptions(core, b.tick, b.first, b.thereafter, b.samplerOpts...)
+	}
+	return core, nil
+}
+
+// Stop flushes and stops the buffers set up by Async for the Cores built so
+// far. It's a no-op if Async wasn't used.
+func (b *CoreBuilder) Stop() error {
+	var err error
+	for _, bws := range b.buffered {
+		err = multierr.Append(err, bws.Stop())
+	}
+	b.buffered = nil
+	return err
+}
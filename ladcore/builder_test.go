@@ -0,0 +1,107 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/auwixcom/lad/internal/ztest"
+	//revive:disable:dot-imports
+	. "github.com/auwixcom/lad/ladcore"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func builderEncoder() Encoder {
+	return NewJSONEncoder(EncoderConfig{MessageKey: "msg", LevelKey: "level", EncodeLevel: LowercaseLevelEncoder})
+}
+
+func writeEntry(core Core, lvl Level, msg string) {
+	if ce := core.Check(Entry{Level: lvl, Message: msg}, nil); ce != nil {
+		ce.Write()
+	}
+}
+
+func TestCoreBuilder(t *testing.T) {
+	var a, b ztest.Buffer
+	var hooked int
+	core, err := Build().
+		Encoder(builderEncoder()).
+		Sink(&a).
+		Sink(&b).
+		MinLevel(WarnLevel).
+		Hooks(func(Entry) error { hooked++; return nil }).
+		Core()
+	require.NoError(t, err, "Unexpected error building core.")
+	assert.Equal(t, WarnLevel, LevelOf(core), "Unexpected level.")
+
+	writeEntry(core, InfoLevel, "dropped")
+	writeEntry(core, ErrorLevel, "kept")
+	want := `{"level":"error","msg":"kept"}` + "\n"
+	assert.Equal(t, want, a.String(), "Unexpected output to first sink.")
+	assert.Equal(t, want, b.String(), "Unexpected output to second sink.")
+	assert.Equal(t, 1, hooked, "Expected hooks to run for written entries.")
+	assert.NoError(t, Build().Stop(), "Expected Stop without Async to be a no-op.")
+}
+
+func TestCoreBuilderDefaults(t *testing.T) {
+	var buf ztest.Buffer
+	core, err := Build().Encoder(builderEncoder()).Sink(&buf).Core()
+	require.NoError(t, err, "Unexpected error building core.")
+	assert.Equal(t, InfoLevel, LevelOf(core), "Expected InfoLevel by default.")
+}
+
+func TestCoreBuilderSampled(t *testing.T) {
+	var buf ztest.Buffer
+	core, err := Build().
+		Encoder(builderEncoder()).
+		Sink(&buf).
+		Sampled(time.Minute, 2, 1000).
+		Core()
+	require.NoError(t, err, "Unexpected error building core.")
+
+	for i := 0; i < 5; i++ {
+		writeEntry(core, InfoLevel, "repeated")
+	}
+	assert.Len(t, buf.Lines(), 2, "Expected sampling to drop repeated entries.")
+}
+
+func TestCoreBuilderAsync(t *testing.T) {
+	var buf ztest.Buffer
+	b := Build().Encoder(builderEncoder()).Sink(&buf).Async(0, time.Hour)
+	core, err := b.Core()
+	require.NoError(t, err, "Unexpected error building core.")
+
+	writeEntry(core, InfoLevel, "buffered")
+	assert.Empty(t, buf.String(), "Expected output to be buffered.")
+	require.NoError(t, b.Stop(), "Unexpected error stopping builder.")
+	assert.Equal(t, `{"level":"info","msg":"buffered"}`+"\n", buf.String(), "Expected Stop to flush output.")
+}
+
+func TestCoreBuilderErrors(t *testing.T) {
+	_, err := Build().Sink(&ztest.Buffer{}).Core()
+	assert.ErrorContains(t, err, "no Encoder", "Expected an error without an Encoder.")
+
+	_, err = Build().Encoder(builderEncoder()).Core()
+	assert.ErrorContains(t, err, "no Sink", "Expected an error without a Sink.")
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore
+
+import "errors"
+
+// ErrChannelFull is returned by a channel Core configured with
+// ChannelDropWhenFull when it drops an entry.
+var ErrChannelFull = errors.New("channel is full, entry dropped")
+
+// EntryWithFields is an Entry along with all of its fields: those added to
+// the logger with With, followed by those passed at the log site.
+type EntryWithFields struct {
+	Entry
+	Fields []Field
+}
+
+// ChannelOption configures a Core created with NewChannelCore.
+type ChannelOption interface {
+	apply(*channelCore)
+}
+
+// channelOptionFunc wraps a func so it satisfies the ChannelOption interface.
+type channelOptionFunc func(*channelCore)
+
+func (f channelOptionFunc) apply(c *channelCore) {
+	f(c)
+}
+
+// ChannelDropWhenFull makes the Core drop entries, returning ErrChannelFull,
+// instead of blocking the logging goroutine when the channel is full.
+func ChannelDropWhenFull() ChannelOption {
+	return channelOptionFunc(func(c *channelCore) {
+		c.dropWhenFull = true
+	})
+}
+
+// NewChannelCore creates a Core that sends each enabled entry, along with its
+// fields, to ch. It lets plugins, TUIs, and tests consume structured entries
+// in-process without parsing encoder output.
+//
+// By default, logging blocks until the consumer receives the entry (or
+// until there's room in a buffered channel); see ChannelDropWhenFull. The
+// channel must not be closed while the Core is in use.
+func NewChannelCore(ch chan<- EntryWithFields, enab LevelEnabler, opts ...ChannelOption) Core {
+	c := &channelCore{
+		LevelEnabler: enab,
+		ch:           ch,
+	}
+	for _, opt := range opts {
+		opt.apply(c)
+	}
+	return c
+}
+
+type channelCore struct {
+	LevelEnabler
+
+	ch           chan<- EntryWithFields
+	dropWhenFull bool
+	context      []Field
+}
+
+var (
+	_ Core           = (*channelCore)(nil)
+	_ leveledEnabler = (*channelCore)(nil)
+)
+
+func (c *channelCore) Level() Level {
+	return LevelOf(c.LevelEnabler)
+}
+
+func (c *channelCore) With(fields []Field) Core {
+	clone := *c
+	clone.context = append(c.context[:len(c.context):len(c.context)], fields...)
+	return &clone
+}
+
+func (c *channelCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *channelCore) Write(ent Entry, fields []Field) error {
+	// The caller owns fields, so the consumer gets a copy.
+	ewf := EntryWithFields{
+		Entry:  ent,
+		Fields: append(c.context[:len(c.context):len(c.context)], fields...),
+	}
+	if !c.dropWhenFull {
+		c.ch <- ewf
+		return nil
+	}
+
+	select {
+	case c.ch <- ewf:
+		return nil
+	default:
+		return ErrChannelFull
+	}
+}
+
+func (c *channelCore) Sync() error {
+	return nil
+}
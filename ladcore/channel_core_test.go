@@ -0,0 +1,77 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore_test
+
+import (
+	"testing"
+
+	//revive:disable:dot-imports
+	. "github.com/auwixcom/lad/ladcore"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChannelCore(t *testing.T) {
+	ch := make(chan EntryWithFields, 2)
+	core := NewChannelCore(ch, InfoLevel)
+	assert.Equal(t, InfoLevel, LevelOf(core), "Unexpected level.")
+
+	child := core.With([]Field{makeInt64Field("a", 1)})
+	fields := []Field{makeInt64Field("b", 2)}
+	for _, lvl := range []Level{DebugLevel, WarnLevel} {
+		if ce := child.Check(Entry{Level: lvl, Message: "msg"}, nil); ce != nil {
+			ce.Write(fields...)
+		}
+	}
+	fields[0] = makeInt64Field("c", 3)
+
+	require.Len(t, ch, 1, "Expected only enabled entries to be sent.")
+	assert.Equal(t, EntryWithFields{
+		Entry:  Entry{Level: WarnLevel, Message: "msg"},
+		Fields: []Field{makeInt64Field("a", 1), makeInt64Field("b", 2)},
+	}, <-ch, "Unexpected entry.")
+
+	require.NoError(t, core.Write(Entry{Message: "parent"}, nil), "Unexpected error writing.")
+	assert.Empty(t, (<-ch).Fields, "Expected the parent core to be unaffected by With.")
+	assert.NoError(t, core.Sync(), "Unexpected error syncing.")
+}
+
+func TestChannelCoreDropWhenFull(t *testing.T) {
+	ch := make(chan EntryWithFields, 1)
+	core := NewChannelCore(ch, InfoLevel, ChannelDropWhenFull())
+
+	require.NoError(t, core.Write(Entry{Message: "first"}, nil), "Unexpected error writing.")
+	assert.ErrorIs(t, core.Write(Entry{Message: "second"}, nil), ErrChannelFull, "Expected entries to be dropped when full.")
+	assert.Equal(t, "first", (<-ch).Message, "Unexpected entry.")
+}
+
+func TestChannelCoreBlocks(t *testing.T) {
+	ch := make(chan EntryWithFields)
+	core := NewChannelCore(ch, InfoLevel)
+
+	done := make(chan error)
+	go func() {
+		done <- core.Write(Entry{Message: "hello"}, nil)
+	}()
+	assert.Equal(t, "hello", (<-ch).Message, "Unexpected entry.")
+	assert.NoError(t, <-done, "Unexpected error writing.")
+}
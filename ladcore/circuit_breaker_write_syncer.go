@@ -0,0 +1,261 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// _defaultBreakerThreshold specifies the default number of consecutive
+	// failures after which a CircuitBreakerWriteSyncer opens.
+	_defaultBreakerThreshold = 5
+
+	// _defaultBreakerCooldown specifies the default time a
+	// CircuitBreakerWriteSyncer stays open before probing the sink again.
+	_defaultBreakerCooldown = 30 * time.Second
+)
+
+// ErrBreakerOpen is returned by a CircuitBreakerWriteSyncer that is rejecting
+// operations because the wrapped WriteSyncer has been failing.
+var ErrBreakerOpen = errors.New("sink circuit breaker is open")
+
+// BreakerState is the state of a CircuitBreakerWriteSyncer.
+type BreakerState uint8
+
+const (
+	// BreakerClosed indicates that operations are forwarded to the wrapped
+	// WriteSyncer. This is the initial state.
+	BreakerClosed BreakerState = iota
+	// BreakerOpen indicates that operations are rejected without being
+	// attempted.
+	BreakerOpen
+	// BreakerHalfOpen indicates that a single probe operation is being
+	// forwarded to find out whether the wrapped WriteSyncer has recovered.
+	BreakerHalfOpen
+)
+
+// String returns a lower-case ASCII representation of the state.
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("BreakerState(%d)", s)
+	}
+}
+
+// A CircuitBreakerWriteSyncer is a WriteSyncer that stops forwarding
+// operations to a wrapped WriteSyncer after it fails repeatedly, preventing
+// retry storms against a collector that is down.
+//
+// After Threshold consecutive failed Writes or Syncs, the breaker opens and
+// every operation fails immediately with ErrBreakerOpen. Once Cooldown has
+// passed, the breaker becomes half-open and lets a single operation through
+// as a probe: if it succeeds, the breaker closes again; otherwise it re-opens
+// for another Cooldown.
+//
+//	ws := &ladcore.CircuitBreakerWriteSyncer{
+//	  WS:        collector,
+//	  Threshold: 3,
+//	  Cooldown:  10 * time.Second,
+//	}
+//
+// CircuitBreakerWriteSyncer is safe for concurrent use if the wrapped
+// WriteSyncer is.
+type CircuitBreakerWriteSyncer struct {
+	// WS is the WriteSyncer protected by the breaker.
+	//
+	// This field is required.
+	WS WriteSyncer
+
+	// Threshold is the number of consecutive failures after which the breaker
+	// opens.
+	//
+	// Defaults to 5 if unspecified.
+	Threshold int
+
+	// Cooldown is how long the breaker stays open before letting a probe
+	// through.
+	//
+	// Defaults to 30 seconds if unspecified.
+	Cooldown time.Duration
+
+	// OnStateChange, if specified, is called whenever the breaker changes
+	// state. It's called synchronously, so it must not block or write to this
+	// WriteSyncer.
+	OnStateChange func(from, to BreakerState)
+
+	// Clock, if specified, provides control of the source of time for the
+	// breaker.
+	//
+	// Defaults to the system clock.
+	Clock Clock
+
+	mu         sync.Mutex
+	state      BreakerState
+	generation uint64    // incremented on every state change
+	failures   int       // consecutive failures while closed
+	openedAt   time.Time // when the breaker last opened
+	probing    bool      // whether a half-open probe is in flight
+}
+
+// breakerOp identifies a forwarded operation by the state it started in, so
+// that its outcome is only recorded against that state.
+type breakerOp struct {
+	generation uint64
+	probe      bool
+}
+
+// Write forwards bs to the wrapped WriteSyncer unless the breaker is open.
+func (s *CircuitBreakerWriteSyncer) Write(bs []byte) (int, error) {
+	op, ok := s.allow()
+	if !ok {
+		return 0, ErrBreakerOpen
+	}
+	n, err := s.WS.Write(bs)
+	s.record(op, err)
+	return n, err
+}
+
+// Sync flushes the wrapped WriteSyncer unless the breaker is open.
+func (s *CircuitBreakerWriteSyncer) Sync() error {
+	op, ok := s.allow()
+	if !ok {
+		return ErrBreakerOpen
+	}
+	err := s.WS.Sync()
+	s.record(op, err)
+	return err
+}
+
+// State reports the current state of the breaker.
+func (s *CircuitBreakerWriteSyncer) State() BreakerState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state
+}
+
+// allow reports whether an operation may be forwarded to the wrapped
+// WriteSyncer, transitioning from open to half-open if the cooldown passed.
+// The returned breakerOp must be passed to record with the outcome.
+func (s *CircuitBreakerWriteSyncer) allow() (breakerOp, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch s.state {
+	case BreakerOpen:
+		if s.now().Sub(s.openedAt) < s.cooldown() {
+			return breakerOp{}, false
+		}
+		s.setState(BreakerHalfOpen)
+		s.probing = true
+		return breakerOp{generation: s.generation, probe: true}, true
+	case BreakerHalfOpen:
+		if s.probing {
+			return breakerOp{}, false
+		}
+		s.probing = true
+		return breakerOp{generation: s.generation, probe: true}, true
+	default:
+		return breakerOp{generation: s.generation}, true
+	}
+}
+
+// record updates the breaker with the outcome of a forwarded operation.
+//
+// Only the half-open probe decides whether the breaker closes or re-opens.
+// Operations that started in an earlier state, like slow writes that were
+// already in flight when the breaker opened, are ignored.
+func (s *CircuitBreakerWriteSyncer) record(op breakerOp, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if op.generation != s.generation {
+		return
+	}
+
+	if op.probe {
+		s.probing = false
+		if err != nil {
+			s.open()
+			return
+		}
+		s.failures = 0
+		s.setState(BreakerClosed)
+		return
+	}
+
+	if err == nil {
+		s.failures = 0
+		return
+	}
+	s.failures++
+	if s.failures >= s.threshold() {
+		s.open()
+	}
+}
+
+func (s *CircuitBreakerWriteSyncer) open() {
+	s.failures = 0
+	s.openedAt = s.now()
+	s.setState(BreakerOpen)
+}
+
+func (s *CircuitBreakerWriteSyncer) setState(to BreakerState) {
+	from := s.state
+	if from == to {
+		return
+	}
+	s.state = to
+	s.generation++
+	if s.OnStateChange != nil {
+		s.OnStateChange(from, to)
+	}
+}
+
+func (s *CircuitBreakerWriteSyncer) now() time.Time {
+	if s.Clock != nil {
+		return s.Clock.Now()
+	}
+	return DefaultClock.Now()
+}
+
+func (s *CircuitBreakerWriteSyncer) threshold() int {
+	if s.Threshold > 0 {
+		return s.Threshold
+	}
+	return _defaultBreakerThreshold
+}
+
+func (s *CircuitBreakerWriteSyncer) cooldown() time.Duration {
+	if s.Cooldown > 0 {
+		return s.Cooldown
+	}
+	return _defaultBreakerCooldown
+}
@@ -0,0 +1,176 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/auwixcom/lad/internal/ztest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flakyWriteSyncer fails all operations while err is set.
+type flakyWriteSyncer struct {
+	ztest.Buffer
+
+	err   error
+	calls int
+}
+
+func (w *flakyWriteSyncer) Write(bs []byte) (int, error) {
+	w.calls++
+	if w.err != nil {
+		return 0, w.err
+	}
+	return w.Buffer.Write(bs)
+}
+
+func (w *flakyWriteSyncer) Sync() error {
+	w.calls++
+	return w.err
+}
+
+func TestCircuitBreakerWriteSyncer(t *testing.T) {
+	errDown := errors.New("collector down")
+
+	clock := ztest.NewMockClock()
+	sink := &flakyWriteSyncer{}
+	var transitions []string
+	ws := &CircuitBreakerWriteSyncer{
+		WS:        sink,
+		Threshold: 2,
+		Cooldown:  time.Minute,
+		Clock:     clock,
+		OnStateChange: func(from, to BreakerState) {
+			transitions = append(transitions, from.String()+"->"+to.String())
+		},
+	}
+
+	requireWriteWorks(t, ws)
+	assert.Equal(t, BreakerClosed, ws.State(), "Expected the breaker to start closed.")
+
+	sink.err = errDown
+	_, err := ws.Write([]byte("a"))
+	assert.ErrorIs(t, err, errDown, "Expected the wrapped error while closed.")
+	assert.Equal(t, BreakerClosed, ws.State(), "Expected the breaker to stay closed below the threshold.")
+
+	assert.ErrorIs(t, ws.Sync(), errDown, "Expected the wrapped error while closed.")
+	assert.Equal(t, BreakerOpen, ws.State(), "Expected the breaker to open at the threshold.")
+
+	calls := sink.calls
+	_, err = ws.Write([]byte("b"))
+	assert.ErrorIs(t, err, ErrBreakerOpen, "Expected writes to fail fast while open.")
+	assert.ErrorIs(t, ws.Sync(), ErrBreakerOpen, "Expected syncs to fail fast while open.")
+	assert.Equal(t, calls, sink.calls, "Expected no operations to reach the sink while open.")
+
+	// A failed probe re-opens the breaker for another cooldown.
+	clock.Add(time.Minute)
+	_, err = ws.Write([]byte("c"))
+	assert.ErrorIs(t, err, errDown, "Expected the probe to reach the sink.")
+	assert.Equal(t, BreakerOpen, ws.State(), "Expected a failed probe to re-open the breaker.")
+	_, err = ws.Write([]byte("d"))
+	assert.ErrorIs(t, err, ErrBreakerOpen, "Expected writes to fail fast after a failed probe.")
+
+	// A successful probe closes it.
+	sink.err = nil
+	clock.Add(time.Minute)
+	_, err = ws.Write([]byte("e"))
+	require.NoError(t, err, "Expected the probe to succeed.")
+	assert.Equal(t, BreakerClosed, ws.State(), "Expected a successful probe to close the breaker.")
+	requireWriteWorks(t, ws)
+
+	assert.Equal(t, "fooefoo", sink.String(), "Unexpected output.")
+	assert.Equal(t, []string{
+		"closed->open",
+		"open->half-open",
+		"half-open->open",
+		"open->half-open",
+		"half-open->closed",
+	}, transitions, "Unexpected state transitions.")
+}
+
+func TestCircuitBreakerWriteSyncerSingleProbe(t *testing.T) {
+	clock := ztest.NewMockClock()
+	ws := &CircuitBreakerWriteSyncer{
+		WS:        &ztest.FailWriter{},
+		Threshold: 1,
+		Clock:     clock,
+	}
+
+	_, err := ws.Write([]byte("foo"))
+	require.Error(t, err, "Expected the first write to fail.")
+	require.Equal(t, BreakerOpen, ws.State(), "Expected the breaker to open.")
+
+	clock.Add(_defaultBreakerCooldown)
+	_, ok := ws.allow()
+	require.True(t, ok, "Expected a probe to be allowed after the cooldown.")
+	assert.Equal(t, BreakerHalfOpen, ws.State(), "Expected the breaker to be half-open.")
+	_, ok = ws.allow()
+	assert.False(t, ok, "Expected only one probe at a time.")
+}
+
+func TestCircuitBreakerWriteSyncerStaleOutcomes(t *testing.T) {
+	errDown := errors.New("collector down")
+	clock := ztest.NewMockClock()
+	ws := &CircuitBreakerWriteSyncer{
+		WS:        &ztest.Discarder{},
+		Threshold: 1,
+		Clock:     clock,
+	}
+
+	// Two slow writes start while the breaker is closed, and another
+	// operation opens it before they finish.
+	slowOK, ok := ws.allow()
+	require.True(t, ok, "Expected writes to be allowed while closed.")
+	slowFailed, ok := ws.allow()
+	require.True(t, ok, "Expected writes to be allowed while closed.")
+	failed, ok := ws.allow()
+	require.True(t, ok, "Expected writes to be allowed while closed.")
+	ws.record(failed, errDown)
+	require.Equal(t, BreakerOpen, ws.State(), "Expected the breaker to open.")
+
+	clock.Add(_defaultBreakerCooldown)
+	probe, ok := ws.allow()
+	require.True(t, ok, "Expected a probe to be allowed after the cooldown.")
+
+	ws.record(slowOK, nil)
+	assert.Equal(t, BreakerHalfOpen, ws.State(), "Expected a stale success not to close the breaker.")
+	ws.record(slowFailed, errDown)
+	assert.Equal(t, BreakerHalfOpen, ws.State(), "Expected a stale failure not to re-open the breaker.")
+
+	ws.record(probe, nil)
+	assert.Equal(t, BreakerClosed, ws.State(), "Expected the probe to close the breaker.")
+}
+
+func TestBreakerStateString(t *testing.T) {
+	tests := map[BreakerState]string{
+		BreakerClosed:   "closed",
+		BreakerOpen:     "open",
+		BreakerHalfOpen: "half-open",
+		BreakerState(7): "BreakerState(7)",
+	}
+	for state, want := range tests {
+		assert.Equal(t, want, state.String(), "Unexpected string for state %d.", state)
+	}
+}
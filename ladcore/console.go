@@ -0,0 +1,246 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strconv"
+)
+
+// ConsoleColorMode describes how a console handles the ANSI escape sequences
+// written by color-aware encoders such as CapitalColorLevelEncoder.
+type ConsoleColorMode int
+
+const (
+	// ConsoleNotTerminal indicates that the file isn't attached to a console
+	// (it's redirected to a file or a pipe, for example). Escape sequences are
+	// written as-is.
+	ConsoleNotTerminal ConsoleColorMode = iota
+	// ConsoleANSI indicates that the console interprets ANSI escape sequences.
+	ConsoleANSI
+	// ConsoleLegacy indicates a Windows console that predates virtual terminal
+	// processing. Escape sequences must be translated into console API calls.
+	ConsoleLegacy
+)
+
+// String returns a lower-case ASCII representation of the mode.
+func (m ConsoleColorMode) String() string {
+	switch m {
+	case ConsoleNotTerminal:
+		return "not-terminal"
+	case ConsoleANSI:
+		return "ansi"
+	case ConsoleLegacy:
+		return "legacy"
+	default:
+		return "ConsoleColorMode(" + strconv.Itoa(int(m)) + ")"
+	}
+}
+
+// ProbeConsoleColor reports how the console attached to f handles ANSI
+// escape sequences. On Windows, it first tries to enable virtual terminal
+// processing, so consoles that support it report ConsoleANSI.
+func ProbeConsoleColor(f *os.File) ConsoleColorMode {
+	return probeConsoleColor(f)
+}
+
+// NewConsoleWriteSyncer wraps f so that the colors written by encoders like
+// CapitalColorLevelEncoder render correctly on its console. On legacy Windows
+// consoles, escape sequences are translated into calls to the native console
+// API instead of being printed verbatim. Everywhere else, including all
+// non-Windows platforms, f is returned as a plain WriteSyncer.
+func NewConsoleWriteSyncer(f *os.File) WriteSyncer {
+	if probeConsoleColor(f) == ConsoleLegacy {
+		if ws, ok := newLegacyConsoleWriteSyncer(f); ok {
+			return ws
+		}
+	}
+	return AddSync(f)
+}
+
+// Character attributes used by the Windows console API.
+const (
+	_consoleForegroundBlue      uint16 = 0x1
+	_consoleForegroundGreen     uint16 = 0x2
+	_consoleForegroundRed       uint16 = 0x4
+	_consoleForegroundIntensity uint16 = 0x8
+	_consoleForegroundColorMask uint16 = 0x7
+	_consoleForegroundMask      uint16 = 0xf
+)
+
+// _ansiToConsoleColor maps ANSI color offsets (black, red, green, yellow,
+// blue, magenta, cyan, white) to console foreground attributes.
+var _ansiToConsoleColor = [8]uint16{
+	0,
+	_consoleForegroundRed,
+	_consoleForegroundGreen,
+	_consoleForegroundRed | _consoleForegroundGreen,
+	_consoleForegroundBlue,
+	_consoleForegroundRed | _consoleForegroundBlue,
+	_consoleForegroundGreen | _consoleForegroundBlue,
+	_consoleForegroundRed | _consoleForegroundGreen | _consoleForegroundBlue,
+}
+
+// _maxPendingEscape is the longest incomplete escape sequence that a
+// consoleAttrWriter carries over to its next Write. Longer ones are written
+// as-is.
+const _maxPendingEscape = 64
+
+// consoleAttrWriter translates ANSI SGR sequences into console text
+// attributes. Sequences other than SGR are dropped, and SGR parameters
+// without a console equivalent are ignored. A sequence split across Writes
+// is held back until the rest of it arrives.
+type consoleAttrWriter struct {
+	w           io.Writer
+	setAttr     func(uint16) error
+	defaultAttr uint16
+	attr        uint16
+	pending     []byte // incomplete escape sequence from the last Write
+}
+
+func newConsoleAttrWriter(w io.Writer, defaultAttr uint16, setAttr func(uint16) error) *consoleAttrWriter {
+	return &consoleAttrWriter{
+		w:           w,
+		setAttr:     setAttr,
+		defaultAttr: defaultAttr,
+		attr:        defaultAttr,
+	}
+}
+
+func (c *consoleAttrWriter) Write(p []byte) (int, error) {
+	// Bytes held back by the last Write were already reported as written, so
+	// they don't count towards this one.
+	data, held := p, len(c.pending)
+	if held > 0 {
+		data = append(c.pending, p...)
+		c.pending = nil
+	}
+	consumed := func(n int) int {
+		if n < held {
+			return 0
+		}
+		return n - held
+	}
+
+	pos := 0
+	for pos < len(data) {
+		i := bytes.IndexByte(data[pos:], '\x1b')
+		if i < 0 {
+			i = len(data) - pos
+		}
+		n, err := c.writeText(data[pos : pos+i])
+		if err != nil {
+			return consumed(pos + n), err
+		}
+		pos += i
+		if pos == len(data) {
+			break
+		}
+
+		// Find the final byte of the control sequence.
+		end := pos + 1
+		if end < len(data) && data[end] == '[' {
+			end++
+			for end < len(data) && (data[end] < 0x40 || data[end] > 0x7e) {
+				end++
+			}
+		}
+		if end == len(data) {
+			if end-pos > _maxPendingEscape {
+				// Too long to be a sequence we understand; write it as-is.
+				n, err := c.writeText(data[pos:])
+				return consumed(pos + n), err
+			}
+			// Wait for the rest of the sequence.
+			c.pending = append([]byte(nil), data[pos:]...)
+			break
+		}
+		if data[pos+1] != '[' {
+			// A lone escape character isn't a control sequence.
+			n, err := c.writeText(data[pos : pos+1])
+			if err != nil {
+				return consumed(pos + n), err
+			}
+			pos++
+			continue
+		}
+		if data[end] == 'm' {
+			if err := c.applySGR(data[pos+2 : end]); err != nil {
+				return consumed(pos), err
+			}
+		}
+		pos = end + 1
+	}
+	return len(p), nil
+}
+
+func (c *consoleAttrWriter) writeText(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	return c.w.Write(p)
+}
+
+func (c *consoleAttrWriter) applySGR(params []byte) error {
+	attr := c.attr
+	if len(params) == 0 {
+		attr = c.defaultAttr
+	}
+	for _, param := range bytes.Split(params, []byte(";")) {
+		n, err := strconv.Atoi(string(param))
+		if err != nil {
+			continue
+		}
+		switch {
+		case n == 0:
+			attr = c.defaultAttr
+		case n == 1:
+			attr |= _consoleForegroundIntensity
+		case n == 22:
+			attr &^= _consoleForegroundIntensity
+		case n >= 30 && n <= 37:
+			attr = attr&^_consoleForegroundColorMask | _ansiToConsoleColor[n-30]
+		case n == 39:
+			attr = attr&^_consoleForegroundMask | c.defaultAttr&_consoleForegroundMask
+		case n >= 90 && n <= 97:
+			attr = attr&^_consoleForegroundMask | _ansiToConsoleColor[n-90] | _consoleForegroundIntensity
+		}
+	}
+	if attr == c.attr {
+		return nil
+	}
+	c.attr = attr
+	return c.setAttr(attr)
+}
+
+// legacyConsoleWriteSyncer translates escape sequences for a legacy Windows
+// console while syncing the underlying file.
+type legacyConsoleWriteSyncer struct {
+	*consoleAttrWriter
+
+	f *os.File
+}
+
+func (ws legacyConsoleWriteSyncer) Sync() error {
+	return ws.f.Sync()
+}
@@ -21,7 +21,11 @@
 package ladcore
 
 import (
+	"bytes"
 	"fmt"
+	"sync"
+	"time"
+	"unicode/utf8"
 
 	"github.com/auwixcom/lad/buffer"
 	"github.com/auwixcom/lad/internal/bufferpool"
@@ -43,8 +47,42 @@ func putSliceEncoder(e *sliceArrayEncoder) {
 	_sliceEncoderPool.Put(e)
 }
 
+// Values for EncoderConfig.ConsoleRepeatedContext.
+const (
+	ConsoleContextElide = "elide"
+	ConsoleContextDim   = "dim"
+)
+
+const (
+	_elidedContext = "..."
+	_dimStart      = "\x1b[2m"
+)
+
 type consoleEncoder struct {
 	*jsonEncoder
+
+	highlights []consoleHighlighter
+	pins       []*jsonEncoder // context for each pinned field, if any
+	repeats    *contextRepeats
+}
+
+// contextRepeats remembers the context of the last entry an encoder and its
+// clones wrote, to spot repeated context.
+type contextRepeats struct {
+	mu   sync.Mutex
+	last []byte
+}
+
+// repeated records context as the last one written and reports whether it
+// was already.
+func (r *contextRepeats) repeated(context []byte) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if bytes.Equal(r.last, context) {
+		return true
+	}
+	r.last = append(r.last[:0], context...)
+	return false
 }
 
 // NewConsoleEncoder creates an encoder whose output is designed for human -
@@ -55,19 +93,191 @@ type consoleEncoder struct {
 // Note that although the console encoder doesn't use the keys specified in the
 // encoder configuration, it will omit any element whose key is set to the empty
 // string.
+//
+// Entries that match one of the configured ConsoleHighlights are colored or
+// bolded. Invalid highlight rules are ignored; see ConsoleHighlight.Validate.
+//
+// The level and caller columns can be padded to fixed widths, and fields can
+// be pinned to the start of the structured context; see
+// EncoderConfig.ConsoleLevelWidth and EncoderConfig.ConsolePinnedFields.
+//
+// With EncoderConfig.ConsoleRepeatedContext, context fields that are the same
+// as the previous entry's are elided or dimmed. The encoder and its clones
+// remember the last entry they encoded, so only use it with a single output,
+// and note that concurrent logging may interleave entries differently from
+// the order they were encoded in. Highlights don't see elided fields.
 func NewConsoleEncoder(cfg EncoderConfig) Encoder {
 	if cfg.ConsoleSeparator == "" {
 		// Use a default delimiter of '\t' for backwards compatibility
 		cfg.ConsoleSeparator = "\t"
 	}
-	return consoleEncoder{newJSONEncoder(cfg, true)}
+	c := consoleEncoder{
+		jsonEncoder: newJSONEncoder(cfg, true),
+		highlights:  compileHighlights(cfg.ConsoleHighlights),
+	}
+	if len(cfg.ConsolePinnedFields) > 0 {
+		c.pins = make([]*jsonEncoder, len(cfg.ConsolePinnedFields))
+	}
+	switch cfg.ConsoleRepeatedContext {
+	case ConsoleContextElide, ConsoleContextDim:
+		c.repeats = &contextRepeats{}
+	}
+	return c
 }
 
 func (c consoleEncoder) Clone() Encoder {
-	return consoleEncoder{c.jsonEncoder.Clone().(*jsonEncoder)}
+	clone := consoleEncoder{
+		jsonEncoder: c.jsonEncoder.Clone().(*jsonEncoder),
+		highlights:  c.highlights,
+		repeats:     c.repeats,
+	}
+	if c.pins != nil {
+		clone.pins = make([]*jsonEncoder, len(c.pins))
+		for i, pin := range c.pins {
+			if pin != nil {
+				clone.pins[i] = pin.Clone().(*jsonEncoder)
+			}
+		}
+	}
+	return clone
+}
+
+// pinIndex returns the index of key in ConsolePinnedFields, or -1 if the
+// key isn't pinned.
+func (c consoleEncoder) pinIndex(key string) int {
+	for i, k := range c.ConsolePinnedFields {
+		if k == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// target returns the encoder that a context field with the given key goes
+// to: its pin, if it's a pinned top-level field, or the rest of the context.
+func (c consoleEncoder) target(key string) *jsonEncoder {
+	if c.pins == nil || c.openNamespaces > 0 {
+		return c.jsonEncoder
+	}
+	i := c.pinIndex(key)
+	if i < 0 {
+		return c.jsonEncoder
+	}
+	if c.pins[i] == nil {
+		c.pins[i] = c.newPin()
+	}
+	return c.pins[i]
+}
+
+// newPin returns an empty top-level encoder with the same configuration.
+func (c consoleEncoder) newPin() *jsonEncoder {
+	pin := c.jsonEncoder.clone()
+	pin.openNamespaces = 0
+	pin.namespace = ""
+	return pin
+}
+
+func (c consoleEncoder) AddArray(key string, arr ArrayMarshaler) error {
+	return c.target(key).AddArray(key, arr)
+}
+
+func (c consoleEncoder) AddObject(key string, obj ObjectMarshaler) error {
+	return c.target(key).AddObject(key, obj)
+}
+
+func (c consoleEncoder) AddBinary(key string, val []byte) {
+	c.target(key).AddBinary(key, val)
+}
+
+func (c consoleEncoder) AddByteString(key string, val []byte) {
+	c.target(key).AddByteString(key, val)
+}
+
+func (c consoleEncoder) AddBool(key string, val bool) {
+	c.target(key).AddBool(key, val)
+}
+
+func (c consoleEncoder) AddComplex128(key string, val complex128) {
+	c.target(key).AddComplex128(key, val)
+}
+
+func (c consoleEncoder) AddComplex64(key string, val complex64) {
+	c.target(key).AddComplex64(key, val)
+}
+
+func (c consoleEncoder) AddDuration(key string, val time.Duration) {
+	c.target(key).AddDuration(key, val)
+}
+
+func (c consoleEncoder) AddFloat64(key string, val float64) {
+	c.target(key).AddFloat64(key, val)
+}
+
+func (c consoleEncoder) AddFloat32(key string, val float32) {
+	c.target(key).AddFloat32(key, val)
+}
+
+func (c consoleEncoder) AddInt(key string, val int) {
+	c.target(key).AddInt(key, val)
+}
+
+func (c consoleEncoder) AddInt64(key string, val int64) {
+	c.target(key).AddInt64(key, val)
+}
+
+func (c consoleEncoder) AddInt32(key string, val int32) {
+	c.target(key).AddInt32(key, val)
+}
+
+func (c consoleEncoder) AddInt16(key string, val int16) {
+	c.target(key).AddInt16(key, val)
+}
+
+func (c consoleEncoder) AddInt8(key string, val int8) {
+	c.target(key).AddInt8(key, val)
+}
+
+func (c consoleEncoder) AddString(key, val string) {
+	c.target(key).AddString(key, val)
+}
+
+func (c consoleEncoder) AddTime(key string, val time.Time) {
+	c.target(key).AddTime(key, val)
+}
+
+func (c consoleEncoder) AddUint(key string, val uint) {
+	c.target(key).AddUint(key, val)
+}
+
+func (c consoleEncoder) AddUint64(key string, val uint64) {
+	c.target(key).AddUint64(key, val)
+}
+
+func (c consoleEncoder) AddUint32(key string, val uint32) {
+	c.target(key).AddUint32(key, val)
+}
+
+func (c consoleEncoder) AddUint16(key string, val uint16) {
+	c.target(key).AddUint16(key, val)
+}
+
+func (c consoleEncoder) AddUint8(key string, val uint8) {
+	c.target(key).AddUint8(key, val)
+}
+
+func (c consoleEncoder) AddUintptr(key string, val uintptr) {
+	c.target(key).AddUintptr(key, val)
+}
+
+func (c consoleEncoder) AddReflected(key string, obj interface{}) error {
+	return c.target(key).AddReflected(key, obj)
 }
 
 func (c consoleEncoder) EncodeEntry(ent Entry, fields []Field) (*buffer.Buffer, error) {
+	if c.RequireMessage && ent.Message == "" {
+		return nil, ErrEmptyMessage
+	}
+
 	line := bufferpool.Get()
 
 	// We don't want the entry's metadata to be quoted and escaped (if it's
@@ -80,7 +290,9 @@ func (c consoleEncoder) EncodeEntry(ent Entry, fields []Field) (*buffer.Buffer,
 	if c.TimeKey != "" && c.EncodeTime != nil && !ent.Time.IsZero() {
 		c.EncodeTime(ent.Time, arr)
 	}
+	levelIdx, callerIdx := -1, -1
 	if c.LevelKey != "" && c.EncodeLevel != nil {
+		levelIdx = len(arr.elems)
 		c.EncodeLevel(ent.Level, arr)
 	}
 	if ent.LoggerName != "" && c.NameKey != "" {
@@ -95,22 +307,32 @@ func (c consoleEncoder) EncodeEntry(ent Entry, fields []Field) (*buffer.Buffer,
 	}
 	if ent.Caller.Defined {
 		if c.CallerKey != "" && c.EncodeCaller != nil {
+			callerIdx = len(arr.elems)
 			c.EncodeCaller(ent.Caller, arr)
 		}
 		if c.FunctionKey != "" {
 			arr.AppendString(ent.Caller.Function)
 		}
+	} else if ent.Caller.Function != "" {
+		arr.AppendString(ent.Caller.Function)
 	}
 	for i := range arr.elems {
 		if i > 0 {
 			line.AppendString(c.ConsoleSeparator)
 		}
-		_, _ = fmt.Fprint(line, arr.elems[i])
+		switch i {
+		case levelIdx:
+			appendPadded(line, arr.elems[i], c.ConsoleLevelWidth)
+		case callerIdx:
+			appendPadded(line, arr.elems[i], c.ConsoleCallerWidth)
+		default:
+			_, _ = fmt.Fprint(line, arr.elems[i])
+		}
 	}
 	putSliceEncoder(arr)
 
 	// Add the message itself.
-	if c.MessageKey != "" {
+	if c.MessageKey != "" && (ent.Message != "" || !c.OmitEmptyMessage) {
 		c.addSeparatorIfNecessary(line)
 		line.AppendString(ent.Message)
 	}
@@ -125,6 +347,12 @@ func (c consoleEncoder) EncodeEntry(ent Entry, fields []Field) (*buffer.Buffer,
 		line.AppendString(ent.Stack)
 	}
 
+	if len(c.highlights) > 0 {
+		line = highlight(c.highlights, ent, line)
+	}
+	if c.ASCIIOnly {
+		line = escapeNonASCII(line)
+	}
 	line.AppendString(c.LineEnding)
 	return line, nil
 }
@@ -138,18 +366,133 @@ func (c consoleEncoder) writeContext(line *buffer.Buffer, extra []Field) {
 		putJSONEncoder(context)
 	}()
 
-	addFields(context, extra)
+	// Transform the fields up front, so that pinning sees the final keys, and
+	// add them to context directly rather than transforming them twice.
+	extra = encodeFields(c.FieldEncoder, extra)
+
+	var pinned *jsonEncoder
+	if c.pins != nil {
+		pinned = c.newPin()
+		defer func() {
+			pinned.buf.Free()
+			putJSONEncoder(pinned)
+		}()
+		extra = c.addPinned(pinned, extra)
+	}
+
+	// Context fields can only be told apart from the rest at the top level.
+	shared := c.buf.Len()
+	repeated := c.repeats != nil && c.repeats.repeated(c.buf.Bytes()) && shared > 0 && c.openNamespaces == 0
+
+	for i := range extra {
+		extra[i].AddTo(context)
+	}
 	context.closeOpenNamespaces()
-	if context.buf.Len() == 0 {
+	if context.buf.Len() == 0 && (pinned == nil || pinned.buf.Len() == 0) {
 		return
 	}
 
 	c.addSeparatorIfNecessary(line)
 	line.AppendByte('{')
-	line.Write(context.buf.Bytes())
+	if pinned != nil && pinned.buf.Len() > 0 {
+		line.Write(pinned.buf.Bytes())
+		if context.buf.Len() > 0 {
+			line.AppendString(", ")
+		}
+	}
+	switch {
+	case !repeated:
+		line.Write(context.buf.Bytes())
+	case c.ConsoleRepeatedContext == ConsoleContextDim:
+		line.AppendString(_dimStart)
+		line.Write(context.buf.Bytes()[:shared])
+		line.AppendString(_highlightReset)
+		line.Write(context.buf.Bytes()[shared:])
+	default:
+		line.AppendString(_elidedContext)
+		line.Write(context.buf.Bytes()[shared:])
+	}
 	line.AppendByte('}')
 }
 
+// addPinned encodes the pinned fields, from the context and then from extra,
+// in the configured order, and returns the rest of extra.
+func (c consoleEncoder) addPinned(pinned *jsonEncoder, extra []Field) []Field {
+	// Fields after a namespace aren't at the top level.
+	top := len(extra)
+	if c.openNamespaces > 0 {
+		top = 0
+	}
+	for i := 0; i < top; i++ {
+		if extra[i].Type == NamespaceType {
+			top = i
+		}
+	}
+
+	var rest []Field
+	for i := 0; i < top; i++ {
+		if c.pinIndex(extra[i].Key) >= 0 {
+			rest = make([]Field, 0, len(extra))
+			break
+		}
+	}
+
+	for i, key := range c.ConsolePinnedFields {
+		if pin := c.pins[i]; pin != nil && pin.buf.Len() > 0 {
+			pinned.addElementSeparator()
+			pinned.buf.Write(pin.buf.Bytes())
+		}
+		if rest == nil {
+			continue
+		}
+		for j := 0; j < top; j++ {
+			if extra[j].Key == key {
+				extra[j].AddTo(pinned)
+			}
+		}
+	}
+	if rest == nil {
+		return extra
+	}
+	for i := range extra {
+		if i >= top || c.pinIndex(extra[i].Key) < 0 {
+			rest = append(rest, extra[i])
+		}
+	}
+	return rest
+}
+
+// appendPadded writes elem, padded with spaces to at least width display
+// columns.
+func appendPadded(line *buffer.Buffer, elem interface{}, width int) {
+	start := line.Len()
+	_, _ = fmt.Fprint(line, elem)
+	for n := displayWidth(line.Bytes()[start:]); n < width; n++ {
+		line.AppendByte(' ')
+	}
+}
+
+// displayWidth returns the number of characters in bs, not counting ANSI
+// escape sequences like those that color levels.
+func displayWidth(bs []byte) int {
+	var n int
+	for i := 0; i < len(bs); {
+		if bs[i] == '\x1b' && i+1 < len(bs) && bs[i+1] == '[' {
+			// Skip to the sequence's final byte, a letter.
+			i += 2
+			for i < len(bs) && (bs[i] < '@' || bs[i] > '~') {
+				i++
+			}
+			i++
+			continue
+		}
+		_, size := utf8.DecodeRune(bs[i:])
+		i += size
+		n++
+	}
+	return n
+}
+
 func (c consoleEncoder) addSeparatorIfNecessary(line *buffer.Buffer) {
 	if line.Len() > 0 {
 		line.AppendString(c.ConsoleSeparator)
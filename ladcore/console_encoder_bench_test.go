@@ -27,7 +27,7 @@ import (
 	. "github.com/auwixcom/lad/ladcore"
 )
 
-func BenchmarkladConsole(b *testing.B) {
+func BenchmarkLadConsole(b *testing.B) {
 	b.RunParallel(func(pb *testing.PB) {
 		for pb.Next() {
 			enc := NewConsoleEncoder(humanEncoderConfig())
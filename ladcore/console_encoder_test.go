@@ -20,10 +20,15 @@
 package ladcore_test
 
 import (
+	"math"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/auwixcom/lad/internal/ztest"
 	//revive:disable:dot-imports
 	. "github.com/auwixcom/lad/ladcore"
 )
@@ -133,3 +138,369 @@ func encoderTestEncoderConfig(separator string) EncoderConfig {
 	testEncoder.ConsoleSeparator = separator
 	return testEncoder
 }
+
+func TestConsoleEncoderEmptyMessage(t *testing.T) {
+	ent := Entry{Level: InfoLevel, LoggerName: "name"}
+	fields := []Field{{Key: "k", Type: StringType, String: "v"}}
+
+	tests := []struct {
+		desc     string
+		omit     bool
+		require  bool
+		expected string
+		err      error
+	}{
+		{desc: "default", expected: "info\tname\t\t{\"k\": \"v\"}\n"},
+		{desc: "omitted", omit: true, expected: "info\tname\t{\"k\": \"v\"}\n"},
+		{desc: "required", require: true, err: ErrEmptyMessage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			cfg := testEncoderConfig()
+			cfg.OmitEmptyMessage = tt.omit
+			cfg.RequireMessage = tt.require
+			buf, err := NewConsoleEncoder(cfg).EncodeEntry(ent, fields)
+			if tt.err != nil {
+				assert.Equal(t, tt.err, err, "Unexpected error.")
+				return
+			}
+			if assert.NoError(t, err, "Unexpected console encoding error.") {
+				assert.Equal(t, tt.expected, buf.String(), "Incorrect encoded entry.")
+				buf.Free()
+			}
+		})
+	}
+}
+
+func TestConsoleEncoderHighlights(t *testing.T) {
+	cfg := EncoderConfig{
+		LevelKey:    "L",
+		MessageKey:  "M",
+		EncodeLevel: CapitalColorLevelEncoder,
+		ConsoleHighlights: []ConsoleHighlight{
+			{Field: "request_id", Value: "abc", Color: "magenta", Bold: true},
+			{Field: "attempt", Value: "3", Color: "cyan"},
+			{Message: "^timeout", Color: "red"},
+			{Message: "(", Color: "red"},      // invalid pattern, ignored
+			{Message: "ignored", Bold: false}, // no style, ignored
+		},
+	}
+	enc := NewConsoleEncoder(cfg).Clone()
+
+	tests := []struct {
+		desc     string
+		msg      string
+		fields   []Field
+		expected string
+	}{
+		{
+			desc:     "no match",
+			msg:      "hello",
+			fields:   []Field{{Key: "request_id", Type: StringType, String: "xyz"}},
+			expected: "\x1b[34mINFO\x1b[0m\thello\t{\"request_id\": \"xyz\"}\n",
+		},
+		{
+			desc:     "string field",
+			msg:      "hello",
+			fields:   []Field{{Key: "request_id", Type: StringType, String: "abc"}},
+			expected: "\x1b[1;35m\x1b[34mINFO\x1b[0m\x1b[1;35m\thello\t{\"request_id\": \"abc\"}\x1b[0m\n",
+		},
+		{
+			desc:     "numeric field",
+			msg:      "hello",
+			fields:   []Field{{Key: "attempt", Type: Int64Type, Integer: 3}},
+			expected: "\x1b[36m\x1b[34mINFO\x1b[0m\x1b[36m\thello\t{\"attempt\": 3}\x1b[0m\n",
+		},
+		{
+			desc:     "numeric prefix",
+			msg:      "hello",
+			fields:   []Field{{Key: "attempt", Type: Int64Type, Integer: 30}},
+			expected: "\x1b[34mINFO\x1b[0m\thello\t{\"attempt\": 30}\n",
+		},
+		{
+			desc:     "message",
+			msg:      "timeout calling backend",
+			expected: "\x1b[31m\x1b[34mINFO\x1b[0m\x1b[31m\ttimeout calling backend\x1b[0m\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			buf, err := enc.EncodeEntry(Entry{Level: InfoLevel, Message: tt.msg}, tt.fields)
+			if assert.NoError(t, err, "Unexpected console encoding error.") {
+				assert.Equal(t, tt.expected, buf.String(), "Incorrect encoded entry.")
+				buf.Free()
+			}
+		})
+	}
+}
+
+func TestConsoleEncoderHighlightsContext(t *testing.T) {
+	enc := NewConsoleEncoder(EncoderConfig{
+		MessageKey:        "M",
+		ConsoleHighlights: []ConsoleHighlight{{Field: "request_id", Value: "abc", Bold: true}},
+	})
+	enc.AddString("request_id", "abc")
+
+	buf, err := enc.EncodeEntry(Entry{Message: "hello"}, nil)
+	if assert.NoError(t, err, "Unexpected console encoding error.") {
+		assert.Equal(t, "\x1b[1mhello\t{\"request_id\": \"abc\"}\x1b[0m\n", buf.String(), "Expected context fields to match highlights.")
+		buf.Free()
+	}
+}
+
+func TestConsoleEncoderASCIIOnly(t *testing.T) {
+	enc := NewConsoleEncoder(EncoderConfig{MessageKey: "M", ASCIIOnly: true})
+	buf, err := enc.EncodeEntry(Entry{Message: "naïve \xff"}, []Field{{Key: "k", Type: StringType, String: "é"}})
+	if assert.NoError(t, err, "Unexpected console encoding error.") {
+		assert.Equal(t, `na\u00efve \ufffd	{"k": "\u00e9"}`+"\n", buf.String(), "Expected non-ASCII characters to be escaped.")
+		buf.Free()
+	}
+}
+
+func TestConsoleEncoderFlattenNamespaces(t *testing.T) {
+	enc := NewConsoleEncoder(EncoderConfig{MessageKey: "M", FlattenNamespaces: true})
+	buf, err := enc.EncodeEntry(Entry{Message: "hello"}, []Field{
+		{Key: "a", Type: NamespaceType},
+		{Key: "b", Type: NamespaceType},
+		{Key: "c", Type: StringType, String: "d"},
+	})
+	if assert.NoError(t, err, "Unexpected console encoding error.") {
+		assert.Equal(t, `hello	{"a.b.c": "d"}`+"\n", buf.String(), "Expected namespaced keys to be flattened.")
+		buf.Free()
+	}
+}
+
+func TestConsoleHighlightValidate(t *testing.T) {
+	assert.NoError(t, ConsoleHighlight{Message: "^a", Color: "red"}.Validate(), "Unexpected error for a valid rule.")
+	assert.ErrorContains(t, ConsoleHighlight{Message: "("}.Validate(), "invalid highlight message pattern", "Expected an error for an invalid pattern.")
+	assert.ErrorContains(t, ConsoleHighlight{Color: "mauve"}.Validate(), `unknown highlight color "mauve"`, "Expected an error for an unknown color.")
+}
+
+func TestConsoleEncoderColumnWidths(t *testing.T) {
+	cfg := testEncoderConfig()
+	cfg.ConsoleLevelWidth = 5
+	cfg.ConsoleCallerWidth = 10
+	cfg.FunctionKey = ""
+	ent := Entry{Level: InfoLevel, Message: "hello", Caller: EntryCaller{Defined: true, File: "foo.go", Line: 42}}
+
+	tests := []struct {
+		desc        string
+		encodeLevel LevelEncoder
+		expected    string
+	}{
+		{
+			desc:        "plain",
+			encodeLevel: CapitalLevelEncoder,
+			expected:    "INFO \tfoo.go:42 \thello\n",
+		},
+		{
+			desc:        "color",
+			encodeLevel: CapitalColorLevelEncoder,
+			expected:    "\x1b[34mINFO\x1b[0m \tfoo.go:42 \thello\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			cfg := cfg
+			cfg.EncodeLevel = tt.encodeLevel
+			buf, err := NewConsoleEncoder(cfg).EncodeEntry(ent, nil)
+			if assert.NoError(t, err, "Unexpected console encoding error.") {
+				assert.Equal(t, tt.expected, buf.String(), "Expected padded columns.")
+				buf.Free()
+			}
+		})
+	}
+
+	// Wider values aren't truncated.
+	cfg.ConsoleLevelWidth = 2
+	buf, err := NewConsoleEncoder(cfg).EncodeEntry(Entry{Level: WarnLevel, Message: "m"}, nil)
+	if assert.NoError(t, err, "Unexpected console encoding error.") {
+		assert.Equal(t, "warn\tm\n", buf.String(), "Expected wide levels to be left alone.")
+		buf.Free()
+	}
+}
+
+func TestConsoleEncoderPinnedFields(t *testing.T) {
+	enc := NewConsoleEncoder(EncoderConfig{MessageKey: "M", ConsolePinnedFields: []string{"request_id", "user"}})
+	enc.AddInt("n", 1)
+	enc.AddString("user", "ann")
+	enc = enc.Clone()
+	enc.AddString("request_id", "abc")
+
+	tests := []struct {
+		desc     string
+		fields   []Field
+		expected string
+	}{
+		{
+			desc:     "context",
+			expected: `m	{"request_id": "abc", "user": "ann", "n": 1}` + "\n",
+		},
+		{
+			desc: "log site",
+			fields: []Field{
+				{Key: "z", Type: StringType, String: "last"},
+				{Key: "user", Type: StringType, String: "bob"},
+			},
+			expected: `m	{"request_id": "abc", "user": "ann", "user": "bob", "n": 1, "z": "last"}` + "\n",
+		},
+		{
+			desc: "namespaced",
+			fields: []Field{
+				{Key: "ns", Type: NamespaceType},
+				{Key: "request_id", Type: StringType, String: "nested"},
+			},
+			expected: `m	{"request_id": "abc", "user": "ann", "n": 1, "ns": {"request_id": "nested"}}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			buf, err := enc.EncodeEntry(Entry{Message: "m"}, tt.fields)
+			if assert.NoError(t, err, "Unexpected console encoding error.") {
+				assert.Equal(t, tt.expected, buf.String(), "Expected pinned fields first.")
+				buf.Free()
+			}
+		})
+	}
+
+	only := NewConsoleEncoder(EncoderConfig{MessageKey: "M", ConsolePinnedFields: []string{"user"}})
+	buf, err := only.EncodeEntry(Entry{Message: "m"}, []Field{{Key: "user", Type: StringType, String: "cy"}})
+	if assert.NoError(t, err, "Unexpected console encoding error.") {
+		assert.Equal(t, `m	{"user": "cy"}`+"\n", buf.String(), "Unexpected output with only pinned fields.")
+		buf.Free()
+	}
+}
+
+func TestConsoleEncoderRepeatedContext(t *testing.T) {
+	encode := func(enc Encoder, fields ...Field) string {
+		buf, err := enc.EncodeEntry(Entry{Message: "m"}, fields)
+		require.NoError(t, err, "Unexpected console encoding error.")
+		defer buf.Free()
+		return buf.String()
+	}
+	n := Field{Key: "n", Type: Int64Type, Integer: 1}
+
+	t.Run("elide", func(t *testing.T) {
+		root := NewConsoleEncoder(EncoderConfig{
+			MessageKey:             "M",
+			ConsolePinnedFields:    []string{"user"},
+			ConsoleRepeatedContext: ConsoleContextElide,
+		})
+		child := root.Clone()
+		child.AddString("component", "db")
+		child.AddString("user", "ann")
+		other := root.Clone()
+		other.AddString("component", "api")
+
+		assert.Equal(t, `m	{"user": "ann", "component": "db"}`+"\n", encode(child), "Expected new context in full.")
+		assert.Equal(t, `m	{"user": "ann", ...}`+"\n", encode(child), "Expected repeated context to be elided.")
+		assert.Equal(t, `m	{"user": "ann", ..., "n": 1}`+"\n", encode(child, n), "Expected log site fields in full.")
+		assert.Equal(t, `m	{"component": "api"}`+"\n", encode(other), "Expected changed context in full.")
+		assert.Equal(t, `m	{"n": 1}`+"\n", encode(root, n), "Expected entries without context to be unaffected.")
+		assert.Equal(t, `m	{"user": "ann", "component": "db"}`+"\n", encode(child), "Expected context after a break in full.")
+	})
+
+	t.Run("dim", func(t *testing.T) {
+		enc := NewConsoleEncoder(EncoderConfig{MessageKey: "M", ConsoleRepeatedContext: ConsoleContextDim})
+		enc.AddString("component", "db")
+
+		assert.Equal(t, `m	{"component": "db"}`+"\n", encode(enc), "Expected new context in full.")
+		assert.Equal(t, "m\t{\x1b[2m\"component\": \"db\"\x1b[0m, \"n\": 1}\n", encode(enc, n),
+			"Expected repeated context to be dimmed.")
+	})
+
+	t.Run("namespaces", func(t *testing.T) {
+		enc := NewConsoleEncoder(EncoderConfig{MessageKey: "M", ConsoleRepeatedContext: ConsoleContextElide})
+		enc.OpenNamespace("ns")
+		enc.AddString("k", "v")
+
+		for i := 0; i < 2; i++ {
+			assert.Equal(t, `m	{"ns": {"k": "v"}}`+"\n", encode(enc), "Expected open namespaces to be shown.")
+		}
+	})
+
+	t.Run("default", func(t *testing.T) {
+		enc := NewConsoleEncoder(EncoderConfig{MessageKey: "M"})
+		enc.AddString("component", "db")
+		for i := 0; i < 2; i++ {
+			assert.Equal(t, `m	{"component": "db"}`+"\n", encode(enc), "Expected context on every line.")
+		}
+	})
+}
+
+func TestConsoleEncoderNumberFormatting(t *testing.T) {
+	fields := []Field{
+		{Key: "small", Type: Int64Type, Integer: 999},
+		{Key: "count", Type: Int64Type, Integer: -1234567},
+		{Key: "bytes", Type: Uint64Type, Integer: 1000000},
+		{Key: "ratio", Type: Float64Type, Integer: int64(math.Float64bits(12345.6789))},
+		{Key: "nan", Type: Float64Type, Integer: int64(math.Float64bits(math.NaN()))},
+		{Key: "str", Type: StringType, String: "1234"},
+	}
+
+	tests := []struct {
+		desc     string
+		cfg      EncoderConfig
+		expected string
+	}{
+		{
+			desc:     "default",
+			expected: `{"small": 999, "count": -1234567, "bytes": 1000000, "ratio": 12345.6789, "nan": "NaN", "str": "1234"}`,
+		},
+		{
+			desc:     "thousands",
+			cfg:      EncoderConfig{ConsoleThousandsSeparator: "_"},
+			expected: `{"small": 999, "count": -1_234_567, "bytes": 1_000_000, "ratio": 12_345.6789, "nan": "NaN", "str": "1234"}`,
+		},
+		{
+			desc:     "locale",
+			cfg:      EncoderConfig{ConsoleThousandsSeparator: ".", ConsoleDecimalSeparator: ",", ConsoleFloatPrecision: 2},
+			expected: `{"small": 999, "count": -1.234.567, "bytes": 1.000.000, "ratio": 12.345,68, "nan": "NaN", "str": "1234"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			cfg := tt.cfg
+			cfg.MessageKey = "M"
+			buf, err := NewConsoleEncoder(cfg).EncodeEntry(Entry{Message: "m"}, fields)
+			if assert.NoError(t, err, "Unexpected console encoding error.") {
+				assert.Equal(t, "m\t"+tt.expected+"\n", buf.String(), "Unexpected number formatting.")
+				buf.Free()
+			}
+
+			// JSON output stays machine-readable.
+			buf, err = NewJSONEncoder(cfg).EncodeEntry(Entry{Message: "m"}, fields[1:2])
+			if assert.NoError(t, err, "Unexpected JSON encoding error.") {
+				assert.Equal(t, `{"M":"m","count":-1234567}`+"\n", buf.String(), "Expected JSON numbers to be left alone.")
+				buf.Free()
+			}
+		})
+	}
+}
+
+func TestConsoleEncoderFieldEncoder(t *testing.T) {
+	enc := NewConsoleEncoder(EncoderConfig{
+		MessageKey:          "M",
+		ConsolePinnedFields: []string{"request_id"},
+		FieldEncoder: func(f Field) Field {
+			f.Key = strings.ToLower(f.Key)
+			return f
+		},
+	})
+	enc.AddString("User", "ann") // added to the encoder directly, so kept as is
+	buf := &ztest.Buffer{}
+	core := NewCore(enc, buf, DebugLevel).With([]Field{{Key: "Tenant", Type: StringType, String: "acme"}})
+
+	err := core.Write(Entry{Message: "m"}, []Field{
+		{Key: "N", Type: Int64Type, Integer: 1},
+		{Key: "REQUEST_ID", Type: StringType, String: "abc"},
+	})
+	require.NoError(t, err, "Unexpected error writing.")
+	assert.Equal(t, `m	{"request_id": "abc", "User": "ann", "tenant": "acme", "n": 1}`+"\n", buf.String(),
+		"Expected fields to be transformed before pinning.")
+}
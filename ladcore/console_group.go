@@ -0,0 +1,333 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore
+
+import (
+	"bytes"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/auwixcom/lad/buffer"
+	"github.com/auwixcom/lad/internal/bufferpool"
+	"go.uber.org/multierr"
+)
+
+const _defaultConsoleGroupWindow = 500 * time.Millisecond
+
+// ConsoleGroupConfig configures a Core built by NewConsoleGroupingCore.
+type ConsoleGroupConfig struct {
+	// Field is the correlation field, like "request_id", whose entries are
+	// grouped. Fields match whether they're logged with the entry or added
+	// to the logger's context.
+	//
+	// This field is required.
+	Field string
+
+	// Window is how long entries are held after the first entry of a group
+	// arrives. Defaults to 500ms if unspecified.
+	Window time.Duration
+
+	// Clock, if specified, provides control of the source of time and the
+	// ticker used to flush groups.
+	//
+	// Defaults to the system clock.
+	Clock Clock
+}
+
+// NewConsoleGroupingCore creates a Core that makes the interleaved output of
+// concurrent requests readable while tailing logs locally. Entries that
+// carry the configured correlation field are held for a short window and
+// then written together, as an indented block under a header naming the
+// field's value:
+//
+//	request_id=8f3a (3 entries)
+//	  2026-10-15T09:30:00.000Z	INFO	started
+//	  2026-10-15T09:30:00.002Z	DEBUG	cache miss
+//	  2026-10-15T09:30:00.041Z	INFO	finished
+//
+// Entries without the field are written immediately. Groups are also written
+// when the Core is synced, and as soon as an entry above ErrorLevel joins
+// them, since the program may be about to exit. Call Close to write the
+// remaining groups and stop the background goroutine that flushes them.
+//
+// The delay makes this Core unsuitable for production; it's intended for use
+// with the console encoder during development, and is experimental.
+func NewConsoleGroupingCore(enc Encoder, ws WriteSyncer, enab LevelEnabler, cfg ConsoleGroupConfig) Core {
+	if cfg.Window <= 0 {
+		cfg.Window = _defaultConsoleGroupWindow
+	}
+	if cfg.Clock == nil {
+		cfg.Clock = DefaultClock
+	}
+	return &groupingCore{
+		LevelEnabler: enab,
+		enc:          enc,
+		grouper: &consoleGrouper{
+			field:  cfg.Field,
+			window: cfg.Window,
+			clock:  cfg.Clock,
+			out:    ws,
+			groups: make(map[string]*consoleGroup),
+		},
+	}
+}
+
+type groupingCore struct {
+	LevelEnabler
+
+	enc     Encoder
+	grouper *consoleGrouper
+	group   string // correlation value from With, if any
+	grouped bool
+}
+
+var (
+	_ Core           = (*groupingCore)(nil)
+	_ leveledEnabler = (*groupingCore)(nil)
+	_ io.Closer      = (*groupingCore)(nil)
+)
+
+func (c *groupingCore) Level() Level {
+	return LevelOf(c.LevelEnabler)
+}
+
+func (c *groupingCore) With(fields []Field) Core {
+	clone := *c
+	clone.enc = c.enc.Clone()
+	addFields(clone.enc, fields)
+	if v, ok := c.grouper.value(fields); ok {
+		clone.group, clone.grouped = v, true
+	}
+	return &clone
+}
+
+func (c *groupingCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *groupingCore) Write(ent Entry, fields []Field) error {
+	buf, err := c.enc.EncodeEntry(ent, fields)
+	if err != nil {
+		return err
+	}
+
+	group, grouped := c.group, c.grouped
+	if v, ok := c.grouper.value(fields); ok {
+		group, grouped = v, true
+	}
+	if !grouped {
+		return c.grouper.write(buf)
+	}
+	return c.grouper.add(group, buf, ent.Level > ErrorLevel)
+}
+
+func (c *groupingCore) Sync() error {
+	return c.grouper.sync()
+}
+
+// Close writes any pending groups and stops the goroutine that flushes them.
+func (c *groupingCore) Close() error {
+	return c.grouper.close()
+}
+
+// consoleGrouper holds the pending groups shared by a groupingCore and its
+// children.
+type consoleGrouper struct {
+	field  string
+	window time.Duration
+	clock  Clock
+
+	mu      sync.Mutex
+	out     WriteSyncer
+	groups  map[string]*consoleGroup
+	order   []string // pending groups, oldest first
+	ticker  *time.Ticker
+	stop    chan struct{} // closed when flushLoop should stop
+	done    chan struct{} // closed when flushLoop has stopped
+	stopped bool
+}
+
+type consoleGroup struct {
+	start time.Time
+	n     int
+	lines *buffer.Buffer
+}
+
+// value returns the correlation value in fields, if any. The last matching
+// field wins, as it would in the encoded output.
+func (g *consoleGrouper) value(fields []Field) (v string, ok bool) {
+	for _, f := range fields {
+		if f.Key == g.field && f.Type != SkipType {
+			v, ok = fieldString(f), true
+		}
+	}
+	return v, ok
+}
+
+func (g *consoleGrouper) write(buf *buffer.Buffer) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	_, err := g.out.Write(buf.Bytes())
+	buf.Free()
+	return err
+}
+
+func (g *consoleGrouper) add(value string, buf *buffer.Buffer, urgent bool) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.stopped {
+		// Closed cores write ungrouped, rather than holding entries that
+		// would never be flushed.
+		_, err := g.out.Write(buf.Bytes())
+		buf.Free()
+		return err
+	}
+	if g.ticker == nil {
+		g.ticker = g.clock.NewTicker(g.window)
+		g.stop = make(chan struct{})
+		g.done = make(chan struct{})
+		go g.flushLoop()
+	}
+
+	grp, ok := g.groups[value]
+	if !ok {
+		grp = &consoleGroup{start: g.clock.Now(), lines: bufferpool.Get()}
+		g.groups[value] = grp
+		g.order = append(g.order, value)
+	}
+	grp.n++
+	indent(grp.lines, buf.Bytes())
+	buf.Free()
+
+	if urgent {
+		err := g.flushLocked(func(v string, grp *consoleGroup) bool {
+			return v == value || g.expired(grp)
+		})
+		return multierr.Append(err, g.out.Sync())
+	}
+	return g.flushLocked(func(_ string, grp *consoleGroup) bool {
+		return g.expired(grp)
+	})
+}
+
+func (g *consoleGrouper) expired(grp *consoleGroup) bool {
+	return g.clock.Now().Sub(grp.start) >= g.window
+}
+
+// flushLocked writes the pending groups for which flush returns true, oldest
+// first.
+func (g *consoleGrouper) flushLocked(flush func(string, *consoleGroup) bool) error {
+	var err error
+	pending := g.order[:0]
+	for _, v := range g.order {
+		grp := g.groups[v]
+		if !flush(v, grp) {
+			pending = append(pending, v)
+			continue
+		}
+
+		header := bufferpool.Get()
+		header.AppendString(g.field)
+		header.AppendByte('=')
+		header.AppendString(v)
+		header.AppendString(" (")
+		header.AppendInt(int64(grp.n))
+		if grp.n == 1 {
+			header.AppendString(" entry)\n")
+		} else {
+			header.AppendString(" entries)\n")
+		}
+		header.Write(grp.lines.Bytes())
+		_, werr := g.out.Write(header.Bytes())
+		err = multierr.Append(err, werr)
+
+		header.Free()
+		grp.lines.Free()
+		delete(g.groups, v)
+	}
+	g.order = pending
+	return err
+}
+
+// indent appends each line of bs to buf, indented by two spaces.
+func indent(buf *buffer.Buffer, bs []byte) {
+	for len(bs) > 0 {
+		line := bs
+		if i := bytes.IndexByte(bs, '\n'); i >= 0 {
+			line = bs[:i+1]
+		}
+		buf.AppendString("  ")
+		buf.Write(line)
+		bs = bs[len(line):]
+	}
+	if b := buf.Bytes(); len(b) > 0 && b[len(b)-1] != '\n' {
+		buf.AppendByte('\n')
+	}
+}
+
+func (g *consoleGrouper) sync() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	err := g.flushLocked(func(string, *consoleGroup) bool { return true })
+	return multierr.Append(err, g.out.Sync())
+}
+
+// flushLoop writes expired groups on every tick until close is called.
+func (g *consoleGrouper) flushLoop() {
+	defer close(g.done)
+
+	for {
+		select {
+		case <-g.ticker.C:
+			g.mu.Lock()
+			// There's no caller to return write errors to.
+			_ = g.flushLocked(func(_ string, grp *consoleGroup) bool {
+				return g.expired(grp)
+			})
+			g.mu.Unlock()
+		case <-g.stop:
+			return
+		}
+	}
+}
+
+func (g *consoleGrouper) close() error {
+	g.mu.Lock()
+	running := g.ticker != nil && !g.stopped
+	g.stopped = true
+	if running {
+		g.ticker.Stop()
+		close(g.stop)
+	}
+	g.mu.Unlock()
+
+	if running {
+		<-g.done
+	}
+	return g.sync()
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/auwixcom/lad/internal/ztest"
+	//revive:disable:dot-imports
+	. "github.com/auwixcom/lad/ladcore"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newGroupingCore(t *testing.T) (Core, *ztest.Buffer, *ztest.MockClock) {
+	buf := &ztest.Buffer{}
+	clock := ztest.NewMockClock()
+	enc := NewConsoleEncoder(EncoderConfig{MessageKey: "M", LevelKey: "L", EncodeLevel: CapitalLevelEncoder})
+	core := NewConsoleGroupingCore(enc, buf, DebugLevel, ConsoleGroupConfig{
+		Field:  "request_id",
+		Window: time.Second,
+		Clock:  clock,
+	})
+	t.Cleanup(func() {
+		assert.NoError(t, core.(interface{ Close() error }).Close(), "Unexpected error closing core.")
+	})
+	return core, buf, clock
+}
+
+func writeGrouped(t *testing.T, core Core, lvl Level, msg string, fields ...Field) {
+	ce := core.Check(Entry{Level: lvl, Message: msg}, nil)
+	require.NotNil(t, ce, "Expected entry to be enabled.")
+	ce.Write(fields...)
+}
+
+func TestConsoleGroupingCore(t *testing.T) {
+	core, buf, clock := newGroupingCore(t)
+	reqA := core.With([]Field{{Key: "request_id", Type: StringType, String: "a"}})
+	reqB := []Field{{Key: "request_id", Type: StringType, String: "b"}}
+
+	writeGrouped(t, reqA, InfoLevel, "a started")
+	writeGrouped(t, core, InfoLevel, "b started", reqB...)
+	writeGrouped(t, core, InfoLevel, "no request")
+	writeGrouped(t, reqA, DebugLevel, "a finished")
+	assert.Equal(t, []string{"INFO\tno request"}, buf.Lines(), "Expected only ungrouped entries to be written.")
+
+	clock.Add(500 * time.Millisecond)
+	writeGrouped(t, core, WarnLevel, "b finished", reqB...)
+	require.NoError(t, core.Sync(), "Unexpected error syncing.")
+	assert.Equal(t, []string{
+		"INFO\tno request",
+		"request_id=a (2 entries)",
+		`  INFO	a started	{"request_id": "a"}`,
+		`  DEBUG	a finished	{"request_id": "a"}`,
+		"request_id=b (2 entries)",
+		`  INFO	b started	{"request_id": "b"}`,
+		`  WARN	b finished	{"request_id": "b"}`,
+	}, buf.Lines(), "Unexpected grouped output.")
+}
+
+func TestConsoleGroupingCoreExpiredOnWrite(t *testing.T) {
+	core, buf, clock := newGroupingCore(t)
+	reqA := core.With([]Field{{Key: "request_id", Type: StringType, String: "a"}})
+	reqB := core.With([]Field{{Key: "request_id", Type: StringType, String: "b"}})
+
+	writeGrouped(t, reqA, InfoLevel, "first")
+	clock.Add(time.Second)
+	writeGrouped(t, reqB, InfoLevel, "second")
+	assert.Equal(t, []string{
+		"request_id=a (1 entry)",
+		`  INFO	first	{"request_id": "a"}`,
+	}, buf.Lines(), "Expected expired group to be written.")
+}
+
+func TestConsoleGroupingCoreUrgent(t *testing.T) {
+	core, buf, _ := newGroupingCore(t)
+	reqA := core.With([]Field{{Key: "request_id", Type: StringType, String: "a"}})
+
+	writeGrouped(t, reqA, InfoLevel, "before")
+	ce := reqA.Check(Entry{Level: DPanicLevel, Message: "boom"}, nil)
+	require.NotNil(t, ce, "Expected entry to be enabled.")
+	ce.Write()
+	assert.Equal(t, []string{
+		"request_id=a (2 entries)",
+		`  INFO	before	{"request_id": "a"}`,
+		`  DPANIC	boom	{"request_id": "a"}`,
+	}, buf.Lines(), "Expected group to be written with entries above ErrorLevel.")
+	assert.True(t, buf.Called(), "Expected output to be synced.")
+}
+
+type chanWriter struct {
+	ztest.Syncer
+	lines chan string
+}
+
+func (w *chanWriter) Write(bs []byte) (int, error) {
+	w.lines <- string(bs)
+	return len(bs), nil
+}
+
+func TestConsoleGroupingCoreTicker(t *testing.T) {
+	out := &chanWriter{lines: make(chan string, 1)}
+	clock := ztest.NewMockClock()
+	core := NewConsoleGroupingCore(NewConsoleEncoder(EncoderConfig{MessageKey: "M"}), out, InfoLevel, ConsoleGroupConfig{
+		Field: "request_id",
+		Clock: clock,
+	})
+	defer core.(interface{ Close() error }).Close()
+
+	writeGrouped(t, core, InfoLevel, "tick", Field{Key: "request_id", Type: StringType, String: "a"})
+	clock.Add(500 * time.Millisecond)
+	select {
+	case got := <-out.lines:
+		assert.Equal(t, "request_id=a (1 entry)\n  tick\t{\"request_id\": \"a\"}\n", got, "Unexpected flushed group.")
+	case <-time.After(time.Second):
+		t.Fatal("Expected ticker to flush expired group.")
+	}
+}
+
+func TestConsoleGroupingCoreClose(t *testing.T) {
+	buf := &ztest.Buffer{}
+	enc := NewConsoleEncoder(EncoderConfig{MessageKey: "M"})
+	core := NewConsoleGroupingCore(enc, buf, InfoLevel, ConsoleGroupConfig{Field: "id"})
+	closer := core.(interface{ Close() error })
+	assert.Equal(t, InfoLevel, LevelOf(core), "Unexpected level.")
+	assert.Nil(t, core.Check(Entry{Level: DebugLevel}, nil), "Expected disabled entry to be skipped.")
+
+	writeGrouped(t, core, InfoLevel, "pending", Field{Key: "id", Type: StringType, String: "x"})
+	require.NoError(t, closer.Close(), "Unexpected error closing core.")
+	assert.Equal(t, []string{"id=x (1 entry)", `  pending	{"id": "x"}`}, buf.Lines(), "Expected Close to write pending groups.")
+
+	writeGrouped(t, core, InfoLevel, "late", Field{Key: "id", Type: StringType, String: "x"})
+	assert.Equal(t, `late	{"id": "x"}`, buf.Lines()[2], "Expected entries after Close to be written immediately.")
+	require.NoError(t, closer.Close(), "Unexpected error closing core twice.")
+}
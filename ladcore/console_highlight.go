@@ -0,0 +1,170 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/auwixcom/lad/buffer"
+	"github.com/auwixcom/lad/internal/bufferpool"
+	"github.com/auwixcom/lad/internal/color"
+)
+
+var _highlightColors = map[string]color.Color{
+	"black":   color.Black,
+	"red":     color.Red,
+	"green":   color.Green,
+	"yellow":  color.Yellow,
+	"blue":    color.Blue,
+	"magenta": color.Magenta,
+	"cyan":    color.Cyan,
+	"white":   color.White,
+}
+
+// ConsoleHighlight is a rule that makes matching lines stand out in the
+// console encoder's output, like every line about a request that's under
+// investigation. It's intended for development, while tailing logs.
+//
+// A rule matches entries whose message matches the Message regular
+// expression and which carry a field named Field whose value is Value.
+// Either half of the rule may be left empty. Fields match whether they're
+// logged with the entry or added to the logger's context, and Value is
+// compared against the field's JSON representation, without quotes for
+// strings.
+type ConsoleHighlight struct {
+	Message string `json:"message" yaml:"message"`
+	Field   string `json:"field" yaml:"field"`
+	Value   string `json:"value" yaml:"value"`
+	// Color is one of black, red, green, yellow, blue, magenta, cyan, or
+	// white. Leave it empty to keep the line's colors.
+	Color string `json:"color" yaml:"color"`
+	Bold  bool   `json:"bold" yaml:"bold"`
+}
+
+// Validate reports whether the rule's message pattern and color are valid.
+func (h ConsoleHighlight) Validate() error {
+	if h.Message != "" {
+		if _, err := regexp.Compile(h.Message); err != nil {
+			return fmt.Errorf("invalid highlight message pattern: %w", err)
+		}
+	}
+	if _, ok := _highlightColors[h.Color]; h.Color != "" && !ok {
+		return fmt.Errorf("unknown highlight color %q", h.Color)
+	}
+	return nil
+}
+
+// consoleHighlighter is a compiled ConsoleHighlight.
+type consoleHighlighter struct {
+	message *regexp.Regexp
+	needles [][]byte // encoded forms of the field to look for
+	start   string   // escape sequence that starts the highlight
+}
+
+// compileHighlights compiles rules, skipping those that are invalid.
+func compileHighlights(rules []ConsoleHighlight) []consoleHighlighter {
+	hs := make([]consoleHighlighter, 0, len(rules))
+	for _, r := range rules {
+		if r.Validate() != nil {
+			continue
+		}
+
+		var h consoleHighlighter
+		if r.Message != "" {
+			h.message = regexp.MustCompile(r.Message)
+		}
+		if r.Field != "" {
+			key := strconv.Quote(r.Field)
+			h.needles = [][]byte{
+				[]byte(key + ": " + strconv.Quote(r.Value)),
+				[]byte(key + ": " + r.Value + ","),
+				[]byte(key + ": " + r.Value + "}"),
+			}
+		}
+
+		var params []string
+		if r.Bold {
+			params = append(params, "1")
+		}
+		if c, ok := _highlightColors[r.Color]; ok {
+			params = append(params, strconv.Itoa(int(c)))
+		}
+		if len(params) == 0 {
+			continue
+		}
+		h.start = "\x1b[" + params[0]
+		for _, p := range params[1:] {
+			h.start += ";" + p
+		}
+		h.start += "m"
+
+		hs = append(hs, h)
+	}
+	return hs
+}
+
+func (h consoleHighlighter) matches(ent Entry, line []byte) bool {
+	if h.message != nil && !h.message.MatchString(ent.Message) {
+		return false
+	}
+	if len(h.needles) == 0 {
+		return true
+	}
+	for _, n := range h.needles {
+		if bytes.Contains(line, n) {
+			return true
+		}
+	}
+	return false
+}
+
+const _highlightReset = "\x1b[0m"
+
+// highlight wraps line in the first matching rule's escape sequences. Resets
+// within the line, like those ending colored levels, restart the highlight.
+func highlight(hs []consoleHighlighter, ent Entry, line *buffer.Buffer) *buffer.Buffer {
+	for _, h := range hs {
+		if !h.matches(ent, line.Bytes()) {
+			continue
+		}
+
+		out := bufferpool.Get()
+		out.AppendString(h.start)
+		rest := line.Bytes()
+		for {
+			i := bytes.Index(rest, []byte(_highlightReset))
+			if i < 0 {
+				break
+			}
+			out.Write(rest[:i+len(_highlightReset)])
+			out.AppendString(h.start)
+			rest = rest[i+len(_highlightReset):]
+		}
+		out.Write(rest)
+		out.AppendString(_highlightReset)
+		line.Free()
+		return out
+	}
+	return line
+}
@@ -0,0 +1,194 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsoleColorModeString(t *testing.T) {
+	tests := map[ConsoleColorMode]string{
+		ConsoleNotTerminal:   "not-terminal",
+		ConsoleANSI:          "ansi",
+		ConsoleLegacy:        "legacy",
+		ConsoleColorMode(42): "ConsoleColorMode(42)",
+	}
+	for mode, want := range tests {
+		assert.Equal(t, want, mode.String(), "Unexpected string for mode.")
+	}
+}
+
+func TestConsoleWriteSyncerFile(t *testing.T) {
+	f, err := os.Create(filepath.Join(t.TempDir(), "log"))
+	require.NoError(t, err, "Failed to create temporary file.")
+	defer f.Close()
+
+	assert.Equal(t, ConsoleNotTerminal, ProbeConsoleColor(f), "Expected regular files not to be consoles.")
+
+	ws := NewConsoleWriteSyncer(f)
+	_, err = ws.Write([]byte("\x1b[34mINFO\x1b[0m\n"))
+	require.NoError(t, err, "Unexpected error writing to file.")
+	require.NoError(t, ws.Sync(), "Unexpected error syncing file.")
+
+	contents, err := os.ReadFile(f.Name())
+	require.NoError(t, err, "Failed to read file.")
+	assert.Equal(t, "\x1b[34mINFO\x1b[0m\n", string(contents), "Expected escape sequences to pass through to files.")
+}
+
+func TestConsoleAttrWriter(t *testing.T) {
+	const defaultAttr = 0x07 | 0x10 // white on blue
+
+	tests := []struct {
+		desc  string
+		input string
+		text  string
+		attrs []uint16
+	}{
+		{
+			desc:  "plain text",
+			input: "hello",
+			text:  "hello",
+		},
+		{
+			desc:  "capital color level",
+			input: "\x1b[34mINFO\x1b[0m\tmsg",
+			text:  "INFO\tmsg",
+			attrs: []uint16{0x10 | _consoleForegroundBlue, defaultAttr},
+		},
+		{
+			desc:  "bright and bold",
+			input: "\x1b[91mA\x1b[1;32mB\x1b[22mC\x1b[39mD",
+			text:  "ABCD",
+			attrs: []uint16{
+				0x10 | _consoleForegroundRed | _consoleForegroundIntensity,
+				0x10 | _consoleForegroundGreen | _consoleForegroundIntensity,
+				0x10 | _consoleForegroundGreen,
+				defaultAttr,
+			},
+		},
+		{
+			desc:  "empty SGR resets",
+			input: "\x1b[33mA\x1b[mB",
+			text:  "AB",
+			attrs: []uint16{0x10 | _consoleForegroundRed | _consoleForegroundGreen, defaultAttr},
+		},
+		{
+			desc:  "non-SGR sequences dropped",
+			input: "A\x1b[2KB",
+			text:  "AB",
+		},
+		{
+			desc:  "unterminated sequence held back",
+			input: "A\x1b[3",
+			text:  "A",
+		},
+		{
+			desc:  "lone escape",
+			input: "A\x1bB",
+			text:  "A\x1bB",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var (
+				buf   bytes.Buffer
+				attrs []uint16
+			)
+			w := newConsoleAttrWriter(&buf, defaultAttr, func(attr uint16) error {
+				attrs = append(attrs, attr)
+				return nil
+			})
+
+			n, err := w.Write([]byte(tt.input))
+			require.NoError(t, err, "Unexpected error writing.")
+			assert.Equal(t, len(tt.input), n, "Expected the full input to be reported as written.")
+			assert.Equal(t, tt.text, buf.String(), "Unexpected text written.")
+			assert.Equal(t, tt.attrs, attrs, "Unexpected console attributes set.")
+		})
+	}
+}
+
+func TestConsoleAttrWriterErrors(t *testing.T) {
+	errAttr := errors.New("set attribute failed")
+	w := newConsoleAttrWriter(&bytes.Buffer{}, 0x07, func(uint16) error { return errAttr })
+	_, err := w.Write([]byte("\x1b[31mERROR\x1b[0m"))
+	assert.Equal(t, errAttr, err, "Expected console API errors to propagate.")
+}
+
+func TestConsoleAttrWriterSplitSequence(t *testing.T) {
+	const defaultAttr = 0x07
+	var (
+		buf   bytes.Buffer
+		attrs []uint16
+	)
+	w := newConsoleAttrWriter(&buf, defaultAttr, func(attr uint16) error {
+		attrs = append(attrs, attr)
+		return nil
+	})
+
+	for _, chunk := range []string{"A\x1b", "[3", "1mB\x1b[", "0mC"} {
+		n, err := w.Write([]byte(chunk))
+		require.NoError(t, err, "Unexpected error writing.")
+		assert.Equal(t, len(chunk), n, "Expected the full chunk to be reported as written.")
+	}
+	assert.Equal(t, "ABC", buf.String(), "Expected split sequences to be translated.")
+	assert.Equal(t, []uint16{_consoleForegroundRed, defaultAttr}, attrs, "Unexpected console attributes set.")
+
+	buf.Reset()
+	long := "\x1b[" + strings.Repeat("1;", _maxPendingEscape)
+	_, err := w.Write([]byte(long))
+	require.NoError(t, err, "Unexpected error writing.")
+	assert.Equal(t, long, buf.String(), "Expected overlong sequences to be written as-is.")
+}
+
+// limitedWriter writes at most limit bytes, failing once it runs out.
+type limitedWriter struct {
+	bytes.Buffer
+
+	limit int
+}
+
+func (w *limitedWriter) Write(p []byte) (int, error) {
+	if len(p) > w.limit {
+		n, _ := w.Buffer.Write(p[:w.limit])
+		w.limit = 0
+		return n, io.ErrShortWrite
+	}
+	w.limit -= len(p)
+	return w.Buffer.Write(p)
+}
+
+func TestConsoleAttrWriterPartialWrite(t *testing.T) {
+	w := newConsoleAttrWriter(&limitedWriter{limit: 3}, 0x07, func(uint16) error { return nil })
+	n, err := w.Write([]byte("\x1b[31mERROR\x1b[0m"))
+	assert.Equal(t, io.ErrShortWrite, err, "Expected the short write to fail.")
+	assert.Equal(t, len("\x1b[31mERR"), n, "Expected the bytes consumed before the failure.")
+}
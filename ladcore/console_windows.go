@@ -0,0 +1,84 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build windows
+
+package ladcore
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+const _enableVirtualTerminalProcessing = 0x4
+
+var (
+	_kernel32                       = syscall.NewLazyDLL("kernel32.dll")
+	_procSetConsoleMode             = _kernel32.NewProc("SetConsoleMode")
+	_procGetConsoleScreenBufferInfo = _kernel32.NewProc("GetConsoleScreenBufferInfo")
+	_procSetConsoleTextAttribute    = _kernel32.NewProc("SetConsoleTextAttribute")
+)
+
+type consoleCoord struct {
+	x, y int16
+}
+
+type consoleScreenBufferInfo struct {
+	size              consoleCoord
+	cursorPosition    consoleCoord
+	attributes        uint16
+	window            [4]int16
+	maximumWindowSize consoleCoord
+}
+
+func probeConsoleColor(f *os.File) ConsoleColorMode {
+	h := syscall.Handle(f.Fd())
+	var mode uint32
+	if err := syscall.GetConsoleMode(h, &mode); err != nil {
+		return ConsoleNotTerminal
+	}
+	if mode&_enableVirtualTerminalProcessing != 0 {
+		return ConsoleANSI
+	}
+	// Windows 10 and later support escape sequences, but only once asked to.
+	if r, _, _ := _procSetConsoleMode.Call(uintptr(h), uintptr(mode|_enableVirtualTerminalProcessing)); r != 0 {
+		return ConsoleANSI
+	}
+	return ConsoleLegacy
+}
+
+func newLegacyConsoleWriteSyncer(f *os.File) (WriteSyncer, bool) {
+	h := syscall.Handle(f.Fd())
+	var info consoleScreenBufferInfo
+	if r, _, _ := _procGetConsoleScreenBufferInfo.Call(uintptr(h), uintptr(unsafe.Pointer(&info))); r == 0 {
+		return nil, false
+	}
+	setAttr := func(attr uint16) error {
+		if r, _, err := _procSetConsoleTextAttribute.Call(uintptr(h), uintptr(attr)); r == 0 {
+			return err
+		}
+		return nil
+	}
+	return legacyConsoleWriteSyncer{
+		consoleAttrWriter: newConsoleAttrWriter(f, info.attributes, setAttr),
+		f:                 f,
+	}, true
+}
@@ -20,6 +20,8 @@
 
 package ladcore
 
+import "go.uber.org/multierr"
+
 // Core is a minimal, fast logger interface. It's designed for library authors
 // to wrap in a more user-friendly API.
 type Core interface {
@@ -120,3 +122,20 @@ func (c *ioCore) clone() *ioCore {
 		out:          c.out,
 	}
 }
+
+// checkAndWrite runs core's full Check and Write path for an entry, for
+// wrappers that write on behalf of another Core outside the usual
+// CheckedEntry flow.
+func checkAndWrite(core Core, ent Entry, fields []Field) error {
+	ce := core.Check(ent, nil)
+	if ce == nil {
+		return nil
+	}
+	defer putCheckedEntry(ce)
+
+	var err error
+	for _, c := range ce.cores {
+		err = multierr.Append(err, c.Write(ce.Entry, fields))
+	}
+	return err
+}
@@ -0,0 +1,193 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/multierr"
+)
+
+// RepeatCountKey is the key of the field that a Core created with
+// NewDedupeCore adds to the entry summarizing a run of repeats.
+const RepeatCountKey = "repeat_count"
+
+// NewDedupeCore creates a Core that collapses runs of identical consecutive
+// entries, cutting volume during error storms. Entries are identical if they
+// have the same level, logger name, message, and fields, including those
+// added with With; their times, callers, and stack traces may differ.
+//
+// The first entry of a run is written right away. Repeats that follow within
+// window of it are held back. The run ends when a different entry is written,
+// when an identical entry is written after the window has passed, or when the
+// Core is synced; the last repeat is then written with a RepeatCountKey field
+// counting the repeats held back. So a storm of N identical entries within
+// the window costs two entries.
+//
+// The Core has no timer of its own: if a storm stops and nothing else is
+// logged, its summary waits for the next Sync.
+//
+// Entries above ErrorLevel are never held back. Like the sampler, the Core
+// measures time using each entry's Time, and runs are tracked across the
+// cores returned from With.
+func NewDedupeCore(inner Core, window time.Duration) Core {
+	return &dedupeCore{
+		LevelEnabler: inner,
+		core:         inner,
+		enc: NewJSONEncoder(EncoderConfig{
+			MessageKey:  "msg",
+			LevelKey:    "level",
+			NameKey:     "logger",
+			EncodeLevel: LowercaseLevelEncoder,
+		}),
+		s: &dedupeState{window: window},
+	}
+}
+
+type dedupeCore struct {
+	LevelEnabler
+
+	core Core
+	enc  Encoder // fingerprints entries; holds the context
+	s    *dedupeState
+}
+
+var (
+	_ Core           = (*dedupeCore)(nil)
+	_ leveledEnabler = (*dedupeCore)(nil)
+)
+
+type dedupeState struct {
+	window time.Duration
+
+	mu  sync.Mutex
+	run *dedupeRun // the current run of identical entries, if any
+}
+
+type dedupeRun struct {
+	fingerprint string
+	core        Core // writes the summary, with the run's context
+	start       time.Time
+
+	// The last repeat, and how many repeats have been held back.
+	ent     Entry
+	fields  []Field
+	repeats int64
+}
+
+func (c *dedupeCore) Level() Level {
+	return LevelOf(c.core)
+}
+
+func (c *dedupeCore) With(fields []Field) Core {
+	clone := *c
+	clone.core = c.core.With(fields)
+	clone.LevelEnabler = clone.core
+	clone.enc = c.enc.Clone()
+	addFields(clone.enc, fields)
+	return &clone
+}
+
+func (c *dedupeCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *dedupeCore) Write(ent Entry, fields []Field) error {
+	if ent.Level > ErrorLevel {
+		err := c.s.endRun().write()
+		return multierr.Append(err, checkAndWrite(c.core, ent, fields))
+	}
+
+	fingerprint, ferr := c.fingerprint(ent, fields)
+	if ferr != nil {
+		// Without a fingerprint, treat the entry as unique.
+		fingerprint = ""
+	}
+
+	c.s.mu.Lock()
+	if r := c.s.run; r != nil && fingerprint != "" && r.fingerprint == fingerprint && c.s.inWindow(r, ent.Time) {
+		if r.repeats == 0 {
+			// The caller owns fields, so keep a copy; repeats have the same
+			// fields by definition.
+			r.fields = append([]Field(nil), fields...)
+		}
+		r.ent = ent
+		r.repeats++
+		c.s.mu.Unlock()
+		return nil
+	}
+	prev := c.s.run
+	c.s.run = nil
+	if fingerprint != "" {
+		c.s.run = &dedupeRun{fingerprint: fingerprint, core: c.core, start: ent.Time}
+	}
+	c.s.mu.Unlock()
+
+	err := prev.write()
+	return multierr.Append(err, checkAndWrite(c.core, ent, fields))
+}
+
+// Sync ends the current run, writing its summary, and flushes the wrapped
+// Core.
+func (c *dedupeCore) Sync() error {
+	err := c.s.endRun().write()
+	return multierr.Append(err, c.core.Sync())
+}
+
+// fingerprint returns a string that's equal for identical entries.
+func (c *dedupeCore) fingerprint(ent Entry, fields []Field) (string, error) {
+	buf, err := c.enc.EncodeEntry(Entry{
+		Level:      ent.Level,
+		LoggerName: ent.LoggerName,
+		Message:    ent.Message,
+	}, fields)
+	if err != nil {
+		return "", err
+	}
+	defer buf.Free()
+	return buf.String(), nil
+}
+
+func (s *dedupeState) inWindow(r *dedupeRun, t time.Time) bool {
+	return !t.Before(r.start) && t.Sub(r.start) < s.window
+}
+
+// endRun ends the current run and returns it.
+func (s *dedupeState) endRun() *dedupeRun {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r := s.run
+	s.run = nil
+	return r
+}
+
+// write writes the run's summary, if it held back any repeats.
+func (r *dedupeRun) write() error {
+	if r == nil || r.repeats == 0 {
+		return nil
+	}
+	fields := append(r.fields, Field{Key: RepeatCountKey, Type: Int64Type, Integer: r.repeats})
+	return checkAndWrite(r.core, r.ent, fields)
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore_test
+
+import (
+	"testing"
+	"time"
+
+	//revive:disable:dot-imports
+	. "github.com/auwixcom/lad/ladcore"
+	"github.com/auwixcom/lad/ladtest/observer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDedupeCore(t *testing.T) {
+	obs, logs := observer.New(InfoLevel)
+	core := NewDedupeCore(obs, time.Minute)
+	assert.Equal(t, InfoLevel, LevelOf(core), "Unexpected level.")
+	db := core.With([]Field{{Key: "db", Type: StringType, String: "primary"}})
+
+	start := time.Unix(0, 0)
+	write := func(c Core, lvl Level, msg string, at time.Duration, fields ...Field) {
+		if ce := c.Check(Entry{Level: lvl, Message: msg, Time: start.Add(at)}, nil); ce != nil {
+			ce.Write(fields...)
+		}
+	}
+	n := func(i int) Field { return makeInt64Field("n", i) }
+
+	write(db, ErrorLevel, "timeout", 0, n(1))
+	write(db, ErrorLevel, "timeout", time.Second, n(1))
+	write(db, ErrorLevel, "timeout", 2*time.Second, n(1))
+	write(core, ErrorLevel, "timeout", 3*time.Second, n(1)) // different context
+	write(core, ErrorLevel, "timeout", 4*time.Second, n(2)) // different fields
+	write(core, ErrorLevel, "timeout", 5*time.Second, n(2))
+	write(core, ErrorLevel, "timeout", 2*time.Minute, n(2)) // outside the window
+	write(core, DebugLevel, "disabled", 2*time.Minute)
+	require.NoError(t, core.Sync(), "Unexpected error syncing.")
+
+	type summary struct {
+		at     time.Duration
+		fields map[string]interface{}
+	}
+	var got []summary
+	for _, e := range logs.All() {
+		got = append(got, summary{e.Time.Sub(start), e.ContextMap()})
+	}
+	assert.Equal(t, []summary{
+		{0, map[string]interface{}{"db": "primary", "n": int64(1)}},
+		{2 * time.Second, map[string]interface{}{"db": "primary", "n": int64(1), "repeat_count": int64(2)}},
+		{3 * time.Second, map[string]interface{}{"n": int64(1)}},
+		{4 * time.Second, map[string]interface{}{"n": int64(2)}},
+		{5 * time.Second, map[string]interface{}{"n": int64(2), "repeat_count": int64(1)}},
+		{2 * time.Minute, map[string]interface{}{"n": int64(2)}},
+	}, got, "Unexpected entries.")
+}
+
+func TestDedupeCoreHighLevels(t *testing.T) {
+	obs, logs := observer.New(InfoLevel)
+	core := NewDedupeCore(obs, time.Minute)
+
+	for _, lvl := range []Level{InfoLevel, InfoLevel, DPanicLevel, DPanicLevel} {
+		require.NoError(t, core.Write(Entry{Level: lvl, Message: "m"}, nil), "Unexpected error writing.")
+	}
+	entries := logs.TakeAll()
+	require.Len(t, entries, 4, "Expected entries above ErrorLevel to be written right away.")
+	assert.Equal(t, int64(1), entries[1].ContextMap()[RepeatCountKey], "Expected the run to end first.")
+	assert.Equal(t, DPanicLevel, entries[3].Level, "Unexpected level.")
+
+	require.NoError(t, core.Sync(), "Unexpected error syncing.")
+	assert.Equal(t, 0, logs.Len(), "Expected no summary without repeats.")
+}
@@ -22,6 +22,7 @@ package ladcore
 
 import (
 	"encoding/json"
+	"errors"
 	"io"
 	"time"
 
@@ -33,6 +34,19 @@ import (
 // behavior.
 const DefaultLineEnding = "\n"
 
+// CRLFLineEnding is the Windows line ending, which some log shippers expect
+// between JSON Lines records.
+const CRLFLineEnding = "\r\n"
+
+// DefaultFunctionKey is the key under which the JSON encoder logs the
+// function name of entries whose caller only has a function, like those
+// logged with lad.AddFunction, if EncoderConfig.FunctionKey is empty.
+const DefaultFunctionKey = "func"
+
+// ErrEmptyMessage is returned by the JSON and console encoders when
+// EncoderConfig.RequireMessage is set and an entry's message is empty.
+var ErrEmptyMessage = errors.New("entry has an empty message")
+
 // OmitKey defines the key to use when callers want to remove a key from log output.
 const OmitKey = ""
 
@@ -74,10 +88,113 @@ func CapitalColorLevelEncoder(l Level, enc PrimitiveArrayEncoder) {
 	enc.AppendString(s)
 }
 
+// LevelMarkers maps levels to the compact markers written by level encoders
+// built with NewMarkerLevelEncoder.
+type LevelMarkers map[Level]string
+
+var (
+	// SymbolLevelMarkers marks levels with single-width Unicode symbols.
+	SymbolLevelMarkers = LevelMarkers{
+		DebugLevel:  "›",
+		InfoLevel:   "ℹ",
+		WarnLevel:   "⚠",
+		ErrorLevel:  "✖",
+		DPanicLevel: "✖",
+		PanicLevel:  "✖",
+		FatalLevel:  "✖",
+	}
+
+	// EmojiLevelMarkers marks levels with emoji.
+	EmojiLevelMarkers = LevelMarkers{
+		DebugLevel:  "🐛",
+		InfoLevel:   "💬",
+		WarnLevel:   "⚠️",
+		ErrorLevel:  "❌",
+		DPanicLevel: "🔥",
+		PanicLevel:  "🔥",
+		FatalLevel:  "💀",
+	}
+)
+
+// NewMarkerLevelEncoder returns a LevelEncoder that serializes each Level to
+// its marker. Levels without a marker are serialized to an all-caps string.
+// It's intended for dense development logs, where a symbol is quicker to
+// scan than a word.
+func NewMarkerLevelEncoder(markers LevelMarkers) LevelEncoder {
+	markers = copyLevelMarkers(markers)
+	return func(l Level, enc PrimitiveArrayEncoder) {
+		s, ok := markers[l]
+		if !ok {
+			s = l.CapitalString()
+		}
+		enc.AppendString(s)
+	}
+}
+
+// NewColorMarkerLevelEncoder is like NewMarkerLevelEncoder, but it colors
+// each marker the same way as CapitalColorLevelEncoder. Levels without a
+// marker are serialized by CapitalColorLevelEncoder.
+func NewColorMarkerLevelEncoder(markers LevelMarkers) LevelEncoder {
+	markers = copyLevelMarkers(markers)
+	colored := make(map[Level]string, len(markers))
+	for level, marker := range markers {
+		c, ok := _levelToColor[level]
+		if !ok {
+			c = _unknownLevelColor
+		}
+		colored[level] = c.Add(marker)
+	}
+	return func(l Level, enc PrimitiveArrayEncoder) {
+		s, ok := colored[l]
+		if !ok {
+			CapitalColorLevelEncoder(l, enc)
+			return
+		}
+		enc.AppendString(s)
+	}
+}
+
+func copyLevelMarkers(markers LevelMarkers) LevelMarkers {
+	cp := make(LevelMarkers, len(markers))
+	for level, marker := range markers {
+		cp[level] = marker
+	}
+	return cp
+}
+
+var (
+	_symbolLevelEncoder      = NewMarkerLevelEncoder(SymbolLevelMarkers)
+	_symbolColorLevelEncoder = NewColorMarkerLevelEncoder(SymbolLevelMarkers)
+	_emojiLevelEncoder       = NewMarkerLevelEncoder(EmojiLevelMarkers)
+)
+
+// SymbolLevelEncoder serializes a Level to a symbol from SymbolLevelMarkers.
+// For example, InfoLevel is serialized to "ℹ".
+func SymbolLevelEncoder(l Level, enc PrimitiveArrayEncoder) {
+	_symbolLevelEncoder(l, enc)
+}
+
+// SymbolColorLevelEncoder serializes a Level to a symbol from
+// SymbolLevelMarkers and adds color. For example, InfoLevel is serialized to
+// "ℹ" and colored blue.
+func SymbolColorLevelEncoder(l Level, enc PrimitiveArrayEncoder) {
+	_symbolColorLevelEncoder(l, enc)
+}
+
+// EmojiLevelEncoder serializes a Level to an emoji from EmojiLevelMarkers.
+// For example, ErrorLevel is serialized to "❌".
+func EmojiLevelEncoder(l Level, enc PrimitiveArrayEncoder) {
+	_emojiLevelEncoder(l, enc)
+}
+
 // UnmarshalText unmarshals text to a LevelEncoder. "capital" is unmarshaled to
 // CapitalLevelEncoder, "coloredCapital" is unmarshaled to CapitalColorLevelEncoder,
-// "colored" is unmarshaled to LowercaseColorLevelEncoder, and anything else
-// is unmarshaled to LowercaseLevelEncoder.
+// "colored" is unmarshaled to LowercaseColorLevelEncoder, "symbol" is
+// unmarshaled to SymbolLevelEncoder, "symbolColor" is unmarshaled to
+// SymbolColorLevelEncoder, "emoji" is unmarshaled to EmojiLevelEncoder, the
+// name of a SeverityProfile ("syslog", "gcp", "otel", or "cloudwatch") is
+// unmarshaled to that profile's TextEncoder, and anything else is
+// unmarshaled to LowercaseLevelEncoder.
 func (e *LevelEncoder) UnmarshalText(text []byte) error {
 	switch string(text) {
 	case "capital":
@@ -86,6 +203,16 @@ func (e *LevelEncoder) UnmarshalText(text []byte) error {
 		*e = CapitalColorLevelEncoder
 	case "color":
 		*e = LowercaseColorLevelEncoder
+	case "symbol":
+		*e = SymbolLevelEncoder
+	case "symbolColor":
+		*e = SymbolColorLevelEncoder
+	case "emoji":
+		*e = EmojiLevelEncoder
+	case "syslog", "gcp", "otel", "cloudwatch":
+		var p SeverityProfile
+		_ = p.UnmarshalText(text) // known to succeed
+		*e = p.TextEncoder()
 	default:
 		*e = LowercaseLevelEncoder
 	}
@@ -340,6 +467,21 @@ type EncoderConfig struct {
 	StacktraceKey  string `json:"stacktraceKey" yaml:"stacktraceKey"`
 	SkipLineEnding bool   `json:"skipLineEnding" yaml:"skipLineEnding"`
 	LineEnding     string `json:"lineEnding" yaml:"lineEnding"`
+	// OmitEmptyMessage omits the message key from entries whose message is
+	// empty, which suits event-style logging where all the information is in
+	// fields. Conversely, RequireMessage makes encoding such entries fail with
+	// ErrEmptyMessage.
+	OmitEmptyMessage bool `json:"omitEmptyMessage" yaml:"omitEmptyMessage"`
+	RequireMessage   bool `json:"requireMessage" yaml:"requireMessage"`
+	// ASCIIOnly makes the JSON and console encoders escape every non-ASCII
+	// character as \uXXXX (using surrogate pairs outside the Basic
+	// Multilingual Plane), for consumers that can't handle UTF-8.
+	ASCIIOnly bool `json:"asciiOnly" yaml:"asciiOnly"`
+	// FlattenNamespaces makes the JSON and console encoders log the fields
+	// of a namespace under dotted keys, like {"a.b.c":1}, instead of in
+	// nested objects, for backends that require flat keys. Objects aren't
+	// flattened.
+	FlattenNamespaces bool `json:"flattenNamespaces" yaml:"flattenNamespaces"`
 	// Configure the primitive representations of common complex types. For
 	// example, some users may want all time.Times serialized as floating-point
 	// seconds since epoch, while others may prefer ISO8601 strings.
@@ -353,9 +495,60 @@ type EncoderConfig struct {
 	// Configure the encoder for interface{} type objects.
 	// If not provided, objects are encoded using json.Encoder
 	NewReflectedEncoder func(io.Writer) ReflectedEncoder `json:"-" yaml:"-"`
+	// FieldEncoder, if set, is called with every top-level field, whether
+	// added with With or at the log site, before the JSON, console, and
+	// MessagePack encoders encode it. It can rename keys, truncate long
+	// strings, or normalize units for every core using the encoder; returning
+	// a field of SkipType drops it. Fields inside objects and arrays aren't
+	// passed to it, and pinned console fields match the keys it returns.
+	// Fields built with lad.Lazy are passed before their value is computed,
+	// and the computed field keeps the key given to Lazy, so renaming them
+	// has no effect.
+	//
+	// Encoders that wrap one of these encoders should implement
+	// FieldTransformer by calling TransformField on the wrapped encoder, so
+	// that fields added with With are transformed too.
+	FieldEncoder func(Field) Field `json:"-" yaml:"-"`
 	// Configures the field separator used by the console encoder. Defaults
 	// to tab.
 	ConsoleSeparator string `json:"consoleSeparator" yaml:"consoleSeparator"`
+	// Configures rules that make matching lines stand out in the console
+	// encoder's output. See ConsoleHighlight for details.
+	ConsoleHighlights []ConsoleHighlight `json:"consoleHighlights" yaml:"consoleHighlights"`
+	// Pad the console encoder's level and caller columns with spaces to at
+	// least the given widths, so that the columns after them line up. Color
+	// escape sequences don't count towards the width.
+	ConsoleLevelWidth  int `json:"consoleLevelWidth" yaml:"consoleLevelWidth"`
+	ConsoleCallerWidth int `json:"consoleCallerWidth" yaml:"consoleCallerWidth"`
+	// ConsolePinnedFields lists keys of top-level fields that the console
+	// encoder always renders first, in the given order, whether they were
+	// added with With or at the log site.
+	ConsolePinnedFields []string `json:"consolePinnedFields" yaml:"consolePinnedFields"`
+	// ConsoleRepeatedContext quiets the context fields that consecutive
+	// entries share, as when tailing a child logger: ConsoleContextElide
+	// replaces them with "..." and ConsoleContextDim renders them faintly.
+	// Fields logged at the call site and pinned fields are always shown. The
+	// default shows every field on every line.
+	ConsoleRepeatedContext string `json:"consoleRepeatedContext" yaml:"consoleRepeatedContext"`
+	// Format the numeric fields in the console encoder's output for people
+	// rather than machines: ConsoleThousandsSeparator groups the digits of
+	// whole parts in threes, ConsoleDecimalSeparator replaces the decimal
+	// point, and a positive ConsoleFloatPrecision fixes the number of
+	// decimals in floats. Pick separators that can't be confused with the
+	// commas between fields, like "_" or a thin space. The JSON encoder
+	// ignores these settings, so machine-readable output stays precise.
+	ConsoleThousandsSeparator string `json:"consoleThousandsSeparator" yaml:"consoleThousandsSeparator"`
+	ConsoleDecimalSeparator   string `json:"consoleDecimalSeparator" yaml:"consoleDecimalSeparator"`
+	ConsoleFloatPrecision     int    `json:"consoleFloatPrecision" yaml:"consoleFloatPrecision"`
+}
+
+// TransformField passes f through FieldEncoder, if it's set. Encoders built
+// from the EncoderConfig implement FieldTransformer through it.
+func (cfg *EncoderConfig) TransformField(f Field) Field {
+	if cfg.FieldEncoder == nil {
+		return f
+	}
+	return cfg.FieldEncoder(f)
 }
 
 // ObjectEncoder is a strongly-typed, encoding-agnostic interface for adding a
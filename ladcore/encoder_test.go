@@ -249,6 +249,39 @@ func TestEncoderConfiguration(t *testing.T) {
 			expectedJSON:    `{"L":"info","T":0,"N":"main","C":"foo.go:42","M":"hello","S":"fake-stack"}` + "\n",
 			expectedConsole: "0\tinfo\tmain\tfoo.go:42\thello\nfake-stack\n",
 		},
+		{
+			desc: "function without file and line",
+			cfg:  base,
+			amendEntry: func(ent Entry) Entry {
+				ent.Caller = EntryCaller{Function: "foo.Foo"}
+				return ent
+			},
+			expectedJSON:    `{"level":"info","ts":0,"name":"main","func":"foo.Foo","msg":"hello","stacktrace":"fake-stack"}` + "\n",
+			expectedConsole: "0\tinfo\tmain\tfoo.Foo\thello\nfake-stack\n",
+		},
+		{
+			desc: "function without file and line uses default key if FunctionKey is omitted",
+			cfg: EncoderConfig{
+				LevelKey:       "L",
+				TimeKey:        "T",
+				MessageKey:     "M",
+				NameKey:        "N",
+				CallerKey:      "C",
+				FunctionKey:    OmitKey,
+				StacktraceKey:  "S",
+				LineEnding:     base.LineEnding,
+				EncodeTime:     base.EncodeTime,
+				EncodeDuration: base.EncodeDuration,
+				EncodeLevel:    base.EncodeLevel,
+				EncodeCaller:   base.EncodeCaller,
+			},
+			amendEntry: func(ent Entry) Entry {
+				ent.Caller = EntryCaller{Function: "foo.Foo"}
+				return ent
+			},
+			expectedJSON:    `{"L":"info","T":0,"N":"main","func":"foo.Foo","M":"hello","S":"fake-stack"}` + "\n",
+			expectedConsole: "0\tinfo\tmain\tfoo.Foo\thello\nfake-stack\n",
+		},
 		{
 			desc: "skip stacktrace if StacktraceKey is omitted",
 			cfg: EncoderConfig{
@@ -568,6 +601,13 @@ func TestLevelEncoders(t *testing.T) {
 	}{
 		{"capital", "INFO"},
 		{"lower", "info"},
+		{"symbol", "ℹ"},
+		{"symbolColor", "\x1b[34mℹ\x1b[0m"},
+		{"emoji", "💬"},
+		{"syslog", "info"},
+		{"gcp", "INFO"},
+		{"otel", "INFO"},
+		{"cloudwatch", "INFO"},
 		{"", "info"},
 		{"something-random", "info"},
 	}
@@ -584,6 +624,37 @@ func TestLevelEncoders(t *testing.T) {
 	}
 }
 
+func TestMarkerLevelEncoders(t *testing.T) {
+	markers := LevelMarkers{InfoLevel: "i", ErrorLevel: "x"}
+	plain := NewMarkerLevelEncoder(markers)
+	colored := NewColorMarkerLevelEncoder(markers)
+	markers[InfoLevel] = "changed"
+
+	tests := []struct {
+		enc      LevelEncoder
+		lvl      Level
+		expected string
+	}{
+		{plain, InfoLevel, "i"},
+		{plain, ErrorLevel, "x"},
+		{plain, WarnLevel, "WARN"},
+		{colored, InfoLevel, "\x1b[34mi\x1b[0m"},
+		{colored, ErrorLevel, "\x1b[31mx\x1b[0m"},
+		{colored, WarnLevel, "\x1b[33mWARN\x1b[0m"},
+		{SymbolLevelEncoder, FatalLevel, "✖"},
+		{EmojiLevelEncoder, Level(42), "LEVEL(42)"},
+	}
+
+	for _, tt := range tests {
+		assertAppended(
+			t,
+			tt.expected,
+			func(arr ArrayEncoder) { tt.enc(tt.lvl, arr) },
+			"Unexpected output serializing %v.", tt.lvl,
+		)
+	}
+}
+
 func TestTimeEncoders(t *testing.T) {
 	moment := time.Unix(100, 50005000).UTC()
 	tests := []struct {
@@ -66,7 +66,9 @@ func NewEntryCaller(pc uintptr, file string, line int, ok bool) EntryCaller {
 	}
 }
 
-// EntryCaller represents the caller of a logging function.
+// EntryCaller represents the caller of a logging function. An undefined
+// EntryCaller may still carry a Function, in which case encoders log just the
+// function name.
 type EntryCaller struct {
 	Defined  bool
 	PC       uintptr
@@ -230,6 +232,8 @@ func (ce *CheckedEntry) reset() {
 // Write writes the entry to the stored Cores, returns any errors, and returns
 // the CheckedEntry reference to a pool for immediate re-use. Finally, it
 // executes any required CheckWriteAction.
+//
+// Fields of TimestampType replace the entry's time before it's written.
 func (ce *CheckedEntry) Write(fields ...Field) {
 	if ce == nil {
 		return
@@ -252,6 +256,7 @@ func (ce *CheckedEntry) Write(fields ...Field) {
 		return
 	}
 	ce.dirty = true
+	fields = ce.applyTimestamp(fields)
 
 	var err error
 	for i := range ce.cores {
@@ -274,6 +279,31 @@ func (ce *CheckedEntry) Write(fields ...Field) {
 	putCheckedEntry(ce)
 }
 
+// applyTimestamp replaces the entry's time with that of the last
+// TimestampType field, if any. Such fields become the original time under
+// their key, or are skipped if they have none. The caller owns fields, so
+// they're copied before any change.
+func (ce *CheckedEntry) applyTimestamp(fields []Field) []Field {
+	copied := false
+	emitTime := ce.Time
+	for i := range fields {
+		if fields[i].Type != TimestampType {
+			continue
+		}
+		if !copied {
+			fields, copied = append([]Field(nil), fields...), true
+		}
+		f := fields[i]
+		ce.Time = f.Interface.(time.Time)
+		if f.Key == "" {
+			fields[i] = Field{Type: SkipType}
+		} else {
+			fields[i] = Field{Key: f.Key, Type: TimeFullType, Interface: emitTime}
+		}
+	}
+	return fields
+}
+
 // AddCore adds a Core that has agreed to log this CheckedEntry. It's intended to be
 // used by Core.Check implementations, and is safe to call on nil CheckedEntry
 // references.
@@ -0,0 +1,145 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+// Windows Event Log event types.
+const (
+	_eventLogError       = 0x0001
+	_eventLogWarning     = 0x0002
+	_eventLogInformation = 0x0004
+)
+
+// _eventLogMaxMessage is the longest message, in bytes, that's reported to
+// the Event Log. The Event Log rejects strings over 31,839 characters.
+const _eventLogMaxMessage = 31839
+
+var errEventLogUnsupported = errors.New("the Windows Event Log is only available on Windows")
+
+// eventReporter reports messages to an event log.
+type eventReporter interface {
+	report(etype uint16, msg string) error
+	close() error
+}
+
+// eventLogType maps a level to an Event Log event type: debug and info
+// entries are informational, warnings are warnings, and everything above is
+// an error.
+func eventLogType(lvl Level) uint16 {
+	switch {
+	case lvl >= ErrorLevel:
+		return _eventLogError
+	case lvl == WarnLevel:
+		return _eventLogWarning
+	}
+	return _eventLogInformation
+}
+
+// NewEventLogCore creates a Core that reports entries to the Windows Event
+// Log's Application log under source, encoding them with enc. Entries map
+// to event types by level: errors and above are reported as errors, warnings
+// as warnings, and the rest as information. Messages longer than the Event
+// Log allows are truncated.
+//
+// The source should be installed with InstallEventSource first, usually by
+// the service's installer, so that Event Viewer can display its messages.
+// The returned Core implements io.Closer, which releases the source; since
+// cores created by its With method share the source, close it when the
+// logger is no longer needed.
+//
+// On other operating systems, NewEventLogCore returns an error.
+func NewEventLogCore(enc Encoder, source string, enab LevelEnabler) (Core, error) {
+	log, err := openEventLog(source)
+	if err != nil {
+		return nil, err
+	}
+	return newEventLogCore(enc, log, enab), nil
+}
+
+func newEventLogCore(enc Encoder, log eventReporter, enab LevelEnabler) *eventLogCore {
+	return &eventLogCore{LevelEnabler: enab, enc: enc, log: log}
+}
+
+type eventLogCore struct {
+	LevelEnabler
+
+	enc Encoder
+	log eventReporter
+}
+
+var (
+	_ Core           = (*eventLogCore)(nil)
+	_ leveledEnabler = (*eventLogCore)(nil)
+)
+
+func (c *eventLogCore) Level() Level {
+	return LevelOf(c.LevelEnabler)
+}
+
+func (c *eventLogCore) With(fields []Field) Core {
+	clone := &eventLogCore{LevelEnabler: c.LevelEnabler, enc: c.enc.Clone(), log: c.log}
+	addFields(clone.enc, fields)
+	return clone
+}
+
+func (c *eventLogCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *eventLogCore) Write(ent Entry, fields []Field) error {
+	buf, err := c.enc.EncodeEntry(ent, fields)
+	if err != nil {
+		return err
+	}
+	msg := truncateEventMessage(strings.TrimRight(buf.String(), "\r\n"))
+	buf.Free()
+	return c.log.report(eventLogType(ent.Level), msg)
+}
+
+// Sync is a no-op: the Event Log service persists events on its own.
+func (c *eventLogCore) Sync() error {
+	return nil
+}
+
+func (c *eventLogCore) Close() error {
+	return c.log.close()
+}
+
+// truncateEventMessage cuts msg to the Event Log's limit, on a rune
+// boundary.
+func truncateEventMessage(msg string) string {
+	if len(msg) <= _eventLogMaxMessage {
+		return msg
+	}
+	n := _eventLogMaxMessage
+	for n > 0 && !utf8.RuneStart(msg[n]) {
+		n--
+	}
+	return msg[:n]
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore
+
+import (
+	"errors"
+	"io"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type reportedEvent struct {
+	etype uint16
+	msg   string
+}
+
+type fakeEventLog struct {
+	events []reportedEvent
+	err    error
+	closed bool
+}
+
+func (l *fakeEventLog) report(etype uint16, msg string) error {
+	l.events = append(l.events, reportedEvent{etype, msg})
+	return l.err
+}
+
+func (l *fakeEventLog) close() error {
+	l.closed = true
+	return nil
+}
+
+func TestEventLogCore(t *testing.T) {
+	log := &fakeEventLog{}
+	enc := NewJSONEncoder(EncoderConfig{MessageKey: "msg"})
+	core := newEventLogCore(enc, log, InfoLevel).With([]Field{{Key: "k", Type: StringType, String: "v"}})
+	assert.Equal(t, InfoLevel, LevelOf(core), "Unexpected level.")
+
+	for _, lvl := range []Level{DebugLevel, InfoLevel, WarnLevel, ErrorLevel, FatalLevel} {
+		if ce := core.Check(Entry{Level: lvl, Message: lvl.String()}, nil); ce != nil {
+			ce.Write()
+		}
+	}
+	assert.Equal(t, []reportedEvent{
+		{_eventLogInformation, `{"msg":"info","k":"v"}`},
+		{_eventLogWarning, `{"msg":"warn","k":"v"}`},
+		{_eventLogError, `{"msg":"error","k":"v"}`},
+		{_eventLogError, `{"msg":"fatal","k":"v"}`},
+	}, log.events, "Unexpected events.")
+
+	log.err = errors.New("fail")
+	assert.ErrorIs(t, core.Write(Entry{Level: InfoLevel}, nil), log.err, "Expected reporting errors.")
+	assert.NoError(t, core.Sync(), "Unexpected error syncing.")
+	require.Implements(t, (*io.Closer)(nil), core, "Expected the core to be closable.")
+	assert.NoError(t, core.(io.Closer).Close(), "Unexpected error closing.")
+	assert.True(t, log.closed, "Expected closing to release the source.")
+}
+
+func TestTruncateEventMessage(t *testing.T) {
+	short := "short"
+	assert.Equal(t, short, truncateEventMessage(short), "Expected short messages to be left alone.")
+
+	long := strings.Repeat("a", _eventLogMaxMessage-1) + "é"
+	got := truncateEventMessage(long)
+	assert.Equal(t, _eventLogMaxMessage-1, len(got), "Expected truncation on a rune boundary.")
+}
+
+func TestEventLogUnsupported(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("The Event Log is supported on Windows.")
+	}
+	_, err := NewEventLogCore(NewJSONEncoder(EncoderConfig{}), "app", InfoLevel)
+	assert.ErrorIs(t, err, errEventLogUnsupported, "Expected an error outside Windows.")
+	assert.ErrorIs(t, InstallEventSource("app"), errEventLogUnsupported, "Expected an error outside Windows.")
+	assert.ErrorIs(t, RemoveEventSource("app"), errEventLogUnsupported, "Expected an error outside Windows.")
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build windows
+
+package ladcore
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+
+	"go.uber.org/multierr"
+)
+
+const (
+	// _eventLogSourcesKey is the registry key, under HKEY_LOCAL_MACHINE,
+	// that holds a subkey per source of the Application log.
+	_eventLogSourcesKey = `SYSTEM\CurrentControlSet\Services\EventLog\Application`
+
+	// EventCreate.exe has a message for each event ID from 1 to 1000 that
+	// just displays the event's string, which saves sources from shipping a
+	// message file.
+	_eventCreateMessageFile = `%SystemRoot%\System32\EventCreate.exe`
+	_eventLogEventID        = 1
+
+	_regOpenedExistingKey = 2
+)
+
+var (
+	_advapi32                  = syscall.NewLazyDLL("advapi32.dll")
+	_procRegisterEventSource   = _advapi32.NewProc("RegisterEventSourceW")
+	_procDeregisterEventSource = _advapi32.NewProc("DeregisterEventSource")
+	_procReportEvent           = _advapi32.NewProc("ReportEventW")
+	_procRegCreateKeyEx        = _advapi32.NewProc("RegCreateKeyExW")
+	_procRegSetValueEx         = _advapi32.NewProc("RegSetValueExW")
+	_procRegDeleteKey          = _advapi32.NewProc("RegDeleteKeyW")
+)
+
+// InstallEventSource registers source with the Windows Event Log's
+// Application log, so that Event Viewer can display the events that
+// NewEventLogCore reports. It requires administrator rights, so it's
+// usually called by a service's installer. It returns an error if the
+// source is already registered.
+func InstallEventSource(source string) error {
+	key, err := syscall.UTF16PtrFromString(_eventLogSourcesKey + `\` + source)
+	if err != nil {
+		return err
+	}
+	var (
+		h           syscall.Handle
+		disposition uint32
+	)
+	if r, _, _ := _procRegCreateKeyEx.Call(
+		uintptr(syscall.HKEY_LOCAL_MACHINE), uintptr(unsafe.Pointer(key)), 0, 0, 0,
+		uintptr(syscall.KEY_WRITE), 0, uintptr(unsafe.Pointer(&h)), uintptr(unsafe.Pointer(&disposition)),
+	); r != 0 {
+		return fmt.Errorf("install event source %q: %w", source, syscall.Errno(r))
+	}
+	defer syscall.RegCloseKey(h)
+	if disposition == _regOpenedExistingKey {
+		return fmt.Errorf("install event source %q: already installed", source)
+	}
+
+	file, err := syscall.UTF16FromString(_eventCreateMessageFile)
+	if err != nil {
+		return err
+	}
+	types := uint32(_eventLogError | _eventLogWarning | _eventLogInformation)
+	return multierr.Combine(
+		setRegistryValue(h, "EventMessageFile", syscall.REG_EXPAND_SZ, unsafe.Pointer(&file[0]), uint32(len(file)*2)),
+		setRegistryValue(h, "TypesSupported", syscall.REG_DWORD, unsafe.Pointer(&types), 4),
+	)
+}
+
+// RemoveEventSource removes a source registered with InstallEventSource.
+func RemoveEventSource(source string) error {
+	key, err := syscall.UTF16PtrFromString(_eventLogSourcesKey + `\` + source)
+	if err != nil {
+		return err
+	}
+	if r, _, _ := _procRegDeleteKey.Call(uintptr(syscall.HKEY_LOCAL_MACHINE), uintptr(unsafe.Pointer(key))); r != 0 {
+		return fmt.Errorf("remove event source %q: %w", source, syscall.Errno(r))
+	}
+	return nil
+}
+
+func setRegistryValue(h syscall.Handle, name string, typ uint32, data unsafe.Pointer, size uint32) error {
+	n, err := syscall.UTF16PtrFromString(name)
+	if err != nil {
+		return err
+	}
+	if r, _, _ := _procRegSetValueEx.Call(
+		uintptr(h), uintptr(unsafe.Pointer(n)), 0, uintptr(typ), uintptr(data), uintptr(size),
+	); r != 0 {
+		return fmt.Errorf("set %s: %w", name, syscall.Errno(r))
+	}
+	return nil
+}
+
+// windowsEventLog reports events through a handle from RegisterEventSource.
+type windowsEventLog struct {
+	h syscall.Handle
+}
+
+func openEventLog(source string) (eventReporter, error) {
+	src, err := syscall.UTF16PtrFromString(source)
+	if err != nil {
+		return nil, err
+	}
+	h, _, err := _procRegisterEventSource.Call(0, uintptr(unsafe.Pointer(src)))
+	if h == 0 {
+		return nil, fmt.Errorf("register event source %q: %w", source, err)
+	}
+	return &windowsEventLog{h: syscall.Handle(h)}, nil
+}
+
+func (l *windowsEventLog) report(etype uint16, msg string) error {
+	s, err := syscall.UTF16PtrFromString(msg)
+	if err != nil {
+		return err
+	}
+	strs := []*uint16{s}
+	if r, _, err := _procReportEvent.Call(
+		uintptr(l.h), uintptr(etype), 0, _eventLogEventID, 0,
+		uintptr(len(strs)), 0, uintptr(unsafe.Pointer(&strs[0])), 0,
+	); r == 0 {
+		return err
+	}
+	return nil
+}
+
+func (l *windowsEventLog) close() error {
+	if r, _, err := _procDeregisterEventSource.Call(uintptr(l.h)); r == 0 {
+		return err
+	}
+	return nil
+}
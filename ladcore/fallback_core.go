@@ -0,0 +1,208 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/multierr"
+)
+
+// _defaultFallbackCooldown specifies the default time a FallbackCore keeps
+// writing to its secondary Core before retrying the primary.
+const _defaultFallbackCooldown = 30 * time.Second
+
+// FallbackOption configures a FallbackCore.
+type FallbackOption interface {
+	apply(*fallbackState)
+}
+
+type fallbackOptionFunc func(*fallbackState)
+
+func (f fallbackOptionFunc) apply(s *fallbackState) {
+	f(s)
+}
+
+// FallbackCooldown sets how long a FallbackCore routes entries to the
+// secondary Core after the primary fails, before trying the primary again.
+// Defaults to 30 seconds.
+func FallbackCooldown(d time.Duration) FallbackOption {
+	return fallbackOptionFunc(func(s *fallbackState) {
+		if d > 0 {
+			s.cooldown = d
+		}
+	})
+}
+
+// FallbackClock sets the Clock a FallbackCore uses to time its cooldown.
+// Defaults to the system clock.
+func FallbackClock(clock Clock) FallbackOption {
+	return fallbackOptionFunc(func(s *fallbackState) {
+		s.clock = clock
+	})
+}
+
+// A FallbackCore writes entries to a primary Core and fails over to a
+// secondary Core when the primary's Write or Sync returns an error, like
+// when a disk fills up or a network sink goes down.
+//
+// An entry the primary fails to write is written to the secondary instead,
+// and subsequent entries go straight to the secondary for the cooldown (see
+// FallbackCooldown). After that, the next entry is tried against the primary
+// again: if it succeeds, the FallbackCore switches back; otherwise it starts
+// another cooldown. Errors are only returned when the secondary fails too.
+//
+// Entries are checked against the primary's level. Cores derived with With
+// share their parent's failover state.
+type FallbackCore struct {
+	primary   Core
+	secondary Core
+	s         *fallbackState
+}
+
+var (
+	_ Core           = (*FallbackCore)(nil)
+	_ leveledEnabler = (*FallbackCore)(nil)
+)
+
+// NewFallbackCore creates a FallbackCore that writes to primary, failing
+// over to secondary.
+func NewFallbackCore(primary, secondary Core, opts ...FallbackOption) *FallbackCore {
+	s := &fallbackState{
+		cooldown: _defaultFallbackCooldown,
+		clock:    DefaultClock,
+	}
+	for _, opt := range opts {
+		opt.apply(s)
+	}
+	return &FallbackCore{primary: primary, secondary: secondary, s: s}
+}
+
+// Enabled reports whether the primary Core is enabled at the given level.
+func (c *FallbackCore) Enabled(lvl Level) bool {
+	return c.primary.Enabled(lvl)
+}
+
+// Level returns the minimum enabled level of the primary Core.
+func (c *FallbackCore) Level() Level {
+	return LevelOf(c.primary)
+}
+
+// With adds structured context to both Cores. The returned Core shares this
+// one's failover state.
+func (c *FallbackCore) With(fields []Field) Core {
+	return &FallbackCore{
+		primary:   c.primary.With(fields),
+		secondary: c.secondary.With(fields),
+		s:         c.s,
+	}
+}
+
+// Check adds the FallbackCore to the CheckedEntry if the primary Core is
+// enabled at the entry's level.
+func (c *FallbackCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write writes the entry to the primary Core, or to the secondary if the
+// primary is failing.
+func (c *FallbackCore) Write(ent Entry, fields []Field) error {
+	if c.s.usePrimary() {
+		err := checkAndWrite(c.primary, ent, fields)
+		if err == nil {
+			c.s.succeed()
+			return nil
+		}
+		c.s.fail()
+		if serr := checkAndWrite(c.secondary, ent, fields); serr != nil {
+			return multierr.Append(err, serr)
+		}
+		return nil
+	}
+	return checkAndWrite(c.secondary, ent, fields)
+}
+
+// Sync flushes both Cores. A failure to flush the primary fails over to the
+// secondary.
+func (c *FallbackCore) Sync() error {
+	if c.s.usePrimary() {
+		if err := c.primary.Sync(); err != nil {
+			c.s.fail()
+		} else {
+			c.s.succeed()
+		}
+	}
+	return c.secondary.Sync()
+}
+
+// Failovers reports how many times the FallbackCore has switched from the
+// primary Core to the secondary.
+func (c *FallbackCore) Failovers() uint64 {
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+	return c.s.failovers
+}
+
+// FailedOver reports whether entries are currently being routed to the
+// secondary Core.
+func (c *FallbackCore) FailedOver() bool {
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+	return c.s.failing
+}
+
+type fallbackState struct {
+	cooldown time.Duration
+	clock    Clock
+
+	mu        sync.Mutex
+	failing   bool      // whether the primary has failed and not recovered
+	failedAt  time.Time // when the primary last failed
+	failovers uint64
+}
+
+// usePrimary reports whether an operation should be tried against the
+// primary Core: either it's healthy, or the cooldown has passed.
+func (s *fallbackState) usePrimary() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return !s.failing || s.clock.Now().Sub(s.failedAt) >= s.cooldown
+}
+
+func (s *fallbackState) succeed() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failing = false
+}
+
+func (s *fallbackState) fail() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.failing {
+		s.failovers++
+	}
+	s.failing = true
+	s.failedAt = s.clock.Now()
+}
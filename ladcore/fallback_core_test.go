@@ -0,0 +1,95 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/auwixcom/lad/internal/ztest"
+	//revive:disable:dot-imports
+	. "github.com/auwixcom/lad/ladcore"
+	"github.com/auwixcom/lad/ladtest/observer"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// flakyWriter is a ztest.Buffer whose writes can be made to fail.
+type flakyWriter struct {
+	ztest.Buffer
+	fail bool
+}
+
+func (w *flakyWriter) Write(bs []byte) (int, error) {
+	if w.fail {
+		return 0, errors.New("disk full")
+	}
+	return w.Buffer.Write(bs)
+}
+
+func TestFallbackCore(t *testing.T) {
+	clock := ztest.NewMockClock()
+	out := &flakyWriter{}
+	primary := NewCore(NewJSONEncoder(EncoderConfig{MessageKey: "msg"}), out, InfoLevel)
+	secondary, logs := observer.New(DebugLevel)
+	core := NewFallbackCore(primary, secondary, FallbackCooldown(time.Minute), FallbackClock(clock))
+	assert.Equal(t, InfoLevel, LevelOf(core), "Expected the primary's level.")
+
+	log := func(c Core, msg string) {
+		if ce := c.Check(Entry{Level: InfoLevel, Message: msg}, nil); ce != nil {
+			ce.Write()
+		}
+	}
+	child := core.With([]Field{makeInt64Field("k", 1)})
+
+	log(core, "healthy")
+	out.fail = true
+	log(child, "failed")
+	out.fail = false
+	log(core, "cooling down")
+	assert.True(t, core.FailedOver(), "Expected to route to the secondary.")
+	assert.Equal(t, []string{`{"msg":"healthy"}`}, out.Lines(), "Unexpected primary output.")
+	assert.Equal(t, []string{"failed", "cooling down"}, messages(logs), "Unexpected secondary output.")
+	assert.Equal(t, map[string]interface{}{"k": int64(1)}, logs.All()[0].ContextMap(), "Expected context on the secondary.")
+
+	clock.Add(time.Minute)
+	log(child, "recovered")
+	assert.False(t, core.FailedOver(), "Expected to switch back to the primary.")
+	assert.Equal(t, []string{`{"msg":"healthy"}`, `{"msg":"recovered","k":1}`}, out.Lines(), "Unexpected primary output.")
+	assert.Equal(t, uint64(1), core.Failovers(), "Unexpected failover count.")
+
+	out.SetError(errors.New("sync failed"))
+	assert.NoError(t, core.Sync(), "Expected the secondary to absorb sync failures.")
+	assert.True(t, core.FailedOver(), "Expected a failed Sync to fail over.")
+	assert.Equal(t, uint64(2), core.Failovers(), "Unexpected failover count.")
+}
+
+func TestFallbackCoreBothFail(t *testing.T) {
+	primary := NewCore(NewJSONEncoder(EncoderConfig{MessageKey: "msg"}), &ztest.FailWriter{}, InfoLevel)
+	secondary := NewCore(NewJSONEncoder(EncoderConfig{MessageKey: "msg"}), &ztest.FailWriter{}, InfoLevel)
+	core := NewFallbackCore(primary, secondary)
+
+	err := core.Write(Entry{Level: InfoLevel, Message: "lost"}, nil)
+	assert.Error(t, err, "Expected an error when both cores fail.")
+	assert.Error(t, core.Write(Entry{Level: InfoLevel, Message: "lost"}, nil), "Expected the secondary's error.")
+	assert.Equal(t, uint64(1), core.Failovers(), "Expected failing over once.")
+}
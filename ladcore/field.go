@@ -22,10 +22,15 @@ package ladcore
 
 import (
 	"bytes"
+	"encoding/binary"
 	"fmt"
 	"math"
+	"net/netip"
+	"net/url"
 	"reflect"
 	"time"
+
+	"github.com/auwixcom/lad/internal/bufferpool"
 )
 
 // A FieldType indicates which member of the Field union struct should be used
@@ -96,24 +101,91 @@ const (
 	// InlineMarshalerType indicates that the field carries an ObjectMarshaler
 	// that should be inlined.
 	InlineMarshalerType
+
+	// UUIDType indicates that the field carries a UUID, either as built by
+	// UUIDField or as a [16]byte in Interface.
+	UUIDType
+
+	// TimestampType indicates that the field replaces the entry's time with
+	// the time.Time it carries. If the field has a key, the entry's original
+	// time is logged under it. See CheckedEntry.Write.
+	TimestampType
+
+	// NetIPAddrType indicates that the field carries a netip.Addr.
+	NetIPAddrType
+
+	// NetIPPrefixType indicates that the field carries a netip.Prefix.
+	NetIPPrefixType
+
+	// URLType indicates that the field carries a *url.URL, which is encoded
+	// with its password redacted.
+	URLType
 )
 
 // A Field is a marshaling operation used to add a key-value pair to a logger's
 // context. Most fields are lazily marshaled, so it's inexpensive to add fields
 // to disabled debug-level log statements.
 type Field struct {
-	Key       string
-	Type      FieldType
+	Key  string
+	Type FieldType
+	// uuid holds bytes 8 through 14 of a UUID built by UUIDField, in space
+	// that would otherwise be padding.
+	uuid      [7]byte
 	Integer   int64
 	String    string
 	Interface interface{}
 }
 
+// UUIDField constructs a field that carries a UUID without allocating. A
+// [16]byte doesn't fit in an interface without being copied to the heap, so
+// the UUID is spread across Integer, which holds the first eight bytes,
+// space the Field otherwise leaves as padding, and Interface, which holds
+// the last byte; single bytes don't need to be copied.
+func UUIDField(key string, val [16]byte) Field {
+	f := Field{
+		Key:       key,
+		Type:      UUIDType,
+		Integer:   int64(binary.BigEndian.Uint64(val[:8])),
+		Interface: val[15],
+	}
+	copy(f.uuid[:], val[8:15])
+	return f
+}
+
+// uuidValue reassembles the UUID carried by a UUIDType field.
+func (f Field) uuidValue() [16]byte {
+	if u, ok := f.Interface.([16]byte); ok {
+		return u
+	}
+	var u [16]byte
+	binary.BigEndian.PutUint64(u[:8], uint64(f.Integer))
+	copy(u[8:15], f.uuid[:])
+	u[15] = f.Interface.(byte)
+	return u
+}
+
 // AddTo exports a field through the ObjectEncoder interface. It's primarily
 // useful to library authors, and shouldn't be necessary in most applications.
 func (f Field) AddTo(enc ObjectEncoder) {
 	var err error
 
+	switch f.Type {
+	case ArrayMarshalerType, ObjectMarshalerType, ReflectType, StringerType, ErrorType:
+		if s, ok := redacted(f.Interface); ok {
+			enc.AddString(f.Key, s)
+			return
+		}
+	case InlineMarshalerType:
+		if s, ok := redacted(f.Interface); ok {
+			key := f.Key
+			if key == "" {
+				key = _inlineRedactedKey
+			}
+			enc.AddString(key, s)
+			return
+		}
+	}
+
 	switch f.Type {
 	case ArrayMarshalerType:
 		err = enc.AddArray(f.Key, f.Interface.(ArrayMarshaler))
@@ -174,7 +246,16 @@ func (f Field) AddTo(enc ObjectEncoder) {
 		err = encodeStringer(f.Key, f.Interface, enc)
 	case ErrorType:
 		err = encodeError(f.Key, f.Interface.(error), enc)
-	case SkipType:
+	case UUIDType:
+		encodeUUID(f.Key, f.uuidValue(), enc)
+	case NetIPAddrType:
+		encodeNetIP(f.Key, f.Interface.(netip.Addr), enc)
+	case NetIPPrefixType:
+		encodeNetIP(f.Key, f.Interface.(netip.Prefix), enc)
+	case URLType:
+		enc.AddString(f.Key, f.Interface.(*url.URL).Redacted())
+	case SkipType, TimestampType:
+		// Timestamp fields only take effect at the log site.
 		break
 	default:
 		panic(fmt.Sprintf("unknown field type: %v", f))
@@ -186,7 +267,7 @@ func (f Field) AddTo(enc ObjectEncoder) {
 }
 
 // Equals returns whether two fields are equal. For non-primitive types such as
-// errors, marshalers, or reflect types, it uses reflect.DeepEqual.
+// errors, marshalers, Stringers, or reflect types, it uses reflect.DeepEqual.
 func (f Field) Equals(other Field) bool {
 	if f.Type != other.Type {
 		return false
@@ -198,19 +279,58 @@ func (f Field) Equals(other Field) bool {
 	switch f.Type {
 	case BinaryType, ByteStringType:
 		return bytes.Equal(f.Interface.([]byte), other.Interface.([]byte))
-	case ArrayMarshalerType, ObjectMarshalerType, ErrorType, ReflectType:
+	case ArrayMarshalerType, ObjectMarshalerType, ErrorType, ReflectType, StringerType, URLType:
 		return reflect.DeepEqual(f.Interface, other.Interface)
+	case UUIDType:
+		return f.uuidValue() == other.uuidValue()
 	default:
 		return f == other
 	}
 }
 
+// A FieldTransformer is an encoder that transforms top-level fields before
+// they're added to it, like the encoders built from an EncoderConfig with a
+// FieldEncoder. Cores pass the fields added with With through it.
+type FieldTransformer interface {
+	TransformField(Field) Field
+}
+
+// TransformField returns f transformed by enc, if enc is a FieldTransformer,
+// and f otherwise.
+func TransformField(enc ObjectEncoder, f Field) Field {
+	if t, ok := enc.(FieldTransformer); ok {
+		return t.TransformField(f)
+	}
+	return f
+}
+
+// addFields adds fields to enc, passing them through enc's TransformField
+// first, if it has one.
 func addFields(enc ObjectEncoder, fields []Field) {
+	if t, ok := enc.(FieldTransformer); ok {
+		for i := range fields {
+			t.TransformField(fields[i]).AddTo(enc)
+		}
+		return
+	}
 	for i := range fields {
 		fields[i].AddTo(enc)
 	}
 }
 
+// encodeFields returns fields transformed by fn, or fields itself if fn is
+// nil. The input slice isn't modified.
+func encodeFields(fn func(Field) Field, fields []Field) []Field {
+	if fn == nil || len(fields) == 0 {
+		return fields
+	}
+	out := make([]Field, len(fields))
+	for i := range fields {
+		out[i] = fn(fields[i])
+	}
+	return out
+}
+
 func encodeStringer(key string, stringer interface{}, enc ObjectEncoder) (retErr error) {
 	// Try to capture panics (from nil references or otherwise) when calling
 	// the String() method, similar to https://golang.org/src/fmt/print.go#L540
@@ -231,3 +351,41 @@ func encodeStringer(key string, stringer interface{}, enc ObjectEncoder) (retErr
 	enc.AddString(key, stringer.(fmt.Stringer).String())
 	return nil
 }
+
+const _hexDigits = "0123456789abcdef"
+
+// encodeUUID adds a UUID in its canonical, hyphenated form. It formats the
+// UUID into a pooled buffer to avoid allocating an intermediate string.
+func encodeUUID(key string, u [16]byte, enc ObjectEncoder) {
+	buf := bufferpool.Get()
+	defer buf.Free()
+
+	for i, b := range u {
+		switch i {
+		case 4, 6, 8, 10:
+			buf.AppendByte('-')
+		}
+		buf.AppendByte(_hexDigits[b>>4])
+		buf.AppendByte(_hexDigits[b&0x0f])
+	}
+	enc.AddByteString(key, buf.Bytes())
+}
+
+// encodeNetIP adds an address or prefix in its standard form, formatting it
+// into a pooled buffer like encodeUUID. Invalid values are logged as their
+// String methods describe them.
+func encodeNetIP[T interface {
+	netip.Addr | netip.Prefix
+	IsValid() bool
+	AppendTo([]byte) []byte
+	String() string
+}](key string, ip T, enc ObjectEncoder) {
+	if !ip.IsValid() {
+		enc.AddString(key, ip.String())
+		return
+	}
+
+	buf := bufferpool.Get()
+	defer buf.Free()
+	enc.AddByteString(key, ip.AppendTo(buf.Bytes()))
+}
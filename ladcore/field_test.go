@@ -24,6 +24,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"net/netip"
 	"net/url"
 	"testing"
 	"time"
@@ -166,6 +167,18 @@ func TestFields(t *testing.T) {
 		{t: StringerType, iface: (*url.URL)(nil), want: "<nil>"},
 		{t: StringerType, iface: (*users)(nil), want: "<nil>"},
 		{t: ErrorType, iface: (*errObj)(nil), want: "<nil>"},
+		{
+			t:     UUIDType,
+			iface: [16]byte{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8},
+			want:  "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		},
+		{t: NetIPAddrType, iface: netip.MustParseAddr("2001:db8::1"), want: "2001:db8::1"},
+		{t: NetIPAddrType, iface: netip.MustParseAddr("fe80::1%eth0"), want: "fe80::1%eth0"},
+		{t: NetIPAddrType, iface: netip.Addr{}, want: "invalid IP"},
+		{t: NetIPPrefixType, iface: netip.MustParsePrefix("192.0.2.0/24"), want: "192.0.2.0/24"},
+		{t: NetIPPrefixType, iface: netip.Prefix{}, want: "invalid Prefix"},
+		{t: URLType, iface: &url.URL{Scheme: "https", Host: "example.com", Path: "/a b"}, want: "https://example.com/a%20b"},
+		{t: URLType, iface: &url.URL{Scheme: "postgres", User: url.UserPassword("app", "hunter2"), Host: "db"}, want: "postgres://app:xxxxx@db"},
 	}
 
 	for _, tt := range tests {
@@ -285,6 +298,16 @@ func TestEquals(t *testing.T) {
 			b:    lad.Ints("k", []int{1, 2}),
 			want: true,
 		},
+		{
+			a:    lad.UUID("k", [16]byte{1, 15: 2}),
+			b:    Field{Key: "k", Type: UUIDType, Interface: [16]byte{1, 15: 2}},
+			want: true,
+		},
+		{
+			a:    lad.UUID("k", [16]byte{1, 15: 2}),
+			b:    lad.UUID("k", [16]byte{1, 14: 2}),
+			want: false,
+		},
 		{
 			a:    lad.Ints("k", []int{1, 2}),
 			b:    lad.Ints("k", []int{1, 3}),
@@ -330,6 +353,26 @@ func TestEquals(t *testing.T) {
 			b:    lad.Object("k", lad.DictObject(lad.String("a", "d"))),
 			want: false,
 		},
+		{
+			a:    lad.HexN("k", []byte{1, 2, 3}, 2),
+			b:    lad.HexN("k", []byte{1, 2, 3}, 2),
+			want: true,
+		},
+		{
+			a:    lad.HexN("k", []byte{1, 2, 3}, 2),
+			b:    lad.HexN("k", []byte{1, 2, 4}, 2),
+			want: false,
+		},
+		{
+			a:    lad.HexN("k", []byte{1, 2, 3}, 2),
+			b:    lad.HexN("k", []byte{1, 2, 3}, 3),
+			want: false,
+		},
+		{
+			a:    lad.Base64N("k", []byte{1, 2, 3}, 2),
+			b:    lad.HexN("k", []byte{1, 2, 3}, 2),
+			want: false,
+		},
 	}
 
 	for _, tt := range tests {
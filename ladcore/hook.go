@@ -75,3 +75,61 @@ func (h *hooked) Write(ent Entry, _ []Field) error {
 	}
 	return err
 }
+
+type fieldHooked struct {
+	Core
+	funcs   []func(Entry, []Field) error
+	context []Field
+}
+
+var (
+	_ Core           = (*fieldHooked)(nil)
+	_ leveledEnabler = (*fieldHooked)(nil)
+)
+
+// RegisterFieldHooks is like RegisterHooks, but the callbacks also receive
+// the entry's structured fields: those added with With, followed by those
+// passed at the log site. Hooks must not retain or modify the slice.
+//
+// This lets hooks implement metrics, auditing, and the like based on field
+// values without implementing the full Core interface.
+func RegisterFieldHooks(core Core, hooks ...func(Entry, []Field) error) Core {
+	funcs := append([]func(Entry, []Field) error{}, hooks...)
+	return &fieldHooked{
+		Core:  core,
+		funcs: funcs,
+	}
+}
+
+func (h *fieldHooked) Level() Level {
+	return LevelOf(h.Core)
+}
+
+func (h *fieldHooked) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	// As with hooked, the wrapped Core registers itself with the
+	// CheckedEntry.
+	if downstream := h.Core.Check(ent, ce); downstream != nil {
+		return downstream.AddCore(ent, h)
+	}
+	return ce
+}
+
+func (h *fieldHooked) With(fields []Field) Core {
+	return &fieldHooked{
+		Core:    h.Core.With(fields),
+		funcs:   h.funcs,
+		context: append(h.context[:len(h.context):len(h.context)], fields...),
+	}
+}
+
+func (h *fieldHooked) Write(ent Entry, fields []Field) error {
+	all := fields
+	if len(h.context) > 0 {
+		all = append(h.context[:len(h.context):len(h.context)], fields...)
+	}
+	var err error
+	for i := range h.funcs {
+		err = multierr.Append(err, h.funcs[i](ent, all))
+	}
+	return err
+}
@@ -81,3 +81,26 @@ func TestHooks(t *testing.T) {
 		}
 	}
 }
+
+func TestFieldHooks(t *testing.T) {
+	fac, logs := observer.New(InfoLevel)
+	ctx := makeInt64Field("ctx", 1)
+	site := makeInt64Field("site", 2)
+
+	var calls [][]Field
+	h := RegisterFieldHooks(fac, func(_ Entry, fields []Field) error {
+		calls = append(calls, append([]Field(nil), fields...))
+		return nil
+	})
+	assert.Equal(t, InfoLevel, LevelOf(h), "Wrapped logger has the wrong log level")
+
+	child := h.With([]Field{ctx})
+	for _, lvl := range []Level{DebugLevel, InfoLevel} {
+		if ce := child.Check(Entry{Level: lvl, Message: "msg"}, nil); ce != nil {
+			ce.Write(site)
+		}
+	}
+
+	assert.Equal(t, [][]Field{{ctx, site}}, calls, "Expected one call with context and log-site fields.")
+	assert.Equal(t, 1, logs.Len(), "Unexpected logs written out.")
+}
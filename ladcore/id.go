@@ -0,0 +1,102 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore
+
+import (
+	"bufio"
+	"crypto/rand"
+	"io"
+
+	"github.com/auwixcom/lad/internal/pool"
+)
+
+// An IDGenerator generates identifiers, such as entry and event IDs, that
+// are attached to log entries. Implementations must be safe for concurrent
+// use.
+type IDGenerator interface {
+	NewID() string
+}
+
+// IDGeneratorFunc is a function that satisfies the IDGenerator interface.
+type IDGeneratorFunc func() string
+
+// NewID calls f.
+func (f IDGeneratorFunc) NewID() string {
+	return f()
+}
+
+// ULIDGenerator is the default IDGenerator. It generates ULIDs: 26
+// Crockford base32 characters holding a millisecond timestamp followed by 80
+// random bits, so IDs sort by the time they were generated.
+//
+// The zero value is ready to use and reads the time from DefaultClock.
+type ULIDGenerator struct {
+	Clock Clock
+}
+
+var _ IDGenerator = ULIDGenerator{}
+
+const _crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ulidState buffers entropy so that generating an ID doesn't need a
+// system call each time.
+type ulidState struct {
+	entropy *bufio.Reader
+	rand    [10]byte
+	out     [26]byte
+}
+
+var _ulidPool = pool.New(func() *ulidState {
+	return &ulidState{entropy: bufio.NewReaderSize(rand.Reader, 256)}
+})
+
+// NewID returns a new ULID.
+func (g ULIDGenerator) NewID() string {
+	clock := g.Clock
+	if clock == nil {
+		clock = DefaultClock
+	}
+	ms := uint64(clock.Now().UnixMilli())
+
+	s := _ulidPool.Get()
+	defer _ulidPool.Put(s)
+	_, _ = io.ReadFull(s.entropy, s.rand[:]) // crypto/rand never fails on supported platforms
+
+	// The 48-bit timestamp takes 10 characters, 5 bits each, with the
+	// first one holding only 3 bits.
+	for i := 9; i >= 0; i-- {
+		s.out[i] = _crockford[ms&0x1f]
+		ms >>= 5
+	}
+	// The 80 random bits take the remaining 16 characters.
+	r := s.rand
+	for i := 0; i < 2; i++ {
+		var v uint64
+		for _, b := range r[i*5 : i*5+5] {
+			v = v<<8 | uint64(b)
+		}
+		for j := 7; j >= 0; j-- {
+			s.out[10+i*8+j] = _crockford[v&0x1f]
+			v >>= 5
+		}
+	}
+	return string(s.out[:])
+}
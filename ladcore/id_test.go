@@ -0,0 +1,71 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore_test
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/auwixcom/lad/internal/ztest"
+	. "github.com/auwixcom/lad/ladcore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time                         { return time.Time(c) }
+func (c fixedClock) NewTicker(d time.Duration) *time.Ticker { return time.NewTicker(d) }
+
+func TestULIDGenerator(t *testing.T) {
+	gen := ULIDGenerator{Clock: fixedClock(time.UnixMilli(1469918176385))}
+
+	id := gen.NewID()
+	require.Len(t, id, 26, "Unexpected ULID length.")
+	assert.Regexp(t, `^[0-9A-HJKMNP-TV-Z]{26}$`, id, "Expected Crockford base32.")
+	assert.Equal(t, "01ARYZ6S41", id[:10], "Unexpected timestamp component.")
+	assert.NotEqual(t, id, gen.NewID(), "Expected IDs to differ.")
+}
+
+func TestULIDGeneratorSortable(t *testing.T) {
+	clock := ztest.NewMockClock()
+	gen := ULIDGenerator{Clock: clock}
+
+	ids := make([]string, 0, 100)
+	for i := 0; i < 100; i++ {
+		ids = append(ids, gen.NewID())
+		clock.Add(time.Millisecond)
+	}
+	assert.True(t, sort.StringsAreSorted(ids), "Expected IDs to sort by generation time.")
+}
+
+func TestULIDGeneratorDefaultClock(t *testing.T) {
+	past := ULIDGenerator{Clock: fixedClock(time.Now().Add(-time.Second))}.NewID()
+	id := ULIDGenerator{}.NewID()
+	assert.Len(t, id, 26, "Unexpected ULID length.")
+	assert.Greater(t, id, past, "Expected the zero value to use the system clock.")
+}
+
+func TestIDGeneratorFunc(t *testing.T) {
+	gen := IDGeneratorFunc(func() string { return "id" })
+	assert.Equal(t, "id", gen.NewID(), "Unexpected ID.")
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore
+
+// NewJournalCore wraps a Core so that entries at PanicLevel and above are
+// first written to a journal: a pre-opened, append-only WriteSyncer, like a
+// file opened with os.O_APPEND. Each such entry is encoded, written, and
+// synced to the journal before the wrapped Core gets to write it, so the
+// reason the process is about to die survives even if the wrapped Core's
+// pipeline is wedged, e.g. behind a full asynchronous queue or an
+// unreachable collector. Entries below PanicLevel don't touch the journal.
+//
+// The journal must be safe for concurrent use; see Lock.
+func NewJournalCore(core Core, enc Encoder, journal WriteSyncer) Core {
+	return &journalCore{
+		Core:    core,
+		enc:     enc,
+		journal: journal,
+	}
+}
+
+type journalCore struct {
+	Core
+
+	enc     Encoder // holds the context
+	journal WriteSyncer
+}
+
+var (
+	_ Core           = (*journalCore)(nil)
+	_ leveledEnabler = (*journalCore)(nil)
+)
+
+func (c *journalCore) Level() Level {
+	return LevelOf(c.Core)
+}
+
+func (c *journalCore) With(fields []Field) Core {
+	enc := c.enc.Clone()
+	addFields(enc, fields)
+	return &journalCore{
+		Core:    c.Core.With(fields),
+		enc:     enc,
+		journal: c.journal,
+	}
+}
+
+func (c *journalCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	if ent.Level >= PanicLevel {
+		// Cores write in the order they're added, so register first.
+		ce = ce.AddCore(ent, c)
+	}
+	return c.Core.Check(ent, ce)
+}
+
+// Write writes the entry to the journal only; the wrapped Core registered
+// itself with the CheckedEntry.
+func (c *journalCore) Write(ent Entry, fields []Field) error {
+	buf, err := c.enc.EncodeEntry(ent, fields)
+	if err != nil {
+		return err
+	}
+	_, err = c.journal.Write(buf.Bytes())
+	buf.Free()
+	if err != nil {
+		return err
+	}
+	return c.journal.Sync()
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore_test
+
+import (
+	"testing"
+
+	"github.com/auwixcom/lad/internal/ztest"
+	//revive:disable:dot-imports
+	. "github.com/auwixcom/lad/ladcore"
+	"github.com/auwixcom/lad/ladtest/observer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJournalCore(t *testing.T) {
+	journal := &ztest.Buffer{}
+	obs, logs := observer.New(InfoLevel)
+	core := NewJournalCore(obs, NewJSONEncoder(EncoderConfig{MessageKey: "msg"}), journal).
+		With([]Field{makeInt64Field("k", 1)})
+	assert.Equal(t, InfoLevel, LevelOf(core), "Unexpected level.")
+
+	for _, lvl := range []Level{DebugLevel, ErrorLevel, PanicLevel, FatalLevel} {
+		if ce := core.Check(Entry{Level: lvl, Message: lvl.String()}, nil); ce != nil {
+			ce.Write(makeInt64Field("n", 2))
+		}
+	}
+
+	assert.Equal(t, []string{
+		`{"msg":"panic","k":1,"n":2}`,
+		`{"msg":"fatal","k":1,"n":2}`,
+	}, journal.Lines(), "Expected only terminal entries in the journal.")
+	assert.True(t, journal.Called(), "Expected the journal to be synced.")
+	assert.Equal(t, 3, logs.Len(), "Expected the wrapped core to write enabled entries.")
+}
+
+func TestJournalCoreFirst(t *testing.T) {
+	journal := &ztest.Buffer{}
+	var journaled []int
+	core := NewJournalCore(
+		RegisterHooks(NewNopCore(), func(Entry) error {
+			journaled = append(journaled, len(journal.Lines()))
+			return nil
+		}),
+		NewJSONEncoder(EncoderConfig{MessageKey: "msg"}),
+		journal,
+	)
+	if ce := core.Check(Entry{Level: FatalLevel, Message: "bye"}, nil); ce != nil {
+		ce.Write()
+	}
+	assert.Equal(t, []int{1}, journaled, "Expected the journal to be written before the wrapped core.")
+}
+
+func TestJournalCoreErrors(t *testing.T) {
+	core := NewJournalCore(NewNopCore(), NewJSONEncoder(EncoderConfig{MessageKey: "msg"}), &ztest.FailWriter{})
+	assert.Error(t, core.Write(Entry{Level: PanicLevel}, nil), "Expected journal write errors.")
+
+	core = NewJournalCore(NewNopCore(), NewJSONEncoder(EncoderConfig{MessageKey: "msg", RequireMessage: true}), &ztest.Buffer{})
+	require.Equal(t, ErrEmptyMessage, core.Write(Entry{Level: PanicLevel}, nil), "Expected encoding errors.")
+}
@@ -0,0 +1,293 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// _journaldSocket is where journald listens for its native protocol.
+const _journaldSocket = "/run/systemd/journal/socket"
+
+// _journaldReservedKeys are the fields that journald gives a meaning, or
+// that the core sets itself. Field keys that map to them are prefixed, so
+// that fields can't pass for the entry's message, priority, or origin.
+var _journaldReservedKeys = map[string]struct{}{
+	"MESSAGE":            {},
+	"MESSAGE_ID":         {},
+	"PRIORITY":           {},
+	"CODE_FILE":          {},
+	"CODE_LINE":          {},
+	"CODE_FUNC":          {},
+	"ERRNO":              {},
+	"INVOCATION_ID":      {},
+	"USER_INVOCATION_ID": {},
+	"SYSLOG_FACILITY":    {},
+	"SYSLOG_IDENTIFIER":  {},
+	"SYSLOG_PID":         {},
+	"SYSLOG_TIMESTAMP":   {},
+	"SYSLOG_RAW":         {},
+	"DOCUMENTATION":      {},
+	"TID":                {},
+	"UNIT":               {},
+	"USER_UNIT":          {},
+	"LOGGER":             {},
+	"STACKTRACE":         {},
+}
+
+var errJournaldUnsupported = errors.New("journald is only available on Linux")
+
+// A JournaldOption configures a core created by NewJournaldCore.
+type JournaldOption interface {
+	apply(*journaldOptions)
+}
+
+type journaldOptions struct {
+	identifier string
+	socket     string
+}
+
+// journaldOptionFunc wraps a func so it satisfies the JournaldOption
+// interface.
+type journaldOptionFunc func(*journaldOptions)
+
+func (f journaldOptionFunc) apply(o *journaldOptions) {
+	f(o)
+}
+
+// JournaldIdentifier sets the SYSLOG_IDENTIFIER of the entries, which
+// journalctl -t filters on. Defaults to the name of the executable.
+func JournaldIdentifier(id string) JournaldOption {
+	return journaldOptionFunc(func(o *journaldOptions) {
+		o.identifier = id
+	})
+}
+
+// JournaldSocket sets the path of journald's socket. Defaults to
+// /run/systemd/journal/socket.
+func JournaldSocket(path string) JournaldOption {
+	return journaldOptionFunc(func(o *journaldOptions) {
+		o.socket = path
+	})
+}
+
+// journalSender sends an encoded entry to journald.
+type journalSender interface {
+	send(datagram []byte) error
+	close() error
+}
+
+// NewJournaldCore creates a Core that writes to systemd-journald using its
+// native protocol, so that fields can be queried with journalctl instead of
+// being flattened into the message. Entries are mapped as follows:
+//
+//   - the message goes in MESSAGE, and the level in PRIORITY, as in
+//     SyslogSeverity
+//   - the logger name goes in LOGGER, the stack trace in STACKTRACE, and the
+//     caller in CODE_FILE, CODE_LINE, and CODE_FUNC
+//   - fields are keyed by their keys, uppercased, with characters other than
+//     letters, digits, and underscores replaced with underscores; journald
+//     reserves keys that start with underscores, so those are trimmed, and
+//     keys that would collide with the fields above or others journald
+//     interprets, like PRIORITY or SYSLOG_IDENTIFIER, are prefixed with F_
+//   - strings are written as-is, times in RFC 3339 format, durations as Go
+//     durations, and objects and arrays as JSON
+//
+// Values may contain newlines and arbitrary bytes. The returned Core
+// implements io.Closer, which closes the connection to journald; cores
+// created by its With method share it.
+//
+// On operating systems other than Linux, NewJournaldCore returns an error.
+func NewJournaldCore(enab LevelEnabler, opts ...JournaldOption) (Core, error) {
+	o := journaldOptions{
+		identifier: filepath.Base(os.Args[0]),
+		socket:     _journaldSocket,
+	}
+	for _, opt := range opts {
+		opt.apply(&o)
+	}
+	sender, err := dialJournald(o.socket)
+	if err != nil {
+		return nil, err
+	}
+	return newJournaldCore(enab, sender, o.identifier), nil
+}
+
+func newJournaldCore(enab LevelEnabler, sender journalSender, identifier string) *journaldCore {
+	return &journaldCore{LevelEnabler: enab, sender: sender, identifier: identifier}
+}
+
+type journaldCore struct {
+	LevelEnabler
+
+	sender     journalSender
+	identifier string
+	context    []Field
+}
+
+var (
+	_ Core           = (*journaldCore)(nil)
+	_ leveledEnabler = (*journaldCore)(nil)
+)
+
+func (c *journaldCore) Level() Level {
+	return LevelOf(c.LevelEnabler)
+}
+
+func (c *journaldCore) With(fields []Field) Core {
+	clone := *c
+	clone.context = append(c.context[:len(c.context):len(c.context)], fields...)
+	return &clone
+}
+
+func (c *journaldCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *journaldCore) Write(ent Entry, fields []Field) error {
+	return c.sender.send(c.encode(ent, fields))
+}
+
+// Sync is a no-op: each entry is sent to journald as it's written.
+func (c *journaldCore) Sync() error {
+	return nil
+}
+
+func (c *journaldCore) Close() error {
+	return c.sender.close()
+}
+
+// encode builds the native protocol datagram for an entry.
+func (c *journaldCore) encode(ent Entry, fields []Field) []byte {
+	var b []byte
+	b = appendJournalField(b, "MESSAGE", ent.Message)
+	b = appendJournalField(b, "PRIORITY", strconv.Itoa(SyslogSeverity.Number(ent.Level)))
+	if c.identifier != "" {
+		b = appendJournalField(b, "SYSLOG_IDENTIFIER", c.identifier)
+	}
+	if ent.LoggerName != "" {
+		b = appendJournalField(b, "LOGGER", ent.LoggerName)
+	}
+	if ent.Caller.Defined {
+		b = appendJournalField(b, "CODE_FILE", ent.Caller.File)
+		b = appendJournalField(b, "CODE_LINE", strconv.Itoa(ent.Caller.Line))
+		if ent.Caller.Function != "" {
+			b = appendJournalField(b, "CODE_FUNC", ent.Caller.Function)
+		}
+	}
+	if ent.Stack != "" {
+		b = appendJournalField(b, "STACKTRACE", ent.Stack)
+	}
+
+	enc := NewMapObjectEncoder()
+	addFields(enc, c.context)
+	addFields(enc, fields)
+	keys := make([]string, 0, len(enc.Fields))
+	for k := range enc.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if key := journalKey(k); key != "" {
+			b = appendJournalField(b, key, journalValue(enc.Fields[k]))
+		}
+	}
+	return b
+}
+
+// journalKey converts a field key to a valid journald field name: uppercase
+// letters, digits, and underscores, not starting with an underscore or a
+// digit, at most 64 bytes long, and not one of the reserved keys. It returns
+// an empty string if nothing is left of the key.
+func journalKey(key string) string {
+	b := make([]byte, 0, len(key))
+	for i := 0; i < len(key); i++ {
+		switch c := key[i]; {
+		case c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '_':
+			b = append(b, c)
+		case c >= 'a' && c <= 'z':
+			b = append(b, c-'a'+'A')
+		default:
+			b = append(b, '_')
+		}
+	}
+	s := strings.TrimLeft(string(b), "_")
+	if s != "" && s[0] >= '0' && s[0] <= '9' {
+		s = "F_" + s
+	} else if _, ok := _journaldReservedKeys[s]; ok {
+		s = "F_" + s
+	}
+	if len(s) > 64 {
+		s = s[:64]
+	}
+	return s
+}
+
+// journalValue formats a value from a MapObjectEncoder.
+func journalValue(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case time.Duration:
+		return v.String()
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, uintptr,
+		float32, float64, complex64, complex128:
+		return fmt.Sprint(v)
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+// appendJournalField appends a field in the native protocol's format:
+// KEY=value on a line, or, if the value contains a newline, the key on a line
+// followed by the value's length as a little-endian uint64, the value, and a
+// newline.
+func appendJournalField(b []byte, key, value string) []byte {
+	b = append(b, key...)
+	if !strings.ContainsRune(value, '\n') {
+		b = append(b, '=')
+		b = append(b, value...)
+		return append(b, '\n')
+	}
+	b = append(b, '\n')
+	b = binary.LittleEndian.AppendUint64(b, uint64(len(value)))
+	b = append(b, value...)
+	return append(b, '\n')
+}
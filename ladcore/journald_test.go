@@ -0,0 +1,166 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeJournal struct {
+	datagrams []string
+	closed    bool
+}
+
+func (j *fakeJournal) send(datagram []byte) error {
+	j.datagrams = append(j.datagrams, string(datagram))
+	return nil
+}
+
+func (j *fakeJournal) close() error {
+	j.closed = true
+	return nil
+}
+
+func TestJournaldCore(t *testing.T) {
+	journal := &fakeJournal{}
+	core := newJournaldCore(InfoLevel, journal, "app").With([]Field{
+		{Key: "request-id", Type: StringType, String: "abc"},
+	})
+	assert.Equal(t, InfoLevel, LevelOf(core), "Unexpected level.")
+
+	ent := Entry{
+		Level:      WarnLevel,
+		LoggerName: "db",
+		Message:    "slow query",
+		Caller:     EntryCaller{Defined: true, File: "db.go", Line: 42, Function: "db.Query"},
+	}
+	if ce := core.Check(ent, nil); ce != nil {
+		ce.Write(
+			Field{Key: "elapsed", Type: DurationType, Integer: int64(1500 * time.Millisecond)},
+			Field{Key: "rows", Type: Int64Type, Integer: 3},
+			Field{Key: "query", Type: StringType, String: "SELECT 1\nFROM t"},
+		)
+	}
+	assert.Nil(t, core.Check(Entry{Level: DebugLevel}, nil), "Expected debug entries to be disabled.")
+
+	require.Len(t, journal.datagrams, 1, "Unexpected number of entries.")
+	query := "SELECT 1\nFROM t"
+	length := binary.LittleEndian.AppendUint64(nil, uint64(len(query)))
+	assert.Equal(t, strings.Join([]string{
+		"MESSAGE=slow query",
+		"PRIORITY=4",
+		"SYSLOG_IDENTIFIER=app",
+		"LOGGER=db",
+		"CODE_FILE=db.go",
+		"CODE_LINE=42",
+		"CODE_FUNC=db.Query",
+		"ELAPSED=1.5s",
+		"QUERY\n" + string(length) + query,
+		"REQUEST_ID=abc",
+		"ROWS=3",
+		"",
+	}, "\n"), journal.datagrams[0], "Unexpected datagram.")
+
+	assert.NoError(t, core.Sync(), "Unexpected error syncing.")
+	require.Implements(t, (*io.Closer)(nil), core, "Expected the core to be closable.")
+	assert.NoError(t, core.(io.Closer).Close(), "Unexpected error closing.")
+	assert.True(t, journal.closed, "Expected closing to close the connection.")
+}
+
+func TestJournalKey(t *testing.T) {
+	tests := map[string]string{
+		"user_id":               "USER_ID",
+		"http.status":           "HTTP_STATUS",
+		"_SYSTEMD_UNIT":         "SYSTEMD_UNIT",
+		"2fa":                   "F_2FA",
+		"___":                   "",
+		"message":               "F_MESSAGE",
+		"priority":              "F_PRIORITY",
+		"code.file":             "F_CODE_FILE",
+		"_syslog_identifier":    "F_SYSLOG_IDENTIFIER",
+		"messages":              "MESSAGES",
+		strings.Repeat("k", 70): strings.Repeat("K", 64),
+	}
+	for key, want := range tests {
+		assert.Equal(t, want, journalKey(key), "Unexpected journald key for %q.", key)
+	}
+}
+
+func TestJournalPriority(t *testing.T) {
+	core := newJournaldCore(DebugLevel, &fakeJournal{}, "")
+	tests := map[Level]string{
+		DebugLevel:  "PRIORITY=7",
+		InfoLevel:   "PRIORITY=6",
+		WarnLevel:   "PRIORITY=4",
+		ErrorLevel:  "PRIORITY=3",
+		DPanicLevel: "PRIORITY=2",
+		PanicLevel:  "PRIORITY=1",
+		FatalLevel:  "PRIORITY=0",
+	}
+	for lvl, want := range tests {
+		lines := strings.Split(string(core.encode(Entry{Level: lvl}, nil)), "\n")
+		assert.Equal(t, want, lines[1], "Expected syslog severities for %v.", lvl)
+	}
+}
+
+func TestJournalValue(t *testing.T) {
+	ts := time.Date(2026, 10, 15, 10, 0, 0, 0, time.UTC)
+	assert.Equal(t, "2026-10-15T10:00:00Z", journalValue(ts), "Unexpected time.")
+	assert.Equal(t, "true", journalValue(true), "Unexpected bool.")
+	assert.Equal(t, `{"a":[1,2]}`, journalValue(map[string]interface{}{"a": []interface{}{1, 2}}), "Expected objects as JSON.")
+	assert.Equal(t, "raw", journalValue([]byte("raw")), "Unexpected bytes.")
+}
+
+func TestNewJournaldCore(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		_, err := NewJournaldCore(InfoLevel)
+		assert.ErrorIs(t, err, errJournaldUnsupported, "Expected an error outside Linux.")
+		return
+	}
+
+	socket := filepath.Join(t.TempDir(), "journal.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socket, Net: "unixgram"})
+	require.NoError(t, err, "Unexpected error listening.")
+	defer conn.Close()
+
+	core, err := NewJournaldCore(InfoLevel, JournaldSocket(socket), JournaldIdentifier("svc"))
+	require.NoError(t, err, "Unexpected error connecting.")
+	defer core.(io.Closer).Close()
+	require.NoError(t, core.Write(Entry{Level: InfoLevel, Message: "hello"}, nil), "Unexpected error writing.")
+
+	buf := make([]byte, 1024)
+	n, err := conn.Read(buf)
+	require.NoError(t, err, "Unexpected error reading.")
+	assert.Equal(t, "MESSAGE=hello\nPRIORITY=6\nSYSLOG_IDENTIFIER=svc\n", string(buf[:n]), "Unexpected datagram.")
+
+	_, err = NewJournaldCore(InfoLevel, JournaldSocket(filepath.Join(t.TempDir(), "missing.sock")))
+	assert.ErrorContains(t, err, "connect to journald", "Expected an error without journald.")
+}
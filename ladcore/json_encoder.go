@@ -21,9 +21,12 @@
 package ladcore
 
 import (
+	"bytes"
 	"encoding/base64"
 	"math"
+	"strconv"
 	"time"
+	"unicode/utf16"
 	"unicode/utf8"
 
 	"github.com/auwixcom/lad/buffer"
@@ -46,6 +49,7 @@ func putJSONEncoder(enc *jsonEncoder) {
 	enc.buf = nil
 	enc.spaced = false
 	enc.openNamespaces = 0
+	enc.namespace = ""
 	enc.reflectBuf = nil
 	enc.reflectEnc = nil
 	_jsonPool.Put(enc)
@@ -56,6 +60,7 @@ type jsonEncoder struct {
 	buf            *buffer.Buffer
 	spaced         bool // include spaces after colons and commas
 	openNamespaces int
+	namespace      string // dotted key prefix, see FlattenNamespaces
 
 	// for encoding generic values by reflection
 	reflectBuf *buffer.Buffer
@@ -177,6 +182,10 @@ func (enc *jsonEncoder) encodeReflected(obj interface{}) ([]byte, error) {
 }
 
 func (enc *jsonEncoder) AddReflected(key string, obj interface{}) error {
+	if s, ok := redacted(obj); ok {
+		enc.AddString(key, s)
+		return nil
+	}
 	valueBytes, err := enc.encodeReflected(obj)
 	if err != nil {
 		return err
@@ -187,6 +196,10 @@ func (enc *jsonEncoder) AddReflected(key string, obj interface{}) error {
 }
 
 func (enc *jsonEncoder) OpenNamespace(key string) {
+	if enc.FlattenNamespaces {
+		enc.namespace += key + "."
+		return
+	}
 	enc.addKey(key)
 	enc.buf.AppendByte('{')
 	enc.openNamespaces++
@@ -208,6 +221,10 @@ func (enc *jsonEncoder) AddUint64(key string, val uint64) {
 }
 
 func (enc *jsonEncoder) AppendArray(arr ArrayMarshaler) error {
+	if s, ok := redacted(arr); ok {
+		enc.AppendString(s)
+		return nil
+	}
 	enc.addElementSeparator()
 	enc.buf.AppendByte('[')
 	err := arr.MarshalLogArray(enc)
@@ -216,16 +233,22 @@ func (enc *jsonEncoder) AppendArray(arr ArrayMarshaler) error {
 }
 
 func (enc *jsonEncoder) AppendObject(obj ObjectMarshaler) error {
+	if s, ok := redacted(obj); ok {
+		enc.AppendString(s)
+		return nil
+	}
 	// Close ONLY new openNamespaces that are created during
 	// AppendObject().
-	old := enc.openNamespaces
+	old, oldNamespace := enc.openNamespaces, enc.namespace
 	enc.openNamespaces = 0
+	enc.namespace = ""
 	enc.addElementSeparator()
 	enc.buf.AppendByte('{')
 	err := obj.MarshalLogObject(enc)
 	enc.buf.AppendByte('}')
 	enc.closeOpenNamespaces()
 	enc.openNamespaces = old
+	enc.namespace = oldNamespace
 	return err
 }
 
@@ -276,10 +299,19 @@ func (enc *jsonEncoder) AppendDuration(val time.Duration) {
 
 func (enc *jsonEncoder) AppendInt64(val int64) {
 	enc.addElementSeparator()
+	if enc.displayNumbers() {
+		var scratch [24]byte
+		enc.appendDisplayNumber(strconv.AppendInt(scratch[:0], val, 10))
+		return
+	}
 	enc.buf.AppendInt(val)
 }
 
 func (enc *jsonEncoder) AppendReflected(val interface{}) error {
+	if s, ok := redacted(val); ok {
+		enc.AppendString(s)
+		return nil
+	}
 	valueBytes, err := enc.encodeReflected(val)
 	if err != nil {
 		return err
@@ -317,6 +349,11 @@ func (enc *jsonEncoder) AppendTime(val time.Time) {
 
 func (enc *jsonEncoder) AppendUint64(val uint64) {
 	enc.addElementSeparator()
+	if enc.displayNumbers() {
+		var scratch [24]byte
+		enc.appendDisplayNumber(strconv.AppendUint(scratch[:0], val, 10))
+		return
+	}
 	enc.buf.AppendUint(val)
 }
 
@@ -354,12 +391,18 @@ func (enc *jsonEncoder) clone() *jsonEncoder {
 	clone.EncoderConfig = enc.EncoderConfig
 	clone.spaced = enc.spaced
 	clone.openNamespaces = enc.openNamespaces
+	clone.namespace = enc.namespace
 	clone.buf = bufferpool.Get()
 	return clone
 }
 
 func (enc *jsonEncoder) EncodeEntry(ent Entry, fields []Field) (*buffer.Buffer, error) {
+	if enc.RequireMessage && ent.Message == "" {
+		return nil, ErrEmptyMessage
+	}
+
 	final := enc.clone()
+	final.namespace = "" // the entry's own keys are never namespaced
 	final.buf.AppendByte('{')
 
 	if final.LevelKey != "" && final.EncodeLevel != nil {
@@ -408,8 +451,11 @@ func (enc *jsonEncoder) EncodeEntry(ent Entry, fields []Field) (*buffer.Buffer,
 			final.addKey(final.FunctionKey)
 			final.AppendString(ent.Caller.Function)
 		}
+	} else if ent.Caller.Function != "" {
+		final.addKey(final.functionKey())
+		final.AppendString(ent.Caller.Function)
 	}
-	if final.MessageKey != "" {
+	if final.MessageKey != "" && (ent.Message != "" || !final.OmitEmptyMessage) {
 		final.addKey(enc.MessageKey)
 		final.AppendString(ent.Message)
 	}
@@ -417,12 +463,16 @@ func (enc *jsonEncoder) EncodeEntry(ent Entry, fields []Field) (*buffer.Buffer,
 		final.addElementSeparator()
 		final.buf.Write(enc.buf.Bytes())
 	}
+	final.namespace = enc.namespace
 	addFields(final, fields)
 	final.closeOpenNamespaces()
 	if ent.Stack != "" && final.StacktraceKey != "" {
 		final.AddString(final.StacktraceKey, ent.Stack)
 	}
 	final.buf.AppendByte('}')
+	if final.ASCIIOnly {
+		final.buf = escapeNonASCII(final.buf)
+	}
 	final.buf.AppendString(final.LineEnding)
 
 	ret := final.buf
@@ -434,16 +484,28 @@ func (enc *jsonEncoder) truncate() {
 	enc.buf.Reset()
 }
 
+// functionKey returns the key for entries whose caller only has a function.
+func (enc *jsonEncoder) functionKey() string {
+	if enc.FunctionKey != "" {
+		return enc.FunctionKey
+	}
+	return DefaultFunctionKey
+}
+
 func (enc *jsonEncoder) closeOpenNamespaces() {
 	for i := 0; i < enc.openNamespaces; i++ {
 		enc.buf.AppendByte('}')
 	}
 	enc.openNamespaces = 0
+	enc.namespace = ""
 }
 
 func (enc *jsonEncoder) addKey(key string) {
 	enc.addElementSeparator()
 	enc.buf.AppendByte('"')
+	if enc.namespace != "" {
+		enc.safeAddString(enc.namespace)
+	}
 	enc.safeAddString(key)
 	enc.buf.AppendByte('"')
 	enc.buf.AppendByte(':')
@@ -477,11 +539,53 @@ func (enc *jsonEncoder) appendFloat(val float64, bitSize int) {
 		enc.buf.AppendString(`"+Inf"`)
 	case math.IsInf(val, -1):
 		enc.buf.AppendString(`"-Inf"`)
+	case enc.displayNumbers():
+		prec := -1
+		if enc.ConsoleFloatPrecision > 0 {
+			prec = enc.ConsoleFloatPrecision
+		}
+		var scratch [64]byte
+		enc.appendDisplayNumber(strconv.AppendFloat(scratch[:0], val, 'f', prec, bitSize))
 	default:
 		enc.buf.AppendFloat(val, bitSize)
 	}
 }
 
+// displayNumbers reports whether numbers should be formatted for display,
+// which only the console encoder does.
+func (enc *jsonEncoder) displayNumbers() bool {
+	return enc.spaced && (enc.ConsoleThousandsSeparator != "" ||
+		enc.ConsoleDecimalSeparator != "" ||
+		enc.ConsoleFloatPrecision > 0)
+}
+
+// appendDisplayNumber appends num, a number formatted by strconv without an
+// exponent, with the configured thousands and decimal separators.
+func (enc *jsonEncoder) appendDisplayNumber(num []byte) {
+	if len(num) > 0 && num[0] == '-' {
+		enc.buf.AppendByte('-')
+		num = num[1:]
+	}
+	whole := bytes.IndexByte(num, '.')
+	if whole < 0 {
+		whole = len(num)
+	}
+	for i := 0; i < whole; i++ {
+		if i > 0 && (whole-i)%3 == 0 {
+			enc.buf.AppendString(enc.ConsoleThousandsSeparator)
+		}
+		enc.buf.AppendByte(num[i])
+	}
+	if whole < len(num) {
+		if enc.ConsoleDecimalSeparator != "" {
+			enc.buf.AppendString(enc.ConsoleDecimalSeparator)
+		} else {
+			enc.buf.AppendByte('.')
+		}
+		enc.buf.Write(num[whole+1:])
+	}
+}
+
 // safeAddString JSON-escapes a string and appends it to the internal buffer.
 // Unlike the standard library's encoder, it doesn't attempt to protect the
 // user from browser vulnerabilities or JSONP-related problems.
@@ -504,6 +608,50 @@ func (enc *jsonEncoder) safeAddByteString(s []byte) {
 	)
 }
 
+// escapeNonASCII returns buf with every non-ASCII character replaced by a
+// \uXXXX escape, freeing buf if it needs to make a copy. Since JSON only
+// allows such characters inside strings, escaping them after encoding keeps
+// the output valid, including the output of reflected encoders.
+func escapeNonASCII(buf *buffer.Buffer) *buffer.Buffer {
+	bs := buf.Bytes()
+	i := 0
+	for i < len(bs) && bs[i] < utf8.RuneSelf {
+		i++
+	}
+	if i == len(bs) {
+		return buf
+	}
+
+	out := bufferpool.Get()
+	out.Write(bs[:i])
+	for i < len(bs) {
+		if bs[i] < utf8.RuneSelf {
+			out.AppendByte(bs[i])
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRune(bs[i:])
+		if r >= 0x10000 {
+			r1, r2 := utf16.EncodeRune(r)
+			appendUnicodeEscape(out, r1)
+			appendUnicodeEscape(out, r2)
+		} else {
+			appendUnicodeEscape(out, r)
+		}
+		i += size
+	}
+	buf.Free()
+	return out
+}
+
+func appendUnicodeEscape(buf *buffer.Buffer, r rune) {
+	buf.AppendString(`\u`)
+	buf.AppendByte(_hex[r>>12&0xF])
+	buf.AppendByte(_hex[r>>8&0xF])
+	buf.AppendByte(_hex[r>>4&0xF])
+	buf.AppendByte(_hex[r&0xF])
+}
+
 // safeAppendStringLike is a generic implementation of safeAddString and safeAddByteString.
 // It appends a string or byte slice to the buffer, escaping all special characters.
 func safeAppendStringLike[S []byte | string](
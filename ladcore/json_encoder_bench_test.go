@@ -43,7 +43,7 @@ func BenchmarkJSONLogMarshalerFunc(b *testing.B) {
 	}
 }
 
-func BenchmarkladJSONFloat32AndComplex64(b *testing.B) {
+func BenchmarkLadJSONFloat32AndComplex64(b *testing.B) {
 	b.RunParallel(func(pb *testing.PB) {
 		for pb.Next() {
 			enc := NewJSONEncoder(testEncoderConfig())
@@ -72,7 +72,7 @@ func generateStringSlice(n int) StringSlice {
 	return output
 }
 
-func BenchmarkladJSON(b *testing.B) {
+func BenchmarkLadJSON(b *testing.B) {
 	additional := generateStringSlice(_sliceSize)
 	b.ResetTimer()
 	b.RunParallel(func(pb *testing.PB) {
@@ -97,6 +97,31 @@ func BenchmarkladJSON(b *testing.B) {
 	})
 }
 
+func BenchmarkLadMsgpack(b *testing.B) {
+	additional := generateStringSlice(_sliceSize)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			enc := NewMsgpackEncoder(testEncoderConfig())
+			enc.AddString("str", "foo")
+			enc.AddInt64("int64-1", 1)
+			enc.AddInt64("int64-2", 2)
+			enc.AddFloat64("float64", 1.0)
+			enc.AddString("string1", "\n")
+			enc.AddString("string2", "💩")
+			enc.AddString("string3", "🤔")
+			enc.AddString("string4", "🙊")
+			enc.AddBool("bool", true)
+			_ = enc.AddArray("test", additional)
+			buf, _ := enc.EncodeEntry(Entry{
+				Message: "fake",
+				Level:   DebugLevel,
+			}, nil)
+			buf.Free()
+		}
+	})
+}
+
 func BenchmarkStandardJSON(b *testing.B) {
 	record := struct {
 		Level      string                 `json:"level"`
@@ -25,6 +25,7 @@ import (
 	"errors"
 	"math"
 	"math/rand"
+	"net/netip"
 	"reflect"
 	"testing"
 	"testing/quick"
@@ -33,6 +34,7 @@ import (
 
 	"github.com/auwixcom/lad/buffer"
 	"github.com/auwixcom/lad/internal/bufferpool"
+	"github.com/auwixcom/lad/internal/ztest"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -734,3 +736,42 @@ func fuzzSafeAppendStringLike(
 	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
 	assert.Equal(t, want, got)
 }
+
+func TestNetIPEncodingAllocs(t *testing.T) {
+	ztest.SkipIfRace(t)
+
+	enc := newJSONEncoder(EncoderConfig{}, false)
+	defer enc.buf.Free()
+
+	fields := []Field{
+		{Key: "addr", Type: NetIPAddrType, Interface: netip.MustParseAddr("2001:db8::1")},
+		{Key: "prefix", Type: NetIPPrefixType, Interface: netip.MustParsePrefix("192.0.2.0/24")},
+	}
+	allocs := testing.AllocsPerRun(100, func() {
+		addFields(enc, fields)
+		enc.buf.Reset()
+	})
+	assert.Zero(t, allocs, "Encoding netip values shouldn't allocate.")
+
+	addFields(enc, fields)
+	assert.Equal(t, `"addr":"2001:db8::1","prefix":"192.0.2.0/24"`, enc.buf.String(), "Unexpected netip encoding.")
+}
+
+func TestUUIDEncodingAllocs(t *testing.T) {
+	ztest.SkipIfRace(t)
+
+	enc := newJSONEncoder(EncoderConfig{}, false)
+	defer enc.buf.Free()
+
+	u := [16]byte{0xde, 0xad, 0xbe, 0xef, 15: 0xff}
+	var f Field
+	allocs := testing.AllocsPerRun(100, func() {
+		f = UUIDField("id", u)
+		f.AddTo(enc)
+		enc.buf.Reset()
+	})
+	assert.Zero(t, allocs, "Constructing and encoding a UUID shouldn't allocate.")
+
+	f.AddTo(enc)
+	assert.Equal(t, `"id":"deadbeef-0000-0000-0000-0000000000ff"`, enc.buf.String(), "Unexpected UUID encoding.")
+}
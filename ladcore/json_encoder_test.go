@@ -28,6 +28,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/auwixcom/lad"
+	"github.com/auwixcom/lad/internal/ztest"
 	"github.com/auwixcom/lad/ladcore"
 )
 
@@ -280,3 +281,146 @@ func TestJSONCustomReflectedEncoder(t *testing.T) {
 		})
 	}
 }
+
+func TestJSONEncoderEmptyMessage(t *testing.T) {
+	ent := ladcore.Entry{Level: ladcore.InfoLevel}
+
+	tests := []struct {
+		desc     string
+		omit     bool
+		require  bool
+		msg      string
+		expected string
+		err      error
+	}{
+		{desc: "default", expected: `{"level":"info","msg":""}` + "\n"},
+		{desc: "omitted", omit: true, expected: `{"level":"info"}` + "\n"},
+		{desc: "omit keeps messages", omit: true, msg: "hi", expected: `{"level":"info","msg":"hi"}` + "\n"},
+		{desc: "required", require: true, err: ladcore.ErrEmptyMessage},
+		{desc: "required and present", require: true, msg: "hi", expected: `{"level":"info","msg":"hi"}` + "\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			enc := ladcore.NewJSONEncoder(ladcore.EncoderConfig{
+				LevelKey:         "level",
+				MessageKey:       "msg",
+				EncodeLevel:      ladcore.LowercaseLevelEncoder,
+				OmitEmptyMessage: tt.omit,
+				RequireMessage:   tt.require,
+			})
+			ent := ent
+			ent.Message = tt.msg
+			buf, err := enc.EncodeEntry(ent, nil)
+			if tt.err != nil {
+				assert.Equal(t, tt.err, err, "Unexpected error.")
+				return
+			}
+			if assert.NoError(t, err, "Unexpected JSON encoding error.") {
+				assert.Equal(t, tt.expected, buf.String(), "Incorrect encoded entry.")
+				buf.Free()
+			}
+		})
+	}
+}
+
+func TestJSONEncoderASCIIOnly(t *testing.T) {
+	enc := ladcore.NewJSONEncoder(ladcore.EncoderConfig{
+		MessageKey: "msg",
+		LineEnding: ladcore.CRLFLineEnding,
+		ASCIIOnly:  true,
+	})
+	buf, err := enc.EncodeEntry(ladcore.Entry{Message: "café 🚀"}, []ladcore.Field{
+		lad.String("ключ", "значение"),
+		lad.Any("reflected", map[string]string{"ü": "ö"}),
+		lad.String("plain", "ascii"),
+	})
+	if assert.NoError(t, err, "Unexpected JSON encoding error.") {
+		assert.Equal(t,
+			`{"msg":"caf\u00e9 \ud83d\ude80",`+
+				`"\u043a\u043b\u044e\u0447":"\u0437\u043d\u0430\u0447\u0435\u043d\u0438\u0435",`+
+				`"reflected":{"\u00fc":"\u00f6"},"plain":"ascii"}`+"\r\n",
+			buf.String(), "Expected non-ASCII characters to be escaped.")
+		buf.Free()
+	}
+}
+
+func TestJSONEncoderFlattenNamespaces(t *testing.T) {
+	enc := ladcore.NewJSONEncoder(ladcore.EncoderConfig{
+		MessageKey:        "msg",
+		StacktraceKey:     "stacktrace",
+		FlattenNamespaces: true,
+	})
+	enc.OpenNamespace("http")
+	enc.AddString("method", "GET")
+
+	buf, err := enc.EncodeEntry(ladcore.Entry{Message: "hello", Stack: "fake-stack"}, []ladcore.Field{
+		lad.Int("status", 200),
+		lad.Namespace("request"),
+		lad.String("path", "/"),
+		lad.Object("obj", ladcore.ObjectMarshalerFunc(func(enc ladcore.ObjectEncoder) error {
+			enc.OpenNamespace("inner")
+			enc.AddBool("ok", true)
+			return nil
+		})),
+		lad.Int("size", 2),
+	})
+	if assert.NoError(t, err, "Unexpected JSON encoding error.") {
+		assert.Equal(t,
+			`{"msg":"hello","http.method":"GET","http.status":200,"http.request.path":"/",`+
+				`"http.request.obj":{"inner.ok":true},"http.request.size":2,"stacktrace":"fake-stack"}`+"\n",
+			buf.String(), "Expected namespaced keys to be flattened.")
+		buf.Free()
+	}
+
+	// The encoder's own namespace is unaffected by encoding entries.
+	buf, err = enc.EncodeEntry(ladcore.Entry{Message: "again"}, []ladcore.Field{lad.Int("status", 404)})
+	if assert.NoError(t, err, "Unexpected JSON encoding error.") {
+		assert.Equal(t, `{"msg":"again","http.method":"GET","http.status":404}`+"\n", buf.String(), "Unexpected output.")
+		buf.Free()
+	}
+}
+
+// snakeTruncate is an EncoderConfig.FieldEncoder that renames camelCase keys
+// to snake_case, truncates long strings, and drops "secret" fields.
+func snakeTruncate(f ladcore.Field) ladcore.Field {
+	if f.Key == "secret" {
+		return lad.Skip()
+	}
+	var key []byte
+	for i := 0; i < len(f.Key); i++ {
+		if c := f.Key[i]; 'A' <= c && c <= 'Z' {
+			key = append(key, '_', c+'a'-'A')
+		} else {
+			key = append(key, c)
+		}
+	}
+	f.Key = string(key)
+	if f.Type == ladcore.StringType && len(f.String) > 5 {
+		f.String = f.String[:5] + "…"
+	}
+	return f
+}
+
+func TestJSONEncoderFieldEncoder(t *testing.T) {
+	buf := &ztest.Buffer{}
+	core := ladcore.NewCore(
+		ladcore.NewJSONEncoder(ladcore.EncoderConfig{MessageKey: "msg", FieldEncoder: snakeTruncate}),
+		buf,
+		ladcore.DebugLevel,
+	).With([]ladcore.Field{lad.String("requestId", "abcdefgh"), lad.String("secret", "hunter2")})
+
+	fields := []ladcore.Field{
+		lad.Int("statusCode", 200),
+		lad.Namespace("httpRequest"),
+		lad.Object("userAgent", ladcore.ObjectMarshalerFunc(func(enc ladcore.ObjectEncoder) error {
+			enc.AddString("browserName", "firefox")
+			return nil
+		})),
+	}
+	assert.NoError(t, core.Write(ladcore.Entry{Message: "hello"}, fields), "Unexpected error writing.")
+	assert.Equal(t,
+		`{"msg":"hello","request_id":"abcde…","status_code":200,"http_request":{"user_agent":{"browserName":"firefox"}}}`+"\n",
+		buf.String(), "Expected top-level fields to be transformed.")
+	assert.Equal(t, "statusCode", fields[0].Key, "Expected the caller's fields to be unchanged.")
+}
@@ -31,6 +31,31 @@ type ObjectMarshaler interface {
 	MarshalLogObject(ObjectEncoder) error
 }
 
+// LazyFieldMarshaler is implemented by the values of fields that are computed
+// when they're first needed, like those built with lad.Lazy. Such fields have
+// InlineMarshalerType, and marshaling them adds the computed field. Cores that
+// inspect field values rather than encode them, like the redacting and
+// secret-detecting cores, call ResolveField to see the computed field.
+//
+// ResolveField computes the field at most once, so every Core that handles an
+// entry sees the same value.
+type LazyFieldMarshaler interface {
+	ObjectMarshaler
+
+	ResolveField() Field
+}
+
+// resolveLazy returns the computed field if f is lazy, and f otherwise.
+func resolveLazy(f Field) (Field, bool) {
+	if f.Type != InlineMarshalerType {
+		return f, false
+	}
+	if lazy, ok := f.Interface.(LazyFieldMarshaler); ok {
+		return lazy.ResolveField(), true
+	}
+	return f, false
+}
+
 // ObjectMarshalerFunc is a type adapter that turns a function into an
 // ObjectMarshaler.
 type ObjectMarshalerFunc func(ObjectEncoder) error
@@ -43,6 +43,10 @@ func NewMapObjectEncoder() *MapObjectEncoder {
 
 // AddArray implements ObjectEncoder.
 func (m *MapObjectEncoder) AddArray(key string, v ArrayMarshaler) error {
+	if s, ok := redacted(v); ok {
+		m.cur[key] = s
+		return nil
+	}
 	arr := &sliceArrayEncoder{elems: make([]interface{}, 0)}
 	err := v.MarshalLogArray(arr)
 	m.cur[key] = arr.elems
@@ -51,6 +55,10 @@ func (m *MapObjectEncoder) AddArray(key string, v ArrayMarshaler) error {
 
 // AddObject implements ObjectEncoder.
 func (m *MapObjectEncoder) AddObject(k string, v ObjectMarshaler) error {
+	if s, ok := redacted(v); ok {
+		m.cur[k] = s
+		return nil
+	}
 	newMap := NewMapObjectEncoder()
 	m.cur[k] = newMap.Fields
 	return v.MarshalLogObject(newMap)
@@ -121,6 +129,9 @@ func (m *MapObjectEncoder) AddUintptr(k string, v uintptr) { m.cur[k] = v }
 
 // AddReflected implements ObjectEncoder.
 func (m *MapObjectEncoder) AddReflected(k string, v interface{}) error {
+	if s, ok := redacted(v); ok {
+		v = s
+	}
 	m.cur[k] = v
 	return nil
 }
@@ -139,6 +150,10 @@ type sliceArrayEncoder struct {
 }
 
 func (s *sliceArrayEncoder) AppendArray(v ArrayMarshaler) error {
+	if r, ok := redacted(v); ok {
+		s.elems = append(s.elems, r)
+		return nil
+	}
 	enc := &sliceArrayEncoder{}
 	err := v.MarshalLogArray(enc)
 	s.elems = append(s.elems, enc.elems)
@@ -146,6 +161,10 @@ func (s *sliceArrayEncoder) AppendArray(v ArrayMarshaler) error {
 }
 
 func (s *sliceArrayEncoder) AppendObject(v ObjectMarshaler) error {
+	if r, ok := redacted(v); ok {
+		s.elems = append(s.elems, r)
+		return nil
+	}
 	m := NewMapObjectEncoder()
 	err := v.MarshalLogObject(m)
 	s.elems = append(s.elems, m.Fields)
@@ -153,6 +172,9 @@ func (s *sliceArrayEncoder) AppendObject(v ObjectMarshaler) error {
 }
 
 func (s *sliceArrayEncoder) AppendReflected(v interface{}) error {
+	if r, ok := redacted(v); ok {
+		v = r
+	}
 	s.elems = append(s.elems, v)
 	return nil
 }
@@ -0,0 +1,138 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore
+
+import "math"
+
+// MetricType identifies fields that carry a metric measurement in addition to
+// their value. Such fields are constructed with lad.Count and lad.Gauge.
+//
+// Encoders treat metric fields like any other number; cores that care about
+// metrics, like the one returned by NewMetricsCore, use MetricTypeOf to pick
+// them out.
+type MetricType uint8
+
+const (
+	// NotMetric indicates that a field doesn't carry a metric.
+	NotMetric MetricType = iota
+	// CounterMetric indicates that a field carries an increment to a counter,
+	// stored as an Int64Type field.
+	CounterMetric
+	// GaugeMetric indicates that a field carries the current value of a gauge,
+	// stored as a Float64Type field.
+	GaugeMetric
+)
+
+// MetricTypeOf reports which kind of metric, if any, the field carries.
+func MetricTypeOf(f Field) MetricType {
+	switch t, _ := f.Interface.(MetricType); {
+	case t == CounterMetric && f.Type == Int64Type:
+		return CounterMetric
+	case t == GaugeMetric && f.Type == Float64Type:
+		return GaugeMetric
+	default:
+		return NotMetric
+	}
+}
+
+// A MetricRecorder receives the metrics extracted from log entries by a
+// metrics Core. Implementations typically forward them to a metrics system,
+// like Prometheus or StatsD, and must be safe for concurrent use.
+type MetricRecorder interface {
+	// Count adds delta to the named counter.
+	Count(name string, delta int64)
+	// Gauge sets the named gauge to value.
+	Gauge(name string, value float64)
+}
+
+// NewMetricsCore creates a Core that extracts the metric fields from each
+// enabled entry and reports them to the MetricRecorder. Metrics are named
+// after their field keys. The Core doesn't write the entries themselves, so
+// it's typically combined with other Cores using NewTee.
+//
+// Gauges added with With are reported with each entry. Counters added with
+// With are ignored: they'd otherwise be counted again with every entry.
+func NewMetricsCore(rec MetricRecorder, enab LevelEnabler) Core {
+	return &metricsCore{
+		LevelEnabler: enab,
+		rec:          rec,
+	}
+}
+
+type metricsCore struct {
+	LevelEnabler
+
+	rec     MetricRecorder
+	context []Field // only gauge fields are retained
+}
+
+var (
+	_ Core           = (*metricsCore)(nil)
+	_ leveledEnabler = (*metricsCore)(nil)
+)
+
+func (c *metricsCore) Level() Level {
+	return LevelOf(c.LevelEnabler)
+}
+
+func (c *metricsCore) With(fields []Field) Core {
+	context := c.context[:len(c.context):len(c.context)]
+	for _, f := range fields {
+		if MetricTypeOf(f) == GaugeMetric {
+			context = append(context, f)
+		}
+	}
+	return &metricsCore{
+		LevelEnabler: c.LevelEnabler,
+		rec:          c.rec,
+		context:      context,
+	}
+}
+
+func (c *metricsCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *metricsCore) Write(_ Entry, fields []Field) error {
+	for i := range c.context {
+		c.record(c.context[i])
+	}
+	for i := range fields {
+		c.record(fields[i])
+	}
+	return nil
+}
+
+func (c *metricsCore) record(f Field) {
+	switch MetricTypeOf(f) {
+	case CounterMetric:
+		c.rec.Count(f.Key, f.Integer)
+	case GaugeMetric:
+		c.rec.Gauge(f.Key, math.Float64frombits(uint64(f.Integer)))
+	}
+}
+
+func (c *metricsCore) Sync() error {
+	return nil
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore_test
+
+import (
+	"math"
+	"sync"
+	"testing"
+
+	//revive:disable:dot-imports
+	. "github.com/auwixcom/lad/ladcore"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordedMetrics struct {
+	mu     sync.Mutex
+	counts map[string]int64
+	gauges map[string]float64
+}
+
+func newRecordedMetrics() *recordedMetrics {
+	return &recordedMetrics{
+		counts: make(map[string]int64),
+		gauges: make(map[string]float64),
+	}
+}
+
+func (r *recordedMetrics) Count(name string, delta int64) {
+	r.mu.Lock()
+	r.counts[name] += delta
+	r.mu.Unlock()
+}
+
+func (r *recordedMetrics) Gauge(name string, value float64) {
+	r.mu.Lock()
+	r.gauges[name] = value
+	r.mu.Unlock()
+}
+
+func counterField(key string, n int64) Field {
+	return Field{Key: key, Type: Int64Type, Integer: n, Interface: CounterMetric}
+}
+
+func gaugeField(key string, v float64) Field {
+	return Field{Key: key, Type: Float64Type, Integer: int64(math.Float64bits(v)), Interface: GaugeMetric}
+}
+
+func TestMetricTypeOf(t *testing.T) {
+	tests := []struct {
+		desc  string
+		field Field
+		want  MetricType
+	}{
+		{"counter", counterField("k", 1), CounterMetric},
+		{"gauge", gaugeField("k", 1), GaugeMetric},
+		{"plain int", makeInt64Field("k", 1), NotMetric},
+		{"mismatched type", Field{Key: "k", Type: StringType, Interface: CounterMetric}, NotMetric},
+		{"time", Field{Key: "k", Type: TimeType}, NotMetric},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.want, MetricTypeOf(tt.field), "Unexpected metric type.")
+		})
+	}
+}
+
+func TestMetricsCore(t *testing.T) {
+	rec := newRecordedMetrics()
+	core := NewMetricsCore(rec, InfoLevel)
+	assert.Equal(t, InfoLevel, LevelOf(core), "Unexpected level.")
+
+	child := core.With([]Field{counterField("jobs", 1), gaugeField("workers", 8), makeInt64Field("ignored", 2)})
+	write := func(c Core, lvl Level, fields ...Field) {
+		if ce := c.Check(Entry{Level: lvl, Message: "msg"}, nil); ce != nil {
+			ce.Write(fields...)
+		}
+	}
+
+	write(child, InfoLevel, counterField("rows", 10), gaugeField("queue", 4))
+	write(child, WarnLevel, counterField("rows", 5), gaugeField("queue", 2.5))
+	write(core, InfoLevel, counterField("rows", 1))
+	write(child, DebugLevel, counterField("rows", 100))
+
+	assert.Equal(t, map[string]int64{"rows": 16}, rec.counts, "Expected counters from With not to be counted per entry.")
+	assert.Equal(t, map[string]float64{"queue": 2.5, "workers": 8}, rec.gauges, "Unexpected gauges.")
+	require.NoError(t, core.Sync(), "Unexpected error syncing.")
+}
@@ -0,0 +1,679 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/auwixcom/lad/buffer"
+	"github.com/auwixcom/lad/internal/bufferpool"
+	"github.com/auwixcom/lad/internal/pool"
+)
+
+// MessagePack type markers used by the encoder. See
+// https://github.com/msgpack/msgpack/blob/master/spec.md.
+const (
+	_mpNil     = 0xc0
+	_mpFalse   = 0xc2
+	_mpTrue    = 0xc3
+	_mpBin8    = 0xc4
+	_mpBin16   = 0xc5
+	_mpBin32   = 0xc6
+	_mpFloat32 = 0xca
+	_mpFloat64 = 0xcb
+	_mpUint8   = 0xcc
+	_mpUint16  = 0xcd
+	_mpUint32  = 0xce
+	_mpUint64  = 0xcf
+	_mpInt8    = 0xd0
+	_mpInt16   = 0xd1
+	_mpInt32   = 0xd2
+	_mpInt64   = 0xd3
+	_mpStr8    = 0xd9
+	_mpStr16   = 0xda
+	_mpStr32   = 0xdb
+	_mpArray32 = 0xdd
+	_mpMap32   = 0xdf
+
+	// Containers always use a 32-bit length, so that it can be filled in
+	// once the container is closed.
+	_mpHeaderLen = 5
+)
+
+var _msgpackPool = pool.New(func() *msgpackEncoder {
+	return &msgpackEncoder{}
+})
+
+func putMsgpackEncoder(enc *msgpackEncoder) {
+	if enc.reflectBuf != nil {
+		enc.reflectBuf.Free()
+	}
+	enc.EncoderConfig = nil
+	enc.buf = nil
+	enc.containers = enc.containers[:0]
+	enc.openNamespaces = 0
+	enc.reflectBuf = nil
+	enc.reflectEnc = nil
+	_msgpackPool.Put(enc)
+}
+
+// mpContainer is a map or array whose length is filled in when it's closed.
+type mpContainer struct {
+	offset int // of the header in buf, or -1 for the implicit top-level map
+	count  int // key-value pairs or elements written so far
+	array  bool
+}
+
+type msgpackEncoder struct {
+	*EncoderConfig
+	buf            *buffer.Buffer
+	containers     []mpContainer // the innermost container is last
+	openNamespaces int
+
+	// for encoding generic values by reflection
+	reflectBuf *buffer.Buffer
+	reflectEnc ReflectedEncoder
+}
+
+// NewMsgpackEncoder creates a fast, low-allocation encoder that writes each
+// entry as a MessagePack map, for high-volume pipelines where encoding JSON
+// is the bottleneck. Keys and values are encoded like the JSON encoder
+// would, using the same EncoderConfig options, except that:
+//
+//   - integers, floats, and booleans use their MessagePack types, and NaN
+//     and infinities are encoded as floats rather than strings;
+//   - binary values use the bin type rather than base64;
+//   - values serialized by reflection are encoded with
+//     EncoderConfig.NewReflectedEncoder, then converted to MessagePack;
+//   - entries are self-delimiting, so no line ending is written;
+//   - ASCIIOnly and FlattenNamespaces don't apply.
+//
+// Maps and arrays always use 32-bit lengths, which all MessagePack decoders
+// accept.
+func NewMsgpackEncoder(cfg EncoderConfig) Encoder {
+	return newMsgpackEncoder(cfg)
+}
+
+func newMsgpackEncoder(cfg EncoderConfig) *msgpackEncoder {
+	if cfg.NewReflectedEncoder == nil {
+		cfg.NewReflectedEncoder = defaultReflectedEncoder
+	}
+	return &msgpackEncoder{
+		EncoderConfig: &cfg,
+		buf:           bufferpool.Get(),
+		containers:    []mpContainer{{offset: -1}},
+	}
+}
+
+func (enc *msgpackEncoder) AddArray(key string, arr ArrayMarshaler) error {
+	enc.addKey(key)
+	return enc.AppendArray(arr)
+}
+
+func (enc *msgpackEncoder) AddObject(key string, obj ObjectMarshaler) error {
+	enc.addKey(key)
+	return enc.AppendObject(obj)
+}
+
+func (enc *msgpackEncoder) AddBinary(key string, val []byte) {
+	enc.addKey(key)
+	enc.appendBinary(val)
+}
+
+func (enc *msgpackEncoder) AddByteString(key string, val []byte) {
+	enc.addKey(key)
+	enc.AppendByteString(val)
+}
+
+func (enc *msgpackEncoder) AddBool(key string, val bool) {
+	enc.addKey(key)
+	enc.AppendBool(val)
+}
+
+func (enc *msgpackEncoder) AddComplex128(key string, val complex128) {
+	enc.addKey(key)
+	enc.AppendComplex128(val)
+}
+
+func (enc *msgpackEncoder) AddComplex64(key string, val complex64) {
+	enc.addKey(key)
+	enc.AppendComplex64(val)
+}
+
+func (enc *msgpackEncoder) AddDuration(key string, val time.Duration) {
+	enc.addKey(key)
+	enc.AppendDuration(val)
+}
+
+func (enc *msgpackEncoder) AddFloat64(key string, val float64) {
+	enc.addKey(key)
+	enc.AppendFloat64(val)
+}
+
+func (enc *msgpackEncoder) AddFloat32(key string, val float32) {
+	enc.addKey(key)
+	enc.AppendFloat32(val)
+}
+
+func (enc *msgpackEncoder) AddInt64(key string, val int64) {
+	enc.addKey(key)
+	enc.AppendInt64(val)
+}
+
+func (enc *msgpackEncoder) AddReflected(key string, obj interface{}) error {
+	if s, ok := redacted(obj); ok {
+		enc.AddString(key, s)
+		return nil
+	}
+	// Encode the value before adding the key, so that a failure leaves no
+	// dangling key behind.
+	valueBytes, err := enc.encodeReflected(obj)
+	if err != nil {
+		return err
+	}
+	enc.addKey(key)
+	return enc.appendJSON(valueBytes)
+}
+
+func (enc *msgpackEncoder) OpenNamespace(key string) {
+	enc.addKey(key)
+	enc.openContainer(false)
+	enc.openNamespaces++
+}
+
+func (enc *msgpackEncoder) AddString(key, val string) {
+	enc.addKey(key)
+	enc.AppendString(val)
+}
+
+func (enc *msgpackEncoder) AddTime(key string, val time.Time) {
+	enc.addKey(key)
+	enc.AppendTime(val)
+}
+
+func (enc *msgpackEncoder) AddUint64(key string, val uint64) {
+	enc.addKey(key)
+	enc.AppendUint64(val)
+}
+
+func (enc *msgpackEncoder) AppendArray(arr ArrayMarshaler) error {
+	if s, ok := redacted(arr); ok {
+		enc.AppendString(s)
+		return nil
+	}
+	enc.addElement()
+	enc.openContainer(true)
+	err := arr.MarshalLogArray(enc)
+	enc.closeContainer()
+	return err
+}
+
+func (enc *msgpackEncoder) AppendObject(obj ObjectMarshaler) error {
+	if s, ok := redacted(obj); ok {
+		enc.AppendString(s)
+		return nil
+	}
+	// Close ONLY new openNamespaces that are created during
+	// AppendObject().
+	old := enc.openNamespaces
+	enc.openNamespaces = 0
+	enc.addElement()
+	enc.openContainer(false)
+	err := obj.MarshalLogObject(enc)
+	enc.closeOpenNamespaces()
+	enc.closeContainer()
+	enc.openNamespaces = old
+	return err
+}
+
+func (enc *msgpackEncoder) AppendBool(val bool) {
+	enc.addElement()
+	if val {
+		enc.buf.AppendByte(_mpTrue)
+	} else {
+		enc.buf.AppendByte(_mpFalse)
+	}
+}
+
+func (enc *msgpackEncoder) AppendByteString(val []byte) {
+	enc.addElement()
+	enc.appendStrHeader(len(val))
+	_, _ = enc.buf.Write(val)
+}
+
+func (enc *msgpackEncoder) AppendComplex128(val complex128) {
+	enc.appendComplex(val, 64)
+}
+
+func (enc *msgpackEncoder) AppendComplex64(val complex64) {
+	enc.appendComplex(complex128(val), 32)
+}
+
+// appendComplex encodes a complex number as a string, like the JSON encoder.
+func (enc *msgpackEncoder) appendComplex(val complex128, precision int) {
+	s := strconv.FormatComplex(val, 'f', -1, 2*precision)
+	enc.AppendString(s[1 : len(s)-1]) // trim the parentheses
+}
+
+func (enc *msgpackEncoder) AppendDuration(val time.Duration) {
+	cur := enc.buf.Len()
+	if e := enc.EncodeDuration; e != nil {
+		e(val, enc)
+	}
+	if cur == enc.buf.Len() {
+		// User-supplied EncodeDuration is a no-op. Fall back to nanoseconds to
+		// keep the map well-formed.
+		enc.AppendInt64(int64(val))
+	}
+}
+
+func (enc *msgpackEncoder) AppendFloat64(val float64) {
+	enc.addElement()
+	enc.buf.AppendByte(_mpFloat64)
+	enc.appendUint64(math.Float64bits(val))
+}
+
+func (enc *msgpackEncoder) AppendFloat32(val float32) {
+	enc.addElement()
+	enc.buf.AppendByte(_mpFloat32)
+	enc.appendUint32(math.Float32bits(val))
+}
+
+func (enc *msgpackEncoder) AppendInt64(val int64) {
+	enc.addElement()
+	switch {
+	case val >= 0:
+		enc.appendUint(uint64(val))
+	case val >= -32:
+		enc.buf.AppendByte(byte(int8(val))) // negative fixint
+	case val >= math.MinInt8:
+		enc.buf.AppendByte(_mpInt8)
+		enc.buf.AppendByte(byte(int8(val)))
+	case val >= math.MinInt16:
+		enc.buf.AppendByte(_mpInt16)
+		enc.appendUint16(uint16(int16(val)))
+	case val >= math.MinInt32:
+		enc.buf.AppendByte(_mpInt32)
+		enc.appendUint32(uint32(int32(val)))
+	default:
+		enc.buf.AppendByte(_mpInt64)
+		enc.appendUint64(uint64(val))
+	}
+}
+
+func (enc *msgpackEncoder) AppendReflected(val interface{}) error {
+	if s, ok := redacted(val); ok {
+		enc.AppendString(s)
+		return nil
+	}
+	valueBytes, err := enc.encodeReflected(val)
+	if err != nil {
+		return err
+	}
+	enc.addElement()
+	return enc.appendJSON(valueBytes)
+}
+
+func (enc *msgpackEncoder) AppendString(val string) {
+	enc.addElement()
+	enc.appendStrHeader(len(val))
+	enc.buf.AppendString(val)
+}
+
+func (enc *msgpackEncoder) AppendTime(val time.Time) {
+	cur := enc.buf.Len()
+	if e := enc.EncodeTime; e != nil {
+		e(val, enc)
+	}
+	if cur == enc.buf.Len() {
+		// User-supplied EncodeTime is a no-op. Fall back to nanos since epoch
+		// to keep the map well-formed.
+		enc.AppendInt64(val.UnixNano())
+	}
+}
+
+func (enc *msgpackEncoder) AppendUint64(val uint64) {
+	enc.addElement()
+	enc.appendUint(val)
+}
+
+func (enc *msgpackEncoder) AddInt(k string, v int)         { enc.AddInt64(k, int64(v)) }
+func (enc *msgpackEncoder) AddInt32(k string, v int32)     { enc.AddInt64(k, int64(v)) }
+func (enc *msgpackEncoder) AddInt16(k string, v int16)     { enc.AddInt64(k, int64(v)) }
+func (enc *msgpackEncoder) AddInt8(k string, v int8)       { enc.AddInt64(k, int64(v)) }
+func (enc *msgpackEncoder) AddUint(k string, v uint)       { enc.AddUint64(k, uint64(v)) }
+func (enc *msgpackEncoder) AddUint32(k string, v uint32)   { enc.AddUint64(k, uint64(v)) }
+func (enc *msgpackEncoder) AddUint16(k string, v uint16)   { enc.AddUint64(k, uint64(v)) }
+func (enc *msgpackEncoder) AddUint8(k string, v uint8)     { enc.AddUint64(k, uint64(v)) }
+func (enc *msgpackEncoder) AddUintptr(k string, v uintptr) { enc.AddUint64(k, uint64(v)) }
+func (enc *msgpackEncoder) AppendInt(v int)                { enc.AppendInt64(int64(v)) }
+func (enc *msgpackEncoder) AppendInt32(v int32)            { enc.AppendInt64(int64(v)) }
+func (enc *msgpackEncoder) AppendInt16(v int16)            { enc.AppendInt64(int64(v)) }
+func (enc *msgpackEncoder) AppendInt8(v int8)              { enc.AppendInt64(int64(v)) }
+func (enc *msgpackEncoder) AppendUint(v uint)              { enc.AppendUint64(uint64(v)) }
+func (enc *msgpackEncoder) AppendUint32(v uint32)          { enc.AppendUint64(uint64(v)) }
+func (enc *msgpackEncoder) AppendUint16(v uint16)          { enc.AppendUint64(uint64(v)) }
+func (enc *msgpackEncoder) AppendUint8(v uint8)            { enc.AppendUint64(uint64(v)) }
+func (enc *msgpackEncoder) AppendUintptr(v uintptr)        { enc.AppendUint64(uint64(v)) }
+
+func (enc *msgpackEncoder) Clone() Encoder {
+	clone := enc.clone()
+	clone.buf.Write(enc.buf.Bytes())
+	clone.containers = append(clone.containers[:0], enc.containers...)
+	clone.openNamespaces = enc.openNamespaces
+	return clone
+}
+
+func (enc *msgpackEncoder) clone() *msgpackEncoder {
+	clone := _msgpackPool.Get()
+	clone.EncoderConfig = enc.EncoderConfig
+	clone.containers = append(clone.containers[:0], mpContainer{offset: -1})
+	clone.buf = bufferpool.Get()
+	return clone
+}
+
+func (enc *msgpackEncoder) EncodeEntry(ent Entry, fields []Field) (*buffer.Buffer, error) {
+	if enc.RequireMessage && ent.Message == "" {
+		return nil, ErrEmptyMessage
+	}
+
+	final := enc.clone()
+	final.containers[0].offset = 0
+	final.writeHeader(_mpMap32)
+
+	if final.LevelKey != "" && final.EncodeLevel != nil {
+		final.addKey(final.LevelKey)
+		cur := final.buf.Len()
+		final.EncodeLevel(ent.Level, final)
+		if cur == final.buf.Len() {
+			// User-supplied EncodeLevel was a no-op. Fall back to strings to
+			// keep the map well-formed.
+			final.AppendString(ent.Level.String())
+		}
+	}
+	if final.TimeKey != "" && !ent.Time.IsZero() {
+		final.AddTime(final.TimeKey, ent.Time)
+	}
+	if ent.LoggerName != "" && final.NameKey != "" {
+		final.addKey(final.NameKey)
+		cur := final.buf.Len()
+		nameEncoder := final.EncodeName
+		if nameEncoder == nil {
+			nameEncoder = FullNameEncoder
+		}
+		nameEncoder(ent.LoggerName, final)
+		if cur == final.buf.Len() {
+			// User-supplied EncodeName was a no-op. Fall back to strings to
+			// keep the map well-formed.
+			final.AppendString(ent.LoggerName)
+		}
+	}
+	if ent.Caller.Defined {
+		if final.CallerKey != "" {
+			final.addKey(final.CallerKey)
+			cur := final.buf.Len()
+			final.EncodeCaller(ent.Caller, final)
+			if cur == final.buf.Len() {
+				// User-supplied EncodeCaller was a no-op. Fall back to strings
+				// to keep the map well-formed.
+				final.AppendString(ent.Caller.String())
+			}
+		}
+		if final.FunctionKey != "" {
+			final.AddString(final.FunctionKey, ent.Caller.Function)
+		}
+	} else if ent.Caller.Function != "" {
+		key := final.FunctionKey
+		if key == "" {
+			key = DefaultFunctionKey
+		}
+		final.AddString(key, ent.Caller.Function)
+	}
+	if final.MessageKey != "" && (ent.Message != "" || !final.OmitEmptyMessage) {
+		final.AddString(final.MessageKey, ent.Message)
+	}
+	if enc.buf.Len() > 0 {
+		// Splice in the context, moving its open namespaces along with it.
+		base := final.buf.Len()
+		final.buf.Write(enc.buf.Bytes())
+		final.containers[0].count += enc.containers[0].count
+		for _, c := range enc.containers[1:] {
+			c.offset += base
+			final.containers = append(final.containers, c)
+		}
+		final.openNamespaces = enc.openNamespaces
+	}
+	addFields(final, fields)
+	final.closeOpenNamespaces()
+	if ent.Stack != "" && final.StacktraceKey != "" {
+		final.AddString(final.StacktraceKey, ent.Stack)
+	}
+	final.closeContainer()
+
+	ret := final.buf
+	putMsgpackEncoder(final)
+	return ret, nil
+}
+
+func (enc *msgpackEncoder) closeOpenNamespaces() {
+	for i := 0; i < enc.openNamespaces; i++ {
+		enc.closeContainer()
+	}
+	enc.openNamespaces = 0
+}
+
+// addKey writes a map key, counting the key-value pair in the innermost
+// container.
+func (enc *msgpackEncoder) addKey(key string) {
+	enc.containers[len(enc.containers)-1].count++
+	enc.appendStrHeader(len(key))
+	enc.buf.AppendString(key)
+}
+
+// addElement counts a value written to an array. Values written after a
+// map key were already counted by addKey.
+func (enc *msgpackEncoder) addElement() {
+	if c := &enc.containers[len(enc.containers)-1]; c.array {
+		c.count++
+	}
+}
+
+func (enc *msgpackEncoder) openContainer(array bool) {
+	enc.containers = append(enc.containers, mpContainer{offset: enc.buf.Len(), array: array})
+	if array {
+		enc.writeHeader(_mpArray32)
+	} else {
+		enc.writeHeader(_mpMap32)
+	}
+}
+
+// closeContainer fills in the length of the innermost container.
+func (enc *msgpackEncoder) closeContainer() {
+	c := enc.containers[len(enc.containers)-1]
+	enc.containers = enc.containers[:len(enc.containers)-1]
+	if c.offset >= 0 {
+		binary.BigEndian.PutUint32(enc.buf.Bytes()[c.offset+1:], uint32(c.count))
+	}
+}
+
+func (enc *msgpackEncoder) writeHeader(marker byte) {
+	enc.buf.AppendByte(marker)
+	enc.appendUint32(0) // filled in by closeContainer
+}
+
+func (enc *msgpackEncoder) appendStrHeader(n int) {
+	switch {
+	case n < 32:
+		enc.buf.AppendByte(0xa0 | byte(n)) // fixstr
+	case n <= math.MaxUint8:
+		enc.buf.AppendByte(_mpStr8)
+		enc.buf.AppendByte(byte(n))
+	case n <= math.MaxUint16:
+		enc.buf.AppendByte(_mpStr16)
+		enc.appendUint16(uint16(n))
+	default:
+		enc.buf.AppendByte(_mpStr32)
+		enc.appendUint32(uint32(n))
+	}
+}
+
+func (enc *msgpackEncoder) appendBinary(val []byte) {
+	enc.addElement()
+	switch n := len(val); {
+	case n <= math.MaxUint8:
+		enc.buf.AppendByte(_mpBin8)
+		enc.buf.AppendByte(byte(n))
+	case n <= math.MaxUint16:
+		enc.buf.AppendByte(_mpBin16)
+		enc.appendUint16(uint16(n))
+	default:
+		enc.buf.AppendByte(_mpBin32)
+		enc.appendUint32(uint32(n))
+	}
+	_, _ = enc.buf.Write(val)
+}
+
+// appendUint writes val in the most compact unsigned representation.
+func (enc *msgpackEncoder) appendUint(val uint64) {
+	switch {
+	case val < 128:
+		enc.buf.AppendByte(byte(val)) // positive fixint
+	case val <= math.MaxUint8:
+		enc.buf.AppendByte(_mpUint8)
+		enc.buf.AppendByte(byte(val))
+	case val <= math.MaxUint16:
+		enc.buf.AppendByte(_mpUint16)
+		enc.appendUint16(uint16(val))
+	case val <= math.MaxUint32:
+		enc.buf.AppendByte(_mpUint32)
+		enc.appendUint32(uint32(val))
+	default:
+		enc.buf.AppendByte(_mpUint64)
+		enc.appendUint64(val)
+	}
+}
+
+func (enc *msgpackEncoder) appendUint16(v uint16) {
+	enc.buf.AppendByte(byte(v >> 8))
+	enc.buf.AppendByte(byte(v))
+}
+
+func (enc *msgpackEncoder) appendUint32(v uint32) {
+	enc.appendUint16(uint16(v >> 16))
+	enc.appendUint16(uint16(v))
+}
+
+func (enc *msgpackEncoder) appendUint64(v uint64) {
+	enc.appendUint32(uint32(v >> 32))
+	enc.appendUint32(uint32(v))
+}
+
+func (enc *msgpackEncoder) encodeReflected(obj interface{}) ([]byte, error) {
+	if obj == nil {
+		return nullLiteralBytes, nil
+	}
+	if enc.reflectBuf == nil {
+		enc.reflectBuf = bufferpool.Get()
+		enc.reflectEnc = enc.NewReflectedEncoder(enc.reflectBuf)
+	} else {
+		enc.reflectBuf.Reset()
+	}
+	if err := enc.reflectEnc.Encode(obj); err != nil {
+		return nil, err
+	}
+	enc.reflectBuf.TrimNewline()
+	return enc.reflectBuf.Bytes(), nil
+}
+
+var errMsgpackJSONEnd = errors.New("unexpected end of reflected JSON value")
+
+// appendJSON converts a JSON value, as produced by the ReflectedEncoder, to
+// MessagePack. The value must already have been counted.
+func (enc *msgpackEncoder) appendJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	// The converted value may contain containers of its own; count it in
+	// a throwaway container so that it isn't counted twice.
+	n := len(enc.containers)
+	enc.containers = append(enc.containers, mpContainer{offset: -1})
+	defer func() { enc.containers = enc.containers[:n] }()
+	return enc.appendJSONValue(dec)
+}
+
+func (enc *msgpackEncoder) appendJSONValue(dec *json.Decoder) error {
+	tok, err := dec.Token()
+	if err == io.EOF {
+		return errMsgpackJSONEnd
+	} else if err != nil {
+		return err
+	}
+
+	switch v := tok.(type) {
+	case json.Delim:
+		array := v == '['
+		enc.addElement()
+		enc.openContainer(array)
+		for dec.More() {
+			if !array {
+				key, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				enc.addKey(key.(string))
+			}
+			if err := enc.appendJSONValue(dec); err != nil {
+				return err
+			}
+		}
+		if _, err := dec.Token(); err != nil { // closing delimiter
+			return err
+		}
+		enc.closeContainer()
+	case string:
+		enc.AppendString(v)
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			enc.AppendInt64(i)
+		} else if u, err := strconv.ParseUint(string(v), 10, 64); err == nil {
+			enc.AppendUint64(u)
+		} else {
+			f, err := v.Float64()
+			if err != nil {
+				return err
+			}
+			enc.AppendFloat64(f)
+		}
+	case bool:
+		enc.AppendBool(v)
+	case nil:
+		enc.addElement()
+		enc.buf.AppendByte(_mpNil)
+	}
+	return nil
+}
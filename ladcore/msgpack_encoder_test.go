@@ -0,0 +1,324 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore_test
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/auwixcom/lad"
+	//revive:disable:dot-imports
+	. "github.com/auwixcom/lad/ladcore"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// decodeMsgpack decodes a single MessagePack value, returning it and the
+// remaining bytes. Integers decode to int64 (or uint64 if they don't fit),
+// maps to map[string]interface{}, and arrays to []interface{}.
+func decodeMsgpack(b []byte) (interface{}, []byte, error) {
+	if len(b) == 0 {
+		return nil, nil, errors.New("unexpected end of input")
+	}
+	c, b := b[0], b[1:]
+	need := func(n int) ([]byte, error) {
+		if len(b) < n {
+			return nil, errors.New("unexpected end of input")
+		}
+		return b[:n], nil
+	}
+	uintN := func(n int) (uint64, error) {
+		p, err := need(n)
+		if err != nil {
+			return 0, err
+		}
+		b = b[n:]
+		var v uint64
+		for _, x := range p {
+			v = v<<8 | uint64(x)
+		}
+		return v, nil
+	}
+	str := func(n uint64) (interface{}, []byte, error) {
+		p, err := need(int(n))
+		if err != nil {
+			return nil, nil, err
+		}
+		return string(p), b[n:], nil
+	}
+	container := func(n uint64, isMap bool) (interface{}, []byte, error) {
+		arr := make([]interface{}, 0, n)
+		m := make(map[string]interface{}, n)
+		for i := uint64(0); i < n; i++ {
+			var key interface{}
+			var err error
+			if isMap {
+				if key, b, err = decodeMsgpack(b); err != nil {
+					return nil, nil, err
+				}
+			}
+			var val interface{}
+			if val, b, err = decodeMsgpack(b); err != nil {
+				return nil, nil, err
+			}
+			if isMap {
+				m[key.(string)] = val
+			} else {
+				arr = append(arr, val)
+			}
+		}
+		if isMap {
+			return m, b, nil
+		}
+		return arr, b, nil
+	}
+
+	switch {
+	case c < 0x80:
+		return int64(c), b, nil
+	case c >= 0xe0:
+		return int64(int8(c)), b, nil
+	case c&0xe0 == 0xa0:
+		return str(uint64(c & 0x1f))
+	case c&0xf0 == 0x80:
+		return container(uint64(c&0x0f), true)
+	case c&0xf0 == 0x90:
+		return container(uint64(c&0x0f), false)
+	}
+
+	switch c {
+	case 0xc0:
+		return nil, b, nil
+	case 0xc2, 0xc3:
+		return c == 0xc3, b, nil
+	case 0xc4, 0xc5, 0xc6:
+		n, err := uintN(1 << (c - 0xc4))
+		if err != nil {
+			return nil, nil, err
+		}
+		p, err := need(int(n))
+		if err != nil {
+			return nil, nil, err
+		}
+		return append([]byte(nil), p...), b[n:], nil
+	case 0xca:
+		v, err := uintN(4)
+		return math.Float32frombits(uint32(v)), b, err
+	case 0xcb:
+		v, err := uintN(8)
+		return math.Float64frombits(v), b, err
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		v, err := uintN(1 << (c - 0xcc))
+		if v > math.MaxInt64 {
+			return v, b, err
+		}
+		return int64(v), b, err
+	case 0xd0:
+		v, err := uintN(1)
+		return int64(int8(v)), b, err
+	case 0xd1:
+		v, err := uintN(2)
+		return int64(int16(v)), b, err
+	case 0xd2:
+		v, err := uintN(4)
+		return int64(int32(v)), b, err
+	case 0xd3:
+		v, err := uintN(8)
+		return int64(v), b, err
+	case 0xd9, 0xda, 0xdb:
+		n, err := uintN(1 << (c - 0xd9))
+		if err != nil {
+			return nil, nil, err
+		}
+		return str(n)
+	case 0xdc, 0xdd:
+		n, err := uintN(2 << (c - 0xdc))
+		if err != nil {
+			return nil, nil, err
+		}
+		return container(n, false)
+	case 0xde, 0xdf:
+		n, err := uintN(2 << (c - 0xde))
+		if err != nil {
+			return nil, nil, err
+		}
+		return container(n, true)
+	}
+	return nil, nil, fmt.Errorf("unsupported type 0x%x", c)
+}
+
+func decodeMsgpackEntry(t *testing.T, enc Encoder, ent Entry, fields ...Field) map[string]interface{} {
+	buf, err := enc.EncodeEntry(ent, fields)
+	require.NoError(t, err, "Unexpected MessagePack encoding error.")
+	defer buf.Free()
+
+	v, rest, err := decodeMsgpack(buf.Bytes())
+	require.NoError(t, err, "Couldn't decode MessagePack output.")
+	assert.Empty(t, rest, "Unexpected trailing bytes.")
+	require.IsType(t, map[string]interface{}{}, v, "Expected each entry to be a map.")
+	return v.(map[string]interface{})
+}
+
+func TestMsgpackEncodeEntry(t *testing.T) {
+	enc := NewMsgpackEncoder(testEncoderConfig())
+	enc.AddString("service", "api")
+
+	ent := Entry{
+		Level:      InfoLevel,
+		Time:       time.Unix(1, 0),
+		LoggerName: "main",
+		Message:    "hello",
+		Caller:     EntryCaller{Defined: true, File: "foo.go", Line: 42, Function: "foo.Foo"},
+		Stack:      "fake-stack",
+	}
+	got := decodeMsgpackEntry(t, enc, ent,
+		lad.Int("count", 300),
+		lad.Int64("neg", -70000),
+		lad.Uint64("big", math.MaxUint64),
+		lad.Bool("ok", true),
+		lad.Float64("ratio", 0.5),
+		lad.Float32("small", 1.5),
+		lad.Binary("bin", []byte{0, 1}),
+		lad.ByteString("bs", []byte("bytes")),
+		lad.Complex128("c", 1+2i),
+		lad.Duration("d", time.Second),
+		lad.String("long", strings.Repeat("x", 300)),
+		lad.Strings("tags", []string{"a", "b"}),
+		lad.Any("reflected", map[string]interface{}{"n": 1.5, "list": []int{1, -2}, "nil": nil}),
+		lad.Error(errors.New("fail")),
+	)
+	assert.Equal(t, map[string]interface{}{
+		"level":      "info",
+		"ts":         float64(1),
+		"name":       "main",
+		"caller":     "foo.go:42",
+		"func":       "foo.Foo",
+		"msg":        "hello",
+		"service":    "api",
+		"count":      int64(300),
+		"neg":        int64(-70000),
+		"big":        uint64(math.MaxUint64),
+		"ok":         true,
+		"ratio":      0.5,
+		"small":      float32(1.5),
+		"bin":        []byte{0, 1},
+		"bs":         "bytes",
+		"c":          "1+2i",
+		"d":          float64(1),
+		"long":       strings.Repeat("x", 300),
+		"tags":       []interface{}{"a", "b"},
+		"reflected":  map[string]interface{}{"n": 1.5, "list": []interface{}{int64(1), int64(-2)}, "nil": nil},
+		"error":      "fail",
+		"stacktrace": "fake-stack",
+	}, got, "Unexpected decoded entry.")
+}
+
+func TestMsgpackEncoderNamespaces(t *testing.T) {
+	enc := NewMsgpackEncoder(EncoderConfig{MessageKey: "msg"})
+	enc.AddString("outer", "1")
+	enc.OpenNamespace("ns")
+	enc.AddString("inner", "2")
+
+	clone := enc.Clone()
+	clone.AddString("cloned", "3")
+
+	obj := ObjectMarshalerFunc(func(enc ObjectEncoder) error {
+		enc.AddInt("a", 1)
+		enc.OpenNamespace("deep")
+		enc.AddInt("b", 2)
+		return nil
+	})
+	got := decodeMsgpackEntry(t, clone, Entry{Message: "m"}, lad.Object("obj", obj), lad.Namespace("more"), lad.Int("c", 3))
+	assert.Equal(t, map[string]interface{}{
+		"msg":   "m",
+		"outer": "1",
+		"ns": map[string]interface{}{
+			"inner":  "2",
+			"cloned": "3",
+			"obj":    map[string]interface{}{"a": int64(1), "deep": map[string]interface{}{"b": int64(2)}},
+			"more":   map[string]interface{}{"c": int64(3)},
+		},
+	}, got, "Unexpected decoded entry.")
+
+	// The original encoder is unaffected by the clone and by encoding.
+	got = decodeMsgpackEntry(t, enc, Entry{Message: "m"})
+	assert.Equal(t, map[string]interface{}{
+		"msg":   "m",
+		"outer": "1",
+		"ns":    map[string]interface{}{"inner": "2"},
+	}, got, "Unexpected decoded entry.")
+}
+
+func TestMsgpackEncoderIntegers(t *testing.T) {
+	tests := []struct {
+		val  int64
+		want []byte
+	}{
+		{0, []byte{0x00}},
+		{127, []byte{0x7f}},
+		{128, []byte{0xcc, 0x80}},
+		{-1, []byte{0xff}},
+		{-32, []byte{0xe0}},
+		{-33, []byte{0xd0, 0xdf}},
+		{-129, []byte{0xd1, 0xff, 0x7f}},
+		{65536, []byte{0xce, 0x00, 0x01, 0x00, 0x00}},
+		{math.MinInt64, []byte{0xd3, 0x80, 0, 0, 0, 0, 0, 0, 0}},
+	}
+	for _, tt := range tests {
+		enc := NewMsgpackEncoder(EncoderConfig{})
+		buf, err := enc.EncodeEntry(Entry{}, []Field{lad.Int64("k", tt.val)})
+		require.NoError(t, err, "Unexpected MessagePack encoding error.")
+		header := []byte{0xdf, 0, 0, 0, 1, 0xa1, 'k'}
+		assert.Equal(t, append(header, tt.want...), buf.Bytes(), "Unexpected encoding of %d.", tt.val)
+		buf.Free()
+	}
+}
+
+func TestMsgpackEncoderRequireMessage(t *testing.T) {
+	enc := NewMsgpackEncoder(EncoderConfig{MessageKey: "msg", RequireMessage: true})
+	_, err := enc.EncodeEntry(Entry{}, nil)
+	assert.ErrorIs(t, err, ErrEmptyMessage, "Expected an error for an empty message.")
+}
+
+func TestMsgpackEncoderReflectedError(t *testing.T) {
+	enc := NewMsgpackEncoder(EncoderConfig{})
+	got := decodeMsgpackEntry(t, enc, Entry{}, lad.Reflect("bad", make(chan int)), lad.String("k", "v"))
+	assert.Equal(t, "v", got["k"], "Expected the entry to stay well-formed.")
+	assert.NotContains(t, got, "bad", "Expected no dangling key.")
+	assert.Contains(t, got, "badError", "Expected the reflection error to be logged.")
+}
+
+func TestMsgpackContainerLength(t *testing.T) {
+	enc := NewMsgpackEncoder(EncoderConfig{})
+	buf, err := enc.EncodeEntry(Entry{}, []Field{lad.Ints("k", []int{1, 2, 3})})
+	require.NoError(t, err, "Unexpected MessagePack encoding error.")
+	defer buf.Free()
+	b := buf.Bytes()
+	require.Equal(t, byte(0xdf), b[0], "Expected a map32 entry.")
+	assert.Equal(t, uint32(1), binary.BigEndian.Uint32(b[1:]), "Unexpected entry length.")
+	assert.Equal(t, []byte{0xa1, 'k', 0xdd, 0, 0, 0, 3, 1, 2, 3}, b[5:], "Unexpected array encoding.")
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore
+
+import (
+	"fmt"
+	"io"
+
+	"go.uber.org/multierr"
+)
+
+// A MultiWriteSyncerOption configures a WriteSyncer created with
+// NewMultiWriteSyncerWithOptions.
+type MultiWriteSyncerOption interface {
+	apply(*policyMultiWriteSyncer)
+}
+
+// multiOptionFunc wraps a func so it satisfies the MultiWriteSyncerOption
+// interface.
+type multiOptionFunc func(*policyMultiWriteSyncer)
+
+func (f multiOptionFunc) apply(ws *policyMultiWriteSyncer) {
+	f(ws)
+}
+
+// MultiWriteFailFast stops a Write or Sync at the first target that fails,
+// returning its error; later targets aren't attempted. It suits setups where
+// the first target is an audit sink that must not fall behind the others.
+func MultiWriteFailFast() MultiWriteSyncerOption {
+	return multiOptionFunc(func(ws *policyMultiWriteSyncer) {
+		ws.failFast = true
+		ws.quorum = 0
+	})
+}
+
+// MultiWriteQuorum makes a Write or Sync succeed as long as at least n
+// targets succeed, so that losing one replica of a log doesn't surface as an
+// error. Every target is still attempted, and failures are still reported to
+// the MultiWriteOnError callback. If fewer than n targets succeed, the
+// errors of the failed targets are returned.
+func MultiWriteQuorum(n int) MultiWriteSyncerOption {
+	return multiOptionFunc(func(ws *policyMultiWriteSyncer) {
+		ws.quorum = n
+		ws.failFast = false
+	})
+}
+
+// MultiWriteOnError registers a function that's called with the index of a
+// target, the target, and its error whenever a Write or Sync to it fails. A
+// write is considered failed if it's short, even without an error.
+func MultiWriteOnError(f func(i int, ws WriteSyncer, err error)) MultiWriteSyncerOption {
+	return multiOptionFunc(func(ws *policyMultiWriteSyncer) {
+		ws.onError = f
+	})
+}
+
+// NewMultiWriteSyncerWithOptions creates a WriteSyncer that duplicates its
+// writes and sync calls to each of ws, like NewMultiWriteSyncer, with a
+// configurable error policy.
+//
+// By default, the policy is best-effort: every target is attempted and the
+// errors of all that fail are returned together. See MultiWriteFailFast and
+// MultiWriteQuorum for alternatives.
+func NewMultiWriteSyncerWithOptions(ws []WriteSyncer, opts ...MultiWriteSyncerOption) WriteSyncer {
+	m := &policyMultiWriteSyncer{targets: ws}
+	for _, opt := range opts {
+		opt.apply(m)
+	}
+	return m
+}
+
+type policyMultiWriteSyncer struct {
+	targets  []WriteSyncer
+	failFast bool
+	quorum   int // zero for best effort
+	onError  func(int, WriteSyncer, error)
+}
+
+var _ WriteSyncCloser = (*policyMultiWriteSyncer)(nil)
+
+func (m *policyMultiWriteSyncer) Write(p []byte) (int, error) {
+	nWritten := len(p)
+	err := m.each(func(w WriteSyncer) error {
+		n, err := w.Write(p)
+		if n < nWritten {
+			nWritten = n
+		}
+		if err == nil && n < len(p) {
+			err = io.ErrShortWrite
+		}
+		return err
+	})
+	if err == nil {
+		// The quorum was met, even if some targets fell short.
+		nWritten = len(p)
+	}
+	return nWritten, err
+}
+
+func (m *policyMultiWriteSyncer) Sync() error {
+	return m.each(WriteSyncer.Sync)
+}
+
+// Close closes each target that implements io.Closer, regardless of the
+// policy.
+func (m *policyMultiWriteSyncer) Close() error {
+	var err error
+	for _, w := range m.targets {
+		err = multierr.Append(err, CloseWriteSyncer(w))
+	}
+	return err
+}
+
+// each calls op for the targets according to the policy.
+func (m *policyMultiWriteSyncer) each(op func(WriteSyncer) error) error {
+	var (
+		errs      error
+		succeeded int
+	)
+	for i, w := range m.targets {
+		err := op(w)
+		if err == nil {
+			succeeded++
+			continue
+		}
+		if m.onError != nil {
+			m.onError(i, w, err)
+		}
+		if m.failFast {
+			return err
+		}
+		errs = multierr.Append(errs, err)
+	}
+
+	if m.quorum > 0 && errs != nil {
+		if succeeded >= m.quorum {
+			return nil
+		}
+		return fmt.Errorf("%d of %d targets succeeded, quorum is %d: %w", succeeded, len(m.targets), m.quorum, errs)
+	}
+	return errs
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/auwixcom/lad/internal/ztest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type targetError struct {
+	i   int
+	err error
+}
+
+func TestMultiWriteSyncerPolicies(t *testing.T) {
+	tests := []struct {
+		desc      string
+		opts      []MultiWriteSyncerOption
+		wantN     int
+		wantErr   bool
+		wantLast  string // contents of the last target
+		wantFails []int  // indexes reported to the callback
+	}{
+		{
+			desc:      "best effort",
+			wantN:     2,
+			wantErr:   true,
+			wantLast:  "foo",
+			wantFails: []int{1, 2},
+		},
+		{
+			desc:      "fail fast",
+			opts:      []MultiWriteSyncerOption{MultiWriteFailFast()},
+			wantN:     3,
+			wantErr:   true,
+			wantLast:  "",
+			wantFails: []int{1},
+		},
+		{
+			desc:      "quorum met",
+			opts:      []MultiWriteSyncerOption{MultiWriteQuorum(2)},
+			wantN:     3,
+			wantLast:  "foo",
+			wantFails: []int{1, 2},
+		},
+		{
+			desc:      "quorum missed",
+			opts:      []MultiWriteSyncerOption{MultiWriteQuorum(3)},
+			wantN:     2,
+			wantErr:   true,
+			wantLast:  "foo",
+			wantFails: []int{1, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var last bytes.Buffer
+			targets := []WriteSyncer{
+				AddSync(&bytes.Buffer{}),
+				&ztest.FailWriter{},
+				&ztest.ShortWriter{},
+				AddSync(&last),
+			}
+			var fails []targetError
+			opts := append(tt.opts, MultiWriteOnError(func(i int, ws WriteSyncer, err error) {
+				assert.Same(t, targets[i], ws, "Unexpected target passed to callback.")
+				fails = append(fails, targetError{i, err})
+			}))
+
+			ws := NewMultiWriteSyncerWithOptions(targets, opts...)
+			n, err := ws.Write([]byte("foo"))
+			assert.Equal(t, tt.wantN, n, "Unexpected number of bytes written.")
+			if tt.wantErr {
+				assert.Error(t, err, "Expected an error.")
+			} else {
+				assert.NoError(t, err, "Unexpected error.")
+			}
+			assert.Equal(t, tt.wantLast, last.String(), "Unexpected contents of the last target.")
+
+			var idx []int
+			for _, f := range fails {
+				idx = append(idx, f.i)
+			}
+			assert.Equal(t, tt.wantFails, idx, "Unexpected targets reported as failed.")
+		})
+	}
+}
+
+func TestMultiWriteSyncerShortWriteError(t *testing.T) {
+	var got error
+	ws := NewMultiWriteSyncerWithOptions(
+		[]WriteSyncer{&ztest.ShortWriter{}},
+		MultiWriteOnError(func(_ int, _ WriteSyncer, err error) { got = err }),
+	)
+	_, err := ws.Write([]byte("foo"))
+	assert.ErrorIs(t, err, io.ErrShortWrite, "Expected short writes to fail.")
+	assert.ErrorIs(t, got, io.ErrShortWrite, "Expected short writes to be reported.")
+}
+
+func TestMultiWriteSyncerWithOptionsSync(t *testing.T) {
+	failing := &ztest.Discarder{}
+	failing.SetError(errors.New("fail"))
+	ok := &ztest.Discarder{}
+
+	ws := NewMultiWriteSyncerWithOptions([]WriteSyncer{failing, ok}, MultiWriteFailFast())
+	assert.Error(t, ws.Sync(), "Expected sync errors to propagate.")
+	assert.False(t, ok.Called(), "Expected fail-fast to skip later targets.")
+
+	ws = NewMultiWriteSyncerWithOptions([]WriteSyncer{failing, ok}, MultiWriteQuorum(1))
+	assert.NoError(t, ws.Sync(), "Expected the quorum to be met.")
+	assert.True(t, ok.Called(), "Expected every target to be synced.")
+
+	ws = NewMultiWriteSyncerWithOptions([]WriteSyncer{failing, ok}, MultiWriteQuorum(2))
+	assert.ErrorContains(t, ws.Sync(), "1 of 2 targets succeeded, quorum is 2", "Expected the quorum to be missed.")
+}
+
+func TestMultiWriteSyncerWithOptionsClose(t *testing.T) {
+	a, b := &closeSpy{}, &closeSpy{}
+	ws := NewMultiWriteSyncerWithOptions([]WriteSyncer{a, AddSync(io.Discard), b}, MultiWriteFailFast())
+	require.NoError(t, CloseWriteSyncer(ws), "Unexpected error closing.")
+	assert.Equal(t, 1, a.closed, "Expected first target to be closed.")
+	assert.Equal(t, 1, b.closed, "Expected last target to be closed.")
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"go.uber.org/multierr"
+)
+
+// A TeeStage is a group of Cores that NewOrderedTee syncs and closes
+// together.
+type TeeStage struct {
+	// Cores are the Cores in this stage. They're synced and closed one after
+	// the other, in order.
+	Cores []Core
+
+	// Timeout bounds how long syncing or closing the stage may take. If it
+	// expires, the stage fails with ErrSinkTimeout and the next stage starts
+	// while the slow Core finishes in the background.
+	//
+	// Defaults to no deadline if unspecified.
+	Timeout time.Duration
+}
+
+// NewOrderedTee creates a Core that duplicates log entries into the Cores of
+// all stages, like NewTee, but syncs them stage by stage, in order. This lets
+// remote sinks flush their batches before local ones are closed:
+//
+//	core := ladcore.NewOrderedTee(
+//	  ladcore.TeeStage{Cores: []ladcore.Core{remote}, Timeout: 5 * time.Second},
+//	  ladcore.TeeStage{Cores: []ladcore.Core{file}},
+//	)
+//
+// The returned Core implements io.Closer. Close syncs each stage in order and
+// closes the Cores in it that implement io.Closer before moving on to the
+// next stage. Errors from all stages are combined.
+func NewOrderedTee(stages ...TeeStage) Core {
+	t := &orderedTee{stages: make([]TeeStage, 0, len(stages))}
+	for _, s := range stages {
+		t.multiCore = append(t.multiCore, s.Cores...)
+		t.stages = append(t.stages, s)
+	}
+	return t
+}
+
+type orderedTee struct {
+	multiCore
+
+	stages []TeeStage
+}
+
+var (
+	_ leveledEnabler = (*orderedTee)(nil)
+	_ Core           = (*orderedTee)(nil)
+	_ io.Closer      = (*orderedTee)(nil)
+)
+
+func (t *orderedTee) With(fields []Field) Core {
+	clone := &orderedTee{
+		multiCore: make(multiCore, 0, len(t.multiCore)),
+		stages:    make([]TeeStage, len(t.stages)),
+	}
+	for i, s := range t.stages {
+		cores := make([]Core, len(s.Cores))
+		for j, c := range s.Cores {
+			cores[j] = c.With(fields)
+		}
+		clone.stages[i] = TeeStage{Cores: cores, Timeout: s.Timeout}
+		clone.multiCore = append(clone.multiCore, cores...)
+	}
+	return clone
+}
+
+// Sync flushes the Cores stage by stage.
+func (t *orderedTee) Sync() error {
+	return t.run(func(c Core) error {
+		return c.Sync()
+	})
+}
+
+// Close flushes the Cores stage by stage, closing those that implement
+// io.Closer once they're flushed.
+func (t *orderedTee) Close() error {
+	return t.run(func(c Core) error {
+		err := c.Sync()
+		if closer, ok := c.(io.Closer); ok {
+			err = multierr.Append(err, closer.Close())
+		}
+		return err
+	})
+}
+
+func (t *orderedTee) run(op func(Core) error) error {
+	var err error
+	for i, s := range t.stages {
+		if serr := runStage(s, op); serr != nil {
+			err = multierr.Append(err, fmt.Errorf("tee stage %d: %w", i, serr))
+		}
+	}
+	return err
+}
+
+func runStage(s TeeStage, op func(Core) error) error {
+	run := func() error {
+		var err error
+		for _, c := range s.Cores {
+			err = multierr.Append(err, op(c))
+		}
+		return err
+	}
+	if s.Timeout <= 0 {
+		return run()
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- run()
+	}()
+
+	timer := time.NewTimer(s.Timeout)
+	defer timer.Stop()
+	select {
+	case err := <-done:
+		return err
+	case <-timer.C:
+		return ErrSinkTimeout
+	}
+}
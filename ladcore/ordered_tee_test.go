@@ -0,0 +1,167 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore_test
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	//revive:disable:dot-imports
+	. "github.com/auwixcom/lad/ladcore"
+	"github.com/auwixcom/lad/ladtest/observer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// shutdownRecorder records the order in which stagedCores are synced and
+// closed.
+type shutdownRecorder struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (r *shutdownRecorder) record(call string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, call)
+}
+
+func (r *shutdownRecorder) Calls() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.calls...)
+}
+
+type stagedCore struct {
+	Core
+
+	name    string
+	rec     *shutdownRecorder
+	block   chan struct{} // if non-nil, Sync waits for it to be closed
+	syncErr error
+}
+
+func (c *stagedCore) With(fields []Field) Core {
+	clone := *c
+	clone.Core = c.Core.With(fields)
+	return &clone
+}
+
+func (c *stagedCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *stagedCore) Sync() error {
+	if c.block != nil {
+		<-c.block
+	}
+	c.rec.record("sync " + c.name)
+	return c.syncErr
+}
+
+func (c *stagedCore) Close() error {
+	c.rec.record("close " + c.name)
+	return nil
+}
+
+func TestOrderedTeeWrite(t *testing.T) {
+	remote, remoteLogs := observer.New(InfoLevel)
+	file, fileLogs := observer.New(DebugLevel)
+	tee := NewOrderedTee(
+		TeeStage{Cores: []Core{remote}},
+		TeeStage{Cores: []Core{file}},
+	)
+	assert.Equal(t, DebugLevel, LevelOf(tee), "Unexpected level.")
+
+	f := makeInt64Field("k", 42)
+	tee = tee.With([]Field{f})
+	for _, lvl := range []Level{DebugLevel, InfoLevel} {
+		if ce := tee.Check(Entry{Level: lvl, Message: lvl.String()}, nil); ce != nil {
+			ce.Write()
+		}
+	}
+
+	assert.Equal(t, []string{"info"}, messages(remoteLogs), "Unexpected remote entries.")
+	assert.Equal(t, []string{"debug", "info"}, messages(fileLogs), "Unexpected file entries.")
+	assert.Equal(t, []Field{f}, fileLogs.All()[0].Context, "Expected context on all stages.")
+	assert.NoError(t, tee.Sync(), "Unexpected error syncing.")
+}
+
+func TestOrderedTeeShutdownOrder(t *testing.T) {
+	rec := &shutdownRecorder{}
+	core := func(name string) *stagedCore {
+		obs, _ := observer.New(DebugLevel)
+		return &stagedCore{Core: obs, name: name, rec: rec}
+	}
+	tee := NewOrderedTee(
+		TeeStage{Cores: []Core{core("kafka"), core("otlp")}},
+		TeeStage{Cores: []Core{core("file")}},
+	).With([]Field{makeInt64Field("k", 1)})
+
+	require.NoError(t, tee.Sync(), "Unexpected error syncing.")
+	assert.Equal(t, []string{"sync kafka", "sync otlp", "sync file"}, rec.Calls(), "Unexpected sync order.")
+
+	rec.calls = nil
+	require.Implements(t, (*io.Closer)(nil), tee, "Expected the tee to be closable.")
+	require.NoError(t, tee.(io.Closer).Close(), "Unexpected error closing.")
+	assert.Equal(t, []string{
+		"sync kafka", "close kafka",
+		"sync otlp", "close otlp",
+		"sync file", "close file",
+	}, rec.Calls(), "Expected each stage to be flushed and closed before the next.")
+}
+
+func TestOrderedTeeStageTimeout(t *testing.T) {
+	rec := &shutdownRecorder{}
+	obs, _ := observer.New(DebugLevel)
+	block := make(chan struct{})
+	defer close(block)
+
+	failed := errors.New("fail")
+	tee := NewOrderedTee(
+		TeeStage{
+			Cores:   []Core{&stagedCore{Core: obs, name: "remote", rec: rec, block: block}},
+			Timeout: 10 * time.Millisecond,
+		},
+		TeeStage{Cores: []Core{&stagedCore{Core: obs, name: "file", rec: rec, syncErr: failed}}},
+	)
+
+	err := tee.Sync()
+	assert.ErrorIs(t, err, ErrSinkTimeout, "Expected the slow stage to time out.")
+	assert.ErrorIs(t, err, failed, "Expected errors from later stages.")
+	assert.ErrorContains(t, err, "tee stage 0: ", "Expected the error to name the stage.")
+	assert.Equal(t, []string{"sync file"}, rec.Calls(), "Expected later stages to run after a timeout.")
+}
+
+func messages(logs *observer.ObservedLogs) []string {
+	var msgs []string
+	for _, e := range logs.All() {
+		msgs = append(msgs, e.Message)
+	}
+	return msgs
+}
@@ -0,0 +1,350 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/multierr"
+)
+
+const (
+	// _quotaSweepSize is how many keys a quota core tracks before it starts
+	// forgetting keys whose windows have ended.
+	_quotaSweepSize = 4096
+	// _quotaMaxKeys is the most keys a quota core tracks at once.
+	_quotaMaxKeys = 1 << 16
+)
+
+// A QuotaKeyFunc picks the quota an entry counts against, given the entry
+// and its fields, including those added with With. Entries that map to the
+// empty string aren't subject to any quota.
+type QuotaKeyFunc func(Entry, []Field) string
+
+// QuotaByLoggerName gives every logger name its own quota.
+func QuotaByLoggerName(ent Entry, _ []Field) string {
+	return ent.LoggerName
+}
+
+// QuotaByField gives every distinct value of the string field with the given
+// key, like "tenant", its own quota. Entries without the field aren't
+// subject to any quota. If the field appears more than once, the last one
+// wins.
+func QuotaByField(key string) QuotaKeyFunc {
+	return func(_ Entry, fields []Field) string {
+		for i := len(fields) - 1; i >= 0; i-- {
+			if f := fields[i]; f.Key == key && f.Type == StringType {
+				return f.String
+			}
+		}
+		return ""
+	}
+}
+
+// QuotaOption configures a Core created with NewQuotaCore.
+type QuotaOption interface {
+	apply(*quotaCore)
+}
+
+type quotaOptionFunc func(*quotaCore)
+
+func (f quotaOptionFunc) apply(c *quotaCore) {
+	f(c)
+}
+
+// QuotaEncoder sets the Encoder used to measure entries. Use the wrapped
+// Core's encoder for exact sizes. Defaults to a JSON encoder with the usual
+// keys.
+func QuotaEncoder(enc Encoder) QuotaOption {
+	return quotaOptionFunc(func(c *quotaCore) {
+		c.enc = enc.Clone()
+	})
+}
+
+// QuotaDownsample keeps one in every n entries that exceed their quota,
+// rather than dropping them all, so that some evidence of what a noisy key
+// logs survives. Values below two drop all excess entries, which is the
+// default.
+func QuotaDownsample(n int) QuotaOption {
+	return quotaOptionFunc(func(c *quotaCore) {
+		if n > 1 {
+			c.q.downsample = n
+		} else {
+			c.q.downsample = 0
+		}
+	})
+}
+
+// NewQuotaCore creates a Core that caps how many bytes of entries each key,
+// like a tenant or logger name, may log per window. Once a key uses up its
+// quota, its entries are dropped (or downsampled, see QuotaDownsample) until
+// the window ends. The wrapped Core then gets a WarnLevel summary entry with
+// the key and the number of entries and bytes dropped; summaries for keys
+// that go quiet are written by Sync.
+//
+//	core = NewQuotaCore(core, 1<<20, time.Minute, QuotaByField("tenant"))
+//
+// Entries are measured by encoding them, which doubles the cost of logging
+// entries that are subject to a quota. Like the sampler, the Core measures
+// time using each entry's Time, and the quotas are shared by the cores
+// returned from With.
+//
+// To bound its memory use, the Core tracks at most 65536 keys at a time.
+// While that many keys are within their windows, entries with other keys
+// aren't subject to any quota.
+//
+// If window isn't positive, it defaults to one minute.
+func NewQuotaCore(core Core, bytes int64, window time.Duration, key QuotaKeyFunc, opts ...QuotaOption) Core {
+	if window <= 0 {
+		window = time.Minute
+	}
+	c := &quotaCore{
+		LevelEnabler: core,
+		core:         core,
+		key:          key,
+		q: &quotaState{
+			limit:   bytes,
+			window:  window,
+			buckets: make(map[string]*quotaBucket),
+		},
+	}
+	for _, opt := range opts {
+		opt.apply(c)
+	}
+	if c.enc == nil {
+		c.enc = NewJSONEncoder(EncoderConfig{
+			MessageKey:     "msg",
+			LevelKey:       "level",
+			NameKey:        "logger",
+			TimeKey:        "ts",
+			CallerKey:      "caller",
+			StacktraceKey:  "stacktrace",
+			EncodeLevel:    LowercaseLevelEncoder,
+			EncodeTime:     EpochTimeEncoder,
+			EncodeDuration: SecondsDurationEncoder,
+			EncodeCaller:   ShortCallerEncoder,
+		})
+	}
+	return c
+}
+
+type quotaCore struct {
+	LevelEnabler
+
+	core    Core
+	enc     Encoder // measures entries; holds the context
+	context []Field // for the key function
+	key     QuotaKeyFunc
+	q       *quotaState
+}
+
+var (
+	_ Core           = (*quotaCore)(nil)
+	_ leveledEnabler = (*quotaCore)(nil)
+)
+
+func (c *quotaCore) Level() Level {
+	return LevelOf(c.core)
+}
+
+func (c *quotaCore) With(fields []Field) Core {
+	clone := *c
+	clone.core = c.core.With(fields)
+	clone.LevelEnabler = clone.core
+	clone.enc = c.enc.Clone()
+	addFields(clone.enc, fields)
+	clone.context = append(c.context[:len(c.context):len(c.context)], fields...)
+	return &clone
+}
+
+func (c *quotaCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *quotaCore) Write(ent Entry, fields []Field) error {
+	all := fields
+	if len(c.context) > 0 {
+		all = append(c.context[:len(c.context):len(c.context)], fields...)
+	}
+	key := c.key(ent, all)
+	if key == "" {
+		return checkAndWrite(c.core, ent, fields)
+	}
+
+	var size int64
+	if buf, err := c.enc.EncodeEntry(ent, fields); err == nil {
+		size = int64(buf.Len())
+		buf.Free()
+	}
+
+	keep, summary := c.q.admit(key, ent.Time, size, c.core)
+	var err error
+	if summary != nil {
+		err = summary.write()
+	}
+	if keep {
+		err = multierr.Append(err, checkAndWrite(c.core, ent, fields))
+	}
+	return err
+}
+
+// Sync writes the summaries of keys that have dropped entries, then flushes
+// the wrapped Core.
+func (c *quotaCore) Sync() error {
+	var err error
+	for _, s := range c.q.drain() {
+		err = multierr.Append(err, s.write())
+	}
+	return multierr.Append(err, c.core.Sync())
+}
+
+// quotaState holds the quotas shared by a quota core and its descendants.
+type quotaState struct {
+	limit      int64
+	window     time.Duration
+	downsample int
+
+	mu      sync.Mutex
+	buckets map[string]*quotaBucket
+	added   int // keys seen since the last sweep
+}
+
+type quotaBucket struct {
+	start time.Time // of the current window
+	used  int64     // bytes logged in the current window
+	over  int       // entries over quota in the current window
+
+	dropped      int
+	droppedBytes int64
+	lastDrop     time.Time
+	core         Core // writes the summary, with the dropping core's context
+}
+
+// quotaSummary reports the entries a key dropped.
+type quotaSummary struct {
+	key          string
+	dropped      int
+	droppedBytes int64
+	time         time.Time
+	core         Core
+}
+
+func (s *quotaSummary) write() error {
+	ent := Entry{
+		Level:   WarnLevel,
+		Time:    s.time,
+		Message: "Log quota exceeded; entries dropped.",
+	}
+	return checkAndWrite(s.core, ent, []Field{
+		{Key: "quotaKey", Type: StringType, String: s.key},
+		{Key: "dropped", Type: Int64Type, Integer: int64(s.dropped)},
+		{Key: "droppedBytes", Type: Int64Type, Integer: s.droppedBytes},
+	})
+}
+
+// takeSummary returns the summary of the entries b dropped, if any, and
+// resets the drop counts.
+func (b *quotaBucket) takeSummary(key string) *quotaSummary {
+	if b.dropped == 0 {
+		return nil
+	}
+	s := &quotaSummary{
+		key:          key,
+		dropped:      b.dropped,
+		droppedBytes: b.droppedBytes,
+		time:         b.lastDrop,
+		core:         b.core,
+	}
+	b.dropped, b.droppedBytes, b.core = 0, 0, nil
+	return s
+}
+
+// admit reports whether an entry of the given size may be logged under key
+// at time t, along with the summary of the key's previous window, if it has
+// just ended with entries dropped.
+func (q *quotaState) admit(key string, t time.Time, size int64, core Core) (bool, *quotaSummary) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	b, ok := q.buckets[key]
+	if !ok {
+		// Sweep once as many new keys have arrived as there are buckets, so
+		// the cost of sweeping is spread evenly over the new keys.
+		q.added++
+		if n := len(q.buckets); n >= _quotaSweepSize && q.added >= n {
+			q.sweep(t)
+			q.added = 0
+		}
+		if len(q.buckets) >= _quotaMaxKeys {
+			return true, nil
+		}
+		b = &quotaBucket{start: t}
+		q.buckets[key] = b
+	}
+
+	var summary *quotaSummary
+	if t.Sub(b.start) >= q.window || t.Before(b.start) {
+		summary = b.takeSummary(key)
+		b.start, b.used, b.over = t, 0, 0
+	}
+
+	if b.used+size <= q.limit {
+		b.used += size
+		return true, summary
+	}
+	b.over++
+	if q.downsample > 0 && b.over%q.downsample == 1 {
+		return true, summary
+	}
+	b.dropped++
+	b.droppedBytes += size
+	b.lastDrop = t
+	b.core = core
+	return false, summary
+}
+
+// sweep forgets keys whose windows have ended without dropping entries,
+// which would start afresh anyway.
+func (q *quotaState) sweep(t time.Time) {
+	for k, b := range q.buckets {
+		if b.dropped == 0 && t.Sub(b.start) >= q.window {
+			delete(q.buckets, k)
+		}
+	}
+}
+
+// drain takes the summaries of all keys that have dropped entries.
+func (q *quotaState) drain() []*quotaSummary {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var summaries []*quotaSummary
+	for k, b := range q.buckets {
+		if s := b.takeSummary(k); s != nil {
+			summaries = append(summaries, s)
+		}
+	}
+	return summaries
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore_test
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	//revive:disable:dot-imports
+	. "github.com/auwixcom/lad/ladcore"
+	"github.com/auwixcom/lad/ladtest/observer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuotaCore(t *testing.T) {
+	enc := NewJSONEncoder(EncoderConfig{MessageKey: "msg"})
+	tenant := func(name string) Field { return Field{Key: "tenant", Type: StringType, String: name} }
+
+	// Every entry below encodes to the same size; allow two per window.
+	buf, err := enc.EncodeEntry(Entry{Message: "m"}, []Field{tenant("a")})
+	require.NoError(t, err, "Unexpected error encoding.")
+	size := int64(buf.Len())
+	buf.Free()
+
+	obs, logs := observer.New(InfoLevel)
+	core := NewQuotaCore(obs, 2*size, time.Minute, QuotaByField("tenant"), QuotaEncoder(enc))
+	noisy := core.With([]Field{tenant("a")})
+	quiet := core.With([]Field{tenant("b")})
+
+	start := time.Unix(0, 0)
+	write := func(c Core, at time.Duration) {
+		ent := Entry{Level: InfoLevel, Message: "m", Time: start.Add(at)}
+		if ce := c.Check(ent, nil); ce != nil {
+			ce.Write()
+		}
+	}
+	for i := 0; i < 5; i++ {
+		write(noisy, time.Duration(i)*time.Second)
+	}
+	write(quiet, 0)
+	write(core, 0) // not subject to any quota
+	assert.Equal(t, 4, logs.Len(), "Expected the noisy tenant to be capped.")
+
+	write(noisy, time.Minute)
+	entries := logs.TakeAll()
+	require.Len(t, entries, 6, "Expected a summary when the window ends.")
+	summary := entries[4]
+	assert.Equal(t, WarnLevel, summary.Level, "Unexpected summary level.")
+	assert.Equal(t, start.Add(4*time.Second), summary.Time, "Expected the summary to be timed at the last drop.")
+	assert.Equal(t, map[string]interface{}{
+		"tenant":       "a",
+		"quotaKey":     "a",
+		"dropped":      int64(3),
+		"droppedBytes": 3 * size,
+	}, summary.ContextMap(), "Unexpected summary fields.")
+	assert.Equal(t, "m", entries[5].Message, "Expected the new window to admit entries.")
+
+	// Summaries of keys that go quiet are written on Sync.
+	write(noisy, time.Minute)
+	write(noisy, time.Minute)
+	assert.Equal(t, 1, logs.Len(), "Expected the quota to apply again.")
+	require.NoError(t, core.Sync(), "Unexpected error syncing.")
+	entries = logs.TakeAll()
+	require.Len(t, entries, 2, "Expected a summary on Sync.")
+	assert.Equal(t, int64(1), entries[1].ContextMap()["dropped"], "Unexpected dropped count.")
+
+	require.NoError(t, core.Sync(), "Unexpected error syncing.")
+	assert.Equal(t, 0, logs.Len(), "Expected summaries to be written once.")
+}
+
+func TestQuotaCoreDownsample(t *testing.T) {
+	obs, logs := observer.New(DebugLevel)
+	core := NewQuotaCore(obs, 0, 0, QuotaByLoggerName, QuotaDownsample(3))
+	assert.Equal(t, DebugLevel, LevelOf(core), "Unexpected level.")
+
+	for i := 0; i < 7; i++ {
+		ent := Entry{Level: InfoLevel, LoggerName: "svc", Message: "m"}
+		if ce := core.Check(ent, nil); ce != nil {
+			ce.Write()
+		}
+	}
+	assert.Equal(t, 3, logs.Len(), "Expected to keep one in three entries over quota.")
+
+	require.NoError(t, core.Sync(), "Unexpected error syncing.")
+	assert.Equal(t, int64(4), logs.All()[3].ContextMap()["dropped"], "Unexpected dropped count.")
+}
+
+func TestQuotaCoreCapsKeys(t *testing.T) {
+	obs, logs := observer.New(InfoLevel)
+	core := NewQuotaCore(obs, 0, time.Minute, QuotaByLoggerName)
+
+	now := time.Unix(0, 0)
+	write := func(name string) {
+		ent := Entry{Level: InfoLevel, LoggerName: name, Message: "m", Time: now}
+		if ce := core.Check(ent, nil); ce != nil {
+			ce.Write()
+		}
+	}
+
+	// All of these keys are within their windows, so none can be forgotten.
+	for i := 0; i < 1<<16; i++ {
+		write(strconv.Itoa(i))
+	}
+	assert.Equal(t, 0, logs.Len(), "Expected tracked keys to be over quota.")
+
+	write("untracked")
+	assert.Equal(t, 1, logs.Len(), "Expected keys beyond the cap not to be subject to a quota.")
+}
@@ -0,0 +1,243 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// _rateLimitSweepSize is how many keys a rate-limited core tracks before
+	// it starts forgetting keys whose buckets have refilled.
+	_rateLimitSweepSize = 4096
+	// _rateLimitMaxKeys is the most keys a rate-limited core tracks at once.
+	_rateLimitMaxKeys = 1 << 16
+)
+
+// A RateLimitKeyFunc picks the token bucket an entry draws from. Entries that
+// map to the same key share a bucket; entries that map to the empty string
+// aren't rate limited at all.
+type RateLimitKeyFunc func(Entry) string
+
+// KeyByMessage gives every distinct message its own bucket.
+func KeyByMessage(ent Entry) string {
+	return ent.Message
+}
+
+// KeyByLoggerAndMessage gives every distinct logger name and message pair its
+// own bucket, so that two subsystems logging the same message don't throttle
+// one another.
+func KeyByLoggerAndMessage(ent Entry) string {
+	if ent.LoggerName == "" {
+		return ent.Message
+	}
+	return ent.LoggerName + "\x00" + ent.Message
+}
+
+// KeyByMessages rate limits only the listed messages, each in its own bucket.
+// All other messages flow through unthrottled.
+func KeyByMessages(msgs ...string) RateLimitKeyFunc {
+	set := make(map[string]struct{}, len(msgs))
+	for _, msg := range msgs {
+		set[msg] = struct{}{}
+	}
+	return func(ent Entry) string {
+		if _, ok := set[ent.Message]; ok {
+			return ent.Message
+		}
+		return ""
+	}
+}
+
+// RateLimitOption configures a rate-limited Core.
+type RateLimitOption interface {
+	apply(*rateLimitedCore)
+}
+
+type rateLimitOptionFunc func(*rateLimitedCore)
+
+func (f rateLimitOptionFunc) apply(c *rateLimitedCore) {
+	f(c)
+}
+
+// RateLimitHook registers a function which will be called each time the
+// rate-limited Core decides whether to keep an entry. It's called with
+// LogDropped for entries that exceed their key's limit, and with LogSampled
+// for all others.
+func RateLimitHook(hook func(Entry, SamplingDecision)) RateLimitOption {
+	return rateLimitOptionFunc(func(c *rateLimitedCore) {
+		c.hook = hook
+	})
+}
+
+// NewRateLimitedCore creates a Core that caps how often entries sharing a key
+// are logged. Each key has its own token bucket, which refills at rate tokens
+// per second and holds up to burst tokens; an entry is logged if its bucket
+// has a token to spend and dropped otherwise.
+//
+// For example,
+//
+//	core = NewRateLimitedCore(core, 5, 10, KeyByMessages("connection refused"))
+//
+// logs at most 10 "connection refused" entries in a burst and 5 per second
+// after that, while other messages aren't limited.
+//
+// Unlike a sampler, which logs the first N entries of every tick and then
+// every Mth entry, a rate-limited core spreads the entries it keeps evenly
+// over time. Like the sampler, it measures time using each entry's Time, and
+// the buckets are shared by the cores returned from With.
+//
+// To bound its memory use, the core tracks at most 65536 keys at a time. While
+// that many keys have buckets that haven't yet refilled, entries with other
+// keys are logged as if their keys were new.
+//
+// If burst is less than one, it defaults to rate (rounded up).
+func NewRateLimitedCore(core Core, rate float64, burst int, key RateLimitKeyFunc, opts ...RateLimitOption) Core {
+	if burst < 1 {
+		burst = int(rate)
+		if float64(burst) < rate {
+			burst++
+		}
+	}
+	c := &rateLimitedCore{
+		Core: core,
+		key:  key,
+		limiter: &rateLimiter{
+			rate:    rate,
+			burst:   float64(burst),
+			buckets: make(map[string]*tokenBucket),
+		},
+		hook: nopSamplingHook,
+	}
+	for _, opt := range opts {
+		opt.apply(c)
+	}
+	return c
+}
+
+type rateLimitedCore struct {
+	Core
+
+	key     RateLimitKeyFunc
+	limiter *rateLimiter
+	hook    func(Entry, SamplingDecision)
+}
+
+var (
+	_ Core           = (*rateLimitedCore)(nil)
+	_ leveledEnabler = (*rateLimitedCore)(nil)
+)
+
+func (c *rateLimitedCore) Level() Level {
+	return LevelOf(c.Core)
+}
+
+func (c *rateLimitedCore) With(fields []Field) Core {
+	clone := *c
+	clone.Core = c.Core.With(fields)
+	return &clone
+}
+
+func (c *rateLimitedCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+
+	if key := c.key(ent); key != "" {
+		if !c.limiter.allow(key, ent.Time) {
+			c.hook(ent, LogDropped)
+			return ce
+		}
+		c.hook(ent, LogSampled)
+	}
+	return c.Core.Check(ent, ce)
+}
+
+// rateLimiter holds one token bucket per key.
+type rateLimiter struct {
+	rate  float64
+	burst float64
+
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+	added   int // keys seen since the last sweep
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time // when the bucket was last refilled
+}
+
+// allow reports whether key's bucket has a token at time t, taking it if so.
+func (l *rateLimiter) allow(key string, t time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.buckets[key]
+	if !ok {
+		// Sweep once as many new keys have arrived as there are buckets, so
+		// the cost of sweeping is spread evenly over the new keys.
+		l.added++
+		if n := len(l.buckets); n >= _rateLimitSweepSize && l.added >= n {
+			l.sweep(t)
+			l.added = 0
+		}
+		if len(l.buckets) >= _rateLimitMaxKeys {
+			// A new key's bucket would start full, so its first entry is
+			// always allowed.
+			return true
+		}
+		b = &tokenBucket{tokens: l.burst, last: t}
+		l.buckets[key] = b
+	}
+	l.refill(b, t)
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+func (l *rateLimiter) refill(b *tokenBucket, t time.Time) {
+	elapsed := t.Sub(b.last).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+	b.last = t
+	b.tokens += elapsed * l.rate
+	if b.tokens > l.burst {
+		b.tokens = l.burst
+	}
+}
+
+// sweep forgets buckets that have refilled completely by time t. They're
+// indistinguishable from the bucket a new key would get, so this bounds
+// memory use without changing any decisions.
+func (l *rateLimiter) sweep(t time.Time) {
+	for key, b := range l.buckets {
+		l.refill(b, t)
+		if b.tokens >= l.burst {
+			delete(l.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	//revive:disable:dot-imports
+	. "github.com/auwixcom/lad/ladcore"
+	"github.com/auwixcom/lad/ladtest/observer"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitedCore(t *testing.T) {
+	core, logs := observer.New(DebugLevel)
+	var dropped int
+	limited := NewRateLimitedCore(core, 2, 3, KeyByMessage, RateLimitHook(func(_ Entry, dec SamplingDecision) {
+		if dec&LogDropped > 0 {
+			dropped++
+		}
+	}))
+
+	start := time.Unix(0, 0)
+	log := func(msg string, at time.Duration) {
+		ent := Entry{Level: InfoLevel, Message: msg, Time: start.Add(at)}
+		if ce := limited.With([]Field{makeInt64Field("iter", int(at))}).Check(ent, nil); ce != nil {
+			ce.Write()
+		}
+	}
+
+	for i := 0; i < 10; i++ {
+		log("connection refused", 0)
+		log("other", 0)
+	}
+	assert.Equal(t, 3, logs.FilterMessage("connection refused").Len(), "Expected burst of entries to be logged.")
+	assert.Equal(t, 3, logs.FilterMessage("other").Len(), "Expected keys to have separate buckets.")
+	assert.Equal(t, 14, dropped, "Unexpected number of dropped entries.")
+
+	// At two tokens per second, half a second earns exactly one more entry.
+	for i := 0; i < 5; i++ {
+		log("connection refused", 500*time.Millisecond)
+	}
+	assert.Equal(t, 4, logs.FilterMessage("connection refused").Len(), "Expected bucket to refill over time.")
+
+	// Buckets never hold more than the burst.
+	for i := 0; i < 10; i++ {
+		log("connection refused", time.Hour)
+	}
+	assert.Equal(t, 7, logs.FilterMessage("connection refused").Len(), "Expected refill to be capped at the burst.")
+}
+
+func TestRateLimitedCoreKeyByMessages(t *testing.T) {
+	core, logs := observer.New(InfoLevel)
+	limited := NewRateLimitedCore(core, 1, 0, KeyByMessages("noisy"))
+
+	now := time.Now()
+	for i := 0; i < 5; i++ {
+		for _, msg := range []string{"noisy", "quiet"} {
+			if ce := limited.Check(Entry{Level: InfoLevel, Message: msg, Time: now}, nil); ce != nil {
+				ce.Write()
+			}
+		}
+	}
+	assert.Equal(t, 1, logs.FilterMessage("noisy").Len(), "Expected listed message to be limited.")
+	assert.Equal(t, 5, logs.FilterMessage("quiet").Len(), "Expected unlisted message to flow unthrottled.")
+
+	assert.Nil(t, limited.Check(Entry{Level: DebugLevel, Message: "quiet", Time: now}, nil), "Expected disabled entries to be skipped.")
+	assert.Equal(t, InfoLevel, LevelOf(limited), "Unexpected level.")
+}
+
+func TestKeyByLoggerAndMessage(t *testing.T) {
+	assert.Equal(t, "msg", KeyByLoggerAndMessage(Entry{Message: "msg"}), "Unexpected key without a logger name.")
+	assert.NotEqual(t,
+		KeyByLoggerAndMessage(Entry{LoggerName: "a", Message: "msg"}),
+		KeyByLoggerAndMessage(Entry{LoggerName: "b", Message: "msg"}),
+		"Expected loggers to have separate keys.",
+	)
+}
+
+func TestRateLimitedCoreForgetsFullBuckets(t *testing.T) {
+	core, logs := observer.New(InfoLevel)
+	limited := NewRateLimitedCore(core, 1, 1, KeyByMessage)
+
+	// Log far more distinct messages than the core tracks; the buckets of
+	// earlier keys refill and are forgotten without changing any decisions.
+	start := time.Unix(0, 0)
+	for i := 0; i < 10000; i++ {
+		ent := Entry{Level: InfoLevel, Message: fmt.Sprint(i), Time: start.Add(time.Duration(i) * time.Second)}
+		if ce := limited.Check(ent, nil); ce != nil {
+			ce.Write()
+		}
+	}
+	assert.Equal(t, 10000, logs.Len(), "Expected each distinct message to be logged.")
+}
+
+func TestRateLimitedCoreCapsKeys(t *testing.T) {
+	core, logs := observer.New(InfoLevel)
+	limited := NewRateLimitedCore(core, 1, 1, KeyByMessage)
+
+	now := time.Unix(0, 0)
+	log := func(msg string) {
+		if ce := limited.Check(Entry{Level: InfoLevel, Message: msg, Time: now}, nil); ce != nil {
+			ce.Write()
+		}
+	}
+
+	// None of these buckets refill, so none of them can be forgotten.
+	for i := 0; i < 1<<16; i++ {
+		log(fmt.Sprint(i))
+	}
+	logs.TakeAll()
+
+	log("0")
+	assert.Equal(t, 0, logs.Len(), "Expected tracked keys to stay limited.")
+	log("untracked")
+	log("untracked")
+	assert.Equal(t, 2, logs.FilterMessage("untracked").Len(), "Expected keys beyond the cap not to be limited.")
+}
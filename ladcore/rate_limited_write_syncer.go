@@ -0,0 +1,215 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore
+
+import (
+	"sync"
+	"time"
+)
+
+// A RateLimitedWriteSyncer is a WriteSyncer that caps the rate at which
+// entries reach a wrapped WriteSyncer, protecting shared disks and network
+// collectors from a misbehaving debug loop. It enforces a token bucket per
+// limit: one measured in bytes per second and one in entries (Writes) per
+// second. Writes that exceed either limit are dropped and counted in Stats,
+// but reported as successful, so a flood of logs doesn't turn into a flood of
+// write errors.
+//
+//	ws := &ladcore.RateLimitedWriteSyncer{
+//	  WS:               ladcore.AddSync(f),
+//	  BytesPerSecond:   1 << 20,
+//	  EntriesPerSecond: 1000,
+//	}
+//
+// Each Write is treated as one entry, which matches how cores write to their
+// WriteSyncers. RateLimitedWriteSyncer is safe for concurrent use if the
+// wrapped WriteSyncer is.
+type RateLimitedWriteSyncer struct {
+	// WS is the WriteSyncer being rate limited.
+	//
+	// This field is required.
+	WS WriteSyncer
+
+	// BytesPerSecond limits how many bytes are written per second. The
+	// bucket holds up to BytesBurst bytes, so short bursts above the rate are
+	// allowed.
+	//
+	// Zero means that bytes aren't limited. BytesBurst defaults to
+	// BytesPerSecond if unspecified.
+	BytesPerSecond int
+	BytesBurst     int
+
+	// EntriesPerSecond limits how many entries are written per second. The
+	// bucket holds up to EntriesBurst entries.
+	//
+	// Zero means that entries aren't limited. EntriesBurst defaults to
+	// EntriesPerSecond if unspecified.
+	EntriesPerSecond int
+	EntriesBurst     int
+
+	// Clock, if specified, provides control of the source of time for the
+	// token buckets.
+	//
+	// Defaults to the system clock.
+	Clock Clock
+
+	mu          sync.Mutex
+	initialized bool
+	last        time.Time // when the buckets were last refilled
+	bytes       float64   // tokens in the byte bucket
+	entries     float64   // tokens in the entry bucket
+	stats       RateLimitStats
+}
+
+// RateLimitStats counts the entries a RateLimitedWriteSyncer has written and
+// dropped.
+type RateLimitStats struct {
+	Written      int64 // entries written to the wrapped WriteSyncer
+	WrittenBytes int64
+	Dropped      int64 // entries dropped for exceeding a limit
+	DroppedBytes int64
+}
+
+// Write forwards bs to the wrapped WriteSyncer if doing so stays within the
+// configured limits, and drops it otherwise. Writes that fail don't count
+// against the limits.
+func (s *RateLimitedWriteSyncer) Write(bs []byte) (int, error) {
+	cost, ok := s.allow(len(bs))
+	if !ok {
+		return len(bs), nil
+	}
+	n, err := s.WS.Write(bs)
+	if err != nil {
+		s.refund(len(bs), cost)
+	}
+	return n, err
+}
+
+// Sync flushes the wrapped WriteSyncer. Syncs aren't rate limited.
+func (s *RateLimitedWriteSyncer) Sync() error {
+	return s.WS.Sync()
+}
+
+// Stats reports how many entries have been written and dropped so far.
+func (s *RateLimitedWriteSyncer) Stats() RateLimitStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stats
+}
+
+// allow reports whether a write of n bytes fits within the limits, taking
+// tokens from the buckets if it does. It also returns the number of byte
+// tokens the write costs.
+func (s *RateLimitedWriteSyncer) allow(n int) (float64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.initialized {
+		s.initialized = true
+		s.last = s.now()
+		s.bytes = float64(s.bytesBurst())
+		s.entries = float64(s.entriesBurst())
+	}
+	s.refill()
+
+	// Entries larger than the byte burst could never fit; let them through
+	// when the bucket is full instead, as though they were exactly one burst.
+	cost := float64(n)
+	if burst := float64(s.bytesBurst()); cost > burst {
+		cost = burst
+	}
+
+	if (s.BytesPerSecond > 0 && s.bytes < cost) || (s.EntriesPerSecond > 0 && s.entries < 1) {
+		s.stats.Dropped++
+		s.stats.DroppedBytes += int64(n)
+		return cost, false
+	}
+	if s.BytesPerSecond > 0 {
+		s.bytes -= cost
+	}
+	if s.EntriesPerSecond > 0 {
+		s.entries--
+	}
+	s.stats.Written++
+	s.stats.WrittenBytes += int64(n)
+	return cost, true
+}
+
+// refund returns the tokens taken by allow for a write of n bytes that
+// failed.
+func (s *RateLimitedWriteSyncer) refund(n int, cost float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.BytesPerSecond > 0 {
+		s.bytes += cost
+		if burst := float64(s.bytesBurst()); s.bytes > burst {
+			s.bytes = burst
+		}
+	}
+	if s.EntriesPerSecond > 0 {
+		s.entries++
+		if burst := float64(s.entriesBurst()); s.entries > burst {
+			s.entries = burst
+		}
+	}
+	s.stats.Written--
+	s.stats.WrittenBytes -= int64(n)
+}
+
+func (s *RateLimitedWriteSyncer) refill() {
+	now := s.now()
+	elapsed := now.Sub(s.last).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+	s.last = now
+
+	s.bytes += elapsed * float64(s.BytesPerSecond)
+	if burst := float64(s.bytesBurst()); s.bytes > burst {
+		s.bytes = burst
+	}
+	s.entries += elapsed * float64(s.EntriesPerSecond)
+	if burst := float64(s.entriesBurst()); s.entries > burst {
+		s.entries = burst
+	}
+}
+
+func (s *RateLimitedWriteSyncer) now() time.Time {
+	if s.Clock != nil {
+		return s.Clock.Now()
+	}
+	return DefaultClock.Now()
+}
+
+func (s *RateLimitedWriteSyncer) bytesBurst() int {
+	if s.BytesBurst > 0 {
+		return s.BytesBurst
+	}
+	return s.BytesPerSecond
+}
+
+func (s *RateLimitedWriteSyncer) entriesBurst() int {
+	if s.EntriesBurst > 0 {
+		return s.EntriesBurst
+	}
+	return s.EntriesPerSecond
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/auwixcom/lad/internal/ztest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitedWriteSyncerEntries(t *testing.T) {
+	clock := ztest.NewMockClock()
+	sink := &ztest.Buffer{}
+	ws := &RateLimitedWriteSyncer{
+		WS:               sink,
+		EntriesPerSecond: 2,
+		EntriesBurst:     3,
+		Clock:            clock,
+	}
+
+	for _, s := range []string{"a", "b", "c", "d"} {
+		n, err := ws.Write([]byte(s))
+		require.NoError(t, err, "Expected dropped writes to succeed.")
+		assert.Equal(t, 1, n, "Expected dropped writes to report the full length.")
+	}
+	assert.Equal(t, "abc", sink.String(), "Expected writes beyond the burst to be dropped.")
+
+	clock.Add(500 * time.Millisecond)
+	_, _ = ws.Write([]byte("e"))
+	_, _ = ws.Write([]byte("f"))
+	assert.Equal(t, "abce", sink.String(), "Expected the bucket to refill at the configured rate.")
+
+	// The bucket never holds more than the burst.
+	clock.Add(time.Hour)
+	for _, s := range []string{"g", "h", "i", "j"} {
+		_, _ = ws.Write([]byte(s))
+	}
+	assert.Equal(t, "abceghi", sink.String(), "Expected refills to be capped at the burst.")
+
+	assert.Equal(t, RateLimitStats{
+		Written:      7,
+		WrittenBytes: 7,
+		Dropped:      3,
+		DroppedBytes: 3,
+	}, ws.Stats(), "Unexpected stats.")
+	assert.NoError(t, ws.Sync(), "Unexpected error syncing.")
+}
+
+func TestRateLimitedWriteSyncerBytes(t *testing.T) {
+	clock := ztest.NewMockClock()
+	sink := &ztest.Buffer{}
+	ws := &RateLimitedWriteSyncer{
+		WS:             sink,
+		BytesPerSecond: 10,
+		Clock:          clock,
+	}
+
+	_, _ = ws.Write([]byte("123456"))
+	_, _ = ws.Write([]byte("789012")) // only 4 bytes left
+	_, _ = ws.Write([]byte("3456"))
+	assert.Equal(t, "1234563456", sink.String(), "Expected writes beyond the byte budget to be dropped.")
+
+	// Entries larger than the burst get through once the bucket is full.
+	clock.Add(time.Second)
+	big := strings.Repeat("x", 25)
+	_, _ = ws.Write([]byte(big))
+	_, _ = ws.Write([]byte("y"))
+	assert.Equal(t, "1234563456"+big, sink.String(), "Expected an oversized entry to drain the bucket.")
+
+	assert.Equal(t, RateLimitStats{
+		Written:      3,
+		WrittenBytes: 35,
+		Dropped:      2,
+		DroppedBytes: 7,
+	}, ws.Stats(), "Unexpected stats.")
+}
+
+func TestRateLimitedWriteSyncerUnlimited(t *testing.T) {
+	ws := &RateLimitedWriteSyncer{WS: &ztest.Buffer{}}
+	for i := 0; i < 100; i++ {
+		requireWriteWorks(t, ws)
+	}
+	assert.Equal(t, int64(100), ws.Stats().Written, "Expected every write through without limits.")
+	assert.Zero(t, ws.Stats().Dropped, "Expected no drops without limits.")
+}
+
+func TestRateLimitedWriteSyncerRefundsFailedWrites(t *testing.T) {
+	ws := &RateLimitedWriteSyncer{
+		WS:               &ztest.FailWriter{},
+		BytesPerSecond:   10,
+		EntriesPerSecond: 1,
+		Clock:            ztest.NewMockClock(),
+	}
+
+	for i := 0; i < 3; i++ {
+		_, err := ws.Write([]byte("123456"))
+		assert.Error(t, err, "Expected errors from the wrapped WriteSyncer.")
+	}
+	assert.Equal(t, RateLimitStats{}, ws.Stats(), "Expected failed writes not to count against the limits.")
+}
@@ -0,0 +1,221 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+)
+
+// A RedactAction is how a RedactionCore disguises a sensitive value.
+type RedactAction int
+
+const (
+	// RedactMask replaces the value with "<redacted>".
+	RedactMask RedactAction = iota
+	// RedactHash replaces the value with a truncated SHA-256 digest, like
+	// "sha256:1f2e3d4c5b6a7988". Equal values hash alike, so entries can
+	// still be correlated, but short or guessable values can be recovered
+	// by brute force; prefer RedactMask for those.
+	RedactHash
+)
+
+// Redact returns the disguised form of s.
+func (a RedactAction) Redact(s string) string {
+	if a == RedactHash {
+		sum := sha256.Sum256([]byte(s))
+		return "sha256:" + hex.EncodeToString(sum[:8])
+	}
+	return _redactedPlaceholder
+}
+
+// A RedactRule selects values for a RedactionCore to redact.
+type RedactRule struct {
+	// Key redacts the entire value of every field whose key matches,
+	// whatever the field's type.
+	Key *regexp.Regexp
+
+	// Value redacts each match within string and fmt.Stringer fields,
+	// leaving the rest of the string intact.
+	Value *regexp.Regexp
+
+	// Action selects how matches are disguised. Defaults to RedactMask.
+	Action RedactAction
+}
+
+// RedactKeys builds a RedactRule that redacts fields whose key matches the
+// given regular expression, e.g. `(?i)^(password|secret|token)$`. It panics
+// if the expression doesn't compile.
+func RedactKeys(pattern string, action RedactAction) RedactRule {
+	return RedactRule{Key: regexp.MustCompile(pattern), Action: action}
+}
+
+// RedactEmails builds a RedactRule that redacts email addresses in string
+// values.
+func RedactEmails(action RedactAction) RedactRule {
+	return RedactRule{
+		Value:  regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`),
+		Action: action,
+	}
+}
+
+// RedactCreditCards builds a RedactRule that redacts sequences of 13 to 19
+// digits, optionally separated by spaces or dashes, in string values.
+func RedactCreditCards(action RedactAction) RedactRule {
+	return RedactRule{
+		Value:  regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`),
+		Action: action,
+	}
+}
+
+// RedactBearerTokens builds a RedactRule that redacts the credentials of
+// "Bearer" and "Basic" authorization values in string values.
+func RedactBearerTokens(action RedactAction) RedactRule {
+	return RedactRule{
+		Value:  regexp.MustCompile(`(?i)\b(?:bearer|basic)\s+[A-Za-z0-9._~+/=-]+`),
+		Action: action,
+	}
+}
+
+type redactionCore struct {
+	LevelEnabler
+
+	core  Core
+	rules []RedactRule
+}
+
+var (
+	_ Core           = (*redactionCore)(nil)
+	_ leveledEnabler = (*redactionCore)(nil)
+)
+
+// NewRedactionCore wraps a Core so that fields matching any of the given
+// rules are redacted before the wrapped Core sees them, both for context
+// added with With and for fields passed at the log site.
+//
+// Rules apply to top-level fields only: they can't see inside objects,
+// arrays, or reflected values. Types that carry sensitive data should also
+// implement SensitiveValue.
+func NewRedactionCore(core Core, rules ...RedactRule) Core {
+	if len(rules) == 0 {
+		return core
+	}
+	return &redactionCore{
+		LevelEnabler: core,
+		core:         core,
+		rules:        append([]RedactRule(nil), rules...),
+	}
+}
+
+func (c *redactionCore) Level() Level {
+	return LevelOf(c.core)
+}
+
+func (c *redactionCore) With(fields []Field) Core {
+	clone := *c
+	clone.core = c.core.With(c.redact(fields))
+	clone.LevelEnabler = clone.core
+	return &clone
+}
+
+func (c *redactionCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	// The wrapped Core can't add itself to the CheckedEntry, or it would
+	// receive the fields before redaction; it's checked in Write instead.
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *redactionCore) Write(ent Entry, fields []Field) error {
+	return checkAndWrite(c.core, ent, c.redact(fields))
+}
+
+func (c *redactionCore) Sync() error {
+	return c.core.Sync()
+}
+
+// redact returns fields with the rules applied. The caller owns fields, so
+// it's copied before the first change.
+func (c *redactionCore) redact(fields []Field) []Field {
+	out, copied := fields, false
+	for i, f := range fields {
+		if r, ok := c.redactField(f); ok {
+			if !copied {
+				out, copied = append([]Field(nil), fields...), true
+			}
+			out[i] = r
+		}
+	}
+	return out
+}
+
+func (c *redactionCore) redactField(f Field) (Field, bool) {
+	// Lazy fields are replaced with their value, so that it's redacted too.
+	f, resolved := resolveLazy(f)
+	switch f.Type {
+	case NamespaceType, SkipType, InlineMarshalerType:
+		return f, resolved
+	}
+
+	for _, r := range c.rules {
+		if r.Key != nil && r.Key.MatchString(f.Key) {
+			return Field{Key: f.Key, Type: StringType, String: r.Action.Redact(fieldString(f))}, true
+		}
+	}
+
+	var s string
+	switch f.Type {
+	case StringType:
+		s = f.String
+	case StringerType, URLType:
+		s = fieldString(f)
+	default:
+		return f, resolved
+	}
+
+	changed := false
+	for _, r := range c.rules {
+		if r.Value == nil {
+			continue
+		}
+		out := r.Value.ReplaceAllStringFunc(s, r.Action.Redact)
+		if out != s {
+			s, changed = out, true
+		}
+	}
+	if !changed {
+		return f, resolved
+	}
+	return Field{Key: f.Key, Type: StringType, String: s}, true
+}
+
+// fieldString returns a string form of the field's value.
+func fieldString(f Field) string {
+	enc := NewMapObjectEncoder()
+	f.AddTo(enc)
+	if s, ok := enc.Fields[f.Key].(string); ok {
+		return s
+	}
+	return fmt.Sprint(enc.Fields[f.Key])
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore_test
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/auwixcom/lad"
+	//revive:disable:dot-imports
+	. "github.com/auwixcom/lad/ladcore"
+	"github.com/auwixcom/lad/ladtest/observer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactAction(t *testing.T) {
+	assert.Equal(t, "<redacted>", RedactMask.Redact("secret"), "Unexpected masked value.")
+	assert.Equal(t, "sha256:2bb80d537b1da3e3", RedactHash.Redact("secret"), "Unexpected hashed value.")
+	assert.Equal(t, RedactHash.Redact("secret"), RedactHash.Redact("secret"), "Expected hashes to be stable.")
+}
+
+func TestRedactionCore(t *testing.T) {
+	inner, logs := observer.New(InfoLevel)
+	core := NewRedactionCore(
+		inner,
+		RedactKeys(`(?i)^(password|token)$`, RedactMask),
+		RedactKeys(`^user_id$`, RedactHash),
+		RedactEmails(RedactMask),
+		RedactCreditCards(RedactMask),
+		RedactBearerTokens(RedactHash),
+	)
+	assert.Equal(t, InfoLevel, LevelOf(core), "Unexpected level.")
+
+	logger := lad.New(core).With(lad.String("Password", "hunter2"), lad.String("env", "prod"))
+	fields := []lad.Field{
+		lad.Int("user_id", 42),
+		lad.String("msg", "mail bob@example.com, card 4111 1111 1111 1111"),
+		lad.Stringer("auth", authHeader("Bearer abc.def")),
+		lad.URL("contact", &url.URL{Scheme: "mailto", Opaque: "bob@example.com"}),
+		lad.Namespace("token"),
+		lad.Error(errors.New("token")),
+		lad.Int("count", 3),
+	}
+	logger.Info("hello", fields...)
+	logger.Debug("disabled", lad.String("token", "x"))
+
+	require.Equal(t, 1, logs.Len(), "Expected only enabled entries to be logged.")
+	assert.Equal(t, map[string]interface{}{
+		"Password": "<redacted>",
+		"env":      "prod",
+		"user_id":  RedactHash.Redact("42"),
+		"msg":      "mail <redacted>, card <redacted>",
+		"auth":     RedactHash.Redact("Bearer abc.def"),
+		"contact":  "mailto:<redacted>",
+		"token": map[string]interface{}{
+			"error": "token",
+			"count": int64(3),
+		},
+	}, logs.All()[0].ContextMap(), "Unexpected redacted fields.")
+	assert.Equal(t, lad.Int("user_id", 42), fields[0], "Expected the caller's fields to be unchanged.")
+	assert.NoError(t, core.Sync(), "Unexpected error syncing.")
+}
+
+func TestRedactionCoreLazyFields(t *testing.T) {
+	redacted, redactedLogs := observer.New(InfoLevel)
+	raw, rawLogs := observer.New(InfoLevel)
+	logger := lad.New(NewTee(
+		NewRedactionCore(redacted, RedactKeys(`^token$`, RedactMask), RedactEmails(RedactMask)),
+		raw,
+	))
+
+	var calls int
+	logger.Info("hello",
+		lad.Lazy("token", func() lad.Field {
+			calls++
+			return lad.String("ignored", "hunter2")
+		}),
+		lad.Lazy("contact", func() lad.Field {
+			calls++
+			return lad.String("ignored", "bob@example.com")
+		}),
+	)
+
+	assert.Equal(t, 2, calls, "Expected each lazy field to be computed once for all cores.")
+	require.Equal(t, 1, redactedLogs.Len(), "Expected the entry to be logged.")
+	assert.Equal(t, map[string]interface{}{
+		"token":   "<redacted>",
+		"contact": "<redacted>",
+	}, redactedLogs.All()[0].ContextMap(), "Expected lazy fields to be redacted.")
+	require.Equal(t, 1, rawLogs.Len(), "Expected the entry to be logged.")
+	assert.Equal(t, map[string]interface{}{
+		"token":   "hunter2",
+		"contact": "bob@example.com",
+	}, rawLogs.All()[0].ContextMap(), "Expected lazy fields to be computed.")
+}
+
+func TestRedactionCoreNoRules(t *testing.T) {
+	inner, _ := observer.New(InfoLevel)
+	assert.Equal(t, inner, NewRedactionCore(inner), "Expected the Core to be returned unwrapped.")
+}
+
+type authHeader string
+
+func (h authHeader) String() string { return string(h) }
@@ -21,6 +21,9 @@
 package ladcore
 
 import (
+	"fmt"
+	"math"
+	"strconv"
 	"sync/atomic"
 	"time"
 )
@@ -124,6 +127,66 @@ func SamplerHook(hook func(entry Entry, dec SamplingDecision)) SamplerOption {
 	})
 }
 
+// A SamplerKeyFunc computes the key that entries are grouped by for
+// sampling, given the entry and its fields, including those added with With.
+// Entries are still grouped by level, too.
+type SamplerKeyFunc func(Entry, []Field) string
+
+// SamplerKey makes the Sampler group entries by the key computed by fn
+// rather than by message alone, e.g. to tell apart entries that share a
+// templated message. Since the log site's fields are only known when the
+// entry is written, the sampling decision is then made in Write rather than
+// in Check, so entries that end up dropped still cost their Check.
+func SamplerKey(fn SamplerKeyFunc) SamplerOption {
+	return optionFunc(func(s *sampler) {
+		s.key = fn
+	})
+}
+
+// SamplerKeyFields makes the Sampler group entries by their message along
+// with the values of the fields with the given keys, like "endpoint" and
+// "status". If a field appears more than once, the last one counts; missing
+// fields count as empty. See SamplerKey for details.
+func SamplerKeyFields(keys ...string) SamplerOption {
+	keys = append([]string(nil), keys...)
+	return SamplerKey(func(ent Entry, fields []Field) string {
+		key := []byte(ent.Message)
+		for _, k := range keys {
+			key = append(key, 0)
+			for i := len(fields) - 1; i >= 0; i-- {
+				if fields[i].Key == k {
+					key = appendFieldValue(key, fields[i])
+					break
+				}
+			}
+		}
+		return string(key)
+	})
+}
+
+// appendFieldValue appends a representation of f's value, good enough to
+// tell values apart.
+func appendFieldValue(b []byte, f Field) []byte {
+	switch f.Type {
+	case StringType:
+		return append(b, f.String...)
+	case BoolType:
+		return strconv.AppendBool(b, f.Integer == 1)
+	case Int64Type, Int32Type, Int16Type, Int8Type, DurationType:
+		return strconv.AppendInt(b, f.Integer, 10)
+	case Uint64Type, Uint32Type, Uint16Type, Uint8Type, UintptrType:
+		return strconv.AppendUint(b, uint64(f.Integer), 10)
+	case Float64Type:
+		return strconv.AppendFloat(b, math.Float64frombits(uint64(f.Integer)), 'g', -1, 64)
+	case Float32Type:
+		return strconv.AppendFloat(b, float64(math.Float32frombits(uint32(f.Integer))), 'g', -1, 32)
+	case StringerType, ErrorType, ReflectType, NetIPAddrType, NetIPPrefixType, URLType:
+		return fmt.Append(b, f.Interface)
+	default:
+		return b
+	}
+}
+
 // NewSamplerWithOptions creates a Core that samples incoming entries, which
 // caps the CPU and I/O load of logging while attempting to preserve a
 // representative subset of your logs.
@@ -144,7 +207,8 @@ func SamplerHook(hook func(entry Entry, dec SamplingDecision)) SamplerOption {
 // in that interval.
 //
 // Sampler can be configured to report sampling decisions with the SamplerHook
-// option.
+// option, and to group entries by more than their message with the
+// SamplerKey and SamplerKeyFields options.
 //
 // Keep in mind that lad's sampling implementation is optimized for speed over
 // absolute precision; under load, each tick may be slightly over- or
@@ -172,6 +236,8 @@ type sampler struct {
 	tick              time.Duration
 	first, thereafter uint64
 	hook              func(Entry, SamplingDecision)
+	key               SamplerKeyFunc
+	context           []Field // for key; only kept if it's set
 }
 
 var (
@@ -208,22 +274,58 @@ func (s *sampler) With(fields []Field) Core {
 		first:      s.first,
 		thereafter: s.thereafter,
 		hook:       s.hook,
+		key:        s.key,
+		context:    s.withContext(fields),
 	}
 }
 
+func (s *sampler) withContext(fields []Field) []Field {
+	if s.key == nil {
+		return nil
+	}
+	return append(s.context[:len(s.context):len(s.context)], fields...)
+}
+
 func (s *sampler) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
 	if !s.Enabled(ent.Level) {
 		return ce
 	}
 
 	if ent.Level >= _minLevel && ent.Level <= _maxLevel {
-		counter := s.counts.get(ent.Level, ent.Message)
-		n := counter.IncCheckReset(ent.Time, s.tick)
-		if n > s.first && (s.thereafter == 0 || (n-s.first)%s.thereafter != 0) {
-			s.hook(ent, LogDropped)
+		if s.key != nil {
+			// Decide in Write, once the fields are known.
+			return ce.AddCore(ent, s)
+		}
+		if !s.sample(ent, ent.Message) {
 			return ce
 		}
-		s.hook(ent, LogSampled)
 	}
 	return s.Core.Check(ent, ce)
 }
+
+func (s *sampler) Write(ent Entry, fields []Field) error {
+	if s.key == nil {
+		return s.Core.Write(ent, fields)
+	}
+	all := fields
+	if len(s.context) > 0 {
+		all = append(s.context[:len(s.context):len(s.context)], fields...)
+	}
+	if !s.sample(ent, s.key(ent, all)) {
+		return nil
+	}
+	return checkAndWrite(s.Core, ent, fields)
+}
+
+// sample reports whether to log an entry with the given key, reporting the
+// decision to the hook.
+func (s *sampler) sample(ent Entry, key string) bool {
+	counter := s.counts.get(ent.Level, key)
+	n := counter.IncCheckReset(ent.Time, s.tick)
+	if n > s.first && (s.thereafter == 0 || (n-s.first)%s.thereafter != 0) {
+		s.hook(ent, LogDropped)
+		return false
+	}
+	s.hook(ent, LogSampled)
+	return true
+}
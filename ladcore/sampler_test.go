@@ -327,3 +327,55 @@ func TestSamplerWithZeroThereafter(t *testing.T) {
 	assert.Equal(t, 4, int(counter.logs.Load()),
 		"Unexpected number of logs")
 }
+
+func TestSamplerKeyFields(t *testing.T) {
+	obs, logs := observer.New(DebugLevel)
+	var dropped int
+	core := NewSamplerWithOptions(obs, time.Minute, 1, 0,
+		SamplerKeyFields("endpoint", "status"),
+		SamplerHook(func(_ Entry, dec SamplingDecision) {
+			if dec&LogDropped > 0 {
+				dropped++
+			}
+		}),
+	).With([]Field{{Key: "endpoint", Type: StringType, String: "/users"}})
+
+	str := func(k, v string) Field { return Field{Key: k, Type: StringType, String: v} }
+	status := func(code int64) Field { return Field{Key: "status", Type: Int64Type, Integer: code} }
+	for _, fields := range [][]Field{
+		{status(200)},
+		{status(200)}, // same key as the first, dropped
+		{status(500)},
+		{str("endpoint", "/orders"), status(200)}, // the last endpoint counts
+		{},
+		{str("other", "x")}, // missing key fields count as empty, dropped
+	} {
+		if ce := core.Check(Entry{Level: InfoLevel, Message: "request done"}, nil); ce != nil {
+			ce.Write(fields...)
+		}
+	}
+	assert.Equal(t, 4, logs.Len(), "Expected entries to be sampled by message and fields.")
+	assert.Equal(t, 2, dropped, "Unexpected number of dropped entries.")
+}
+
+func TestSamplerKey(t *testing.T) {
+	obs, logs := observer.New(InfoLevel)
+	statusClass := func(_ Entry, fields []Field) string {
+		for _, f := range fields {
+			if f.Key == "status" {
+				return string(rune('0' + f.Integer/100))
+			}
+		}
+		return ""
+	}
+	core := NewSamplerWithOptions(obs, time.Minute, 1, 0, SamplerKey(statusClass))
+
+	for i, code := range []int64{200, 201, 503, 500} {
+		ent := Entry{Level: InfoLevel, Message: "done"}
+		assert.NoError(t, core.Write(ent, []Field{makeInt64Field("status", int(code))}), "Unexpected error writing entry %d.", i)
+	}
+	if ce := core.Check(Entry{Level: DebugLevel}, nil); ce != nil {
+		ce.Write()
+	}
+	assert.Equal(t, 2, logs.Len(), "Expected one entry per status class.")
+}
@@ -0,0 +1,274 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	_defaultSecretMinLength     = 20
+	_defaultSecretHexEntropy    = 3.0
+	_defaultSecretBase64Entropy = 4.0
+)
+
+// _secretLeakOutput is where leaks are reported by default.
+var _secretLeakOutput = Lock(os.Stderr)
+
+// SecretLeakError describes an entry that carries values that look like
+// secrets. A Core created with NewSecretDetectorCore reports it to stderr or
+// to the function registered with SecretLeakHook.
+type SecretLeakError struct {
+	// Message is the message of the entry that carried the values.
+	Message string
+	// Keys are the keys of the fields that carried the values.
+	Keys []string
+}
+
+func (e *SecretLeakError) Error() string {
+	return fmt.Sprintf("possible secret logged in fields %q of entry %q", e.Keys, e.Message)
+}
+
+// SecretDetectorOption configures a Core created with NewSecretDetectorCore.
+type SecretDetectorOption interface {
+	apply(*secretDetectorCore)
+}
+
+// secretDetectorOptionFunc wraps a func so it satisfies the
+// SecretDetectorOption interface.
+type secretDetectorOptionFunc func(*secretDetectorCore)
+
+func (f secretDetectorOptionFunc) apply(c *secretDetectorCore) {
+	f(c)
+}
+
+// SecretMinLength sets how long a token must be to be considered a secret.
+// Defaults to 20 characters if unspecified or non-positive.
+func SecretMinLength(n int) SecretDetectorOption {
+	return secretDetectorOptionFunc(func(c *secretDetectorCore) {
+		if n > 0 {
+			c.minLength = n
+		}
+	})
+}
+
+// SecretEntropy sets the Shannon entropy, in bits per character, above which
+// tokens are considered secrets: hex for tokens made only of hexadecimal
+// digits, and base64 for other tokens made of letters, digits, and the
+// characters "+/=_-.". Non-positive values keep the defaults of 3.0 and 4.0.
+func SecretEntropy(hex, base64 float64) SecretDetectorOption {
+	return secretDetectorOptionFunc(func(c *secretDetectorCore) {
+		if hex > 0 {
+			c.hexEntropy = hex
+		}
+		if base64 > 0 {
+			c.base64Entropy = base64
+		}
+	})
+}
+
+// SecretIgnoreKeys exempts fields with the given keys, like "trace_id", whose
+// values are random identifiers rather than secrets.
+func SecretIgnoreKeys(keys ...string) SecretDetectorOption {
+	return secretDetectorOptionFunc(func(c *secretDetectorCore) {
+		if c.ignored == nil {
+			c.ignored = make(map[string]struct{}, len(keys))
+		}
+		for _, k := range keys {
+			c.ignored[k] = struct{}{}
+		}
+	})
+}
+
+// SecretLeakHook registers a function to call, after the entry is written,
+// for each entry that carries values that look like secrets. It replaces the
+// default report to stderr. The function may be called concurrently.
+func SecretLeakHook(hook func(*SecretLeakError)) SecretDetectorOption {
+	return secretDetectorOptionFunc(func(c *secretDetectorCore) {
+		if hook != nil {
+			c.hook = hook
+		}
+	})
+}
+
+type secretDetectorCore struct {
+	LevelEnabler
+
+	core          Core
+	minLength     int
+	hexEntropy    float64
+	base64Entropy float64
+	ignored       map[string]struct{}
+	hook          func(*SecretLeakError)
+	contextKeys   []string // keys of suspicious fields added with With
+}
+
+var (
+	_ Core           = (*secretDetectorCore)(nil)
+	_ leveledEnabler = (*secretDetectorCore)(nil)
+)
+
+// NewSecretDetectorCore wraps a Core so that string values that look like
+// keys or tokens, judging by their length, alphabet, and entropy, are
+// reported. Entries are written as usual, and a *SecretLeakError naming the
+// suspicious fields is then written to stderr, or passed to the function
+// registered with SecretLeakHook. Leaks aren't returned from Write, so
+// wrappers that act on write errors, like fallback and circuit-breaking
+// cores, don't mistake them for failures. Suspicious context added with With
+// is reported with every entry.
+//
+// Each whitespace-separated word of string, byte string, and fmt.Stringer
+// fields, including computed lazy fields, is examined. The analysis is approximate and relatively expensive,
+// so the Core is meant for development and staging, to catch accidental
+// secret logging before it reaches production. Random identifiers, like
+// trace IDs, look like secrets too; exempt them with SecretIgnoreKeys.
+func NewSecretDetectorCore(core Core, opts ...SecretDetectorOption) Core {
+	c := &secretDetectorCore{
+		LevelEnabler:  core,
+		core:          core,
+		minLength:     _defaultSecretMinLength,
+		hexEntropy:    _defaultSecretHexEntropy,
+		base64Entropy: _defaultSecretBase64Entropy,
+		hook:          reportSecretLeak,
+	}
+	for _, opt := range opts {
+		opt.apply(c)
+	}
+	return c
+}
+
+func (c *secretDetectorCore) Level() Level {
+	return LevelOf(c.core)
+}
+
+func (c *secretDetectorCore) With(fields []Field) Core {
+	clone := *c
+	clone.core = c.core.With(fields)
+	clone.LevelEnabler = clone.core
+	clone.contextKeys = c.suspiciousKeys(c.contextKeys[:len(c.contextKeys):len(c.contextKeys)], fields)
+	return &clone
+}
+
+func (c *secretDetectorCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *secretDetectorCore) Write(ent Entry, fields []Field) error {
+	err := checkAndWrite(c.core, ent, fields)
+	if keys := c.suspiciousKeys(c.contextKeys, fields); len(keys) > 0 {
+		c.hook(&SecretLeakError{Message: ent.Message, Keys: keys})
+	}
+	return err
+}
+
+// reportSecretLeak is the default SecretLeakHook.
+func reportSecretLeak(leak *SecretLeakError) {
+	_, _ = fmt.Fprintf(_secretLeakOutput, "%v\n", leak)
+	_ = _secretLeakOutput.Sync() // ignore error
+}
+
+func (c *secretDetectorCore) Sync() error {
+	return c.core.Sync()
+}
+
+// suspiciousKeys appends the keys of fields whose values look like secrets
+// to keys.
+func (c *secretDetectorCore) suspiciousKeys(keys []string, fields []Field) []string {
+	for _, f := range fields {
+		f, _ = resolveLazy(f)
+		if _, ok := c.ignored[f.Key]; ok {
+			continue
+		}
+		var s string
+		switch f.Type {
+		case StringType:
+			s = f.String
+		case ByteStringType:
+			s = string(f.Interface.([]byte))
+		case StringerType:
+			s = fieldString(f)
+		default:
+			continue
+		}
+		for _, word := range strings.Fields(s) {
+			if c.looksSecret(word) {
+				keys = append(keys, f.Key)
+				break
+			}
+		}
+	}
+	return keys
+}
+
+func (c *secretDetectorCore) looksSecret(s string) bool {
+	if utf8.RuneCountInString(s) < c.minLength {
+		return false
+	}
+	hex, letters, digits := true, false, false
+	for _, r := range s {
+		switch {
+		case '0' <= r && r <= '9':
+			digits = true
+		case 'a' <= r && r <= 'f', 'A' <= r && r <= 'F':
+			letters = true
+		case 'g' <= r && r <= 'z', 'G' <= r && r <= 'Z':
+			letters, hex = true, false
+		case strings.ContainsRune("+/=_-.", r):
+			hex = false
+		default:
+			return false
+		}
+	}
+	if !letters || !digits {
+		// Keys and tokens mix letters and digits; this rules out words,
+		// identifiers, and numbers.
+		return false
+	}
+	if hex {
+		return shannonEntropy(s) >= c.hexEntropy
+	}
+	return shannonEntropy(s) >= c.base64Entropy
+}
+
+// shannonEntropy returns the Shannon entropy of s, an ASCII string, in bits
+// per character.
+func shannonEntropy(s string) float64 {
+	var counts [128]int
+	for i := 0; i < len(s); i++ {
+		counts[s[i]]++
+	}
+	var h float64
+	n := float64(len(s))
+	for _, k := range counts {
+		if k > 0 {
+			p := float64(k) / n
+			h -= p * math.Log2(p)
+		}
+	}
+	return h
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore
+
+// _redactedPlaceholder stands in for a SensitiveValue whose LogRedacted
+// method panics, so that a buggy implementation can't leak the full value.
+const _redactedPlaceholder = "<redacted>"
+
+// _inlineRedactedKey is the key of an inlined SensitiveValue's redacted form.
+const _inlineRedactedKey = "redacted"
+
+// SensitiveValue is implemented by types that must never be logged in full,
+// like credentials or personal data. Wherever lad encounters a field value,
+// marshaler, or reflected value that implements SensitiveValue, it encodes
+// the string returned by LogRedacted instead. This gives domain types a
+// single place to declare how they should appear in logs:
+//
+//	type CardNumber string
+//
+//	func (c CardNumber) LogRedacted() string {
+//	  return "****" + string(c[len(c)-4:])
+//	}
+//
+// Redaction applies to values passed to lad directly and to those handed to
+// an encoder by a marshaler. It can't see values nested inside a type that's
+// serialized by reflection; such types should implement SensitiveValue
+// themselves. An inlined SensitiveValue has no key of its own, so its
+// redacted form is logged under the key "redacted".
+type SensitiveValue interface {
+	LogRedacted() string
+}
+
+// redacted returns the redacted form of v if v is a SensitiveValue.
+func redacted(v interface{}) (string, bool) {
+	sv, ok := v.(SensitiveValue)
+	if !ok {
+		return "", false
+	}
+	return safeLogRedacted(sv), true
+}
+
+func safeLogRedacted(sv SensitiveValue) (s string) {
+	defer func() {
+		if err := recover(); err != nil {
+			s = _redactedPlaceholder
+		}
+	}()
+	return sv.LogRedacted()
+}
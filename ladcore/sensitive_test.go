@@ -0,0 +1,137 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type secret string
+
+func (s secret) LogRedacted() string { return "s***" }
+
+type secretObject struct{ token string }
+
+func (secretObject) LogRedacted() string { return "token=***" }
+
+func (o secretObject) MarshalLogObject(enc ObjectEncoder) error {
+	enc.AddString("token", o.token)
+	return nil
+}
+
+type secretError struct{}
+
+func (secretError) Error() string       { return "password=hunter2" }
+func (secretError) LogRedacted() string { return "password=***" }
+
+type panickySecret struct{}
+
+func (panickySecret) LogRedacted() string { panic("oops") }
+
+type nestedSecrets struct{}
+
+func (nestedSecrets) MarshalLogObject(enc ObjectEncoder) error {
+	if err := enc.AddReflected("reflected", secret("hunter2")); err != nil {
+		return err
+	}
+	if err := enc.AddObject("object", secretObject{token: "hunter2"}); err != nil {
+		return err
+	}
+	return enc.AddArray("array", ArrayMarshalerFunc(func(arr ArrayEncoder) error {
+		if err := arr.AppendReflected(secret("hunter2")); err != nil {
+			return err
+		}
+		return arr.AppendObject(secretObject{token: "hunter2"})
+	}))
+}
+
+func TestSensitiveValueFields(t *testing.T) {
+	tests := []struct {
+		desc  string
+		field Field
+		want  interface{}
+	}{
+		{"reflected", Field{Key: "k", Type: ReflectType, Interface: secret("hunter2")}, "s***"},
+		{"object", Field{Key: "k", Type: ObjectMarshalerType, Interface: secretObject{token: "hunter2"}}, "token=***"},
+		{"error", Field{Key: "k", Type: ErrorType, Interface: secretError{}}, "password=***"},
+		{"panic", Field{Key: "k", Type: ReflectType, Interface: panickySecret{}}, "<redacted>"},
+		{
+			"nested",
+			Field{Key: "k", Type: ObjectMarshalerType, Interface: nestedSecrets{}},
+			map[string]interface{}{
+				"reflected": "s***",
+				"object":    "token=***",
+				"array":     []interface{}{"s***", "token=***"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			enc := NewMapObjectEncoder()
+			tt.field.AddTo(enc)
+			assert.Equal(t, map[string]interface{}{"k": tt.want}, enc.Fields, "Unexpected redacted output.")
+		})
+	}
+}
+
+func TestSensitiveValueInline(t *testing.T) {
+	enc := NewMapObjectEncoder()
+	Field{Type: InlineMarshalerType, Interface: secretObject{token: "hunter2"}}.AddTo(enc)
+	assert.Equal(t, map[string]interface{}{"redacted": "token=***"}, enc.Fields, "Expected inlined sensitive values to be redacted.")
+
+	jsonEnc := NewJSONEncoder(EncoderConfig{MessageKey: "msg"})
+	buf, err := jsonEnc.EncodeEntry(Entry{Message: "login"}, []Field{
+		{Type: InlineMarshalerType, Interface: secretObject{token: "hunter2"}},
+	})
+	require.NoError(t, err, "Unexpected error encoding entry.")
+	defer buf.Free()
+	assert.Equal(t, `{"msg":"login","redacted":"token=***"}`+"\n", buf.String(), "Unexpected redacted JSON output.")
+}
+
+func TestSensitiveValueJSON(t *testing.T) {
+	enc := NewJSONEncoder(EncoderConfig{MessageKey: "msg"})
+	buf, err := enc.EncodeEntry(Entry{Message: "login"}, []Field{
+		{Key: "password", Type: ReflectType, Interface: secret("hunter2")},
+		{Key: "err", Type: ErrorType, Interface: secretError{}},
+		{Key: "nested", Type: ObjectMarshalerType, Interface: nestedSecrets{}},
+	})
+	require.NoError(t, err, "Unexpected error encoding entry.")
+	defer buf.Free()
+
+	assert.Equal(t,
+		`{"msg":"login","password":"s***","err":"password=***",`+
+			`"nested":{"reflected":"s***","object":"token=***","array":["s***","token=***"]}}`+"\n",
+		buf.String(),
+		"Unexpected redacted JSON output.",
+	)
+	assert.NotContains(t, buf.String(), "hunter2", "Sensitive value leaked.")
+}
+
+func TestSensitiveValueNonSensitive(t *testing.T) {
+	s, ok := redacted(errors.New("plain"))
+	assert.False(t, ok, "Expected plain errors not to be redacted.")
+	assert.Empty(t, s, "Unexpected redacted string.")
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore
+
+import "fmt"
+
+// A SeverityProfile maps lad's levels onto a logging backend's severity
+// scheme, as both a number and a name. Encoders and sinks that talk to such
+// backends share these profiles instead of each defining their own mapping.
+//
+// Levels outside lad's range map to the nearest supported level.
+type SeverityProfile struct {
+	name    string
+	numbers [_maxLevel - _minLevel + 1]int
+	texts   [_maxLevel - _minLevel + 1]string
+}
+
+// The profiles below list severities from DebugLevel to FatalLevel.
+var (
+	// SyslogSeverity follows RFC 5424: DPanicLevel maps to critical,
+	// PanicLevel to alert, and FatalLevel to emergency.
+	SyslogSeverity = SeverityProfile{
+		name:    "syslog",
+		numbers: [...]int{7, 6, 4, 3, 2, 1, 0},
+		texts:   [...]string{"debug", "info", "warning", "err", "crit", "alert", "emerg"},
+	}
+
+	// GCPSeverity follows Google Cloud Logging's LogSeverity.
+	GCPSeverity = SeverityProfile{
+		name:    "gcp",
+		numbers: [...]int{100, 200, 400, 500, 600, 700, 800},
+		texts:   [...]string{"DEBUG", "INFO", "WARNING", "ERROR", "CRITICAL", "ALERT", "EMERGENCY"},
+	}
+
+	// OTelSeverity follows OpenTelemetry's SeverityNumber, using the first
+	// number of each range and spreading lad's levels above ErrorLevel
+	// through the ERROR and FATAL ranges.
+	OTelSeverity = SeverityProfile{
+		name:    "otel",
+		numbers: [...]int{5, 9, 13, 17, 18, 21, 24},
+		texts:   [...]string{"DEBUG", "INFO", "WARN", "ERROR", "ERROR2", "FATAL", "FATAL4"},
+	}
+
+	// CloudWatchSeverity uses the level names understood by CloudWatch
+	// Logs Insights and Lambda's log-level filtering. CloudWatch has no
+	// numeric severities, so the numbers follow syslog.
+	CloudWatchSeverity = SeverityProfile{
+		name:    "cloudwatch",
+		numbers: SyslogSeverity.numbers,
+		texts:   [...]string{"DEBUG", "INFO", "WARN", "ERROR", "FATAL", "FATAL", "FATAL"},
+	}
+)
+
+var _severityProfiles = []SeverityProfile{SyslogSeverity, GCPSeverity, OTelSeverity, CloudWatchSeverity}
+
+func (p SeverityProfile) index(l Level) int {
+	switch {
+	case l < _minLevel:
+		l = _minLevel
+	case l > _maxLevel:
+		l = _maxLevel
+	}
+	return int(l - _minLevel)
+}
+
+// Name returns the profile's name, as accepted by UnmarshalText.
+func (p SeverityProfile) Name() string {
+	return p.name
+}
+
+// Number returns the backend's numeric severity for the level.
+func (p SeverityProfile) Number(l Level) int {
+	return p.numbers[p.index(l)]
+}
+
+// Text returns the backend's name for the level.
+func (p SeverityProfile) Text(l Level) string {
+	return p.texts[p.index(l)]
+}
+
+// TextEncoder returns a LevelEncoder that encodes levels by their name in
+// this profile.
+func (p SeverityProfile) TextEncoder() LevelEncoder {
+	return func(l Level, enc PrimitiveArrayEncoder) {
+		enc.AppendString(p.Text(l))
+	}
+}
+
+// NumberEncoder returns a LevelEncoder that encodes levels by their number
+// in this profile.
+func (p SeverityProfile) NumberEncoder() LevelEncoder {
+	return func(l Level, enc PrimitiveArrayEncoder) {
+		enc.AppendInt(p.Number(l))
+	}
+}
+
+// MarshalText marshals the profile to its name.
+func (p SeverityProfile) MarshalText() ([]byte, error) {
+	return []byte(p.name), nil
+}
+
+// UnmarshalText selects a profile by name: "syslog", "gcp", "otel", or
+// "cloudwatch". This lets configuration files choose a profile.
+func (p *SeverityProfile) UnmarshalText(text []byte) error {
+	for _, sp := range _severityProfiles {
+		if sp.name == string(text) {
+			*p = sp
+			return nil
+		}
+	}
+	return fmt.Errorf("unrecognized severity profile: %q", text)
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeverityProfiles(t *testing.T) {
+	tests := []struct {
+		profile SeverityProfile
+		numbers []int
+		texts   []string
+	}{
+		{SyslogSeverity, []int{7, 6, 4, 3, 2, 1, 0}, []string{"debug", "info", "warning", "err", "crit", "alert", "emerg"}},
+		{GCPSeverity, []int{100, 200, 400, 500, 600, 700, 800}, []string{"DEBUG", "INFO", "WARNING", "ERROR", "CRITICAL", "ALERT", "EMERGENCY"}},
+		{OTelSeverity, []int{5, 9, 13, 17, 18, 21, 24}, []string{"DEBUG", "INFO", "WARN", "ERROR", "ERROR2", "FATAL", "FATAL4"}},
+		{CloudWatchSeverity, []int{7, 6, 4, 3, 2, 1, 0}, []string{"DEBUG", "INFO", "WARN", "ERROR", "FATAL", "FATAL", "FATAL"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.profile.Name(), func(t *testing.T) {
+			var numbers []int
+			var texts []string
+			for lvl := _minLevel; lvl <= _maxLevel; lvl++ {
+				numbers = append(numbers, tt.profile.Number(lvl))
+				texts = append(texts, tt.profile.Text(lvl))
+			}
+			assert.Equal(t, tt.numbers, numbers, "Unexpected numbers.")
+			assert.Equal(t, tt.texts, texts, "Unexpected texts.")
+
+			assert.Equal(t, tt.profile.Number(DebugLevel), tt.profile.Number(DebugLevel-1), "Expected low levels to clamp.")
+			assert.Equal(t, tt.profile.Text(FatalLevel), tt.profile.Text(InvalidLevel), "Expected high levels to clamp.")
+
+			text, err := tt.profile.MarshalText()
+			require.NoError(t, err, "Unexpected error marshaling.")
+			var p SeverityProfile
+			require.NoError(t, p.UnmarshalText(text), "Unexpected error unmarshaling.")
+			assert.Equal(t, tt.profile, p, "Expected profiles to round-trip.")
+
+			enc := NewMapObjectEncoder()
+			require.NoError(t, enc.AddArray("k", ArrayMarshalerFunc(func(arr ArrayEncoder) error {
+				tt.profile.TextEncoder()(WarnLevel, arr)
+				tt.profile.NumberEncoder()(WarnLevel, arr)
+				return nil
+			})), "Unexpected error encoding.")
+			assert.Equal(t, []interface{}{tt.profile.Text(WarnLevel), tt.profile.Number(WarnLevel)}, enc.Fields["k"], "Unexpected encoded level.")
+		})
+	}
+}
+
+func TestSeverityProfileUnmarshalUnknown(t *testing.T) {
+	var p SeverityProfile
+	assert.EqualError(t, p.UnmarshalText([]byte("nope")), `unrecognized severity profile: "nope"`, "Unexpected error.")
+}
@@ -0,0 +1,391 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/auwixcom/lad/buffer"
+	"github.com/auwixcom/lad/internal/bufferpool"
+)
+
+const (
+	_defaultSyslogFacility     = 1 // user-level messages
+	_defaultSyslogSDID         = "lad@32473"
+	_defaultSyslogDialTimeout  = 5 * time.Second
+	_defaultSyslogWriteTimeout = 5 * time.Second
+
+	// _syslogBOM marks the message as UTF-8, as RFC 5424 requires.
+	_syslogBOM = "\xef\xbb\xbf"
+)
+
+// _localSyslogAddrs are the usual local syslog sockets, tried in order.
+var _localSyslogAddrs = []string{"/dev/log", "/var/run/syslog", "/var/run/log"}
+
+// SyslogConfig configures a Core created with NewSyslogCore.
+type SyslogConfig struct {
+	// Network is "udp", "tcp", "tls", "unix", or "unixgram". If both Network
+	// and Address are empty, the Core uses the local syslog daemon's socket.
+	Network string
+
+	// Address of the syslog server, e.g. "logs.example.com:6514".
+	Address string
+
+	// TLSConfig is used when Network is "tls".
+	TLSConfig *tls.Config
+
+	// Facility of the messages, from 0 (kernel) to 23 (local7).
+	// Defaults to 1 (user-level) if unspecified.
+	Facility int
+
+	// Hostname and AppName identify the sender. They default to the
+	// machine's hostname and the program's name if unspecified.
+	Hostname string
+	AppName  string
+
+	// SDID is the structured data ID under which fields are logged.
+	// Defaults to "lad@32473" if unspecified.
+	SDID string
+
+	// DialTimeout bounds each connection attempt.
+	// Defaults to 5 seconds if unspecified.
+	DialTimeout time.Duration
+
+	// WriteTimeout bounds each write, so that a stalled server can't block
+	// logging indefinitely.
+	// Defaults to 5 seconds if unspecified.
+	WriteTimeout time.Duration
+}
+
+// NewSyslogCore creates a Core that sends entries to a syslog server as RFC
+// 5424 messages. Levels map to syslog severities as in SyslogSeverity, and
+// fields become the structured data element named by SDID, with nested
+// objects flattened into dotted parameter names. Stack traces are appended
+// to the message.
+//
+// The Core connects lazily and reconnects after a failed write. Messages sent
+// over TCP and TLS are framed with octet counting, as in RFC 6587 and RFC
+// 5425. The returned Core implements io.Closer to release its connection.
+func NewSyslogCore(cfg SyslogConfig, enab LevelEnabler) (Core, error) {
+	if cfg.Facility < 0 || cfg.Facility > 23 {
+		return nil, fmt.Errorf("invalid syslog facility %d", cfg.Facility)
+	}
+	switch cfg.Network {
+	case "", "udp", "tcp", "tls", "unix", "unixgram":
+	default:
+		return nil, fmt.Errorf("unsupported syslog network %q", cfg.Network)
+	}
+	if cfg.Network == "" && cfg.Address != "" {
+		return nil, errors.New("syslog address requires a network")
+	}
+
+	if cfg.Facility == 0 {
+		cfg.Facility = _defaultSyslogFacility
+	}
+	if cfg.SDID == "" {
+		cfg.SDID = _defaultSyslogSDID
+	}
+	if cfg.DialTimeout == 0 {
+		cfg.DialTimeout = _defaultSyslogDialTimeout
+	}
+	if cfg.WriteTimeout == 0 {
+		cfg.WriteTimeout = _defaultSyslogWriteTimeout
+	}
+	if cfg.Hostname == "" {
+		cfg.Hostname, _ = os.Hostname()
+	}
+	if cfg.AppName == "" && len(os.Args) > 0 {
+		cfg.AppName = filepath.Base(os.Args[0])
+	}
+
+	return &syslogCore{
+		LevelEnabler: enab,
+		conn:         &syslogConn{cfg: cfg},
+		header: fmt.Sprintf(" %s %s %d ",
+			syslogHeaderField(cfg.Hostname, 255),
+			syslogHeaderField(cfg.AppName, 48),
+			os.Getpid(),
+		),
+	}, nil
+}
+
+type syslogCore struct {
+	LevelEnabler
+
+	conn    *syslogConn
+	header  string // " HOSTNAME APP-NAME PROCID "
+	context []Field
+}
+
+var (
+	_ Core           = (*syslogCore)(nil)
+	_ leveledEnabler = (*syslogCore)(nil)
+)
+
+func (c *syslogCore) Level() Level {
+	return LevelOf(c.LevelEnabler)
+}
+
+func (c *syslogCore) With(fields []Field) Core {
+	clone := *c
+	clone.context = append(c.context[:len(c.context):len(c.context)], fields...)
+	return &clone
+}
+
+func (c *syslogCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *syslogCore) Write(ent Entry, fields []Field) error {
+	buf := bufferpool.Get()
+	defer buf.Free()
+
+	c.format(buf, ent, fields)
+	return c.conn.write(buf.Bytes())
+}
+
+func (c *syslogCore) Sync() error {
+	return nil
+}
+
+// Close closes the connection to the syslog server.
+func (c *syslogCore) Close() error {
+	return c.conn.close()
+}
+
+// format appends an RFC 5424 message for the entry to buf.
+func (c *syslogCore) format(buf *buffer.Buffer, ent Entry, fields []Field) {
+	cfg := c.conn.cfg
+
+	buf.AppendByte('<')
+	buf.AppendInt(int64(cfg.Facility*8 + SyslogSeverity.Number(ent.Level)))
+	buf.AppendString(">1 ")
+	if ent.Time.IsZero() {
+		buf.AppendByte('-')
+	} else {
+		buf.AppendTime(ent.Time, "2006-01-02T15:04:05.000000Z07:00")
+	}
+	buf.AppendString(c.header)
+	buf.AppendString(syslogHeaderField(ent.LoggerName, 32)) // MSGID
+	buf.AppendByte(' ')
+
+	enc := NewMapObjectEncoder()
+	addFields(enc, c.context)
+	addFields(enc, fields)
+	if ent.Caller.Defined {
+		enc.AddString("caller", ent.Caller.TrimmedPath())
+	}
+	params := make(map[string]string, len(enc.Fields))
+	flattenSyslogParams(params, "", enc.Fields)
+	if len(params) == 0 {
+		buf.AppendByte('-')
+	} else {
+		keys := make([]string, 0, len(params))
+		for k := range params {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		buf.AppendByte('[')
+		buf.AppendString(cfg.SDID)
+		for _, k := range keys {
+			buf.AppendByte(' ')
+			buf.AppendString(k)
+			buf.AppendString(`="`)
+			appendSyslogParamValue(buf, params[k])
+			buf.AppendByte('"')
+		}
+		buf.AppendByte(']')
+	}
+
+	if ent.Message != "" || ent.Stack != "" {
+		buf.AppendByte(' ')
+		buf.AppendString(_syslogBOM)
+		buf.AppendString(ent.Message)
+		if ent.Stack != "" {
+			buf.AppendByte('\n')
+			buf.AppendString(ent.Stack)
+		}
+	}
+}
+
+// syslogHeaderField returns s as a header field: printable ASCII without
+// spaces, at most max bytes, or "-" if empty.
+func syslogHeaderField(s string, max int) string {
+	if s == "" {
+		return "-"
+	}
+	b := []byte(s)
+	if len(b) > max {
+		b = b[:max]
+	}
+	for i, c := range b {
+		if c < 33 || c > 126 {
+			b[i] = '_'
+		}
+	}
+	return string(b)
+}
+
+// syslogParamName returns s as an SD-PARAM name: printable ASCII other than
+// '=', ' ', ']', and '"', at most 32 bytes.
+func syslogParamName(s string) string {
+	name := []byte(syslogHeaderField(s, 32))
+	for i, c := range name {
+		if c == '=' || c == ']' || c == '"' {
+			name[i] = '_'
+		}
+	}
+	return string(name)
+}
+
+func appendSyslogParamValue(buf *buffer.Buffer, s string) {
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '"', '\\', ']':
+			buf.AppendByte('\\')
+		}
+		buf.AppendByte(s[i])
+	}
+}
+
+// flattenSyslogParams converts encoded fields into SD-PARAMs, joining the
+// keys of nested objects with dots.
+func flattenSyslogParams(params map[string]string, prefix string, fields map[string]interface{}) {
+	for k, v := range fields {
+		key := prefix + k
+		switch v := v.(type) {
+		case map[string]interface{}:
+			flattenSyslogParams(params, key+".", v)
+		case string:
+			params[syslogParamName(key)] = v
+		case time.Time:
+			params[syslogParamName(key)] = v.Format(time.RFC3339Nano)
+		case fmt.Stringer:
+			params[syslogParamName(key)] = v.String()
+		case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, uintptr, float32, float64, complex64, complex128:
+			params[syslogParamName(key)] = fmt.Sprint(v)
+		default:
+			if b, err := json.Marshal(v); err == nil {
+				params[syslogParamName(key)] = string(b)
+			} else {
+				params[syslogParamName(key)] = fmt.Sprint(v)
+			}
+		}
+	}
+}
+
+// syslogConn is the connection shared by a syslog Core and its children.
+type syslogConn struct {
+	cfg SyslogConfig
+
+	mu      sync.Mutex
+	conn    net.Conn
+	network string // network of conn, which may differ from cfg.Network
+}
+
+func (s *syslogConn) write(msg []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Retry once on a fresh connection, in case the server went away.
+	var err error
+	for attempt := 0; attempt < 2; attempt++ {
+		if s.conn == nil {
+			if s.conn, s.network, err = s.dial(); err != nil {
+				return err
+			}
+		}
+		if err = s.send(msg); err == nil {
+			return nil
+		}
+		_ = s.conn.Close()
+		s.conn = nil
+	}
+	return err
+}
+
+func (s *syslogConn) send(msg []byte) error {
+	switch s.network {
+	case "tcp", "tls":
+		// Octet counting: "MSG-LEN SP SYSLOG-MSG".
+		frame := make([]byte, 0, len(msg)+8)
+		frame = strconv.AppendInt(frame, int64(len(msg)), 10)
+		frame = append(frame, ' ')
+		msg = append(frame, msg...)
+	case "unix":
+		msg = append(msg[:len(msg):len(msg)], '\n')
+	}
+	if err := s.conn.SetWriteDeadline(time.Now().Add(s.cfg.WriteTimeout)); err != nil {
+		return err
+	}
+	_, err := s.conn.Write(msg)
+	return err
+}
+
+func (s *syslogConn) dial() (net.Conn, string, error) {
+	d := &net.Dialer{Timeout: s.cfg.DialTimeout}
+	switch s.cfg.Network {
+	case "":
+		for _, addr := range _localSyslogAddrs {
+			for _, network := range []string{"unixgram", "unix"} {
+				if conn, err := d.Dial(network, addr); err == nil {
+					return conn, network, nil
+				}
+			}
+		}
+		return nil, "", errors.New("can't connect to the local syslog daemon")
+	case "tls":
+		conn, err := tls.DialWithDialer(d, "tcp", s.cfg.Address, s.cfg.TLSConfig)
+		if err != nil {
+			return nil, "", err // don't return a typed nil
+		}
+		return conn, s.cfg.Network, nil
+	default:
+		conn, err := d.Dial(s.cfg.Network, s.cfg.Address)
+		return conn, s.cfg.Network, err
+	}
+}
+
+func (s *syslogConn) close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
@@ -0,0 +1,175 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _syslogTestTime = time.Date(2026, 10, 15, 12, 30, 45, 123456000, time.UTC)
+
+func newTestSyslogCore(t *testing.T, network, addr string) Core {
+	core, err := NewSyslogCore(SyslogConfig{
+		Network:  network,
+		Address:  addr,
+		Facility: 16,
+		Hostname: "host",
+		AppName:  "my app",
+	}, InfoLevel)
+	require.NoError(t, err, "Unexpected error creating syslog core.")
+	t.Cleanup(func() { assert.NoError(t, core.(io.Closer).Close(), "Unexpected error closing.") })
+	return core
+}
+
+func TestSyslogCoreUDP(t *testing.T) {
+	server, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err, "Failed to listen.")
+	defer server.Close()
+
+	core := newTestSyslogCore(t, "udp", server.LocalAddr().String())
+	assert.Equal(t, InfoLevel, LevelOf(core), "Unexpected level.")
+	core = core.With([]Field{{Key: "n", Type: Int64Type, Integer: 1}})
+
+	ce := core.Check(Entry{Level: WarnLevel, Time: _syslogTestTime, LoggerName: "svc", Message: "hello"}, nil)
+	require.NotNil(t, ce, "Expected the entry to be enabled.")
+	ce.Write(
+		Field{Key: "quote", Type: StringType, String: `a "b" ] \c`},
+		Field{Key: "ns", Type: NamespaceType},
+		Field{Key: "ok", Type: BoolType, Integer: 1},
+	)
+	assert.Nil(t, core.Check(Entry{Level: DebugLevel}, nil), "Expected debug entries to be disabled.")
+
+	buf := make([]byte, 1024)
+	require.NoError(t, server.SetReadDeadline(time.Now().Add(5*time.Second)), "Failed to set deadline.")
+	n, _, err := server.ReadFrom(buf)
+	require.NoError(t, err, "Failed to read message.")
+
+	want := fmt.Sprintf(
+		`<132>1 2026-10-15T12:30:45.123456Z host my_app %d svc [lad@32473 n="1" ns.ok="true" quote="a \"b\" \] \\c"] %shello`,
+		os.Getpid(), _syslogBOM,
+	)
+	assert.Equal(t, want, string(buf[:n]), "Unexpected syslog message.")
+	assert.NoError(t, core.Sync(), "Unexpected error syncing.")
+}
+
+func TestSyslogCoreTCP(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err, "Failed to listen.")
+	defer ln.Close()
+
+	received := make(chan string, 2)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		for {
+			lenStr, err := r.ReadString(' ')
+			if err != nil {
+				return
+			}
+			n, _ := strconv.Atoi(lenStr[:len(lenStr)-1])
+			msg := make([]byte, n)
+			if _, err := io.ReadFull(r, msg); err != nil {
+				return
+			}
+			received <- string(msg)
+		}
+	}()
+
+	core := newTestSyslogCore(t, "tcp", ln.Addr().String())
+	for _, msg := range []string{"one", "two"} {
+		require.NoError(t, core.Write(Entry{Level: ErrorLevel, Message: msg, Stack: "stack"}, nil), "Unexpected error writing.")
+	}
+
+	prefix := fmt.Sprintf("<131>1 - host my_app %d - - %s", os.Getpid(), _syslogBOM)
+	assert.Equal(t, prefix+"one\nstack", <-received, "Unexpected first message.")
+	assert.Equal(t, prefix+"two\nstack", <-received, "Unexpected second message.")
+}
+
+func TestSyslogCoreDialError(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err, "Failed to listen.")
+	addr := ln.Addr().String()
+	require.NoError(t, ln.Close(), "Failed to close listener.")
+
+	core := newTestSyslogCore(t, "tcp", addr)
+	assert.Error(t, core.Write(Entry{Message: "lost"}, nil), "Expected an error when the server is down.")
+}
+
+func TestSyslogCoreWriteTimeout(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err, "Failed to listen.")
+	addr := ln.Addr().String()
+	require.NoError(t, ln.Close(), "Failed to close listener.")
+
+	// The other end of the pipe never reads, like a stalled server.
+	client, server := net.Pipe()
+	defer server.Close()
+	conn := &syslogConn{
+		cfg:     SyslogConfig{Network: "tcp", Address: addr, WriteTimeout: 10 * time.Millisecond},
+		conn:    client,
+		network: "tcp",
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- conn.write([]byte("stalled")) }()
+	select {
+	case err := <-done:
+		assert.Error(t, err, "Expected an error writing to a stalled server.")
+	case <-time.After(5 * time.Second):
+		t.Fatal("Write to a stalled server didn't time out.")
+	}
+}
+
+func TestSyslogConfigValidation(t *testing.T) {
+	tests := []struct {
+		cfg     SyslogConfig
+		wantErr string
+	}{
+		{SyslogConfig{Facility: 24}, "invalid syslog facility 24"},
+		{SyslogConfig{Network: "carrier-pigeon"}, `unsupported syslog network "carrier-pigeon"`},
+		{SyslogConfig{Address: "localhost:514"}, "syslog address requires a network"},
+	}
+	for _, tt := range tests {
+		_, err := NewSyslogCore(tt.cfg, InfoLevel)
+		assert.EqualError(t, err, tt.wantErr, "Unexpected error for %+v.", tt.cfg)
+	}
+}
+
+func TestSyslogHeaderField(t *testing.T) {
+	assert.Equal(t, "-", syslogHeaderField("", 10), "Expected empty fields to be nil values.")
+	assert.Equal(t, "a_b", syslogHeaderField("a b", 10), "Expected spaces to be replaced.")
+	assert.Equal(t, "abc", syslogHeaderField("abcdef", 3), "Expected long fields to be truncated.")
+	assert.Equal(t, "a_b_c", syslogParamName(`a=b"c`), "Expected invalid name characters to be replaced.")
+}
@@ -20,7 +20,9 @@
 
 package ladcore
 
-import "go.uber.org/multierr"
+import (
+	"go.uber.org/multierr"
+)
 
 type multiCore []Core
 
@@ -94,3 +96,94 @@ func (mc multiCore) Sync() error {
 	}
 	return err
 }
+
+// TeeOption configures a Core created with NewTeeWithOptions.
+type TeeOption interface {
+	apply(*entryIDTee)
+}
+
+// teeOptionFunc wraps a func so it satisfies the TeeOption interface.
+type teeOptionFunc func(*entryIDTee)
+
+func (f teeOptionFunc) apply(t *entryIDTee) {
+	f(t)
+}
+
+// TeeEntryID makes the Tee add a field with the given key and a freshly
+// generated ID to each entry before handing it to its children, so that
+// every copy of an entry carries the same ID. This makes it possible to
+// correlate copies delivered to different backends.
+func TeeEntryID(key string) TeeOption {
+	return teeOptionFunc(func(t *entryIDTee) {
+		t.key = key
+	})
+}
+
+// TeeEntryIDGenerator sets the function used to generate entry IDs for
+// TeeEntryID. Defaults to a ULIDGenerator if unspecified.
+func TeeEntryIDGenerator(gen func() string) TeeOption {
+	return TeeIDGenerator(IDGeneratorFunc(gen))
+}
+
+// TeeIDGenerator sets the IDGenerator used to generate entry IDs for
+// TeeEntryID. Defaults to a ULIDGenerator if unspecified.
+func TeeIDGenerator(gen IDGenerator) TeeOption {
+	return teeOptionFunc(func(t *entryIDTee) {
+		t.gen = gen
+	})
+}
+
+// NewTeeWithOptions creates a Core that duplicates log entries into the
+// given Cores, like NewTee, but with additional options.
+//
+// Without TeeEntryID, it behaves exactly like NewTee.
+func NewTeeWithOptions(cores []Core, opts ...TeeOption) Core {
+	t := &entryIDTee{gen: ULIDGenerator{}}
+	for _, opt := range opts {
+		opt.apply(t)
+	}
+	if t.key == "" || len(cores) == 0 {
+		return NewTee(cores...)
+	}
+	t.multiCore = multiCore(cores)
+	return t
+}
+
+// entryIDTee is a Tee that stamps each entry with a shared ID. It takes
+// over the children's Check so that the ID is only generated once per
+// entry, at write time.
+type entryIDTee struct {
+	multiCore
+
+	key string
+	gen IDGenerator
+}
+
+var (
+	_ leveledEnabler = (*entryIDTee)(nil)
+	_ Core           = (*entryIDTee)(nil)
+)
+
+func (t *entryIDTee) With(fields []Field) Core {
+	clone := *t
+	clone.multiCore = t.multiCore.With(fields).(multiCore)
+	return &clone
+}
+
+func (t *entryIDTee) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	if t.Enabled(ent.Level) {
+		return ce.AddCore(ent, t)
+	}
+	return ce
+}
+
+func (t *entryIDTee) Write(ent Entry, fields []Field) error {
+	id := Field{Key: t.key, Type: StringType, String: t.gen.NewID()}
+	fields = append(fields[:len(fields):len(fields)], id)
+
+	var err error
+	for _, c := range t.multiCore {
+		err = multierr.Append(err, checkAndWrite(c, ent, fields))
+	}
+	return err
+}
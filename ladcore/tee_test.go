@@ -190,3 +190,58 @@ func TestTeeSync(t *testing.T) {
 	tee = NewTee(tee, noSync)
 	assert.Equal(t, err, tee.Sync(), "Expected an error when part of tee can't Sync.")
 }
+
+func TestTeeEntryID(t *testing.T) {
+	debugLogger, debugLogs := observer.New(DebugLevel)
+	warnLogger, warnLogs := observer.New(WarnLevel)
+
+	var n int
+	tee := NewTeeWithOptions(
+		[]Core{debugLogger, warnLogger},
+		TeeEntryID("entry_id"),
+		TeeEntryIDGenerator(func() string {
+			n++
+			return string(rune('a' + n - 1))
+		}),
+	).With([]Field{makeInt64Field("k", 1)})
+	assert.Equal(t, DebugLevel, LevelOf(tee), "Unexpected level.")
+
+	for _, lvl := range []Level{DebugLevel, WarnLevel} {
+		if ce := tee.Check(Entry{Level: lvl, Message: "msg"}, nil); ce != nil {
+			ce.Write()
+		}
+	}
+	assert.NoError(t, tee.Sync(), "Unexpected error syncing.")
+
+	assert.Equal(t, []map[string]interface{}{
+		{"k": int64(1), "entry_id": "a"},
+		{"k": int64(1), "entry_id": "b"},
+	}, contextMaps(debugLogs), "Unexpected debug logs.")
+	assert.Equal(t, []map[string]interface{}{
+		{"k": int64(1), "entry_id": "b"},
+	}, contextMaps(warnLogs), "Expected copies of an entry to share an ID.")
+}
+
+func TestTeeWithOptionsDefaults(t *testing.T) {
+	core, logs := observer.New(DebugLevel)
+	assert.Equal(t, core, NewTeeWithOptions([]Core{core}), "Expected a plain Tee without options.")
+
+	tee := NewTeeWithOptions([]Core{core, core}, TeeEntryID("id"))
+	if ce := tee.Check(Entry{Level: InfoLevel, Message: "msg"}, nil); ce != nil {
+		ce.Write()
+	}
+	entries := logs.TakeAll()
+	if assert.Len(t, entries, 2, "Expected an entry per child.") {
+		id := entries[0].ContextMap()["id"]
+		assert.Len(t, id, 26, "Expected a ULID by default.")
+		assert.Equal(t, id, entries[1].ContextMap()["id"], "Expected copies of an entry to share an ID.")
+	}
+}
+
+func contextMaps(logs *observer.ObservedLogs) []map[string]interface{} {
+	var maps []map[string]interface{}
+	for _, e := range logs.AllUntimed() {
+		maps = append(maps, e.ContextMap())
+	}
+	return maps
+}
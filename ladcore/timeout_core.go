@@ -0,0 +1,141 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// A TimeoutCore bounds how long each Write and Sync may block on a wrapped
+// Core, like one that sends entries over the network. Operations that don't
+// complete within the deadline are abandoned and fail with an error wrapping
+// ErrSinkTimeout, which the logger reports through its internal error output
+// instead of blocking the application.
+//
+// An abandoned operation keeps running in the background. Until it returns,
+// the wrapped Core is considered wedged, and further operations fail
+// immediately with ErrSinkTimeout, without being attempted, so that stuck
+// goroutines don't pile up. Entries are dropped in the meantime.
+//
+// Because writes complete asynchronously, fields that refer to mutable data,
+// like ObjectMarshalers, should not be modified after they're logged. Cores
+// derived with With share their parent's state and counter.
+type TimeoutCore struct {
+	inner Core
+	s     *timeoutState
+}
+
+type timeoutState struct {
+	timeout  time.Duration
+	wedged   atomic.Int32  // abandoned operations still running
+	timedOut atomic.Uint64 // operations abandoned or dropped
+}
+
+var (
+	_ Core           = (*TimeoutCore)(nil)
+	_ leveledEnabler = (*TimeoutCore)(nil)
+)
+
+// NewTimeoutCore creates a TimeoutCore that gives each Write and Sync on
+// inner at most d to complete. A d of zero or less defaults to one second.
+func NewTimeoutCore(inner Core, d time.Duration) *TimeoutCore {
+	if d <= 0 {
+		d = _defaultSinkTimeout
+	}
+	return &TimeoutCore{inner: inner, s: &timeoutState{timeout: d}}
+}
+
+// Enabled reports whether the wrapped Core is enabled at the given level.
+func (c *TimeoutCore) Enabled(lvl Level) bool {
+	return c.inner.Enabled(lvl)
+}
+
+// Level returns the minimum enabled level of the wrapped Core.
+func (c *TimeoutCore) Level() Level {
+	return LevelOf(c.inner)
+}
+
+// With adds structured context to the wrapped Core. The returned Core shares
+// this one's state.
+func (c *TimeoutCore) With(fields []Field) Core {
+	return &TimeoutCore{inner: c.inner.With(fields), s: c.s}
+}
+
+// Check adds the TimeoutCore to the CheckedEntry if the wrapped Core is
+// enabled at the entry's level.
+func (c *TimeoutCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write writes the entry to the wrapped Core, waiting at most the deadline
+// for it to complete.
+func (c *TimeoutCore) Write(ent Entry, fields []Field) error {
+	// The caller may re-use fields as soon as we return.
+	fields = append([]Field(nil), fields...)
+	return c.do("write", func() error {
+		return checkAndWrite(c.inner, ent, fields)
+	})
+}
+
+// Sync flushes the wrapped Core, waiting at most the deadline for it to
+// complete.
+func (c *TimeoutCore) Sync() error {
+	return c.do("sync", c.inner.Sync)
+}
+
+// TimedOut reports how many operations have been abandoned or dropped
+// because they didn't complete in time.
+func (c *TimeoutCore) TimedOut() uint64 {
+	return c.s.timedOut.Load()
+}
+
+func (c *TimeoutCore) do(name string, op func() error) error {
+	if c.s.wedged.Load() > 0 {
+		c.s.timedOut.Add(1)
+		return fmt.Errorf("%s dropped while an earlier operation is stuck: %w", name, ErrSinkTimeout)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- op()
+	}()
+
+	timer := time.NewTimer(c.s.timeout)
+	defer timer.Stop()
+	select {
+	case err := <-done:
+		return err
+	case <-timer.C:
+	}
+
+	c.s.timedOut.Add(1)
+	c.s.wedged.Add(1)
+	go func() {
+		<-done
+		c.s.wedged.Add(-1)
+	}()
+	return fmt.Errorf("%s abandoned after %v: %w", name, c.s.timeout, ErrSinkTimeout)
+}
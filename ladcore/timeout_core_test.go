@@ -0,0 +1,99 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/auwixcom/lad/internal/ztest"
+	//revive:disable:dot-imports
+	. "github.com/auwixcom/lad/ladcore"
+	"github.com/auwixcom/lad/ladtest/observer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingCore blocks writes and syncs until unblock is closed.
+type blockingCore struct {
+	Core
+
+	unblock chan struct{}
+}
+
+func (c *blockingCore) With(fields []Field) Core {
+	return &blockingCore{Core: c.Core.With(fields), unblock: c.unblock}
+}
+
+func (c *blockingCore) Check(ent Entry, ce *CheckedEntry) *CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *blockingCore) Write(ent Entry, fields []Field) error {
+	<-c.unblock
+	return c.Core.Write(ent, fields)
+}
+
+func (c *blockingCore) Sync() error {
+	<-c.unblock
+	return c.Core.Sync()
+}
+
+func TestTimeoutCore(t *testing.T) {
+	obs, logs := observer.New(InfoLevel)
+	inner := &blockingCore{Core: obs, unblock: make(chan struct{})}
+	core := NewTimeoutCore(inner, 10*time.Millisecond).With([]Field{makeInt64Field("k", 1)}).(*TimeoutCore)
+	assert.Equal(t, InfoLevel, LevelOf(core), "Unexpected level.")
+	assert.Nil(t, core.Check(Entry{Level: DebugLevel}, nil), "Expected debug entries to be disabled.")
+
+	errOut := &ztest.Buffer{}
+	ce := core.Check(Entry{Level: InfoLevel, Message: "slow"}, nil)
+	require.NotNil(t, ce, "Expected info entries to be enabled.")
+	ce.ErrorOutput = errOut
+	ce.Write()
+	assert.Contains(t, errOut.String(), "write abandoned after 10ms: sink operation timed out",
+		"Expected the timeout in the error output.")
+
+	err := core.Write(Entry{Level: InfoLevel, Message: "dropped"}, nil)
+	assert.ErrorIs(t, err, ErrSinkTimeout, "Expected writes to fail fast while the core is stuck.")
+	assert.ErrorIs(t, core.Sync(), ErrSinkTimeout, "Expected syncs to fail fast while the core is stuck.")
+	assert.Equal(t, uint64(3), core.TimedOut(), "Unexpected number of timeouts.")
+
+	close(inner.unblock)
+	assert.Eventually(t, func() bool {
+		return core.Write(Entry{Level: InfoLevel, Message: "recovered"}, nil) == nil
+	}, time.Second, time.Millisecond, "Expected writes to resume once the stuck write finishes.")
+	assert.NoError(t, core.Sync(), "Unexpected error syncing.")
+
+	assert.Equal(t, "slow", logs.All()[0].Message, "Expected the abandoned write to complete eventually.")
+	assert.Equal(t, []Field{makeInt64Field("k", 1)}, logs.All()[0].Context, "Expected context.")
+	assert.Equal(t, "recovered", logs.All()[logs.Len()-1].Message, "Expected writes after recovery.")
+}
+
+func TestTimeoutCoreErrors(t *testing.T) {
+	core := NewTimeoutCore(NewCore(NewJSONEncoder(testEncoderConfig()), &ztest.FailWriter{}, DebugLevel), 0)
+	assert.Error(t, core.Write(Entry{}, nil), "Expected the wrapped Core's errors.")
+	assert.Zero(t, core.TimedOut(), "Expected no timeouts.")
+}
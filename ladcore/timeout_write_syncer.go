@@ -0,0 +1,157 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// _defaultSinkTimeout specifies the default timeout used by
+// TimeoutWriteSyncer.
+const _defaultSinkTimeout = time.Second
+
+// ErrSinkTimeout is returned by a TimeoutWriteSyncer when the wrapped
+// WriteSyncer doesn't complete a Write or Sync within the configured timeout.
+var ErrSinkTimeout = errors.New("sink operation timed out")
+
+// A TimeoutWriteSyncer is a WriteSyncer that bounds how long each Write and
+// Sync may block on a wrapped WriteSyncer. It's intended for destinations that
+// can hang indefinitely, like a stale NFS mount or a dead TCP collector.
+//
+// Operations that don't complete in time return ErrSinkTimeout, which Cores
+// report through the logger's internal error output. The timed out operation
+// keeps running in the background; until it returns, the wrapped WriteSyncer
+// is considered busy and further operations wait for it (up to the timeout)
+// or, if DropOnTimeout is set, fail immediately without being attempted.
+//
+// Because writes complete asynchronously, Write copies its input.
+//
+//	ws := &ladcore.TimeoutWriteSyncer{
+//	  WS:      collector,
+//	  Timeout: 100 * time.Millisecond,
+//	}
+//	core := ladcore.NewCore(enc, ws, lvl)
+//
+// TimeoutWriteSyncer is safe for concurrent use, and serializes the
+// operations it forwards to the wrapped WriteSyncer.
+type TimeoutWriteSyncer struct {
+	// WS is the WriteSyncer whose operations are bounded.
+	//
+	// This field is required.
+	WS WriteSyncer
+
+	// Timeout is the maximum amount of time a Write or Sync may block,
+	// including time spent waiting for earlier operations to finish.
+	//
+	// Defaults to one second if unspecified.
+	Timeout time.Duration
+
+	// DropOnTimeout makes operations fail immediately with ErrSinkTimeout,
+	// rather than wait, while the wrapped WriteSyncer is still busy with an
+	// operation that timed out. Entries written in the meantime are dropped.
+	// Operations that are merely queued behind one that hasn't timed out yet
+	// still wait their turn.
+	DropOnTimeout bool
+
+	once      sync.Once
+	busy      chan struct{} // holds a token while an operation is in flight
+	abandoned atomic.Int32  // timed out operations still running
+}
+
+type timeoutResult struct {
+	n   int
+	err error
+}
+
+func (s *TimeoutWriteSyncer) initialize() {
+	s.once.Do(func() {
+		s.busy = make(chan struct{}, 1)
+	})
+}
+
+func (s *TimeoutWriteSyncer) timeout() time.Duration {
+	if s.Timeout > 0 {
+		return s.Timeout
+	}
+	return _defaultSinkTimeout
+}
+
+// Write writes bs to the wrapped WriteSyncer, waiting at most Timeout for it
+// to complete.
+func (s *TimeoutWriteSyncer) Write(bs []byte) (int, error) {
+	// The caller may re-use bs as soon as we return, which can happen before
+	// the wrapped Write does.
+	p := make([]byte, len(bs))
+	copy(p, bs)
+
+	res := s.do(func() (int, error) {
+		return s.WS.Write(p)
+	})
+	return res.n, res.err
+}
+
+// Sync flushes the wrapped WriteSyncer, waiting at most Timeout for it to
+// complete.
+func (s *TimeoutWriteSyncer) Sync() error {
+	return s.do(func() (int, error) {
+		return 0, s.WS.Sync()
+	}).err
+}
+
+func (s *TimeoutWriteSyncer) do(op func() (int, error)) timeoutResult {
+	s.initialize()
+
+	timer := time.NewTimer(s.timeout())
+	defer timer.Stop()
+
+	if s.DropOnTimeout && s.abandoned.Load() > 0 {
+		return timeoutResult{err: ErrSinkTimeout}
+	}
+	select {
+	case s.busy <- struct{}{}:
+	case <-timer.C:
+		return timeoutResult{err: ErrSinkTimeout}
+	}
+
+	done := make(chan timeoutResult, 1)
+	go func() {
+		n, err := op()
+		done <- timeoutResult{n: n, err: err}
+	}()
+
+	select {
+	case res := <-done:
+		<-s.busy // release the wrapped WriteSyncer for the next operation
+		return res
+	case <-timer.C:
+	}
+
+	s.abandoned.Add(1)
+	go func() {
+		<-done
+		s.abandoned.Add(-1)
+		<-s.busy
+	}()
+	return timeoutResult{err: ErrSinkTimeout}
+}
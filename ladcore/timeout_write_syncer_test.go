@@ -0,0 +1,179 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladcore
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/auwixcom/lad/internal/ztest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingWriteSyncer blocks every Write and Sync until unblock is called.
+type blockingWriteSyncer struct {
+	mu      sync.Mutex
+	buf     bytes.Buffer
+	release chan struct{}
+}
+
+func newBlockingWriteSyncer() *blockingWriteSyncer {
+	return &blockingWriteSyncer{release: make(chan struct{})}
+}
+
+func (w *blockingWriteSyncer) Write(bs []byte) (int, error) {
+	<-w.release
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.Write(bs)
+}
+
+func (w *blockingWriteSyncer) Sync() error {
+	<-w.release
+	return nil
+}
+
+func (w *blockingWriteSyncer) unblock() { close(w.release) }
+
+func (w *blockingWriteSyncer) String() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.String()
+}
+
+// slowWriteSyncer delays every Write.
+type slowWriteSyncer struct {
+	WriteSyncer
+
+	delay time.Duration
+}
+
+func (w *slowWriteSyncer) Write(bs []byte) (int, error) {
+	time.Sleep(w.delay)
+	return w.WriteSyncer.Write(bs)
+}
+
+func TestTimeoutWriteSyncer(t *testing.T) {
+	t.Run("passthrough", func(t *testing.T) {
+		buf := &ztest.Buffer{}
+		ws := &TimeoutWriteSyncer{WS: buf}
+
+		requireWriteWorks(t, ws)
+		assert.Equal(t, "foo", buf.String(), "Unexpected log string.")
+		assert.NoError(t, ws.Sync(), "Unexpected error syncing.")
+		assert.True(t, buf.Called(), "Expected Sync to be forwarded.")
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		ws := &TimeoutWriteSyncer{WS: &ztest.FailWriter{}}
+		_, err := ws.Write([]byte("foo"))
+		assert.EqualError(t, err, "failed", "Expected the wrapped error.")
+	})
+
+	t.Run("write timeout", func(t *testing.T) {
+		blocked := newBlockingWriteSyncer()
+		ws := &TimeoutWriteSyncer{WS: blocked, Timeout: time.Millisecond}
+
+		msg := []byte("foo")
+		n, err := ws.Write(msg)
+		assert.ErrorIs(t, err, ErrSinkTimeout, "Expected a timeout.")
+		assert.Zero(t, n, "Expected no bytes to be reported as written.")
+		copy(msg, "bar") // must not affect the pending write
+
+		assert.ErrorIs(t, ws.Sync(), ErrSinkTimeout, "Expected Sync to time out while busy.")
+
+		blocked.unblock()
+		require.Eventually(t, func() bool {
+			_, err := ws.Write([]byte("baz"))
+			return err == nil
+		}, time.Second, time.Millisecond, "Expected writes to resume.")
+		assert.Equal(t, "foobaz", blocked.String(), "Unexpected log string.")
+	})
+
+	t.Run("drop on timeout", func(t *testing.T) {
+		blocked := newBlockingWriteSyncer()
+		ws := &TimeoutWriteSyncer{
+			WS:            blocked,
+			Timeout:       time.Millisecond,
+			DropOnTimeout: true,
+		}
+
+		_, err := ws.Write([]byte("foo"))
+		require.ErrorIs(t, err, ErrSinkTimeout, "Expected a timeout.")
+
+		// While the first write is stuck, further writes fail immediately even
+		// with a generous timeout.
+		ws.Timeout = time.Hour
+		_, err = ws.Write([]byte("bar"))
+		assert.ErrorIs(t, err, ErrSinkTimeout, "Expected the write to be dropped.")
+
+		blocked.unblock()
+		require.Eventually(t, func() bool {
+			return ws.Sync() == nil
+		}, time.Second, time.Millisecond, "Expected the sink to recover.")
+		assert.Equal(t, "foo", blocked.String(), "Expected dropped writes to be discarded.")
+	})
+
+	t.Run("drop on timeout queues behind slow writes", func(t *testing.T) {
+		buf := &ztest.Buffer{}
+		ws := &TimeoutWriteSyncer{
+			WS:            &slowWriteSyncer{WriteSyncer: buf, delay: 10 * time.Millisecond},
+			Timeout:       time.Minute,
+			DropOnTimeout: true,
+		}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := ws.Write([]byte("x"))
+				assert.NoError(t, err, "Expected writes behind a slow write to wait rather than drop.")
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, "xxxx", buf.String(), "Expected every write to reach the sink.")
+	})
+}
+
+func TestTimeoutWriteSyncerErrorOutput(t *testing.T) {
+	blocked := newBlockingWriteSyncer()
+	defer blocked.unblock()
+
+	errOut := &ztest.Buffer{}
+	core := NewCore(
+		NewJSONEncoder(EncoderConfig{MessageKey: "msg"}),
+		&TimeoutWriteSyncer{WS: blocked, Timeout: time.Millisecond},
+		DebugLevel,
+	)
+
+	ent := Entry{Level: InfoLevel, Message: "hello"}
+	ce := core.Check(ent, nil)
+	require.NotNil(t, ce, "Expected the entry to be enabled.")
+	ce.ErrorOutput = errOut
+	ce.Write()
+
+	assert.Contains(t, errOut.String(), ErrSinkTimeout.Error(), "Expected the timeout to be reported.")
+	assert.ErrorIs(t, core.Sync(), ErrSinkTimeout, "Expected Sync to time out as well.")
+}
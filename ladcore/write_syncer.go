@@ -22,6 +22,7 @@ package ladcore
 
 import (
 	"io"
+	"os"
 	"sync"
 
 	"go.uber.org/multierr"
@@ -46,6 +47,49 @@ func AddSync(w io.Writer) WriteSyncer {
 	}
 }
 
+// A WriteSyncCloser is a WriteSyncer that can also be closed, releasing the
+// underlying resource. Network connections and compressing writers need to
+// be closed to flush their final bytes.
+//
+// Close doesn't imply Sync: callers that want buffered data written should
+// Sync first. The WriteSyncers returned by Lock and NewMultiWriteSyncer, and
+// BufferedWriteSyncer, implement WriteSyncCloser by closing the WriteSyncers
+// they wrap, if those implement io.Closer; use CloseWriteSyncer to close any
+// WriteSyncer that may or may not be closable.
+type WriteSyncCloser interface {
+	WriteSyncer
+	io.Closer
+}
+
+// AddSyncCloser converts an io.WriteCloser to a WriteSyncCloser. Like
+// AddSync, it uses the existing Sync method of the concrete type, if there is
+// one, and adds a no-op Sync otherwise.
+func AddSyncCloser(w io.WriteCloser) WriteSyncCloser {
+	switch w := w.(type) {
+	case WriteSyncCloser:
+		return w
+	default:
+		return writeCloserWrapper{w}
+	}
+}
+
+type writeCloserWrapper struct {
+	io.WriteCloser
+}
+
+func (w writeCloserWrapper) Sync() error {
+	return nil
+}
+
+// CloseWriteSyncer closes ws if it implements io.Closer, and does nothing
+// otherwise.
+func CloseWriteSyncer(ws WriteSyncer) error {
+	if c, ok := ws.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
 type lockedWriteSyncer struct {
 	sync.Mutex
 	ws WriteSyncer
@@ -53,6 +97,10 @@ type lockedWriteSyncer struct {
 
 // Lock wraps a WriteSyncer in a mutex to make it safe for concurrent use. In
 // particular, *os.Files must be locked before use.
+//
+// Closing the returned WriteSyncer closes ws if it implements io.Closer,
+// unless ws is os.Stdout or os.Stderr: the process owns those, and they're
+// often shared with other code, so closing them is a no-op.
 func Lock(ws WriteSyncer) WriteSyncer {
 	if _, ok := ws.(*lockedWriteSyncer); ok {
 		// no need to layer on another lock
@@ -75,6 +123,16 @@ func (s *lockedWriteSyncer) Sync() error {
 	return err
 }
 
+func (s *lockedWriteSyncer) Close() error {
+	if f, ok := s.ws.(*os.File); ok && (f == os.Stdout || f == os.Stderr) {
+		return nil
+	}
+	s.Lock()
+	err := CloseWriteSyncer(s.ws)
+	s.Unlock()
+	return err
+}
+
 type writerWrapper struct {
 	io.Writer
 }
@@ -86,7 +144,9 @@ func (w writerWrapper) Sync() error {
 type multiWriteSyncer []WriteSyncer
 
 // NewMultiWriteSyncer creates a WriteSyncer that duplicates its writes
-// and sync calls, much like io.MultiWriter.
+// and sync calls, much like io.MultiWriter. Closing it closes each of the
+// WriteSyncers that implement io.Closer. Errors from all targets are
+// combined; see NewMultiWriteSyncerWithOptions for other error policies.
 func NewMultiWriteSyncer(ws ...WriteSyncer) WriteSyncer {
 	if len(ws) == 1 {
 		return ws[0]
@@ -120,3 +180,11 @@ func (ws multiWriteSyncer) Sync() error {
 	}
 	return err
 }
+
+func (ws multiWriteSyncer) Close() error {
+	var err error
+	for _, w := range ws {
+		err = multierr.Append(err, CloseWriteSyncer(w))
+	}
+	return err
+}
@@ -24,6 +24,7 @@ import (
 	"bytes"
 	"errors"
 	"io"
+	"os"
 	"testing"
 
 	"github.com/auwixcom/lad/internal/ztest"
@@ -134,3 +135,86 @@ func TestMultiWriteSyncerSync_AllCalled(t *testing.T) {
 	assert.True(t, failed.Called(), "Expected first sink to have Sync method called.")
 	assert.True(t, second.Called(), "Expected call to Sync even with first failure.")
 }
+
+// closeSpy is a WriteSyncer that records calls to Close.
+type closeSpy struct {
+	bytes.Buffer
+	ztest.Syncer
+
+	closed int
+	err    error
+}
+
+func (c *closeSpy) Close() error {
+	c.closed++
+	return c.err
+}
+
+// writeCloseSpy is an io.WriteCloser without a Sync method.
+type writeCloseSpy struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (c *writeCloseSpy) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestAddSyncCloser(t *testing.T) {
+	t.Run("write closer", func(t *testing.T) {
+		w := &writeCloseSpy{}
+		ws := AddSyncCloser(w)
+		requireWriteWorks(t, ws)
+		assert.NoError(t, ws.Sync(), "Expected a no-op Sync.")
+		require.NoError(t, ws.Close(), "Unexpected error closing.")
+		assert.True(t, w.closed, "Expected Close to reach the io.WriteCloser.")
+	})
+
+	t.Run("write sync closer", func(t *testing.T) {
+		spy := &closeSpy{}
+		ws := AddSyncCloser(spy)
+		assert.Same(t, spy, ws, "Expected WriteSyncClosers to be returned as-is.")
+	})
+}
+
+func TestCloseWriteSyncer(t *testing.T) {
+	assert.NoError(t, CloseWriteSyncer(AddSync(&bytes.Buffer{})), "Expected non-closers to be ignored.")
+
+	spy := &closeSpy{err: errors.New("fail")}
+	assert.Equal(t, spy.err, CloseWriteSyncer(spy), "Expected Close errors to propagate.")
+	assert.Equal(t, 1, spy.closed, "Expected Close to be called.")
+}
+
+func TestWrappersPropagateClose(t *testing.T) {
+	t.Run("lock", func(t *testing.T) {
+		spy := &closeSpy{}
+		require.NoError(t, CloseWriteSyncer(Lock(spy)), "Unexpected error closing.")
+		assert.Equal(t, 1, spy.closed, "Expected Lock to propagate Close.")
+	})
+
+	t.Run("lock std streams", func(t *testing.T) {
+		for _, f := range []*os.File{os.Stdout, os.Stderr} {
+			require.NoError(t, CloseWriteSyncer(Lock(f)), "Unexpected error closing %v.", f.Name())
+			_, err := f.Stat()
+			assert.NoError(t, err, "Expected %v to stay open.", f.Name())
+		}
+	})
+
+	t.Run("multi", func(t *testing.T) {
+		a, b := &closeSpy{}, &closeSpy{err: errors.New("fail")}
+		ws := NewMultiWriteSyncer(a, AddSync(&bytes.Buffer{}), b)
+		assert.Error(t, CloseWriteSyncer(ws), "Expected Close errors to propagate.")
+		assert.Equal(t, 1, a.closed, "Expected first closer to be closed.")
+		assert.Equal(t, 1, b.closed, "Expected second closer to be closed.")
+	})
+
+	t.Run("buffered", func(t *testing.T) {
+		spy := &closeSpy{}
+		ws := &BufferedWriteSyncer{WS: spy}
+		requireWriteWorks(t, ws)
+		require.NoError(t, CloseWriteSyncer(ws), "Unexpected error closing.")
+		assert.Equal(t, "foo", spy.String(), "Expected Close to flush buffered data.")
+		assert.Equal(t, 1, spy.closed, "Expected BufferedWriteSyncer to propagate Close.")
+	})
+}
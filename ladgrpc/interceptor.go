@@ -0,0 +1,274 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladgrpc
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/auwixcom/lad"
+	"github.com/auwixcom/lad/ladcore"
+)
+
+// A Code is a gRPC status code. Its values match those of
+// google.golang.org/grpc/codes, so conversions between the two are safe.
+type Code uint32
+
+// gRPC status codes.
+const (
+	OK Code = iota
+	Canceled
+	Unknown
+	InvalidArgument
+	DeadlineExceeded
+	NotFound
+	AlreadyExists
+	PermissionDenied
+	ResourceExhausted
+	FailedPrecondition
+	Aborted
+	OutOfRange
+	Unimplemented
+	Internal
+	Unavailable
+	DataLoss
+	Unauthenticated
+)
+
+var _codeNames = [...]string{
+	OK:                 "OK",
+	Canceled:           "Canceled",
+	Unknown:            "Unknown",
+	InvalidArgument:    "InvalidArgument",
+	DeadlineExceeded:   "DeadlineExceeded",
+	NotFound:           "NotFound",
+	AlreadyExists:      "AlreadyExists",
+	PermissionDenied:   "PermissionDenied",
+	ResourceExhausted:  "ResourceExhausted",
+	FailedPrecondition: "FailedPrecondition",
+	Aborted:            "Aborted",
+	OutOfRange:         "OutOfRange",
+	Unimplemented:      "Unimplemented",
+	Internal:           "Internal",
+	Unavailable:        "Unavailable",
+	DataLoss:           "DataLoss",
+	Unauthenticated:    "Unauthenticated",
+}
+
+// String returns the code's name, as gRPC spells it.
+func (c Code) String() string {
+	if int(c) < len(_codeNames) {
+		return _codeNames[c]
+	}
+	return "Code(" + strconv.FormatUint(uint64(c), 10) + ")"
+}
+
+// CodeOf returns the gRPC status code of an error returned by a handler:
+// OK for nil, the code of errors carrying a gRPC status (anywhere in their
+// chain), Canceled and DeadlineExceeded for the corresponding context errors,
+// and Unknown otherwise.
+func CodeOf(err error) Code {
+	if err == nil {
+		return OK
+	}
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		// Errors from google.golang.org/grpc/status have a GRPCStatus method
+		// returning a *status.Status, whose Code method returns a codes.Code.
+		// Look them up by name so that this package needn't depend on gRPC.
+		m := reflect.ValueOf(e).MethodByName("GRPCStatus")
+		if !m.IsValid() || m.Type().NumIn() != 0 || m.Type().NumOut() != 1 {
+			continue
+		}
+		st := m.Call(nil)[0]
+		if st.Kind() == reflect.Ptr && st.IsNil() {
+			continue
+		}
+		code := st.MethodByName("Code")
+		if code.IsValid() && code.Type().NumIn() == 0 && code.Type().NumOut() == 1 &&
+			code.Type().Out(0).Kind() == reflect.Uint32 {
+			return Code(code.Call(nil)[0].Uint())
+		}
+	}
+	switch {
+	case errors.Is(err, context.Canceled):
+		return Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return DeadlineExceeded
+	}
+	return Unknown
+}
+
+// DefaultCodeLevels maps gRPC status codes to the levels at which a
+// ServerLogger logs calls that end with them: client errors at InfoLevel,
+// conditions worth watching at WarnLevel, and server faults at ErrorLevel.
+var DefaultCodeLevels = map[Code]ladcore.Level{
+	OK:                 ladcore.InfoLevel,
+	Canceled:           ladcore.InfoLevel,
+	InvalidArgument:    ladcore.InfoLevel,
+	NotFound:           ladcore.InfoLevel,
+	AlreadyExists:      ladcore.InfoLevel,
+	Unauthenticated:    ladcore.InfoLevel,
+	DeadlineExceeded:   ladcore.WarnLevel,
+	PermissionDenied:   ladcore.WarnLevel,
+	ResourceExhausted:  ladcore.WarnLevel,
+	FailedPrecondition: ladcore.WarnLevel,
+	Aborted:            ladcore.WarnLevel,
+	OutOfRange:         ladcore.WarnLevel,
+	Unknown:            ladcore.ErrorLevel,
+	Unimplemented:      ladcore.ErrorLevel,
+	Internal:           ladcore.ErrorLevel,
+	Unavailable:        ladcore.ErrorLevel,
+	DataLoss:           ladcore.ErrorLevel,
+}
+
+// A ServerOption configures a ServerLogger.
+type ServerOption interface {
+	applyServer(*ServerLogger)
+}
+
+type serverOptionFunc func(*ServerLogger)
+
+func (f serverOptionFunc) applyServer(s *ServerLogger) {
+	f(s)
+}
+
+// WithCodeLevel logs calls that end with code at lvl, overriding
+// DefaultCodeLevels.
+func WithCodeLevel(code Code, lvl ladcore.Level) ServerOption {
+	return serverOptionFunc(func(s *ServerLogger) {
+		s.levels[code] = lvl
+	})
+}
+
+// WithPeer reports the address of the calling peer, which is logged under
+// "peer.address". It's typically
+//
+//	func(ctx context.Context) string {
+//		if p, ok := peer.FromContext(ctx); ok {
+//			return p.Addr.String()
+//		}
+//		return ""
+//	}
+func WithPeer(peer func(context.Context) string) ServerOption {
+	return serverOptionFunc(func(s *ServerLogger) {
+		s.peer = peer
+	})
+}
+
+// WithServerClock configures the clock used to time calls.
+func WithServerClock(clock ladcore.Clock) ServerOption {
+	return serverOptionFunc(func(s *ServerLogger) {
+		s.clock = clock
+	})
+}
+
+// A ServerLogger logs one entry for each gRPC call a server handles, with
+// its method, duration, status code, and peer. The entry's level depends on
+// the status code; see DefaultCodeLevels.
+//
+// Its methods are shaped to be called from gRPC interceptors without this
+// package depending on gRPC:
+//
+//	calls := ladgrpc.NewServerLogger(logger, ladgrpc.WithPeer(peerAddr))
+//	srv := grpc.NewServer(
+//		grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, h grpc.UnaryHandler) (interface{}, error) {
+//			return calls.Unary(ctx, req, info.FullMethod, h)
+//		}),
+//		grpc.StreamInterceptor(func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, h grpc.StreamHandler) error {
+//			return calls.Stream(ss.Context(), info.FullMethod, func() error { return h(srv, ss) })
+//		}),
+//	)
+type ServerLogger struct {
+	log    *lad.Logger
+	levels map[Code]ladcore.Level
+	peer   func(context.Context) string
+	clock  ladcore.Clock
+}
+
+// NewServerLogger builds a ServerLogger that writes to l.
+func NewServerLogger(l *lad.Logger, opts ...ServerOption) *ServerLogger {
+	s := &ServerLogger{
+		log:    l,
+		levels: make(map[Code]ladcore.Level, len(DefaultCodeLevels)),
+		clock:  ladcore.DefaultClock,
+	}
+	for code, lvl := range DefaultCodeLevels {
+		s.levels[code] = lvl
+	}
+	for _, opt := range opts {
+		opt.applyServer(s)
+	}
+	return s
+}
+
+// Unary calls handler and logs the call. Pass it a grpc.UnaryHandler and
+// the method from grpc.UnaryServerInfo.FullMethod.
+func (s *ServerLogger) Unary(
+	ctx context.Context,
+	req interface{},
+	fullMethod string,
+	handler func(context.Context, interface{}) (interface{}, error),
+) (interface{}, error) {
+	start := s.clock.Now()
+	resp, err := handler(ctx, req)
+	s.logCall(ctx, "finished unary call", fullMethod, start, err)
+	return resp, err
+}
+
+// Stream calls handler, which should run the grpc.StreamHandler, and logs
+// the call. Pass it the stream's context and the method from
+// grpc.StreamServerInfo.FullMethod.
+func (s *ServerLogger) Stream(ctx context.Context, fullMethod string, handler func() error) error {
+	start := s.clock.Now()
+	err := handler()
+	s.logCall(ctx, "finished streaming call", fullMethod, start, err)
+	return err
+}
+
+func (s *ServerLogger) logCall(ctx context.Context, msg, fullMethod string, start time.Time, err error) {
+	code := CodeOf(err)
+	lvl, ok := s.levels[code]
+	if !ok {
+		lvl = ladcore.ErrorLevel
+	}
+
+	ce := s.log.Check(lvl, msg)
+	if ce == nil {
+		return
+	}
+	fields := []lad.Field{
+		lad.String("grpc.method", fullMethod),
+		lad.String("grpc.code", code.String()),
+		lad.Duration("grpc.duration", s.clock.Now().Sub(start)),
+	}
+	if s.peer != nil {
+		if addr := s.peer(ctx); addr != "" {
+			fields = append(fields, lad.String("peer.address", addr))
+		}
+	}
+	if err != nil {
+		fields = append(fields, lad.Error(err))
+	}
+	ce.Write(fields...)
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladgrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/auwixcom/lad"
+	"github.com/auwixcom/lad/internal/ztest"
+	"github.com/auwixcom/lad/ladcore"
+	"github.com/auwixcom/lad/ladtest/observer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStatus mimics *status.Status from google.golang.org/grpc/status.
+type fakeStatus struct{ code fakeCode }
+
+type fakeCode uint32
+
+func (s *fakeStatus) Code() fakeCode { return s.code }
+
+type statusError struct{ code fakeCode }
+
+func (e statusError) Error() string { return fmt.Sprintf("rpc error: code = %d", e.code) }
+
+func (e statusError) GRPCStatus() *fakeStatus { return &fakeStatus{e.code} }
+
+type nilStatusError struct{}
+
+func (nilStatusError) Error() string { return "nil status" }
+
+func (nilStatusError) GRPCStatus() *fakeStatus { return nil }
+
+func TestCodeOf(t *testing.T) {
+	tests := []struct {
+		err  error
+		want Code
+	}{
+		{nil, OK},
+		{statusError{code: 5}, NotFound},
+		{fmt.Errorf("wrapped: %w", statusError{code: 14}), Unavailable},
+		{context.Canceled, Canceled},
+		{fmt.Errorf("wrapped: %w", context.DeadlineExceeded), DeadlineExceeded},
+		{nilStatusError{}, Unknown},
+		{errors.New("boom"), Unknown},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, CodeOf(tt.err), "Unexpected code for %v.", tt.err)
+	}
+}
+
+func TestCodeString(t *testing.T) {
+	assert.Equal(t, "OK", OK.String(), "Unexpected name.")
+	assert.Equal(t, "Unauthenticated", Unauthenticated.String(), "Unexpected name.")
+	assert.Equal(t, "Code(42)", Code(42).String(), "Unexpected name for unknown code.")
+}
+
+func TestServerLoggerUnary(t *testing.T) {
+	core, logs := observer.New(ladcore.DebugLevel)
+	clock := ztest.NewMockClock()
+	calls := NewServerLogger(lad.New(core),
+		WithServerClock(clock),
+		WithPeer(func(context.Context) string { return "10.0.0.1:5000" }),
+		WithCodeLevel(NotFound, ladcore.DebugLevel),
+	)
+
+	resp, err := calls.Unary(context.Background(), "req", "/pkg.Service/Get", func(ctx context.Context, req interface{}) (interface{}, error) {
+		clock.Add(25 * time.Millisecond)
+		return "resp", nil
+	})
+	require.NoError(t, err, "Unexpected error.")
+	assert.Equal(t, "resp", resp, "Expected handler's response.")
+
+	notFound := statusError{code: 5}
+	_, err = calls.Unary(context.Background(), "req", "/pkg.Service/Get", func(context.Context, interface{}) (interface{}, error) {
+		return nil, notFound
+	})
+	assert.Equal(t, notFound, err, "Expected handler's error.")
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 2, "Expected one entry per call.")
+	assert.Equal(t, ladcore.InfoLevel, entries[0].Level, "Unexpected level for OK.")
+	assert.Equal(t, "finished unary call", entries[0].Message, "Unexpected message.")
+	assert.Equal(t, map[string]interface{}{
+		"grpc.method":   "/pkg.Service/Get",
+		"grpc.code":     "OK",
+		"grpc.duration": 25 * time.Millisecond,
+		"peer.address":  "10.0.0.1:5000",
+	}, entries[0].ContextMap(), "Unexpected fields.")
+
+	assert.Equal(t, ladcore.DebugLevel, entries[1].Level, "Expected level override for NotFound.")
+	assert.Equal(t, "NotFound", entries[1].ContextMap()["grpc.code"], "Unexpected code.")
+	assert.Equal(t, notFound.Error(), entries[1].ContextMap()["error"], "Expected error field.")
+}
+
+func TestServerLoggerStream(t *testing.T) {
+	core, logs := observer.New(ladcore.WarnLevel)
+	calls := NewServerLogger(lad.New(core))
+
+	assert.NoError(t, calls.Stream(context.Background(), "/pkg.Service/Watch", func() error {
+		return nil
+	}), "Unexpected error.")
+	assert.Equal(t, 0, logs.Len(), "Expected OK call to be below the logger's level.")
+
+	boom := errors.New("boom")
+	assert.Equal(t, boom, calls.Stream(context.Background(), "/pkg.Service/Watch", func() error {
+		return boom
+	}), "Expected handler's error.")
+	require.Equal(t, 1, logs.Len(), "Expected failed call to be logged.")
+	entry := logs.All()[0]
+	assert.Equal(t, ladcore.ErrorLevel, entry.Level, "Unexpected level for Unknown.")
+	assert.Equal(t, "finished streaming call", entry.Message, "Unexpected message.")
+	assert.Equal(t, "Unknown", entry.ContextMap()["grpc.code"], "Unexpected code.")
+	assert.NotContains(t, entry.ContextMap(), "peer.address", "Expected no peer without WithPeer.")
+}
+
+func TestServerLoggerUnmappedCode(t *testing.T) {
+	core, logs := observer.New(ladcore.DebugLevel)
+	calls := NewServerLogger(lad.New(core))
+	_ = calls.Stream(context.Background(), "/pkg.Service/Watch", func() error {
+		return statusError{code: 99}
+	})
+	require.Equal(t, 1, logs.Len(), "Expected call to be logged.")
+	assert.Equal(t, ladcore.ErrorLevel, logs.All()[0].Level, "Expected unmapped codes to log at ErrorLevel.")
+	assert.Equal(t, "Code(99)", logs.All()[0].ContextMap()["grpc.code"], "Unexpected code.")
+}
@@ -18,7 +18,8 @@
 // OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
 // THE SOFTWARE.
 
-// Package ladgrpc provides a logger that is compatible with grpclog.
+// Package ladgrpc provides a logger that is compatible with grpclog, and a
+// ServerLogger that logs the calls a gRPC server handles.
 package ladgrpc // import "github.com/auwixcom/lad/ladgrpc"
 
 import (
@@ -0,0 +1,393 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package ladhoneycomb sends lad log entries to Honeycomb as wide events,
+// for teams whose observability is built on structured events rather than
+// log lines.
+package ladhoneycomb
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/auwixcom/lad/ladcore"
+	"go.uber.org/multierr"
+)
+
+const (
+	// _defaultAPIHost is the Honeycomb API used when APIHost is unset.
+	_defaultAPIHost = "https://api.honeycomb.io"
+
+	// _defaultDataset names the dataset for entries from unnamed loggers.
+	_defaultDataset = "logs"
+
+	// _defaultBatchSize is the number of events per dataset that triggers a
+	// flush.
+	_defaultBatchSize = 100
+
+	// _defaultFlushInterval specifies how often buffered events are sent.
+	_defaultFlushInterval = 5 * time.Second
+)
+
+var (
+	errNoAPIKey = errors.New("honeycomb: Exporter.APIKey is required")
+	errStopped  = errors.New("honeycomb: Exporter is stopped")
+)
+
+// An Exporter buffers events and sends them to Honeycomb's batch API, one
+// request per dataset, whenever a dataset accumulates BatchSize events, at
+// a fixed interval, or when Sync is called--whichever comes first. Only Sync
+// sends on the calling goroutine; other batches are sent in the background,
+// and any errors go to ErrorOutput.
+//
+// An Exporter is safe for concurrent use. Defer a call to Stop to send any
+// remaining events and clean up the background goroutine:
+//
+//	exp := &ladhoneycomb.Exporter{APIKey: os.Getenv("HONEYCOMB_API_KEY")}
+//	defer exp.Stop()
+//
+//	core := ladhoneycomb.NewCore(exp, lad.InfoLevel)
+//	logger := lad.New(core)
+//
+// Events from a named logger go to the dataset with the logger's name;
+// other events go to Dataset.
+type Exporter struct {
+	// APIKey authenticates requests to Honeycomb.
+	//
+	// This field is required.
+	APIKey string
+
+	// APIHost is the base URL of the Honeycomb API.
+	//
+	// Defaults to https://api.honeycomb.io if unspecified.
+	APIHost string
+
+	// Dataset receives the events of entries without a logger name.
+	//
+	// Defaults to "logs" if unspecified.
+	Dataset string
+
+	// BatchSize is the number of buffered events in a dataset that triggers
+	// an immediate flush of that dataset.
+	//
+	// Defaults to 100 if unspecified.
+	BatchSize int
+
+	// FlushInterval specifies how often buffered events are sent.
+	//
+	// Defaults to 5 seconds if unspecified.
+	FlushInterval time.Duration
+
+	// Client sends requests to Honeycomb.
+	//
+	// Defaults to http.DefaultClient if unspecified.
+	Client *http.Client
+
+	// Clock, if specified, provides control of the source of time for the
+	// flush interval.
+	//
+	// Defaults to the system clock.
+	Clock ladcore.Clock
+
+	// ErrorOutput receives errors from sending events in the background,
+	// including events that Honeycomb rejects.
+	//
+	// By default, such errors are discarded.
+	ErrorOutput ladcore.WriteSyncer
+
+	// unexported fields for state
+	mu          sync.Mutex
+	initialized bool  // whether initialize() has run
+	stopped     bool  // whether Stop() has run
+	rejected    int64 // events dropped because they arrived after Stop
+	pending     map[string][]event
+	dropped     map[sampleKey]int // entries dropped by a sampler since the last sampled one
+	rates       map[sampleKey]int // sample rates of sampled entries awaiting Write
+	ticker      *time.Ticker
+	full        chan struct{} // signaled when a dataset's batch is full
+	stop        chan struct{} // closed when flushLoop should stop
+	done        chan struct{} // closed when flushLoop has stopped
+
+	sendMu sync.Mutex // held while sending, so Sync waits for batches in flight
+}
+
+// event is a single element of a batch request.
+type event struct {
+	Time       time.Time              `json:"time"`
+	SampleRate int                    `json:"samplerate,omitempty"`
+	Data       map[string]interface{} `json:"data"`
+}
+
+// eventStatus is an element of the batch API's response, reporting what
+// became of the corresponding event.
+type eventStatus struct {
+	Status int    `json:"status"`
+	Error  string `json:"error"`
+}
+
+// sampleKey identifies entries the way ladcore's sampler does.
+type sampleKey struct {
+	level   ladcore.Level
+	message string
+}
+
+func (e *Exporter) initialize() {
+	if e.BatchSize == 0 {
+		e.BatchSize = _defaultBatchSize
+	}
+	flushInterval := e.FlushInterval
+	if flushInterval == 0 {
+		flushInterval = _defaultFlushInterval
+	}
+	if e.Clock == nil {
+		e.Clock = ladcore.DefaultClock
+	}
+
+	e.pending = make(map[string][]event)
+	e.ticker = e.Clock.NewTicker(flushInterval)
+	e.full = make(chan struct{}, 1)
+	e.stop = make(chan struct{})
+	e.done = make(chan struct{})
+	e.initialized = true
+	go e.flushLoop()
+}
+
+// SamplerHook lets the Exporter report the sample rate of entries that pass
+// through ladcore's sampler, so that Honeycomb can reweight them. Register it
+// with the sampler in front of the Exporter's Core:
+//
+//	core := ladcore.NewSamplerWithOptions(
+//		ladhoneycomb.NewCore(exp, lad.InfoLevel),
+//		time.Second, 100, 100,
+//		ladcore.SamplerHook(exp.SamplerHook),
+//	)
+//
+// Each sampled entry then carries a sample rate of one plus the number of
+// entries with the same level and message dropped since the previous one was
+// sampled.
+func (e *Exporter) SamplerHook(ent ladcore.Entry, dec ladcore.SamplingDecision) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.dropped == nil {
+		e.dropped = make(map[sampleKey]int)
+		e.rates = make(map[sampleKey]int)
+	}
+
+	key := sampleKey{level: ent.Level, message: ent.Message}
+	if dec&ladcore.LogDropped != 0 {
+		e.dropped[key]++
+		return
+	}
+	if dec&ladcore.LogSampled != 0 {
+		e.rates[key] = 1 + e.dropped[key]
+		delete(e.dropped, key)
+	}
+}
+
+// add buffers an event for the entry, waking the background goroutine if
+// the dataset's batch is full.
+func (e *Exporter) add(ent ladcore.Entry, data map[string]interface{}) error {
+	if e.APIKey == "" {
+		return errNoAPIKey
+	}
+
+	dataset := ent.LoggerName
+	if dataset == "" {
+		dataset = e.Dataset
+	}
+	if dataset == "" {
+		dataset = _defaultDataset
+	}
+
+	e.mu.Lock()
+	if e.stopped {
+		// Nothing would send the event, so don't buffer it.
+		e.rejected++
+		e.mu.Unlock()
+		return errStopped
+	}
+	if !e.initialized {
+		e.initialize()
+	}
+	ev := event{Time: ent.Time, Data: data}
+	if e.rates != nil {
+		key := sampleKey{level: ent.Level, message: ent.Message}
+		if rate, ok := e.rates[key]; ok {
+			ev.SampleRate = rate
+			delete(e.rates, key)
+		}
+	}
+	e.pending[dataset] = append(e.pending[dataset], ev)
+	if len(e.pending[dataset]) >= e.BatchSize {
+		select {
+		case e.full <- struct{}{}:
+		default: // flushLoop already has a batch to send
+		}
+	}
+	e.mu.Unlock()
+	return nil
+}
+
+// Dropped reports how many events were dropped because they were written
+// after Stop.
+func (e *Exporter) Dropped() int64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.rejected
+}
+
+// Sync sends all buffered events.
+func (e *Exporter) Sync() error {
+	e.sendMu.Lock()
+	defer e.sendMu.Unlock()
+
+	e.mu.Lock()
+	pending := e.pending
+	if e.initialized {
+		e.pending = make(map[string][]event)
+	}
+	e.mu.Unlock()
+
+	var err error
+	for dataset, batch := range pending {
+		err = multierr.Append(err, e.send(dataset, batch))
+	}
+	return err
+}
+
+func (e *Exporter) send(dataset string, batch []event) error {
+	if e.APIKey == "" {
+		return errNoAPIKey
+	}
+
+	body, err := json.Marshal(batch)
+	if err != nil {
+		return err
+	}
+
+	host := e.APIHost
+	if host == "" {
+		host = _defaultAPIHost
+	}
+	u := strings.TrimSuffix(host, "/") + "/1/batch/" + url.PathEscape(dataset)
+	req, err := http.NewRequest(http.MethodPost, u, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Honeycomb-Team", e.APIKey)
+
+	client := e.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("honeycomb: sending %d events to dataset %q: %s: %s",
+			len(batch), dataset, resp.Status, bytes.TrimSpace(msg))
+	}
+	defer func() { _, _ = io.Copy(io.Discard, resp.Body) }()
+
+	// A successful response can still reject some of the events; it lists
+	// the status of each.
+	var statuses []eventStatus
+	if json.NewDecoder(resp.Body).Decode(&statuses) != nil {
+		return nil
+	}
+	var (
+		rejected int
+		first    eventStatus
+	)
+	for _, st := range statuses {
+		if st.Status/100 != 2 {
+			if rejected == 0 {
+				first = st
+			}
+			rejected++
+		}
+	}
+	if rejected > 0 {
+		return fmt.Errorf("honeycomb: %d of %d events rejected by dataset %q: %d %s",
+			rejected, len(batch), dataset, first.Status, first.Error)
+	}
+	return nil
+}
+
+// flushLoop sends buffered events at the configured interval until Stop is
+// called.
+func (e *Exporter) flushLoop() {
+	defer close(e.done)
+
+	for {
+		select {
+		case <-e.ticker.C:
+		case <-e.full:
+		case <-e.stop:
+			return
+		}
+		if err := e.Sync(); err != nil && e.ErrorOutput != nil {
+			_, _ = fmt.Fprintf(e.ErrorOutput, "%v export error: %v\n", e.Clock.Now(), err)
+			_ = e.ErrorOutput.Sync() // ignore error
+		}
+	}
+}
+
+// Stop cleans up the background goroutine and sends any remaining events.
+// Events written after Stop are dropped with an error.
+func (e *Exporter) Stop() error {
+	stopped := func() bool {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+
+		if e.stopped {
+			return false
+		}
+		e.stopped = true
+		if !e.initialized {
+			return false
+		}
+
+		e.ticker.Stop()
+		close(e.stop)
+		return true
+	}()
+
+	if !stopped {
+		return nil
+	}
+
+	<-e.done
+	return e.Sync()
+}
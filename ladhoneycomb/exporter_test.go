@@ -0,0 +1,249 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladhoneycomb
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/auwixcom/lad"
+	"github.com/auwixcom/lad/internal/ztest"
+	"github.com/auwixcom/lad/ladcore"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type batchRequest struct {
+	Path   string
+	APIKey string
+	Events []event
+}
+
+// fakeHoneycomb records the batches it receives.
+type fakeHoneycomb struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	batches  []batchRequest
+	response string // body of successful responses
+	received chan struct{}
+}
+
+func newFakeHoneycomb(t *testing.T, status int) *fakeHoneycomb {
+	f := &fakeHoneycomb{received: make(chan struct{}, 16)}
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var events []event
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&events), "Unexpected error decoding batch.")
+
+		f.mu.Lock()
+		f.batches = append(f.batches, batchRequest{
+			Path:   r.URL.Path,
+			APIKey: r.Header.Get("X-Honeycomb-Team"),
+			Events: events,
+		})
+		response := f.response
+		f.mu.Unlock()
+
+		w.WriteHeader(status)
+		if status == http.StatusOK {
+			_, _ = io.WriteString(w, response)
+		}
+		f.received <- struct{}{}
+	}))
+	t.Cleanup(f.Close)
+	return f
+}
+
+func (f *fakeHoneycomb) SetResponse(body string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.response = body
+}
+
+func (f *fakeHoneycomb) WaitForBatch(t *testing.T) {
+	select {
+	case <-f.received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for a batch.")
+	}
+}
+
+func (f *fakeHoneycomb) Batches() []batchRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]batchRequest(nil), f.batches...)
+}
+
+func TestExporterBatchesByDataset(t *testing.T) {
+	srv := newFakeHoneycomb(t, http.StatusOK)
+	exp := &Exporter{APIKey: "key", APIHost: srv.URL, BatchSize: 2}
+	defer func() { assert.NoError(t, exp.Stop(), "Unexpected error stopping Exporter.") }()
+
+	logger := lad.New(NewCore(exp, lad.InfoLevel)).With(lad.String("service", "api"))
+	logger.Info("first", lad.Int("n", 1))
+	assert.Empty(t, srv.Batches(), "Expected events to be buffered.")
+
+	logger.Named("billing").Warn("second", lad.Namespace("req"), lad.Int("n", 2))
+	logger.Info("third", lad.Int("n", 3))
+	srv.WaitForBatch(t)
+
+	batches := srv.Batches()
+	require.Len(t, batches, 1, "Expected a full batch to be sent.")
+	assert.Equal(t, "/1/batch/logs", batches[0].Path, "Unexpected dataset.")
+	assert.Equal(t, "key", batches[0].APIKey, "Unexpected API key.")
+	require.Len(t, batches[0].Events, 2, "Unexpected batch size.")
+	assert.Equal(t, map[string]interface{}{
+		"service": "api",
+		"n":       float64(1),
+		"level":   "info",
+		"message": "first",
+	}, batches[0].Events[0].Data, "Unexpected event data.")
+
+	require.NoError(t, logger.Sync(), "Unexpected error syncing.")
+	batches = srv.Batches()
+	require.Len(t, batches, 2, "Expected Sync to send the buffered event.")
+	assert.Equal(t, "/1/batch/billing", batches[1].Path, "Expected the logger name to pick the dataset.")
+	assert.Equal(t, map[string]interface{}{
+		"service": "api",
+		"req":     map[string]interface{}{"n": float64(2)},
+		"level":   "warn",
+		"message": "second",
+	}, batches[1].Events[0].Data, "Unexpected event data.")
+}
+
+func TestExporterFlushInterval(t *testing.T) {
+	srv := newFakeHoneycomb(t, http.StatusOK)
+	clock := ztest.NewMockClock()
+	exp := &Exporter{APIKey: "key", APIHost: srv.URL, FlushInterval: time.Second, Clock: clock}
+	defer func() { assert.NoError(t, exp.Stop(), "Unexpected error stopping Exporter.") }()
+
+	lad.New(NewCore(exp, lad.InfoLevel)).Info("hello")
+	assert.Empty(t, srv.Batches(), "Expected events to be buffered.")
+
+	clock.Add(time.Second)
+	select {
+	case <-srv.received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the flush interval to send buffered events.")
+	}
+	assert.Len(t, srv.Batches(), 1, "Expected one batch.")
+}
+
+func TestExporterSampleRate(t *testing.T) {
+	srv := newFakeHoneycomb(t, http.StatusOK)
+	exp := &Exporter{APIKey: "key", APIHost: srv.URL}
+	defer func() { assert.NoError(t, exp.Stop(), "Unexpected error stopping Exporter.") }()
+
+	core := ladcore.NewSamplerWithOptions(
+		NewCore(exp, lad.InfoLevel),
+		time.Minute, 1, 4,
+		ladcore.SamplerHook(exp.SamplerHook),
+	)
+	logger := lad.New(core)
+	logger.Info("unique")
+	for i := 0; i < 5; i++ {
+		logger.Info("repeated")
+	}
+	require.NoError(t, logger.Sync(), "Unexpected error syncing.")
+
+	batches := srv.Batches()
+	require.Len(t, batches, 1, "Expected one batch.")
+	var rates []int
+	for _, ev := range batches[0].Events {
+		rates = append(rates, ev.SampleRate)
+	}
+	// The first entry of each message is sampled on its own; the fifth
+	// "repeated" entry stands in for the three dropped before it.
+	assert.Equal(t, []int{1, 1, 4}, rates, "Unexpected sample rates.")
+}
+
+func TestExporterErrors(t *testing.T) {
+	srv := newFakeHoneycomb(t, http.StatusBadRequest)
+	exp := &Exporter{APIKey: "key", APIHost: srv.URL}
+	defer func() { assert.NoError(t, exp.Stop(), "Unexpected error stopping Exporter.") }()
+
+	core := NewCore(exp, lad.InfoLevel)
+	require.NoError(t, core.Write(ladcore.Entry{Message: "hello"}, nil), "Expected events to be buffered.")
+	assert.ErrorContains(t, core.Sync(), "400 Bad Request", "Expected the response status in the error.")
+
+	noKey := &Exporter{APIHost: srv.URL, BatchSize: 1}
+	defer func() { assert.NoError(t, noKey.Stop(), "Unexpected error stopping Exporter.") }()
+	assert.ErrorIs(t, NewCore(noKey, lad.InfoLevel).Write(ladcore.Entry{}, nil), errNoAPIKey, "Expected an error without an API key.")
+}
+
+func TestExporterRejectedEvents(t *testing.T) {
+	srv := newFakeHoneycomb(t, http.StatusOK)
+	srv.SetResponse(`[{"status":202},{"status":400,"error":"event too large"}]`)
+	errOut := make(chanWriteSyncer, 1)
+	exp := &Exporter{APIKey: "key", APIHost: srv.URL, BatchSize: 2, ErrorOutput: errOut}
+	defer func() { assert.NoError(t, exp.Stop(), "Unexpected error stopping Exporter.") }()
+
+	// Full batches are sent in the background, so errors go to ErrorOutput.
+	core := NewCore(exp, lad.InfoLevel)
+	for i := 0; i < 2; i++ {
+		require.NoError(t, core.Write(ladcore.Entry{Message: "hello"}, nil), "Expected events to be buffered.")
+	}
+	select {
+	case msg := <-errOut:
+		assert.Contains(t, msg, `export error: honeycomb: 1 of 2 events rejected by dataset "logs": 400 event too large`,
+			"Expected rejected events to be reported.")
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected rejected events to be reported.")
+	}
+
+	srv.SetResponse(`[{"status":202}]`)
+	require.NoError(t, core.Write(ladcore.Entry{Message: "hello"}, nil), "Expected events to be buffered.")
+	assert.NoError(t, core.Sync(), "Expected accepted events to succeed.")
+}
+
+func TestExporterWriteAfterStop(t *testing.T) {
+	srv := newFakeHoneycomb(t, http.StatusOK)
+	exp := &Exporter{APIKey: "key", APIHost: srv.URL}
+	core := NewCore(exp, lad.InfoLevel)
+	require.NoError(t, core.Write(ladcore.Entry{Message: "before"}, nil), "Expected events to be buffered.")
+	require.NoError(t, exp.Stop(), "Unexpected error stopping Exporter.")
+	require.Len(t, srv.Batches(), 1, "Expected Stop to send buffered events.")
+
+	assert.ErrorIs(t, core.Write(ladcore.Entry{Message: "after"}, nil), errStopped, "Expected writes after Stop to fail.")
+	assert.Equal(t, int64(1), exp.Dropped(), "Expected writes after Stop to be counted as dropped.")
+	require.NoError(t, core.Sync(), "Unexpected error syncing.")
+	assert.Len(t, srv.Batches(), 1, "Expected events written after Stop not to be sent.")
+
+	unused := &Exporter{APIKey: "key", APIHost: srv.URL}
+	require.NoError(t, unused.Stop(), "Unexpected error stopping Exporter.")
+	assert.ErrorIs(t, NewCore(unused, lad.InfoLevel).Write(ladcore.Entry{}, nil), errStopped, "Expected writes after Stop to fail, even if nothing was written before.")
+}
+
+// chanWriteSyncer sends each write to the channel.
+type chanWriteSyncer chan string
+
+func (w chanWriteSyncer) Write(b []byte) (int, error) {
+	w <- string(b)
+	return len(b), nil
+}
+
+func (w chanWriteSyncer) Sync() error { return nil }
@@ -0,0 +1,91 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladkafka
+
+import (
+	"bytes"
+
+	"github.com/auwixcom/lad/ladcore"
+)
+
+// NewCore creates a Core that encodes each enabled entry with enc and hands
+// it to the Producer as one message, keyed by the Producer's KeyFunc. The
+// encoder's line ending is trimmed, since each message holds one entry.
+func NewCore(p *Producer, enc ladcore.Encoder, enab ladcore.LevelEnabler) ladcore.Core {
+	return &core{
+		LevelEnabler: enab,
+		enc:          enc,
+		p:            p,
+	}
+}
+
+type core struct {
+	ladcore.LevelEnabler
+
+	enc ladcore.Encoder
+	p   *Producer
+}
+
+var _ ladcore.Core = (*core)(nil)
+
+func (c *core) Level() ladcore.Level {
+	return ladcore.LevelOf(c.LevelEnabler)
+}
+
+func (c *core) With(fields []ladcore.Field) ladcore.Core {
+	clone := &core{
+		LevelEnabler: c.LevelEnabler,
+		enc:          c.enc.Clone(),
+		p:            c.p,
+	}
+	for _, f := range fields {
+		f.AddTo(clone.enc)
+	}
+	return clone
+}
+
+func (c *core) Check(ent ladcore.Entry, ce *ladcore.CheckedEntry) *ladcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *core) Write(ent ladcore.Entry, fields []ladcore.Field) error {
+	buf, err := c.enc.EncodeEntry(ent, fields)
+	if err != nil {
+		return err
+	}
+	// The Producer holds on to messages, so they can't share the pooled
+	// buffer.
+	value := append([]byte(nil), bytes.TrimRight(buf.Bytes(), "\r\n")...)
+	buf.Free()
+
+	msg := Message{Value: value, Time: ent.Time}
+	if c.p.Key != nil {
+		msg.Key = c.p.Key(ent)
+	}
+	return c.p.add(msg)
+}
+
+func (c *core) Sync() error {
+	return c.p.Sync()
+}
@@ -0,0 +1,403 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package ladkafka produces lad log entries to a Kafka topic.
+//
+// It doesn't depend on a Kafka client library. Instead, adapt the client
+// your service already uses to the small Client interface, and the Producer
+// takes care of batching, partition keys, retries, and backpressure.
+package ladkafka // import "github.com/auwixcom/lad/ladkafka"
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/auwixcom/lad/ladcore"
+	"go.uber.org/multierr"
+)
+
+const (
+	// _defaultBatchSize is the number of pending messages that triggers a
+	// flush.
+	_defaultBatchSize = 100
+
+	// _defaultFlushInterval specifies how often pending messages are sent.
+	_defaultFlushInterval = time.Second
+
+	// _defaultMaxPending caps the number of messages waiting to be sent.
+	_defaultMaxPending = 10000
+
+	// _defaultMaxRetries is how many times a failed batch is retried.
+	_defaultMaxRetries = 3
+
+	// _defaultRetryBackoff is the delay before the first retry.
+	_defaultRetryBackoff = 100 * time.Millisecond
+
+	// _defaultTimeout bounds each call to Client.Produce.
+	_defaultTimeout = 10 * time.Second
+)
+
+var (
+	errNoClient = errors.New("kafka: Producer.Client is required")
+	errNoTopic  = errors.New("kafka: Producer.Topic is required")
+	errStopped  = errors.New("kafka: Producer is stopped")
+)
+
+// A Message is a record to produce to Kafka.
+type Message struct {
+	Topic string
+	Key   []byte // nil lets the client pick a partition
+	Value []byte
+	Time  time.Time
+}
+
+// A Client produces batches of messages to Kafka. Implementations typically
+// wrap the producer of a Kafka library, and must be safe for concurrent use.
+//
+// Produce should return only once the batch is acknowledged, or with an error
+// if any message in it wasn't; failed batches are retried in full.
+type Client interface {
+	Produce(ctx context.Context, msgs []Message) error
+}
+
+// A ClientFunc adapts a function into a Client.
+type ClientFunc func(ctx context.Context, msgs []Message) error
+
+// Produce calls f(ctx, msgs).
+func (f ClientFunc) Produce(ctx context.Context, msgs []Message) error {
+	return f(ctx, msgs)
+}
+
+// A KeyFunc picks the partition key of an entry's message. Entries with the
+// same key are produced to the same partition, so they stay in order.
+type KeyFunc func(ladcore.Entry) []byte
+
+// KeyByLevel keys messages by the entry's level.
+func KeyByLevel(ent ladcore.Entry) []byte {
+	return []byte(ent.Level.String())
+}
+
+// KeyByLoggerName keys messages by the name of the logger that wrote them.
+func KeyByLoggerName(ent ladcore.Entry) []byte {
+	return []byte(ent.LoggerName)
+}
+
+// StaticKey keys every message with key, such as the name of the service.
+func StaticKey(key string) KeyFunc {
+	k := []byte(key)
+	return func(ladcore.Entry) []byte {
+		return k
+	}
+}
+
+// A Producer buffers encoded entries and produces them to Kafka in batches,
+// whenever BatchSize messages are pending, at a fixed interval, or when Sync
+// is called--whichever comes first. Failed batches are retried with
+// exponential backoff.
+//
+// Batches are sent from a background goroutine, so writing an entry only
+// queues its message; unless Block is set, logging never waits on the
+// cluster. Messages that don't fit in the queue are dropped and counted in
+// Stats.
+//
+// A Producer is safe for concurrent use. Defer a call to Stop to send any
+// remaining messages and clean up the background goroutine:
+//
+//	p := &ladkafka.Producer{Client: client, Topic: "logs", Key: ladkafka.KeyByLevel}
+//	defer p.Stop()
+//
+//	core := ladkafka.NewCore(p, ladcore.NewJSONEncoder(cfg), lad.InfoLevel)
+//	logger := lad.New(core)
+type Producer struct {
+	// Client produces the batches.
+	//
+	// This field is required.
+	Client Client
+
+	// Topic receives the messages.
+	//
+	// This field is required.
+	Topic string
+
+	// Key picks each message's partition key.
+	//
+	// Defaults to no key if unspecified.
+	Key KeyFunc
+
+	// BatchSize is the number of pending messages that triggers a flush, and
+	// the largest batch passed to the Client.
+	//
+	// Defaults to 100 if unspecified.
+	BatchSize int
+
+	// FlushInterval specifies how often pending messages are sent.
+	//
+	// Defaults to 1 second if unspecified.
+	FlushInterval time.Duration
+
+	// MaxPending caps the number of messages waiting to be sent, so that a
+	// slow or unavailable cluster can't exhaust memory. Once it's reached,
+	// new messages are dropped, or, if Block is set, writes wait for room.
+	//
+	// Defaults to 10000 if unspecified, and is never less than BatchSize.
+	MaxPending int
+
+	// Block applies backpressure to logging, rather than dropping messages,
+	// when MaxPending messages are waiting to be sent.
+	Block bool
+
+	// MaxRetries is how many times a failed batch is retried before its
+	// messages are dropped. The delay between attempts starts at
+	// RetryBackoff and doubles after each one.
+	//
+	// Defaults to 3 retries and 100ms if unspecified. Set MaxRetries to a
+	// negative number to disable retries.
+	MaxRetries   int
+	RetryBackoff time.Duration
+
+	// Timeout bounds each call to Client.Produce.
+	//
+	// Defaults to 10 seconds if unspecified.
+	Timeout time.Duration
+
+	// Clock, if specified, provides control of the source of time for the
+	// flush interval and the delay between retries.
+	//
+	// Defaults to the system clock.
+	Clock ladcore.Clock
+
+	// unexported fields for state
+	mu          sync.Mutex
+	room        *sync.Cond // signaled when pending messages are taken
+	initialized bool       // whether initialize() has run
+	stopped     bool       // whether Stop() has run
+	pending     []Message
+	stats       Stats
+	ticker      *time.Ticker
+	full        chan struct{} // signaled when a batch is ready to send
+	stop        chan struct{} // closed when flushLoop should stop
+	done        chan struct{} // closed when flushLoop has stopped
+
+	sendMu sync.Mutex // held while sending, so batches stay in order
+}
+
+// Stats counts the messages a Producer has handled.
+type Stats struct {
+	Produced int64 // messages acknowledged by the Client
+	Dropped  int64 // messages dropped because MaxPending was reached or the Producer was stopped
+	Failed   int64 // messages dropped after exhausting retries
+	Retries  int64 // retried batches
+}
+
+func (p *Producer) initialize() {
+	if p.BatchSize <= 0 {
+		p.BatchSize = _defaultBatchSize
+	}
+	if p.MaxPending <= 0 {
+		p.MaxPending = _defaultMaxPending
+	}
+	if p.MaxPending < p.BatchSize {
+		p.MaxPending = p.BatchSize
+	}
+	if p.MaxRetries == 0 {
+		p.MaxRetries = _defaultMaxRetries
+	}
+	if p.RetryBackoff <= 0 {
+		p.RetryBackoff = _defaultRetryBackoff
+	}
+	if p.Timeout <= 0 {
+		p.Timeout = _defaultTimeout
+	}
+	flushInterval := p.FlushInterval
+	if flushInterval <= 0 {
+		flushInterval = _defaultFlushInterval
+	}
+	if p.Clock == nil {
+		p.Clock = ladcore.DefaultClock
+	}
+
+	p.room = sync.NewCond(&p.mu)
+	p.ticker = p.Clock.NewTicker(flushInterval)
+	p.full = make(chan struct{}, 1)
+	p.stop = make(chan struct{})
+	p.done = make(chan struct{})
+	p.initialized = true
+	go p.flushLoop()
+}
+
+// Stats reports how many messages have been produced and dropped so far.
+func (p *Producer) Stats() Stats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.stats
+}
+
+// add queues a message, waking flushLoop if there are enough pending
+// messages to fill a batch.
+func (p *Producer) add(msg Message) error {
+	if p.Client == nil {
+		return errNoClient
+	}
+	if p.Topic == "" {
+		return errNoTopic
+	}
+	msg.Topic = p.Topic
+
+	p.mu.Lock()
+	if !p.initialized && !p.stopped {
+		p.initialize()
+	}
+	for p.Block && !p.stopped && len(p.pending) >= p.MaxPending {
+		p.room.Wait()
+	}
+	if p.stopped {
+		// Nothing would send the message, so don't queue it.
+		p.stats.Dropped++
+		p.mu.Unlock()
+		return errStopped
+	}
+	if len(p.pending) >= p.MaxPending {
+		p.stats.Dropped++
+		p.mu.Unlock()
+		return nil
+	}
+	p.pending = append(p.pending, msg)
+	if len(p.pending) >= p.BatchSize {
+		select {
+		case p.full <- struct{}{}:
+		default: // flushLoop already has a batch to send
+		}
+	}
+	p.mu.Unlock()
+	return nil
+}
+
+// Sync sends all pending messages.
+func (p *Producer) Sync() error {
+	return p.flush()
+}
+
+func (p *Producer) flush() error {
+	p.sendMu.Lock()
+	defer p.sendMu.Unlock()
+
+	p.mu.Lock()
+	pending := p.pending
+	p.pending = nil
+	if p.initialized {
+		p.room.Broadcast()
+	}
+	p.mu.Unlock()
+
+	var err error
+	for len(pending) > 0 {
+		n := len(pending)
+		if n > p.BatchSize {
+			n = p.BatchSize
+		}
+		err = multierr.Append(err, p.send(pending[:n]))
+		pending = pending[n:]
+	}
+	return err
+}
+
+// send produces a batch, retrying it if it fails.
+func (p *Producer) send(batch []Message) error {
+	backoff := p.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), p.Timeout)
+		err := p.Client.Produce(ctx, batch)
+		cancel()
+
+		p.mu.Lock()
+		switch {
+		case err == nil:
+			p.stats.Produced += int64(len(batch))
+		case attempt >= p.MaxRetries:
+			p.stats.Failed += int64(len(batch))
+		default:
+			p.stats.Retries++
+		}
+		p.mu.Unlock()
+
+		if err == nil || attempt >= p.MaxRetries {
+			return err
+		}
+		p.wait(backoff)
+		backoff *= 2
+	}
+}
+
+// wait blocks for d, as measured by the Producer's Clock.
+func (p *Producer) wait(d time.Duration) {
+	t := p.Clock.NewTicker(d)
+	defer t.Stop()
+	<-t.C
+}
+
+// flushLoop sends pending messages at the configured interval, and whenever
+// a batch fills up, until Stop is called.
+func (p *Producer) flushLoop() {
+	defer close(p.done)
+
+	for {
+		select {
+		case <-p.ticker.C:
+		case <-p.full:
+		case <-p.stop:
+			return
+		}
+		// Errors are dropped here since there's nobody to report them to;
+		// they're counted in Stats.
+		_ = p.Sync()
+	}
+}
+
+// Stop cleans up the background goroutine and sends any remaining messages.
+// Writes that are blocked waiting for room stop waiting, and they and any
+// later writes are dropped with an error.
+func (p *Producer) Stop() error {
+	stopped := func() bool {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
+		if p.stopped {
+			return false
+		}
+		p.stopped = true
+		if !p.initialized {
+			return false
+		}
+
+		p.ticker.Stop()
+		close(p.stop)
+		p.room.Broadcast()
+		return true
+	}()
+
+	if !stopped {
+		return nil
+	}
+
+	<-p.done
+	return p.Sync()
+}
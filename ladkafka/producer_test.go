@@ -0,0 +1,320 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladkafka
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/auwixcom/lad"
+	"github.com/auwixcom/lad/internal/ztest"
+	"github.com/auwixcom/lad/ladcore"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClient records the batches it's asked to produce.
+type fakeClient struct {
+	mu      sync.Mutex
+	batches [][]Message
+	errs    []error       // returned by successive calls, then nil
+	release chan struct{} // if non-nil, Produce waits for it
+	calls   chan struct{}
+}
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{calls: make(chan struct{}, 64)}
+}
+
+func (c *fakeClient) Produce(ctx context.Context, msgs []Message) error {
+	c.calls <- struct{}{}
+	if c.release != nil {
+		<-c.release
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.errs) > 0 {
+		err := c.errs[0]
+		c.errs = c.errs[1:]
+		if err != nil {
+			return err
+		}
+	}
+	c.batches = append(c.batches, append([]Message(nil), msgs...))
+	return nil
+}
+
+func (c *fakeClient) values() [][]string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var out [][]string
+	for _, b := range c.batches {
+		var vs []string
+		for _, m := range b {
+			vs = append(vs, string(m.Value))
+		}
+		out = append(out, vs)
+	}
+	return out
+}
+
+func newTestLogger(p *Producer) *lad.Logger {
+	enc := ladcore.NewJSONEncoder(ladcore.EncoderConfig{MessageKey: "msg"})
+	return lad.New(NewCore(p, enc, lad.InfoLevel))
+}
+
+func TestProducerBatches(t *testing.T) {
+	client := newFakeClient()
+	p := &Producer{
+		Client:    client,
+		Topic:     "logs",
+		Key:       KeyByLevel,
+		BatchSize: 2,
+		Clock:     ztest.NewMockClock(),
+	}
+	defer p.Stop()
+	logger := newTestLogger(p).With(lad.String("service", "api"))
+
+	logger.Info("one")
+	assert.Empty(t, client.values(), "Expected partial batch to be buffered.")
+	logger.Warn("two")
+	logger.Debug("disabled")
+	logger.Error("three")
+	require.NoError(t, logger.Sync(), "Unexpected error syncing.")
+
+	assert.Equal(t, [][]string{
+		{`{"msg":"one","service":"api"}`, `{"msg":"two","service":"api"}`},
+		{`{"msg":"three","service":"api"}`},
+	}, client.values(), "Unexpected batches.")
+
+	first := client.batches[0][0]
+	assert.Equal(t, "logs", first.Topic, "Unexpected topic.")
+	assert.Equal(t, []byte("info"), first.Key, "Unexpected partition key.")
+	assert.Equal(t, []byte("warn"), client.batches[0][1].Key, "Unexpected partition key.")
+	assert.Equal(t, Stats{Produced: 3}, p.Stats(), "Unexpected stats.")
+}
+
+func TestProducerFlushInterval(t *testing.T) {
+	client := newFakeClient()
+	clock := ztest.NewMockClock()
+	p := &Producer{Client: client, Topic: "logs", FlushInterval: time.Second, Clock: clock}
+	defer p.Stop()
+
+	newTestLogger(p).Info("tick")
+	clock.Add(time.Second)
+	select {
+	case <-client.calls:
+	case <-time.After(time.Second):
+		t.Fatal("Expected pending messages to be sent on the flush interval.")
+	}
+}
+
+func TestProducerSendsInBackground(t *testing.T) {
+	client := newFakeClient()
+	client.release = make(chan struct{})
+	p := &Producer{Client: client, Topic: "logs", BatchSize: 1, Clock: ztest.NewMockClock()}
+	logger := newTestLogger(p)
+
+	// Both writes return while the first batch is stuck in the client.
+	logger.Info("one")
+	<-client.calls
+	logger.Info("two")
+
+	close(client.release)
+	require.NoError(t, p.Stop(), "Unexpected error stopping producer.")
+	assert.Equal(t, [][]string{{`{"msg":"one"}`}, {`{"msg":"two"}`}}, client.values(), "Unexpected batches.")
+	assert.Equal(t, Stats{Produced: 2}, p.Stats(), "Unexpected stats.")
+}
+
+func TestProducerRetries(t *testing.T) {
+	fail := errors.New("broker unavailable")
+
+	t.Run("recovers", func(t *testing.T) {
+		client := newFakeClient()
+		client.errs = []error{fail, fail}
+		p := &Producer{Client: client, Topic: "logs", RetryBackoff: time.Microsecond}
+		defer p.Stop()
+
+		newTestLogger(p).Info("hello")
+		require.NoError(t, p.Sync(), "Expected retries to succeed.")
+		assert.Equal(t, [][]string{{`{"msg":"hello"}`}}, client.values(), "Unexpected batches.")
+		assert.Equal(t, Stats{Produced: 1, Retries: 2}, p.Stats(), "Unexpected stats.")
+	})
+
+	t.Run("gives up", func(t *testing.T) {
+		client := newFakeClient()
+		client.errs = []error{fail, fail}
+		p := &Producer{Client: client, Topic: "logs", MaxRetries: 1, RetryBackoff: time.Microsecond}
+		defer p.Stop()
+
+		newTestLogger(p).Info("hello")
+		assert.ErrorIs(t, p.Sync(), fail, "Expected error after exhausting retries.")
+		assert.Empty(t, client.values(), "Expected no batches to be produced.")
+		assert.Equal(t, Stats{Failed: 1, Retries: 1}, p.Stats(), "Unexpected stats.")
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		client := newFakeClient()
+		client.errs = []error{fail}
+		p := &Producer{Client: client, Topic: "logs", MaxRetries: -1}
+		defer p.Stop()
+
+		newTestLogger(p).Info("hello")
+		assert.ErrorIs(t, p.Sync(), fail, "Expected error without retries.")
+		assert.Equal(t, Stats{Failed: 1}, p.Stats(), "Unexpected stats.")
+	})
+}
+
+func TestProducerRetryBackoffUsesClock(t *testing.T) {
+	client := newFakeClient()
+	client.errs = []error{errors.New("broker unavailable")}
+	clock := ztest.NewMockClock()
+	p := &Producer{Client: client, Topic: "logs", RetryBackoff: time.Hour, Clock: clock}
+	defer p.Stop()
+
+	newTestLogger(p).Info("hello")
+	synced := make(chan error, 1)
+	go func() { synced <- p.Sync() }()
+
+	// The backoff only elapses as the mock clock advances.
+	var err error
+	require.Eventually(t, func() bool {
+		clock.Add(time.Hour)
+		select {
+		case err = <-synced:
+			return true
+		default:
+			return false
+		}
+	}, 5*time.Second, time.Millisecond, "Expected the retry to wait on the Clock.")
+	require.NoError(t, err, "Expected the retry to succeed.")
+	assert.Equal(t, Stats{Produced: 1, Retries: 1}, p.Stats(), "Unexpected stats.")
+}
+
+func TestProducerWriteAfterStop(t *testing.T) {
+	enc := ladcore.NewJSONEncoder(ladcore.EncoderConfig{MessageKey: "msg"})
+	ent := ladcore.Entry{Level: ladcore.InfoLevel}
+
+	client := newFakeClient()
+	p := &Producer{Client: client, Topic: "logs"}
+	core := NewCore(p, enc, lad.InfoLevel)
+	require.NoError(t, core.Write(ent, nil), "Unexpected error writing.")
+	require.NoError(t, p.Stop(), "Unexpected error stopping producer.")
+
+	assert.ErrorIs(t, core.Write(ent, nil), errStopped, "Expected writes after Stop to fail.")
+	require.NoError(t, p.Sync(), "Unexpected error syncing.")
+	assert.Len(t, client.values(), 1, "Expected messages written after Stop not to be produced.")
+	assert.Equal(t, Stats{Produced: 1, Dropped: 1}, p.Stats(), "Expected writes after Stop to be counted as dropped.")
+
+	unused := &Producer{Client: client, Topic: "logs"}
+	require.NoError(t, unused.Stop(), "Unexpected error stopping producer.")
+	assert.ErrorIs(t, NewCore(unused, enc, lad.InfoLevel).Write(ent, nil), errStopped, "Expected writes after Stop to fail, even if nothing was written before.")
+}
+
+func TestProducerBackpressure(t *testing.T) {
+	for _, block := range []bool{false, true} {
+		client := newFakeClient()
+		client.release = make(chan struct{})
+		p := &Producer{Client: client, Topic: "logs", BatchSize: 2, MaxPending: 2, Block: block}
+		logger := newTestLogger(p)
+
+		// Fill a batch, whose send blocks in the client, and then fill the
+		// queue behind it.
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			logger.Info("1")
+			logger.Info("2")
+		}()
+		<-client.calls
+		go func() {
+			defer wg.Done()
+			logger.Info("3")
+			logger.Info("4")
+		}()
+		require.Eventually(t, func() bool {
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			return len(p.pending) == 2
+		}, time.Second, time.Millisecond, "Expected queue to fill.")
+
+		written := make(chan struct{})
+		go func() {
+			logger.Info("5")
+			close(written)
+		}()
+
+		if block {
+			select {
+			case <-written:
+				t.Fatal("Expected write to wait for room in the queue.")
+			case <-time.After(10 * time.Millisecond):
+			}
+		} else {
+			<-written
+		}
+
+		close(client.release)
+		wg.Wait()
+		<-written
+		require.NoError(t, p.Stop(), "Unexpected error stopping producer.")
+
+		if block {
+			assert.Equal(t, Stats{Produced: 5}, p.Stats(), "Expected blocked write to be produced.")
+		} else {
+			assert.Equal(t, Stats{Produced: 4, Dropped: 1}, p.Stats(), "Expected write to a full queue to be dropped.")
+		}
+	}
+}
+
+func TestProducerMisconfigured(t *testing.T) {
+	enc := ladcore.NewJSONEncoder(ladcore.EncoderConfig{MessageKey: "msg"})
+	ent := ladcore.Entry{Level: ladcore.InfoLevel}
+
+	err := NewCore(&Producer{Topic: "logs"}, enc, lad.InfoLevel).Write(ent, nil)
+	assert.Equal(t, errNoClient, err, "Expected error without a client.")
+
+	err = NewCore(&Producer{Client: newFakeClient()}, enc, lad.InfoLevel).Write(ent, nil)
+	assert.Equal(t, errNoTopic, err, "Expected error without a topic.")
+
+	assert.NoError(t, (&Producer{}).Stop(), "Expected stopping an unused producer to succeed.")
+}
+
+func TestKeyFuncs(t *testing.T) {
+	ent := ladcore.Entry{Level: ladcore.ErrorLevel, LoggerName: "payments"}
+	assert.Equal(t, []byte("error"), KeyByLevel(ent), "Unexpected level key.")
+	assert.Equal(t, []byte("payments"), KeyByLoggerName(ent), "Unexpected logger name key.")
+	assert.Equal(t, []byte("api"), StaticKey("api")(ent), "Unexpected static key.")
+
+	var called bool
+	err := ClientFunc(func(context.Context, []Message) error {
+		called = true
+		return nil
+	}).Produce(context.Background(), nil)
+	assert.NoError(t, err, "Unexpected error.")
+	assert.True(t, called, "Expected ClientFunc to call the function.")
+}
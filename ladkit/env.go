@@ -0,0 +1,134 @@
+package ladglobal
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/auwixcom/lad/ladcore"
+)
+
+// Environment variables read by NewFromEnv.
+const (
+	EnvLevel          = "LAD_LEVEL"           // "debug", "info" (default), "warn", ...
+	EnvFormat         = "LAD_FORMAT"          // "console" (default) or "json"
+	EnvColor          = "LAD_COLOR"           // colored console levels; default false
+	EnvTimeFormat     = "LAD_TIME_FORMAT"     // console timestamp layout
+	EnvFile           = "LAD_FILE"            // log to this file instead of stdout
+	EnvMaxSizeMB      = "LAD_MAX_SIZE_MB"     // see FileConfig
+	EnvMaxBackups     = "LAD_MAX_BACKUPS"     // see FileConfig
+	EnvMaxAgeDays     = "LAD_MAX_AGE_DAYS"    // see FileConfig
+	EnvCompress       = "LAD_COMPRESS"        // see FileConfig
+	EnvRotateInterval = "LAD_ROTATE_INTERVAL" // e.g. "24h"; see FileConfig
+	EnvCaller         = "LAD_CALLER"          // add callers; default false
+)
+
+// NewFromEnv is like New, but configures the output from the LAD_*
+// environment variables above, so a deployment can change how a service
+// logs without code changes. Unset variables take sensible defaults: with
+// none set, the logger writes console lines to stdout at InfoLevel. opts
+// are applied after the environment's, e.g. to add outputs.
+//
+// Invalid values are reported as an error, and the global logger is left
+// alone.
+func NewFromEnv(opts ...Option) (*Handle, error) {
+	envOpts, err := envOptions(os.Getenv)
+	if err != nil {
+		return nil, err
+	}
+	return New(append(envOpts, opts...)...)
+}
+
+// envOptions translates the environment, as seen through getenv, into
+// options.
+func envOptions(getenv func(string) string) ([]Option, error) {
+	p := envParser{getenv: getenv}
+
+	level := ladcore.InfoLevel
+	if s := getenv(EnvLevel); s != "" {
+		l, err := ladcore.ParseLevel(s)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", EnvLevel, err)
+		}
+		level = l
+	}
+
+	format := getenv(EnvFormat)
+	switch format {
+	case "", "console", "json":
+	default:
+		return nil, fmt.Errorf(`%s: unknown format %q, want "console" or "json"`, EnvFormat, format)
+	}
+
+	var opts []Option
+	if p.bool(EnvCaller) {
+		opts = append(opts, WithCaller())
+	}
+
+	if file := getenv(EnvFile); file != "" {
+		opts = append(opts, WithFile(FileConfig{
+			Level:          level,
+			Filename:       file,
+			MaxSizeMB:      p.int(EnvMaxSizeMB),
+			MaxBackups:     p.int(EnvMaxBackups),
+			MaxAgeDays:     p.int(EnvMaxAgeDays),
+			Compress:       p.bool(EnvCompress),
+			Encoding:       format,
+			RotateInterval: p.duration(EnvRotateInterval),
+		}))
+	} else if format == "json" {
+		opts = append(opts, WithConsoleJSON(level))
+	} else {
+		opts = append(opts, WithConsole(level, p.bool(EnvColor), getenv(EnvTimeFormat)))
+	}
+	return opts, p.err
+}
+
+// envParser parses environment variables, keeping the first error.
+type envParser struct {
+	getenv func(string) string
+	err    error
+}
+
+func (p *envParser) bool(key string) bool {
+	s := p.getenv(key)
+	if s == "" {
+		return false
+	}
+	b, err := strconv.ParseBool(s)
+	p.fail(key, err)
+	return b
+}
+
+func (p *envParser) int(key string) int {
+	s := p.getenv(key)
+	if s == "" {
+		return 0
+	}
+	n, err := strconv.Atoi(s)
+	if err == nil && n < 0 {
+		err = fmt.Errorf("%d is negative", n)
+	}
+	p.fail(key, err)
+	return n
+}
+
+func (p *envParser) duration(key string) time.Duration {
+	s := p.getenv(key)
+	if s == "" {
+		return 0
+	}
+	d, err := time.ParseDuration(s)
+	if err == nil && d < 0 {
+		err = fmt.Errorf("%v is negative", d)
+	}
+	p.fail(key, err)
+	return d
+}
+
+func (p *envParser) fail(key string, err error) {
+	if err != nil && p.err == nil {
+		p.err = fmt.Errorf("%s: %w", key, err)
+	}
+}
@@ -0,0 +1,73 @@
+package ladglobal
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/auwixcom/lad"
+	"github.com/auwixcom/lad/ladcore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFromEnv(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "app.json")
+	t.Setenv(EnvLevel, "warn")
+	t.Setenv(EnvFormat, "json")
+	t.Setenv(EnvFile, filename)
+	t.Setenv(EnvMaxSizeMB, "10")
+	t.Setenv(EnvCaller, "true")
+
+	h, err := NewFromEnv()
+	require.NoError(t, err, "Unexpected error configuring from the environment.")
+	defer lad.ReplaceGlobals(lad.NewNop())
+	assert.Equal(t, ladcore.WarnLevel, h.Level(), "Unexpected level.")
+
+	lad.L().Info("dropped")
+	lad.L().Warn("kept")
+	require.NoError(t, lad.L().Sync(), "Unexpected error syncing.")
+
+	contents, err := os.ReadFile(filename)
+	require.NoError(t, err, "Failed to read log file.")
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(contents, &entry), "Expected a single JSON log line, got %q.", contents)
+	assert.Equal(t, "kept", entry["msg"], "Unexpected message.")
+	assert.Contains(t, entry, "caller", "Expected callers.")
+}
+
+func TestEnvOptions(t *testing.T) {
+	tests := []struct {
+		desc    string
+		env     map[string]string
+		wantErr string
+	}{
+		{desc: "defaults", env: map[string]string{}},
+		{desc: "console", env: map[string]string{EnvFormat: "console", EnvColor: "1", EnvTimeFormat: "15:04"}},
+		{desc: "json stdout", env: map[string]string{EnvFormat: "json"}},
+		{desc: "bad level", env: map[string]string{EnvLevel: "loud"}, wantErr: "LAD_LEVEL: "},
+		{desc: "bad format", env: map[string]string{EnvFormat: "xml"}, wantErr: `LAD_FORMAT: unknown format "xml"`},
+		{desc: "bad bool", env: map[string]string{EnvCaller: "yes please"}, wantErr: "LAD_CALLER: "},
+		{desc: "bad int", env: map[string]string{EnvFile: "x.log", EnvMaxBackups: "-1"}, wantErr: "LAD_MAX_BACKUPS: -1 is negative"},
+		{desc: "bad duration", env: map[string]string{EnvFile: "x.log", EnvRotateInterval: "daily"}, wantErr: "LAD_ROTATE_INTERVAL: "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			opts, err := envOptions(func(key string) string { return tt.env[key] })
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr, "Expected an error.")
+				return
+			}
+			require.NoError(t, err, "Unexpected error.")
+
+			cfg := &Config{level: lad.NewAtomicLevel()}
+			for _, opt := range opts {
+				opt(cfg)
+			}
+			assert.Len(t, cfg.cores, 1, "Expected a single output.")
+			assert.Equal(t, ladcore.InfoLevel, cfg.maxLevel, "Expected InfoLevel by default.")
+		})
+	}
+}
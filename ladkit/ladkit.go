@@ -1,6 +1,8 @@
 package ladglobal
 
 import (
+	"fmt"
+	"net/http"
 	"os"
 	"time"
 
@@ -14,8 +16,56 @@ type Option func(*Config)
 
 // Config holds the configured cores and caller flag.
 type Config struct {
-	cores  []ladcore.Core
-	caller bool
+	cores    []ladcore.Core
+	caller   bool
+	level    lad.AtomicLevel // runtime override, see Handle
+	maxLevel ladcore.Level   // least verbose core level
+	err      error           // first error from an option, see New
+}
+
+// fail records err, unless an earlier option already failed.
+func (cfg *Config) fail(err error) {
+	if cfg.err == nil {
+		cfg.err = err
+	}
+}
+
+// enabler returns the level enabler for a core configured at level: it logs
+// at level or at the runtime override, whichever is more verbose.
+func (cfg *Config) enabler(level ladcore.Level) ladcore.LevelEnabler {
+	if len(cfg.cores) == 0 || level > cfg.maxLevel {
+		cfg.maxLevel = level
+	}
+	override := cfg.level
+	return lad.LevelEnablerFunc(func(l ladcore.Level) bool {
+		return l >= level || override.Enabled(l)
+	})
+}
+
+// Handle adjusts the logger configured by New at runtime.
+type Handle struct {
+	level lad.AtomicLevel
+}
+
+// SetLevel makes every output log at l or its own level, whichever is more
+// verbose; e.g. SetLevel(lad.DebugLevel) turns on debug logs everywhere.
+// Outputs never become less verbose than configured.
+func (h *Handle) SetLevel(l ladcore.Level) {
+	h.level.SetLevel(l)
+}
+
+// Level returns the current override. It starts at the least verbose output
+// level, where it has no effect.
+func (h *Handle) Level() ladcore.Level {
+	return h.level.Level()
+}
+
+// LevelHandler returns an HTTP handler that reports or changes the level,
+// like lad.AtomicLevel.ServeHTTP:
+//
+//	curl -X PUT localhost:8080/log/level -d level=debug
+func (h *Handle) LevelHandler() http.Handler {
+	return h.level
 }
 
 // FileConfig groups parameters for file output.
@@ -26,6 +76,34 @@ type FileConfig struct {
 	MaxBackups int           // max number of backups
 	MaxAgeDays int           // retention days
 	Compress   bool          // compress old logs
+	Encoding   string        // "console" (default) or "json"; others fail New
+
+	// RotateInterval rotates on a schedule (e.g. time.Hour, 24*time.Hour)
+	// instead of by size, writing each period to a timestamped file such as
+	// app-2026-10-15.log. MaxBackups and MaxAgeDays still apply;
+	// MaxSizeMB and Compress are ignored. Zero rotates by size.
+	RotateInterval time.Duration
+}
+
+// newEncoder builds an encoder for the given encoding name, which defaults
+// to console. Console encoders use a human-readable timestamp; JSON encoders
+// use ISO8601.
+func newEncoder(encoding string, encCfg ladcore.EncoderConfig, timeFormat string) (ladcore.Encoder, error) {
+	switch encoding {
+	case "json":
+		encCfg.EncodeTime = ladcore.ISO8601TimeEncoder
+		return ladcore.NewJSONEncoder(encCfg), nil
+	case "", "console":
+		if timeFormat == "" {
+			timeFormat = "2006-01-02 15:04:05.000"
+		}
+		encCfg.EncodeTime = func(t time.Time, pae ladcore.PrimitiveArrayEncoder) {
+			pae.AppendString(t.Format(timeFormat))
+		}
+		return ladcore.NewConsoleEncoder(encCfg), nil
+	default:
+		return nil, fmt.Errorf(`unknown encoding %q, want "console" or "json"`, encoding)
+	}
 }
 
 // WithConsole adds a console core to the logger.
@@ -33,54 +111,85 @@ type FileConfig struct {
 func WithConsole(level ladcore.Level, enableColor bool, timeFormat string) Option {
 	return func(cfg *Config) {
 		encCfg := lad.NewProductionEncoderConfig()
-		// time formatting
-		tf := timeFormat
-		if tf == "" {
-			tf = "2006-01-02 15:04:05.000"
-		}
-		encCfg.EncodeTime = func(t time.Time, pae ladcore.PrimitiveArrayEncoder) {
-			pae.AppendString(t.Format(tf))
-		}
 		// level encoding
 		if enableColor {
 			encCfg.EncodeLevel = ladcore.CapitalColorLevelEncoder
 		}
+		enc, err := newEncoder("console", encCfg, timeFormat)
+		if err != nil {
+			cfg.fail(err)
+			return
+		}
+		core := ladcore.NewCore(
+			enc,
+			ladcore.AddSync(os.Stdout),
+			cfg.enabler(level),
+		)
+		cfg.cores = append(cfg.cores, core)
+	}
+}
+
+// WithConsoleJSON adds a console core that writes JSON lines to stdout,
+// for environments where stdout is collected by a log shipper.
+func WithConsoleJSON(level ladcore.Level) Option {
+	return func(cfg *Config) {
+		enc, err := newEncoder("json", lad.NewProductionEncoderConfig(), "")
+		if err != nil {
+			cfg.fail(err)
+			return
+		}
 		core := ladcore.NewCore(
-			ladcore.NewConsoleEncoder(encCfg),
+			enc,
 			ladcore.AddSync(os.Stdout),
-			level,
+			cfg.enabler(level),
 		)
 		cfg.cores = append(cfg.cores, core)
 	}
 }
 
 // WithFile adds a rotating file core to the logger using a FileConfig struct.
+// Files rotate by size unless fc.RotateInterval is set.
 func WithFile(fc FileConfig) Option {
 	return func(cfg *Config) {
-		hook := &lumberjack.Logger{
-			Filename:   fc.Filename,
-			MaxSize:    fc.MaxSizeMB,
-			MaxBackups: fc.MaxBackups,
-			MaxAge:     fc.MaxAgeDays,
-			Compress:   fc.Compress,
+		encCfg := lad.NewProductionEncoderConfig()
+		if fc.Encoding != "json" {
+			encCfg.EncodeLevel = ladcore.CapitalLevelEncoder
+		}
+		enc, err := newEncoder(fc.Encoding, encCfg, "")
+		if err != nil {
+			cfg.fail(fmt.Errorf("file %q: %w", fc.Filename, err))
+			return
 		}
 
-		encCfg := lad.NewProductionEncoderConfig()
-		// default timestamp format
-		encCfg.EncodeTime = func(t time.Time, pae ladcore.PrimitiveArrayEncoder) {
-			pae.AppendString(t.Format("2006-01-02 15:04:05.000"))
+		var hook ladcore.WriteSyncer
+		if fc.RotateInterval > 0 {
+			hook = newTimeRotator(fc)
+		} else {
+			hook = ladcore.AddSync(&lumberjack.Logger{
+				Filename:   fc.Filename,
+				MaxSize:    fc.MaxSizeMB,
+				MaxBackups: fc.MaxBackups,
+				MaxAge:     fc.MaxAgeDays,
+				Compress:   fc.Compress,
+			})
 		}
-		encCfg.EncodeLevel = ladcore.CapitalLevelEncoder
 
 		core := ladcore.NewCore(
-			ladcore.NewConsoleEncoder(encCfg), // or JSONEncoder if preferred
-			ladcore.AddSync(hook),
-			fc.Level,
+			enc,
+			hook,
+			cfg.enabler(fc.Level),
 		)
 		cfg.cores = append(cfg.cores, core)
 	}
 }
 
+// WithFileRotateDaily adds a file core that starts a new, date-stamped file
+// every day at local midnight.
+func WithFileRotateDaily(fc FileConfig) Option {
+	fc.RotateInterval = _day
+	return WithFile(fc)
+}
+
 // WithCaller enables adding the caller information to logs.
 func WithCaller() Option {
 	return func(cfg *Config) {
@@ -90,15 +199,23 @@ func WithCaller() Option {
 
 // New configures and replaces the global logger based on the provided options.
 // If no cores are added, defaults to a console core at DebugLevel.
-func New(opts ...Option) {
-	cfg := &Config{}
+// The returned Handle changes the level at runtime.
+//
+// Invalid options, such as an unknown FileConfig.Encoding, are reported as
+// an error, and the global logger is left alone.
+func New(opts ...Option) (*Handle, error) {
+	cfg := &Config{level: lad.NewAtomicLevel()}
 	for _, opt := range opts {
 		opt(cfg)
 	}
+	if cfg.err != nil {
+		return nil, cfg.err
+	}
 	if len(cfg.cores) == 0 {
 		// default console core
 		WithConsole(lad.DebugLevel, true, "")(cfg)
 	}
+	cfg.level.SetLevel(cfg.maxLevel)
 
 	// combine cores
 	core := ladcore.NewTee(cfg.cores...)
@@ -108,4 +225,5 @@ func New(opts ...Option) {
 	}
 	logger := lad.New(core, zapOpts...)
 	lad.ReplaceGlobals(logger)
+	return &Handle{level: cfg.level}, nil
 }
@@ -1,10 +1,19 @@
 package ladglobal
 
 import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/auwixcom/lad"
 	"github.com/auwixcom/lad/ladcore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNew(t *testing.T) {
@@ -26,3 +35,72 @@ func TestNew(t *testing.T) {
 	lad.S().Info("Service started successfully")
 	lad.S().Warn("This is a warning log")
 }
+
+func TestNewFileJSON(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "app.json")
+	New(
+		WithFile(FileConfig{
+			Level:    ladcore.InfoLevel,
+			Filename: filename,
+			Encoding: "json",
+		}),
+		WithConsoleJSON(ladcore.ErrorLevel),
+	)
+	defer lad.ReplaceGlobals(lad.NewNop())
+
+	lad.L().Info("hello", lad.Int("n", 1))
+	_ = lad.L().Sync() // syncing stdout fails when it isn't a file
+
+	contents, err := os.ReadFile(filename)
+	require.NoError(t, err, "Failed to read log file.")
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(contents, &entry), "Expected a JSON log line, got %q.", contents)
+	assert.Equal(t, "hello", entry["msg"], "Unexpected message.")
+	assert.Equal(t, "info", entry["level"], "Unexpected level.")
+	assert.Equal(t, float64(1), entry["n"], "Unexpected field.")
+}
+
+func TestNewUnknownEncoding(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "app.log")
+	before := lad.L()
+	h, err := New(WithFile(FileConfig{Filename: filename, Encoding: "yaml"}))
+	assert.EqualError(t, err, fmt.Sprintf(`file %q: unknown encoding "yaml", want "console" or "json"`, filename), "Unexpected error.")
+	assert.Nil(t, h, "Expected no Handle on error.")
+	assert.Same(t, before, lad.L(), "Expected the global logger to be left alone.")
+	assert.NoFileExists(t, filename, "Expected no file to be created.")
+}
+
+func TestHandleSetLevel(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "app.json")
+	h, err := New(
+		WithFile(FileConfig{Level: ladcore.WarnLevel, Filename: filename, Encoding: "json"}),
+		WithConsoleJSON(ladcore.ErrorLevel),
+	)
+	require.NoError(t, err, "Unexpected error configuring the logger.")
+	defer lad.ReplaceGlobals(lad.NewNop())
+	assert.Equal(t, ladcore.ErrorLevel, h.Level(), "Expected the override to start at the least verbose level.")
+
+	lad.L().Info("before")
+	h.SetLevel(ladcore.DebugLevel)
+	lad.L().Debug("after set")
+
+	req := httptest.NewRequest(http.MethodPut, "/log/level?level=error", nil)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	h.LevelHandler().ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code, "Unexpected status.")
+	assert.Equal(t, ladcore.ErrorLevel, h.Level(), "Expected the handler to change the level.")
+	lad.L().Info("after reset")
+	lad.L().Warn("warn")
+
+	contents, err := os.ReadFile(filename)
+	require.NoError(t, err, "Failed to read log file.")
+	var msgs []string
+	for _, line := range strings.Split(strings.TrimSpace(string(contents)), "\n") {
+		var entry map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &entry), "Expected a JSON log line, got %q.", line)
+		msgs = append(msgs, entry["msg"].(string))
+	}
+	assert.Equal(t, []string{"after set", "warn"}, msgs, "Unexpected logged messages.")
+}
@@ -0,0 +1,154 @@
+package ladglobal
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const _day = 24 * time.Hour
+
+// timeRotator is a WriteSyncer that writes each period (hour, day, ...) to
+// its own timestamped file, e.g. app-2026-10-15.log for daily rotation.
+// Files are never renamed, so a file is complete once the next one appears.
+type timeRotator struct {
+	filename   string        // base path; the timestamp goes before the extension
+	interval   time.Duration // should divide 24h evenly, or be a whole number of days
+	maxAge     time.Duration // 0 keeps files forever
+	maxBackups int           // 0 keeps all files
+	now        func() time.Time
+
+	mu   sync.Mutex
+	file *os.File
+	end  time.Time // end of the current period
+}
+
+func newTimeRotator(fc FileConfig) *timeRotator {
+	return &timeRotator{
+		filename:   fc.Filename,
+		interval:   fc.RotateInterval,
+		maxAge:     time.Duration(fc.MaxAgeDays) * _day,
+		maxBackups: fc.MaxBackups,
+		now:        time.Now,
+	}
+}
+
+func (r *timeRotator) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if now := r.now(); r.file == nil || !now.Before(r.end) {
+		if err := r.rotate(now); err != nil {
+			return 0, err
+		}
+	}
+	return r.file.Write(p)
+}
+
+func (r *timeRotator) Sync() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return nil
+	}
+	return r.file.Sync()
+}
+
+// Close closes the current file.
+func (r *timeRotator) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+// period returns the bounds of the period containing t. Periods are aligned
+// to local midnight so that daily files match calendar days.
+func (r *timeRotator) period(t time.Time) (start, end time.Time) {
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	if r.interval >= _day {
+		return midnight, midnight.AddDate(0, 0, int(r.interval/_day))
+	}
+	start = midnight.Add(t.Sub(midnight).Truncate(r.interval))
+	return start, start.Add(r.interval)
+}
+
+func (r *timeRotator) layout() string {
+	switch {
+	case r.interval >= _day:
+		return "2006-01-02"
+	case r.interval >= time.Hour:
+		return "2006-01-02T15"
+	default:
+		return "2006-01-02T15-04"
+	}
+}
+
+func (r *timeRotator) split() (prefix, ext string) {
+	ext = filepath.Ext(r.filename)
+	return strings.TrimSuffix(r.filename, ext) + "-", ext
+}
+
+func (r *timeRotator) rotate(now time.Time) error {
+	if r.file != nil {
+		_ = r.file.Close()
+		r.file = nil
+	}
+
+	start, end := r.period(now)
+	prefix, ext := r.split()
+	name := prefix + start.Format(r.layout()) + ext
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	r.file, r.end = f, end
+	r.cleanup(name, now)
+	return nil
+}
+
+// cleanup removes old files beyond maxBackups or older than maxAge. Errors
+// are ignored; a leftover file is better than a failed write.
+func (r *timeRotator) cleanup(current string, now time.Time) {
+	if r.maxAge <= 0 && r.maxBackups <= 0 {
+		return
+	}
+	prefix, ext := r.split()
+	matches, err := filepath.Glob(prefix + "*" + ext)
+	if err != nil {
+		return
+	}
+
+	var old []string
+	for _, m := range matches {
+		if _, err := time.Parse(r.layout(), strings.TrimSuffix(strings.TrimPrefix(m, prefix), ext)); err == nil && m != current {
+			old = append(old, m)
+		}
+	}
+	// Timestamps sort lexically, so this is newest first.
+	sort.Sort(sort.Reverse(sort.StringSlice(old)))
+
+	for i, m := range old {
+		expired := r.maxBackups > 0 && i >= r.maxBackups
+		if !expired && r.maxAge > 0 {
+			if info, err := os.Stat(m); err == nil && now.Sub(info.ModTime()) > r.maxAge {
+				expired = true
+			}
+		}
+		if expired {
+			_ = os.Remove(m)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package ladglobal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeRotator(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2026, 10, 15, 23, 30, 0, 0, time.Local)
+	r := newTimeRotator(FileConfig{
+		Filename:       filepath.Join(dir, "app.log"),
+		RotateInterval: _day,
+		MaxBackups:     1,
+	})
+	r.now = func() time.Time { return now }
+	defer r.Close()
+
+	write := func(s string) {
+		_, err := r.Write([]byte(s))
+		require.NoError(t, err, "Unexpected error writing.")
+	}
+	read := func(name string) string {
+		b, err := os.ReadFile(filepath.Join(dir, name))
+		require.NoError(t, err, "Failed to read %v.", name)
+		return string(b)
+	}
+
+	write("a\n")
+	now = now.Add(40 * time.Minute)
+	write("b\n")
+	now = now.Add(12 * time.Hour)
+	write("c\n")
+	require.NoError(t, r.Sync(), "Unexpected error syncing.")
+
+	assert.Equal(t, "a\n", read("app-2026-10-15.log"), "Unexpected first day.")
+	assert.Equal(t, "b\nc\n", read("app-2026-10-16.log"), "Unexpected second day.")
+
+	now = now.Add(_day)
+	write("d\n")
+	_, err := os.Stat(filepath.Join(dir, "app-2026-10-15.log"))
+	assert.True(t, os.IsNotExist(err), "Expected files beyond MaxBackups to be removed.")
+	assert.Equal(t, "d\n", read("app-2026-10-17.log"), "Unexpected third day.")
+}
+
+func TestTimeRotatorPeriods(t *testing.T) {
+	at := time.Date(2026, 10, 15, 13, 47, 5, 0, time.Local)
+	tests := []struct {
+		interval  time.Duration
+		wantStart time.Time
+		wantName  string
+	}{
+		{_day, time.Date(2026, 10, 15, 0, 0, 0, 0, time.Local), "app-2026-10-15.log"},
+		{time.Hour, time.Date(2026, 10, 15, 13, 0, 0, 0, time.Local), "app-2026-10-15T13.log"},
+		{15 * time.Minute, time.Date(2026, 10, 15, 13, 45, 0, 0, time.Local), "app-2026-10-15T13-45.log"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.interval.String(), func(t *testing.T) {
+			r := &timeRotator{filename: "app.log", interval: tt.interval}
+			start, end := r.period(at)
+			assert.Equal(t, tt.wantStart, start, "Unexpected period start.")
+			assert.Equal(t, tt.interval, end.Sub(start), "Unexpected period length.")
+
+			prefix, ext := r.split()
+			assert.Equal(t, tt.wantName, prefix+start.Format(r.layout())+ext, "Unexpected filename.")
+		})
+	}
+}
+
+func TestTimeRotatorMaxAge(t *testing.T) {
+	dir := t.TempDir()
+	stale := filepath.Join(dir, "app-2026-10-01.log")
+	unrelated := filepath.Join(dir, "app-backup.log")
+	for _, name := range []string{stale, unrelated} {
+		require.NoError(t, os.WriteFile(name, nil, 0o644), "Failed to create %v.", name)
+		old := time.Now().Add(-10 * _day)
+		require.NoError(t, os.Chtimes(name, old, old), "Failed to age %v.", name)
+	}
+
+	r := newTimeRotator(FileConfig{
+		Filename:       filepath.Join(dir, "app.log"),
+		RotateInterval: _day,
+		MaxAgeDays:     7,
+	})
+	defer r.Close()
+	_, err := r.Write([]byte("x\n"))
+	require.NoError(t, err, "Unexpected error writing.")
+
+	_, err = os.Stat(stale)
+	assert.True(t, os.IsNotExist(err), "Expected expired files to be removed.")
+	assert.FileExists(t, unrelated, "Expected files without a timestamp to be kept.")
+}
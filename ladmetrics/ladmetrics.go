@@ -0,0 +1,141 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package ladmetrics makes log volume observable: it wraps lad Cores and
+// Encoders to count the entries they write, the writes that fail, and the
+// time spent encoding, so that anomalies like a sudden flood of errors or a
+// failing sink show up on a dashboard.
+//
+// Measurements go to a Recorder. The Prometheus type is a Recorder that
+// serves them in the Prometheus text exposition format:
+//
+//	prom := ladmetrics.NewPrometheus(nil)
+//	http.Handle("/metrics", prom)
+//
+//	enc := ladmetrics.WrapEncoder(ladcore.NewJSONEncoder(cfg), prom)
+//	core := ladmetrics.NewCore(ladcore.NewCore(enc, sink, level), prom)
+package ladmetrics
+
+import (
+	"time"
+
+	"github.com/auwixcom/lad/buffer"
+	"github.com/auwixcom/lad/ladcore"
+)
+
+// A Recorder receives measurements from the Cores and Encoders in this
+// package. Implementations typically forward them to a metrics system and
+// must be safe for concurrent use.
+type Recorder interface {
+	// CountEntry records that an entry at the given level was written.
+	CountEntry(lvl ladcore.Level)
+	// CountWriteError records that writing an entry at the given level
+	// failed.
+	CountWriteError(lvl ladcore.Level)
+	// ObserveEncode records how long encoding an entry took.
+	ObserveEncode(d time.Duration)
+}
+
+// NewCore wraps a Core to report each entry it writes, and each write that
+// fails, to the Recorder.
+//
+// The wrapped Core's Write is called directly once the entry is enabled, so
+// wrap the Core closest to the output, like one from ladcore.NewCore, rather
+// than one that filters entries in Check, like a sampler. That way the
+// counts reflect entries actually written.
+func NewCore(core ladcore.Core, rec Recorder) ladcore.Core {
+	return &metricsCore{
+		LevelEnabler: core,
+		core:         core,
+		rec:          rec,
+	}
+}
+
+type metricsCore struct {
+	ladcore.LevelEnabler
+
+	core ladcore.Core
+	rec  Recorder
+}
+
+var _ ladcore.Core = (*metricsCore)(nil)
+
+func (c *metricsCore) Level() ladcore.Level {
+	return ladcore.LevelOf(c.core)
+}
+
+func (c *metricsCore) With(fields []ladcore.Field) ladcore.Core {
+	core := c.core.With(fields)
+	return &metricsCore{
+		LevelEnabler: core,
+		core:         core,
+		rec:          c.rec,
+	}
+}
+
+func (c *metricsCore) Check(ent ladcore.Entry, ce *ladcore.CheckedEntry) *ladcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *metricsCore) Write(ent ladcore.Entry, fields []ladcore.Field) error {
+	if err := c.core.Write(ent, fields); err != nil {
+		c.rec.CountWriteError(ent.Level)
+		return err
+	}
+	c.rec.CountEntry(ent.Level)
+	return nil
+}
+
+func (c *metricsCore) Sync() error {
+	return c.core.Sync()
+}
+
+// WrapEncoder wraps an Encoder to report how long each call to EncodeEntry
+// takes to the Recorder. Clones of the returned Encoder report to the same
+// Recorder.
+func WrapEncoder(enc ladcore.Encoder, rec Recorder) ladcore.Encoder {
+	return &timedEncoder{Encoder: enc, rec: rec}
+}
+
+type timedEncoder struct {
+	ladcore.Encoder
+
+	rec Recorder
+}
+
+func (e *timedEncoder) Clone() ladcore.Encoder {
+	return &timedEncoder{Encoder: e.Encoder.Clone(), rec: e.rec}
+}
+
+// TransformField forwards to the wrapped Encoder, so that fields added with
+// With are transformed as its EncoderConfig says.
+func (e *timedEncoder) TransformField(f ladcore.Field) ladcore.Field {
+	return ladcore.TransformField(e.Encoder, f)
+}
+
+func (e *timedEncoder) EncodeEntry(ent ladcore.Entry, fields []ladcore.Field) (*buffer.Buffer, error) {
+	start := time.Now()
+	buf, err := e.Encoder.EncodeEntry(ent, fields)
+	e.rec.ObserveEncode(time.Since(start))
+	return buf, err
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladmetrics
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/auwixcom/lad/internal/ztest"
+	"github.com/auwixcom/lad/ladcore"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRecorder struct {
+	entries, errors map[ladcore.Level]int
+	encodes         int
+}
+
+func newFakeRecorder() *fakeRecorder {
+	return &fakeRecorder{
+		entries: make(map[ladcore.Level]int),
+		errors:  make(map[ladcore.Level]int),
+	}
+}
+
+func (r *fakeRecorder) CountEntry(lvl ladcore.Level)      { r.entries[lvl]++ }
+func (r *fakeRecorder) CountWriteError(lvl ladcore.Level) { r.errors[lvl]++ }
+func (r *fakeRecorder) ObserveEncode(time.Duration)       { r.encodes++ }
+
+func TestCore(t *testing.T) {
+	rec := newFakeRecorder()
+	enc := WrapEncoder(ladcore.NewJSONEncoder(ladcore.EncoderConfig{MessageKey: "msg"}), rec)
+	out := &ztest.Buffer{}
+	core := NewCore(ladcore.NewCore(enc, out, ladcore.InfoLevel), rec).
+		With([]ladcore.Field{{Key: "k", Type: ladcore.StringType, String: "v"}})
+	assert.Equal(t, ladcore.InfoLevel, ladcore.LevelOf(core), "Unexpected level.")
+
+	for _, lvl := range []ladcore.Level{ladcore.DebugLevel, ladcore.InfoLevel, ladcore.InfoLevel, ladcore.ErrorLevel} {
+		if ce := core.Check(ladcore.Entry{Level: lvl, Message: "m"}, nil); ce != nil {
+			ce.Write()
+		}
+	}
+	require.NoError(t, core.Sync(), "Unexpected error syncing.")
+
+	assert.Equal(t, map[ladcore.Level]int{ladcore.InfoLevel: 2, ladcore.ErrorLevel: 1}, rec.entries, "Unexpected entry counts.")
+	assert.Empty(t, rec.errors, "Unexpected write errors.")
+	assert.Equal(t, 3, rec.encodes, "Expected to time every encoded entry.")
+	assert.Equal(t, 3, len(out.Lines()), "Unexpected output.")
+}
+
+func TestWrapEncoderFieldEncoder(t *testing.T) {
+	upper := func(f ladcore.Field) ladcore.Field {
+		f.Key = strings.ToUpper(f.Key)
+		return f
+	}
+	enc := WrapEncoder(ladcore.NewJSONEncoder(ladcore.EncoderConfig{MessageKey: "msg", FieldEncoder: upper}), newFakeRecorder())
+	out := &ztest.Buffer{}
+	core := ladcore.NewCore(enc, out, ladcore.InfoLevel).
+		With([]ladcore.Field{{Key: "ctx", Type: ladcore.StringType, String: "v"}})
+	require.NoError(t, core.Write(ladcore.Entry{Level: ladcore.InfoLevel, Message: "m"}, []ladcore.Field{
+		{Key: "arg", Type: ladcore.StringType, String: "v"},
+	}), "Unexpected error writing.")
+	assert.Equal(t, []string{`{"msg":"m","CTX":"v","ARG":"v"}`}, out.Lines(), "Expected With fields to be transformed through the wrapper.")
+}
+
+func TestCoreWriteError(t *testing.T) {
+	rec := newFakeRecorder()
+	enc := ladcore.NewJSONEncoder(ladcore.EncoderConfig{MessageKey: "msg"})
+	core := NewCore(ladcore.NewCore(enc, &ztest.FailWriter{}, ladcore.InfoLevel), rec)
+
+	assert.Error(t, core.Write(ladcore.Entry{Level: ladcore.WarnLevel}, nil), "Expected a write error.")
+	assert.Equal(t, map[ladcore.Level]int{ladcore.WarnLevel: 1}, rec.errors, "Unexpected error counts.")
+	assert.Empty(t, rec.entries, "Didn't expect failed writes to count as entries.")
+}
+
+func TestPrometheus(t *testing.T) {
+	p := NewPrometheus([]float64{0.002, 0.001})
+	p.CountEntry(ladcore.InfoLevel)
+	p.CountEntry(ladcore.InfoLevel)
+	p.CountEntry(ladcore.Level(42)) // out of range, ignored
+	p.CountWriteError(ladcore.ErrorLevel)
+	p.ObserveEncode(500 * time.Microsecond)
+	p.ObserveEncode(time.Millisecond)
+	p.ObserveEncode(time.Second)
+
+	rr := httptest.NewRecorder()
+	p.ServeHTTP(rr, httptest.NewRequest("GET", "/metrics", nil))
+	assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", rr.Header().Get("Content-Type"), "Unexpected content type.")
+
+	body := rr.Body.String()
+	for _, line := range []string{
+		"# TYPE lad_log_entries_total counter",
+		`lad_log_entries_total{level="debug"} 0`,
+		`lad_log_entries_total{level="info"} 2`,
+		`lad_log_write_errors_total{level="error"} 1`,
+		"# TYPE lad_log_encode_duration_seconds histogram",
+		`lad_log_encode_duration_seconds_bucket{le="0.001"} 2`,
+		`lad_log_encode_duration_seconds_bucket{le="0.002"} 2`,
+		`lad_log_encode_duration_seconds_bucket{le="+Inf"} 3`,
+		"lad_log_encode_duration_seconds_sum 1.0015",
+		"lad_log_encode_duration_seconds_count 3",
+	} {
+		assert.Contains(t, strings.Split(body, "\n"), line, "Missing line in exposition.")
+	}
+}
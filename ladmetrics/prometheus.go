@@ -0,0 +1,183 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladmetrics
+
+import (
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/auwixcom/lad/buffer"
+	"github.com/auwixcom/lad/internal/bufferpool"
+	"github.com/auwixcom/lad/ladcore"
+)
+
+// DefaultBuckets are the upper bounds, in seconds, of the encode latency
+// histogram buckets used when NewPrometheus isn't given any.
+var DefaultBuckets = []float64{1e-6, 2.5e-6, 5e-6, 1e-5, 2.5e-5, 5e-5, 1e-4, 2.5e-4, 5e-4, 1e-3}
+
+// _numLevels is the number of levels, from DebugLevel to FatalLevel, that
+// Prometheus tracks.
+const _numLevels = int(ladcore.FatalLevel-ladcore.DebugLevel) + 1
+
+// Prometheus is a Recorder that keeps its measurements in memory and serves
+// them over HTTP in the Prometheus text exposition format, without
+// depending on the Prometheus client library. It exports:
+//
+//	lad_log_entries_total{level="..."}       counter
+//	lad_log_write_errors_total{level="..."}  counter
+//	lad_log_encode_duration_seconds          histogram
+//
+// Prometheus is safe for concurrent use.
+type Prometheus struct {
+	entries [_numLevels]atomic.Uint64
+	errors  [_numLevels]atomic.Uint64
+
+	mu      sync.Mutex
+	buckets []float64 // upper bounds, ascending
+	counts  []uint64  // per bucket, not cumulative; the last is +Inf
+	sum     float64
+	count   uint64
+}
+
+var (
+	_ Recorder     = (*Prometheus)(nil)
+	_ http.Handler = (*Prometheus)(nil)
+)
+
+// NewPrometheus creates a Prometheus with the given encode latency histogram
+// bucket bounds, in seconds. If buckets is empty, DefaultBuckets is used.
+func NewPrometheus(buckets []float64) *Prometheus {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+	return &Prometheus{
+		buckets: buckets,
+		counts:  make([]uint64, len(buckets)+1),
+	}
+}
+
+// CountEntry implements Recorder.
+func (p *Prometheus) CountEntry(lvl ladcore.Level) {
+	if i, ok := levelIndex(lvl); ok {
+		p.entries[i].Add(1)
+	}
+}
+
+// CountWriteError implements Recorder.
+func (p *Prometheus) CountWriteError(lvl ladcore.Level) {
+	if i, ok := levelIndex(lvl); ok {
+		p.errors[i].Add(1)
+	}
+}
+
+// ObserveEncode implements Recorder.
+func (p *Prometheus) ObserveEncode(d time.Duration) {
+	secs := d.Seconds()
+	i := sort.SearchFloat64s(p.buckets, secs) // first bound >= secs
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.counts[i]++
+	p.sum += secs
+	p.count++
+}
+
+// ServeHTTP serves the current measurements.
+func (p *Prometheus) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = p.WriteTo(w)
+}
+
+// WriteTo writes the current measurements to w in the Prometheus text
+// exposition format.
+func (p *Prometheus) WriteTo(w io.Writer) (int64, error) {
+	buf := bufferpool.Get()
+	defer buf.Free()
+
+	p.writeCounter(buf, "lad_log_entries_total", "Log entries written, by level.", &p.entries)
+	p.writeCounter(buf, "lad_log_write_errors_total", "Log entries that failed to be written, by level.", &p.errors)
+
+	buf.AppendString("# HELP lad_log_encode_duration_seconds Time spent encoding log entries.\n")
+	buf.AppendString("# TYPE lad_log_encode_duration_seconds histogram\n")
+	p.mu.Lock()
+	var cumulative uint64
+	for i, count := range p.counts {
+		cumulative += count
+		le := math.Inf(1)
+		if i < len(p.buckets) {
+			le = p.buckets[i]
+		}
+		buf.AppendString(`lad_log_encode_duration_seconds_bucket{le="`)
+		buf.AppendString(formatFloat(le))
+		buf.AppendString(`"} `)
+		buf.AppendUint(cumulative)
+		buf.AppendByte('\n')
+	}
+	buf.AppendString("lad_log_encode_duration_seconds_sum ")
+	buf.AppendString(formatFloat(p.sum))
+	buf.AppendString("\nlad_log_encode_duration_seconds_count ")
+	buf.AppendUint(p.count)
+	buf.AppendByte('\n')
+	p.mu.Unlock()
+
+	n, err := w.Write(buf.Bytes())
+	return int64(n), err
+}
+
+func (p *Prometheus) writeCounter(buf *buffer.Buffer, name, help string, counts *[_numLevels]atomic.Uint64) {
+	buf.AppendString("# HELP " + name + " " + help + "\n")
+	buf.AppendString("# TYPE " + name + " counter\n")
+	for i := range counts {
+		buf.AppendString(name)
+		buf.AppendString(`{level="`)
+		buf.AppendString((ladcore.DebugLevel + ladcore.Level(i)).String())
+		buf.AppendString(`"} `)
+		buf.AppendUint(counts[i].Load())
+		buf.AppendByte('\n')
+	}
+}
+
+func levelIndex(lvl ladcore.Level) (int, bool) {
+	i := int(lvl - ladcore.DebugLevel)
+	return i, i >= 0 && i < _numLevels
+}
+
+// formatFloat formats f the way Prometheus expects.
+func formatFloat(f float64) string {
+	switch {
+	case math.IsInf(f, 1):
+		return "+Inf"
+	case math.IsInf(f, -1):
+		return "-Inf"
+	case math.IsNaN(f):
+		return "NaN"
+	default:
+		return strconv.FormatFloat(f, 'g', -1, 64)
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package ladotel correlates lad entries with OpenTelemetry traces. It adds
+// the active span's trace_id, span_id, and trace_flags to entries logged
+// with Logger.WithContext or lad.Ctx, and can mirror error entries onto the
+// span as events.
+//
+// To keep lad free of OpenTelemetry dependencies, ladotel works with a
+// small SpanContext struct. Bridge it to the OpenTelemetry API with a few
+// lines of code:
+//
+//	spanContext := func(ctx context.Context) ladotel.SpanContext {
+//	  sc := trace.SpanContextFromContext(ctx)
+//	  return ladotel.SpanContext{
+//	    TraceID:    sc.TraceID(),
+//	    SpanID:     sc.SpanID(),
+//	    TraceFlags: byte(sc.TraceFlags()),
+//	  }
+//	}
+//	recordEvent := func(ctx context.Context, ent ladcore.Entry, _ []lad.Field) {
+//	  trace.SpanFromContext(ctx).AddEvent(ent.Message, trace.WithTimestamp(ent.Time))
+//	}
+//
+//	logger := lad.New(core,
+//	  lad.WithContextExtractors(ladotel.NewExtractor(spanContext)),
+//	  ladotel.WithSpanEvents(recordEvent, lad.ErrorLevel),
+//	)
+//	logger.WithContext(ctx).Error("charge failed") // has trace_id, span_id, trace_flags
+package ladotel // import "github.com/auwixcom/lad/ladotel"
+
+import (
+	"context"
+	"encoding/hex"
+
+	"github.com/auwixcom/lad"
+	"github.com/auwixcom/lad/ladcore"
+)
+
+// SpanContext identifies a span. Its layout matches OpenTelemetry's
+// trace.TraceID, trace.SpanID, and trace.TraceFlags.
+type SpanContext struct {
+	TraceID    [16]byte
+	SpanID     [8]byte
+	TraceFlags byte
+}
+
+// IsValid reports whether both IDs are non-zero, as OpenTelemetry requires.
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID != [16]byte{} && sc.SpanID != [8]byte{}
+}
+
+// NewExtractor builds a lad.ContextExtractor that adds the trace_id,
+// span_id, and trace_flags of the span that spanContext finds in a context,
+// hex-encoded as in the W3C Trace Context. Contexts without a valid span
+// contribute no fields.
+func NewExtractor(spanContext func(context.Context) SpanContext) lad.ContextExtractor {
+	return lad.ContextExtractorFunc(func(ctx context.Context) []lad.Field {
+		sc := spanContext(ctx)
+		if !sc.IsValid() {
+			return nil
+		}
+		return []lad.Field{
+			lad.String("trace_id", hex.EncodeToString(sc.TraceID[:])),
+			lad.String("span_id", hex.EncodeToString(sc.SpanID[:])),
+			lad.String("trace_flags", hex.EncodeToString([]byte{sc.TraceFlags})),
+			// Invisible to encoders; lets WithSpanEvents find the span.
+			{Type: ladcore.SkipType, Interface: spanMarker{ctx}},
+		}
+	})
+}
+
+// spanMarker carries the context a span was extracted from.
+type spanMarker struct {
+	ctx context.Context
+}
+
+// EventFunc records a log entry on the span in ctx, typically as a span
+// event. It receives the fields passed at the log site, but not those
+// added with With.
+type EventFunc func(ctx context.Context, ent ladcore.Entry, fields []ladcore.Field)
+
+// WithSpanEvents configures a Logger to call record for entries at or above
+// the given level that are logged with a context whose span was found by a
+// NewExtractor extractor. Entries are still written as usual.
+func WithSpanEvents(record EventFunc, enab ladcore.LevelEnabler) lad.Option {
+	return lad.WrapCore(func(core ladcore.Core) ladcore.Core {
+		return &eventCore{Core: core, record: record, enab: enab}
+	})
+}
+
+type eventCore struct {
+	ladcore.Core
+
+	record EventFunc
+	enab   ladcore.LevelEnabler
+	ctx    context.Context // nil until a span is found
+}
+
+var _ ladcore.Core = (*eventCore)(nil)
+
+func (c *eventCore) Level() ladcore.Level {
+	return ladcore.LevelOf(c.Core)
+}
+
+func (c *eventCore) With(fields []ladcore.Field) ladcore.Core {
+	clone := *c
+	clone.Core = c.Core.With(fields)
+	for _, f := range fields {
+		if m, ok := f.Interface.(spanMarker); ok && f.Type == ladcore.SkipType {
+			clone.ctx = m.ctx
+		}
+	}
+	return &clone
+}
+
+func (c *eventCore) Check(ent ladcore.Entry, ce *ladcore.CheckedEntry) *ladcore.CheckedEntry {
+	// Like ladcore.RegisterHooks, let the wrapped Core register itself and
+	// tag along only if it will write the entry.
+	downstream := c.Core.Check(ent, ce)
+	if downstream != nil && c.ctx != nil && c.enab.Enabled(ent.Level) {
+		return downstream.AddCore(ent, c)
+	}
+	return downstream
+}
+
+func (c *eventCore) Write(ent ladcore.Entry, fields []ladcore.Field) error {
+	c.record(c.ctx, ent, fields)
+	return nil
+}
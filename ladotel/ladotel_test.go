@@ -0,0 +1,103 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladotel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/auwixcom/lad"
+	"github.com/auwixcom/lad/ladcore"
+	"github.com/auwixcom/lad/ladtest/observer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type spanKey struct{}
+
+func spanContext(ctx context.Context) SpanContext {
+	sc, _ := ctx.Value(spanKey{}).(SpanContext)
+	return sc
+}
+
+var _testSpan = SpanContext{
+	TraceID:    [16]byte{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+	SpanID:     [8]byte{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+	TraceFlags: 1,
+}
+
+type event struct {
+	ctx    context.Context
+	msg    string
+	fields []ladcore.Field
+}
+
+func TestExtractor(t *testing.T) {
+	core, logs := observer.New(lad.DebugLevel)
+	var events []event
+	logger := lad.New(core,
+		lad.WithContextExtractors(NewExtractor(spanContext)),
+		WithSpanEvents(func(ctx context.Context, ent ladcore.Entry, fields []ladcore.Field) {
+			events = append(events, event{ctx, ent.Message, fields})
+		}, lad.ErrorLevel),
+	)
+
+	ctx := context.WithValue(context.Background(), spanKey{}, _testSpan)
+	logger.WithContext(ctx).Info("info")
+	logger.WithContext(ctx).With(lad.Int("n", 1)).Error("error", lad.String("k", "v"))
+	logger.WithContext(context.Background()).Error("no span")
+	logger.Error("no context")
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 4, "Unexpected number of entries.")
+	want := map[string]interface{}{
+		"trace_id":    "4bf92f3577b34da6a3ce929d0e0e4736",
+		"span_id":     "00f067aa0ba902b7",
+		"trace_flags": "01",
+	}
+	assert.Equal(t, want, entries[0].ContextMap(), "Unexpected trace fields.")
+	assert.Empty(t, entries[2].ContextMap(), "Expected no fields without a span.")
+
+	require.Len(t, events, 1, "Expected only errors with a span to be recorded.")
+	assert.Equal(t, ctx, events[0].ctx, "Unexpected context.")
+	assert.Equal(t, "error", events[0].msg, "Unexpected message.")
+	assert.Equal(t, []ladcore.Field{lad.String("k", "v")}, events[0].fields, "Unexpected fields.")
+}
+
+func TestSpanEventsRespectLevel(t *testing.T) {
+	core, logs := observer.New(lad.ErrorLevel + 1)
+	var recorded int
+	logger := lad.New(core,
+		lad.WithContextExtractors(NewExtractor(spanContext)),
+		WithSpanEvents(func(context.Context, ladcore.Entry, []ladcore.Field) { recorded++ }, lad.ErrorLevel),
+	)
+
+	ctx := context.WithValue(context.Background(), spanKey{}, _testSpan)
+	logger.WithContext(ctx).Error("disabled")
+	assert.Zero(t, logs.Len(), "Expected the entry to be dropped.")
+	assert.Zero(t, recorded, "Expected no event for entries the core drops.")
+}
+
+func TestSpanContextIsValid(t *testing.T) {
+	assert.True(t, _testSpan.IsValid(), "Expected a valid span.")
+	assert.False(t, SpanContext{}.IsValid(), "Expected a zero span to be invalid.")
+	assert.False(t, SpanContext{TraceID: _testSpan.TraceID}.IsValid(), "Expected a span without a span ID to be invalid.")
+}
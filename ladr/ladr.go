@@ -0,0 +1,220 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package ladr adapts lad to logr, so that libraries built on logr, such as
+// controller-runtime and client-go, log through a lad Logger:
+//
+//	ctrl.SetLogger(ladr.NewLogger(logger))
+//
+// logr's verbosity levels map onto lad's levels by negation: V(0) is
+// InfoLevel, V(1) is DebugLevel, and V(n) is ladcore.Level(-n). To see V(2)
+// logs, build the Logger with an enabler that allows ladcore.Level(-2).
+package ladr // import "github.com/auwixcom/lad/ladr"
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/auwixcom/lad"
+	"github.com/auwixcom/lad/ladcore"
+	"github.com/go-logr/logr"
+)
+
+const (
+	_oddNumberErrMsg    = "Ignored key without a value."
+	_nonStringKeyErrMsg = "Ignored key-value pairs with non-string keys."
+)
+
+// An Option configures a LogSink.
+type Option interface {
+	apply(*LogSink)
+}
+
+// optionFunc wraps a func so it satisfies the Option interface.
+type optionFunc func(*LogSink)
+
+func (f optionFunc) apply(s *LogSink) {
+	f(s)
+}
+
+// NameField logs the names given to WithName, joined with periods, as a
+// string field under key instead of as the lad Logger's name. Use it when the
+// Logger already has a name that logr's names shouldn't be appended to.
+func NameField(key string) Option {
+	return optionFunc(func(s *LogSink) {
+		s.nameKey = key
+	})
+}
+
+// ErrorKey sets the key under which Error logs its error. Defaults to
+// "error".
+func ErrorKey(key string) Option {
+	return optionFunc(func(s *LogSink) {
+		s.errorKey = key
+	})
+}
+
+// LogSink is a logr.LogSink backed by a lad Logger. Values added with
+// WithValues are converted to fields once, and encoded into the Logger's
+// context just like fields added with Logger.With, so they aren't re-encoded
+// for every entry.
+type LogSink struct {
+	log      *lad.Logger
+	errorKey string
+	nameKey  string
+	name     string
+}
+
+var (
+	_ logr.LogSink          = (*LogSink)(nil)
+	_ logr.CallDepthLogSink = (*LogSink)(nil)
+)
+
+// NewLogger returns a logr.Logger that writes to log.
+func NewLogger(log *lad.Logger, opts ...Option) logr.Logger {
+	return logr.New(NewLogSink(log, opts...))
+}
+
+// NewLogSink returns a LogSink that writes to log.
+func NewLogSink(log *lad.Logger, opts ...Option) *LogSink {
+	s := &LogSink{
+		// Skip the LogSink's own frame; logr reports the rest through Init.
+		log:      log.WithOptions(lad.AddCallerSkip(1)),
+		errorKey: "error",
+	}
+	for _, opt := range opts {
+		opt.apply(s)
+	}
+	return s
+}
+
+// Init implements logr.LogSink, skipping logr's frames when annotating
+// entries with the caller.
+func (s *LogSink) Init(ri logr.RuntimeInfo) {
+	s.log = s.log.WithOptions(lad.AddCallerSkip(ri.CallDepth))
+}
+
+// Enabled implements logr.LogSink.
+func (s *LogSink) Enabled(level int) bool {
+	return s.log.Core().Enabled(toLevel(level))
+}
+
+// Info implements logr.LogSink.
+func (s *LogSink) Info(level int, msg string, keysAndValues ...interface{}) {
+	if ce := s.log.Check(toLevel(level), msg); ce != nil {
+		ce.Write(s.fields(keysAndValues, 0)...)
+	}
+}
+
+// Error implements logr.LogSink. Errors are logged at ErrorLevel, whatever
+// the logr.Logger's verbosity.
+func (s *LogSink) Error(err error, msg string, keysAndValues ...interface{}) {
+	if ce := s.log.Check(lad.ErrorLevel, msg); ce != nil {
+		fields := s.fields(keysAndValues, 1)
+		ce.Write(append(fields, lad.NamedError(s.errorKey, err))...)
+	}
+}
+
+// WithValues implements logr.LogSink.
+func (s *LogSink) WithValues(keysAndValues ...interface{}) logr.LogSink {
+	clone := *s
+	clone.log = s.log.With(s.sweeten(keysAndValues, 0)...)
+	return &clone
+}
+
+// WithName implements logr.LogSink.
+func (s *LogSink) WithName(name string) logr.LogSink {
+	clone := *s
+	switch {
+	case s.nameKey == "":
+		clone.log = s.log.Named(name)
+	case s.name == "":
+		clone.name = name
+	default:
+		clone.name = s.name + "." + name
+	}
+	return &clone
+}
+
+// WithCallDepth implements logr.CallDepthLogSink.
+func (s *LogSink) WithCallDepth(depth int) logr.LogSink {
+	clone := *s
+	clone.log = s.log.WithOptions(lad.AddCallerSkip(depth))
+	return &clone
+}
+
+// fields converts the key-value pairs passed to Info or Error, making room
+// for extra fields and adding the name field, if any.
+func (s *LogSink) fields(keysAndValues []interface{}, extra int) []lad.Field {
+	if s.name != "" {
+		extra++
+	}
+	fields := s.sweeten(keysAndValues, extra)
+	if s.name != "" {
+		fields = append(fields, lad.String(s.nameKey, s.name))
+	}
+	return fields
+}
+
+// sweeten converts logr's alternating keys and values to fields. Like the
+// SugaredLogger, it reports dangling keys and non-string keys rather than
+// dropping them silently.
+func (s *LogSink) sweeten(keysAndValues []interface{}, extra int) []lad.Field {
+	if len(keysAndValues) == 0 && extra == 0 {
+		return nil
+	}
+
+	fields := make([]lad.Field, 0, len(keysAndValues)/2+extra)
+	var invalid []string // non-string keys, formatted
+	for i := 0; i < len(keysAndValues); i += 2 {
+		if i == len(keysAndValues)-1 {
+			s.reportInvalid(_oddNumberErrMsg, lad.Any("ignored", keysAndValues[i]))
+			break
+		}
+		key, val := keysAndValues[i], keysAndValues[i+1]
+		keyStr, ok := key.(string)
+		if !ok {
+			invalid = append(invalid, fmt.Sprint(key))
+			continue
+		}
+		if m, ok := val.(logr.Marshaler); ok {
+			val = m.MarshalLog()
+		}
+		fields = append(fields, lad.Any(keyStr, val))
+	}
+	if len(invalid) > 0 {
+		s.reportInvalid(_nonStringKeyErrMsg, lad.Strings("invalid", invalid))
+	}
+	return fields
+}
+
+func (s *LogSink) reportInvalid(msg string, field lad.Field) {
+	if ce := s.log.Check(lad.DPanicLevel, msg); ce != nil {
+		ce.Write(field)
+	}
+}
+
+// toLevel maps a logr verbosity to a lad level.
+func toLevel(level int) ladcore.Level {
+	if level > -math.MinInt8 {
+		return ladcore.Level(math.MinInt8)
+	}
+	return ladcore.Level(-level)
+}
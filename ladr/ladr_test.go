@@ -0,0 +1,136 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladr
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/auwixcom/lad"
+	"github.com/auwixcom/lad/ladcore"
+	"github.com/auwixcom/lad/ladtest/observer"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type marshaled struct{ name string }
+
+func (m marshaled) MarshalLog() interface{} { return "marshaled " + m.name }
+
+func TestLogSinkVerbosity(t *testing.T) {
+	core, logs := observer.New(ladcore.Level(-1))
+	log := NewLogger(lad.New(core))
+
+	log.Info("info")
+	log.V(1).Info("debug")
+	log.V(2).Info("too verbose")
+	assert.True(t, log.V(1).Enabled(), "Expected V(1) to be enabled.")
+	assert.False(t, log.V(2).Enabled(), "Expected V(2) to be disabled.")
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 2, "Unexpected number of entries.")
+	assert.Equal(t, ladcore.InfoLevel, entries[0].Level, "Unexpected level for V(0).")
+	assert.Equal(t, ladcore.DebugLevel, entries[1].Level, "Unexpected level for V(1).")
+	assert.Equal(t, ladcore.Level(-128), toLevel(1000), "Expected huge verbosities to be clamped.")
+}
+
+func TestLogSinkValues(t *testing.T) {
+	core, logs := observer.New(ladcore.DebugLevel)
+	log := NewLogger(lad.New(core)).WithValues("pod", "web-1", "obj", marshaled{"x"})
+
+	log.Info("hello", "count", 2)
+	log.Error(errors.New("boom"), "failed", "attempt", 3)
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 2, "Unexpected number of entries.")
+	assert.Equal(t, []ladcore.Field{
+		lad.String("pod", "web-1"),
+		lad.String("obj", "marshaled x"),
+	}, entries[0].Context[:2], "Expected WithValues fields in the context.")
+	assert.Equal(t, map[string]interface{}{
+		"pod": "web-1", "obj": "marshaled x", "count": int64(2),
+	}, entries[0].ContextMap(), "Unexpected Info fields.")
+	assert.Equal(t, ladcore.ErrorLevel, entries[1].Level, "Unexpected level for Error.")
+	assert.Equal(t, "boom", entries[1].ContextMap()["error"], "Expected the error under the default key.")
+}
+
+func TestLogSinkInvalidKeyValues(t *testing.T) {
+	core, logs := observer.New(ladcore.DebugLevel)
+	log := NewLogger(lad.New(core))
+
+	log.Info("odd", "dangling")
+	log.Info("non-string", 42, "value", "ok", true)
+
+	assert.Equal(t, []string{
+		_oddNumberErrMsg, "odd",
+		_nonStringKeyErrMsg, "non-string",
+	}, messages(logs), "Unexpected messages.")
+	invalid := logs.FilterMessage(_nonStringKeyErrMsg).All()[0]
+	assert.Equal(t, []interface{}{"42"}, invalid.ContextMap()["invalid"], "Unexpected invalid keys.")
+	assert.Equal(t, map[string]interface{}{"ok": true}, logs.FilterMessage("non-string").All()[0].ContextMap(),
+		"Expected valid pairs to be kept.")
+}
+
+func TestLogSinkNames(t *testing.T) {
+	t.Run("logger name", func(t *testing.T) {
+		core, logs := observer.New(ladcore.DebugLevel)
+		NewLogger(lad.New(core)).WithName("controller").WithName("pods").Info("msg")
+		assert.Equal(t, "controller.pods", logs.All()[0].LoggerName, "Unexpected logger name.")
+	})
+
+	t.Run("name field", func(t *testing.T) {
+		core, logs := observer.New(ladcore.DebugLevel)
+		log := NewLogger(lad.New(core).Named("app"), NameField("logger"), ErrorKey("err"))
+		log = log.WithName("controller").WithName("pods")
+		log.Error(errors.New("boom"), "msg")
+
+		entry := logs.All()[0]
+		assert.Equal(t, "app", entry.LoggerName, "Expected the lad name to be untouched.")
+		assert.Equal(t, map[string]interface{}{
+			"logger": "controller.pods",
+			"err":    "boom",
+		}, entry.ContextMap(), "Unexpected fields.")
+	})
+}
+
+func TestLogSinkCaller(t *testing.T) {
+	core, logs := observer.New(ladcore.DebugLevel)
+	log := NewLogger(lad.New(core, lad.AddCaller()))
+
+	log.Info("direct")
+	helper := func(log logr.Logger) { log.WithCallDepth(1).Info("helper") }
+	helper(log)
+
+	for _, entry := range logs.All() {
+		assert.Contains(t, entry.Caller.File, "ladr_test.go", "Unexpected caller for %q.", entry.Message)
+	}
+	assert.Equal(t, logs.All()[0].Caller.Line+2, logs.All()[1].Caller.Line,
+		"Expected WithCallDepth to skip the helper.")
+}
+
+func messages(logs *observer.ObservedLogs) []string {
+	var msgs []string
+	for _, e := range logs.All() {
+		msgs = append(msgs, e.Message)
+	}
+	return msgs
+}
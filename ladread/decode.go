@@ -0,0 +1,170 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladread
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/auwixcom/lad"
+	"github.com/auwixcom/lad/ladcore"
+)
+
+// _timeLayouts are the layouts of lad's string time encoders, most precise
+// first.
+var _timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.000Z0700", // ISO8601TimeEncoder
+}
+
+// decodeEntry decodes a line of JSON into an Entry, keeping the order of
+// its fields.
+func decodeEntry(line []byte, keys ladcore.EncoderConfig) (Entry, error) {
+	dec := json.NewDecoder(bytes.NewReader(line))
+	dec.UseNumber()
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return Entry{}, errors.New("not a JSON object")
+	}
+
+	ent := Entry{Entry: ladcore.Entry{Level: ladcore.InfoLevel}}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return Entry{}, err
+		}
+		key, _ := tok.(string)
+		var val interface{}
+		if err := dec.Decode(&val); err != nil {
+			return Entry{}, err
+		}
+		if err := ent.set(key, val, keys); err != nil {
+			return Entry{}, fmt.Errorf("decode %q: %w", key, err)
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return Entry{}, err
+	}
+	return ent, nil
+}
+
+// set stores a key-value pair in the entry's metadata, if the key is one of
+// the metadata keys, or as a field.
+func (e *Entry) set(key string, val interface{}, keys ladcore.EncoderConfig) error {
+	s, isString := val.(string)
+	var err error
+	switch {
+	case key == ladcore.OmitKey:
+		// Don't mistake an empty key for an omitted metadata key.
+	case key == keys.TimeKey:
+		e.Time, err = parseTime(val)
+		return err
+	case key == keys.LevelKey && isString:
+		e.Level, err = ladcore.ParseLevel(s)
+		return err
+	case key == keys.NameKey && isString:
+		e.LoggerName = s
+		return nil
+	case key == keys.CallerKey && isString:
+		e.Caller = parseCaller(s, e.Caller.Function)
+		return nil
+	case key == keys.FunctionKey && isString:
+		e.Caller.Function = s
+		return nil
+	case key == keys.MessageKey && isString:
+		e.Message = s
+		return nil
+	case key == keys.StacktraceKey && isString:
+		e.Stack = s
+		return nil
+	}
+	e.Fields = append(e.Fields, toField(key, val))
+	return nil
+}
+
+// parseTime decodes the output of any of lad's time encoders. Epoch numbers
+// are told apart by their magnitude: seconds, milliseconds, microseconds, or
+// nanoseconds.
+func parseTime(val interface{}) (time.Time, error) {
+	switch v := val.(type) {
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			return time.Time{}, err
+		}
+		switch abs := math.Abs(f); {
+		case abs < 1e11:
+			sec, frac := math.Modf(f)
+			return time.Unix(int64(sec), int64(frac*1e9)), nil
+		case abs < 1e14:
+			return time.UnixMicro(int64(f * 1e3)), nil
+		case abs < 1e17:
+			return time.UnixMicro(int64(f)), nil
+		}
+		n, err := v.Int64()
+		if err != nil {
+			n = int64(f)
+		}
+		return time.Unix(0, n), nil
+	case string:
+		for _, layout := range _timeLayouts {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t, nil
+			}
+		}
+		return time.Time{}, fmt.Errorf("unrecognized time %q", v)
+	}
+	return time.Time{}, fmt.Errorf("unrecognized time %v", val)
+}
+
+// parseCaller decodes a caller written as file:line, like the short and full
+// caller encoders write.
+func parseCaller(s, function string) ladcore.EntryCaller {
+	c := ladcore.EntryCaller{Defined: true, File: s, Function: function}
+	if i := strings.LastIndexByte(s, ':'); i >= 0 {
+		if line, err := strconv.Atoi(s[i+1:]); err == nil {
+			c.File, c.Line = s[:i], line
+		}
+	}
+	return c
+}
+
+// toField converts a decoded JSON value to a field. Whole numbers become
+// int64s and other numbers float64s; objects and arrays are kept as maps and
+// slices.
+func toField(key string, val interface{}) ladcore.Field {
+	switch v := val.(type) {
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return lad.Int64(key, n)
+		}
+		f, _ := v.Float64()
+		return lad.Float64(key, f)
+	case nil:
+		return lad.Reflect(key, nil)
+	}
+	return lad.Any(key, val)
+}
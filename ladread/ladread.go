@@ -0,0 +1,360 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package ladread reads logs written by lad's JSON encoder back into entries
+// and fields. It handles plain and gzipped files and the sets of files that
+// rotation leaves behind, and can filter entries by time and level, so that
+// replay tools, tests, and command-line tools share one implementation.
+//
+// A Reader works like a bufio.Scanner:
+//
+//	r, err := ladread.OpenRotated("/var/log/app.log", ladread.Levels(lad.WarnLevel))
+//	if err != nil {
+//	    return err
+//	}
+//	defer r.Close()
+//	for r.Next() {
+//	    ent := r.Entry()
+//	    // ...
+//	}
+//	return r.Err()
+//
+// Replay feeds a Reader's entries into a ladcore.Core at their original
+// pacing, or a multiple of it, for load-testing sinks with real traffic.
+package ladread // import "github.com/auwixcom/lad/ladread"
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/auwixcom/lad"
+	"github.com/auwixcom/lad/ladcore"
+)
+
+// An Entry is a decoded log entry: the entry's metadata, and the rest of its
+// keys as fields, in the order they were written.
+type Entry struct {
+	ladcore.Entry
+
+	Fields []ladcore.Field
+}
+
+// An Option configures a Reader.
+type Option interface {
+	apply(*Reader)
+}
+
+// optionFunc wraps a func so it satisfies the Option interface.
+type optionFunc func(*Reader)
+
+func (f optionFunc) apply(r *Reader) {
+	f(r)
+}
+
+// Keys sets the keys that hold each entry's metadata, like the time and
+// message, from the EncoderConfig the logs were written with. Only the keys
+// are used; times, levels, and callers are decoded from any of the formats
+// lad's encoders write. Defaults to lad.NewProductionEncoderConfig.
+func Keys(cfg ladcore.EncoderConfig) Option {
+	return optionFunc(func(r *Reader) {
+		r.keys = cfg
+	})
+}
+
+// TimeRange only reads entries written at or after from and before to.
+// Either may be zero to leave that end of the range open. Entries without a
+// time are skipped if either end is set.
+func TimeRange(from, to time.Time) Option {
+	return optionFunc(func(r *Reader) {
+		r.from, r.to = from, to
+	})
+}
+
+// Levels only reads entries whose levels are enabled by enab, like
+// lad.WarnLevel or a lad.LevelEnablerFunc. Entries without a level are read
+// as InfoLevel.
+func Levels(enab ladcore.LevelEnabler) Option {
+	return optionFunc(func(r *Reader) {
+		r.levels = enab
+	})
+}
+
+// SkipInvalid skips lines that aren't JSON objects, like output from other
+// programs mixed into the logs, instead of stopping with an error.
+func SkipInvalid() Option {
+	return optionFunc(func(r *Reader) {
+		r.skipInvalid = true
+	})
+}
+
+// A Reader streams entries from one or more logs. It isn't safe for
+// concurrent use.
+type Reader struct {
+	keys        ladcore.EncoderConfig
+	from, to    time.Time
+	levels      ladcore.LevelEnabler
+	skipInvalid bool
+
+	paths  []string // files left to open
+	src    *bufio.Reader
+	closer io.Closer // closes the current file, if any
+	name   string    // names the current source in errors
+	line   int
+	entry  Entry
+	err    error
+}
+
+// NewReader returns a Reader that reads from r, which may be gzipped.
+func NewReader(r io.Reader, opts ...Option) *Reader {
+	rd := newReader(opts)
+	rd.err = rd.setSource(r, "input")
+	return rd
+}
+
+// Open returns a Reader that reads the files at paths in turn. Gzipped files
+// are decompressed. Files are opened as they're reached, so Open only reports
+// errors for missing files.
+func Open(paths []string, opts ...Option) (*Reader, error) {
+	for _, path := range paths {
+		if _, err := os.Stat(path); err != nil {
+			return nil, err
+		}
+	}
+	r := newReader(opts)
+	r.paths = append([]string(nil), paths...)
+	return r, nil
+}
+
+// _rotatedLayouts are the timestamp formats in the names of rotated files:
+// lumberjack's size rotation, followed by ladkit's daily, hourly, and
+// minutely time rotation.
+var _rotatedLayouts = []string{
+	"2006-01-02T15-04-05.000",
+	"2006-01-02",
+	"2006-01-02T15",
+	"2006-01-02T15-04",
+}
+
+// OpenRotated returns a Reader that reads the log at filename along with the
+// files rotated out of it, oldest first. It finds the backups left by size
+// rotation, like app-2026-10-15T10-00-00.000.log and its gzipped form, and
+// by time rotation, like app-2026-10-15.log, all of which sort by their
+// timestamps. Other files that share the prefix, like app-error.log, are
+// ignored. The current file, if it exists, is read last.
+func OpenRotated(filename string, opts ...Option) (*Reader, error) {
+	ext := filepath.Ext(filename)
+	prefix := strings.TrimSuffix(filename, ext) + "-"
+
+	var paths []string
+	for _, suffix := range []string{ext, ext + ".gz"} {
+		matches, err := filepath.Glob(globEscape(prefix) + "*" + suffix)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range matches {
+			if isRotatedStamp(strings.TrimSuffix(strings.TrimPrefix(m, prefix), suffix)) {
+				paths = append(paths, m)
+			}
+		}
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		return strings.TrimSuffix(paths[i], ".gz") < strings.TrimSuffix(paths[j], ".gz")
+	})
+	if _, err := os.Stat(filename); err == nil {
+		paths = append(paths, filename)
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no logs found for %s", filename)
+	}
+	return Open(paths, opts...)
+}
+
+// isRotatedStamp reports whether s is the timestamp of a rotated file.
+func isRotatedStamp(s string) bool {
+	for _, layout := range _rotatedLayouts {
+		if _, err := time.Parse(layout, s); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func newReader(opts []Option) *Reader {
+	r := &Reader{keys: lad.NewProductionEncoderConfig()}
+	for _, opt := range opts {
+		opt.apply(r)
+	}
+	return r
+}
+
+// Next advances to the next entry that passes the Reader's filters, which
+// is then available through Entry. It returns false at the end of the input
+// or on an error, which Err reports.
+func (r *Reader) Next() bool {
+	for r.err == nil {
+		line, err := r.readLine()
+		if err != nil {
+			if err == io.EOF {
+				err = r.nextFile()
+			}
+			r.err = err
+			continue
+		}
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		ent, err := decodeEntry(line, r.keys)
+		if err != nil {
+			if r.skipInvalid {
+				continue
+			}
+			r.err = fmt.Errorf("%s:%d: %w", r.name, r.line, err)
+			continue
+		}
+		if r.keep(ent) {
+			r.entry = ent
+			return true
+		}
+	}
+	return false
+}
+
+// Entry returns the entry that the last call to Next advanced to.
+func (r *Reader) Entry() Entry {
+	return r.entry
+}
+
+// Err returns the first error the Reader encountered, if any. Reaching the
+// end of the input isn't an error.
+func (r *Reader) Err() error {
+	if r.err == io.EOF {
+		return nil
+	}
+	return r.err
+}
+
+// Close closes the file the Reader is reading, if any.
+func (r *Reader) Close() error {
+	r.paths = nil
+	return r.closeFile()
+}
+
+func (r *Reader) closeFile() error {
+	r.src = nil
+	if r.closer == nil {
+		return nil
+	}
+	err := r.closer.Close()
+	r.closer = nil
+	return err
+}
+
+func (r *Reader) keep(ent Entry) bool {
+	if r.levels != nil && !r.levels.Enabled(ent.Level) {
+		return false
+	}
+	if r.from.IsZero() && r.to.IsZero() {
+		return true
+	}
+	if ent.Time.IsZero() {
+		return false
+	}
+	return !ent.Time.Before(r.from) && (r.to.IsZero() || ent.Time.Before(r.to))
+}
+
+// readLine reads the next line from the current source, returning io.EOF at
+// its end.
+func (r *Reader) readLine() ([]byte, error) {
+	if r.src == nil {
+		return nil, io.EOF
+	}
+	line, err := r.src.ReadBytes('\n')
+	if len(line) > 0 {
+		r.line++
+		return line, nil
+	}
+	if err == io.EOF {
+		r.src = nil
+	}
+	return nil, err
+}
+
+// nextFile closes the current file and opens the next one, returning io.EOF
+// if there are none left.
+func (r *Reader) nextFile() error {
+	if err := r.closeFile(); err != nil {
+		return err
+	}
+	if len(r.paths) == 0 {
+		return io.EOF
+	}
+	path := r.paths[0]
+	r.paths = r.paths[1:]
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	r.closer = f
+	return r.setSource(f, path)
+}
+
+// setSource starts reading from src, decompressing it if it's gzipped.
+func (r *Reader) setSource(src io.Reader, name string) error {
+	r.name, r.line = name, 0
+	br := bufio.NewReader(src)
+	if magic, _ := br.Peek(2); bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		br = bufio.NewReader(gz)
+	}
+	r.src = br
+	return nil
+}
+
+// globEscape escapes the characters that filepath.Glob treats specially.
+// Windows has no escape character, since it separates paths with
+// backslashes.
+func globEscape(s string) string {
+	if runtime.GOOS == "windows" {
+		return s
+	}
+	var b strings.Builder
+	for _, c := range s {
+		switch c {
+		case '*', '?', '[', '\\':
+			b.WriteByte('\\')
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
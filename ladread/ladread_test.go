@@ -0,0 +1,202 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladread
+
+import (
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/auwixcom/lad"
+	"github.com/auwixcom/lad/ladcore"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stepClock starts at the Unix epoch and advances a second every time it's
+// read.
+type stepClock struct{ now time.Time }
+
+func (c *stepClock) Now() time.Time {
+	c.now = c.now.Add(time.Second)
+	return c.now
+}
+
+func (c *stepClock) NewTicker(d time.Duration) *time.Ticker {
+	return time.NewTicker(d)
+}
+
+// writeLogs logs a message per second, starting a second after the Unix
+// epoch, with a production JSON encoder.
+func writeLogs(msgs ...string) []byte {
+	var buf bytes.Buffer
+	clock := &stepClock{now: time.Unix(0, 0)}
+	enc := ladcore.NewJSONEncoder(lad.NewProductionEncoderConfig())
+	logger := lad.New(ladcore.NewCore(enc, ladcore.AddSync(&buf), lad.DebugLevel), lad.WithClock(clock), lad.AddCaller())
+	for i, msg := range msgs {
+		lvl := lad.InfoLevel
+		if i%2 == 1 {
+			lvl = lad.WarnLevel
+		}
+		logger.Named("app").Log(lvl, msg, lad.Int("n", i), lad.Float64("ratio", 0.5), lad.Strings("tags", []string{"a"}))
+	}
+	return buf.Bytes()
+}
+
+func readAll(t *testing.T, r *Reader) []Entry {
+	defer func() { assert.NoError(t, r.Close(), "Unexpected error closing.") }()
+	var entries []Entry
+	for r.Next() {
+		entries = append(entries, r.Entry())
+	}
+	require.NoError(t, r.Err(), "Unexpected error reading.")
+	return entries
+}
+
+func messages(entries []Entry) []string {
+	var msgs []string
+	for _, e := range entries {
+		msgs = append(msgs, e.Message)
+	}
+	return msgs
+}
+
+func TestReaderDecodes(t *testing.T) {
+	entries := readAll(t, NewReader(bytes.NewReader(writeLogs("first"))))
+	require.Len(t, entries, 1, "Unexpected number of entries.")
+
+	ent := entries[0]
+	assert.Equal(t, lad.InfoLevel, ent.Level, "Unexpected level.")
+	assert.Equal(t, "app", ent.LoggerName, "Unexpected logger name.")
+	assert.Equal(t, "first", ent.Message, "Unexpected message.")
+	assert.Equal(t, int64(1), ent.Time.Unix(), "Unexpected time.")
+	assert.True(t, ent.Caller.Defined, "Expected a caller.")
+	assert.Equal(t, "ladread/ladread_test.go", ent.Caller.File, "Unexpected caller file.")
+	assert.Positive(t, ent.Caller.Line, "Expected a caller line.")
+	assert.Equal(t, []ladcore.Field{
+		lad.Int64("n", 0),
+		lad.Float64("ratio", 0.5),
+		lad.Any("tags", []interface{}{"a"}),
+	}, ent.Fields, "Expected fields in the order they were written.")
+}
+
+func TestReaderFilters(t *testing.T) {
+	logs := writeLogs("a", "b", "c", "d", "e")
+
+	entries := readAll(t, NewReader(bytes.NewReader(logs), Levels(lad.WarnLevel)))
+	assert.Equal(t, []string{"b", "d"}, messages(entries), "Unexpected entries at warn and above.")
+
+	entries = readAll(t, NewReader(bytes.NewReader(logs), TimeRange(time.Unix(2, 0), time.Unix(4, 0))))
+	assert.Equal(t, []string{"b", "c"}, messages(entries), "Unexpected entries in the time range.")
+
+	entries = readAll(t, NewReader(bytes.NewReader(logs), TimeRange(time.Unix(4, 0), time.Time{})))
+	assert.Equal(t, []string{"d", "e"}, messages(entries), "Expected an open-ended range.")
+
+	entries = readAll(t, NewReader(strings.NewReader(`{"msg":"no time"}`), TimeRange(time.Unix(4, 0), time.Time{})))
+	assert.Empty(t, entries, "Expected entries without times to be left out of ranges.")
+}
+
+func TestReaderInvalidLines(t *testing.T) {
+	input := "not json\n" + `{"msg":"ok"}` + "\n\n" + `{"level":"bogus"}`
+
+	r := NewReader(strings.NewReader(input))
+	assert.False(t, r.Next(), "Expected the first line to stop the reader.")
+	assert.ErrorContains(t, r.Err(), "input:1: not a JSON object", "Expected the error to name the line.")
+
+	r = NewReader(strings.NewReader(input), SkipInvalid())
+	assert.Equal(t, []string{"ok"}, messages(readAll(t, r)), "Expected invalid lines to be skipped.")
+}
+
+func TestReaderCustomKeys(t *testing.T) {
+	keys := ladcore.EncoderConfig{TimeKey: "@timestamp", MessageKey: "message", LevelKey: "severity"}
+	input := `{"@timestamp":"2026-10-15T10:00:00.5Z","severity":"ERROR","message":"hi","msg":"field"}`
+
+	entries := readAll(t, NewReader(strings.NewReader(input), Keys(keys)))
+	require.Len(t, entries, 1, "Unexpected number of entries.")
+	assert.Equal(t, lad.ErrorLevel, entries[0].Level, "Unexpected level.")
+	assert.Equal(t, "hi", entries[0].Message, "Unexpected message.")
+	assert.Equal(t, time.Date(2026, 10, 15, 10, 0, 0, 5e8, time.UTC), entries[0].Time.UTC(), "Unexpected time.")
+	assert.Equal(t, []ladcore.Field{lad.String("msg", "field")}, entries[0].Fields, "Expected other keys as fields.")
+}
+
+func TestParseTime(t *testing.T) {
+	want := time.Date(2026, 10, 15, 10, 0, 0, 123000000, time.UTC)
+	tests := []struct {
+		desc string
+		enc  ladcore.TimeEncoder
+	}{
+		{"epoch", ladcore.EpochTimeEncoder},
+		{"epoch millis", ladcore.EpochMillisTimeEncoder},
+		{"epoch nanos", ladcore.EpochNanosTimeEncoder},
+		{"ISO8601", ladcore.ISO8601TimeEncoder},
+		{"RFC3339", ladcore.RFC3339NanoTimeEncoder},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			cfg := ladcore.EncoderConfig{TimeKey: "ts", EncodeTime: tt.enc}
+			buf, err := ladcore.NewJSONEncoder(cfg).EncodeEntry(ladcore.Entry{Time: want}, nil)
+			require.NoError(t, err, "Unexpected error encoding.")
+
+			ent, err := decodeEntry(buf.Bytes(), cfg)
+			require.NoError(t, err, "Unexpected error decoding.")
+			assert.WithinDuration(t, want, ent.Time, time.Microsecond, "Unexpected time.")
+		})
+	}
+
+	_, err := parseTime("yesterday")
+	assert.ErrorContains(t, err, `unrecognized time "yesterday"`, "Expected an error for unknown formats.")
+}
+
+func TestOpenRotated(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "app.log")
+	write := func(path string, data []byte) {
+		require.NoError(t, os.WriteFile(path, data, 0o644), "Unexpected error writing %s.", path)
+	}
+
+	var gz bytes.Buffer
+	zw := gzip.NewWriter(&gz)
+	_, err := zw.Write(writeLogs("oldest"))
+	require.NoError(t, err, "Unexpected error compressing.")
+	require.NoError(t, zw.Close(), "Unexpected error compressing.")
+
+	write(filepath.Join(dir, "app-2026-10-14T10-00-00.000.log.gz"), gz.Bytes())
+	write(filepath.Join(dir, "app-2026-10-15T10-00-00.000.log"), writeLogs("older"))
+	write(base, writeLogs("current"))
+	write(filepath.Join(dir, "app-2026-10-15T09.log"), writeLogs("hourly"))
+	write(filepath.Join(dir, "other.log"), writeLogs("unrelated"))
+	write(filepath.Join(dir, "app-error.log"), writeLogs("sibling"))
+	write(filepath.Join(dir, "app-2026.log"), writeLogs("not a rotation"))
+
+	r, err := OpenRotated(base)
+	require.NoError(t, err, "Unexpected error opening.")
+	assert.Equal(t, []string{"oldest", "hourly", "older", "current"}, messages(readAll(t, r)), "Unexpected entries.")
+
+	_, err = OpenRotated(filepath.Join(dir, "missing.log"))
+	assert.ErrorContains(t, err, "no logs found", "Expected an error without logs.")
+	_, err = Open([]string{filepath.Join(dir, "missing.log")})
+	assert.Error(t, err, "Expected an error opening a missing file.")
+}
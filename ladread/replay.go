@@ -0,0 +1,176 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladread
+
+import (
+	"context"
+	"time"
+
+	"github.com/auwixcom/lad/ladcore"
+)
+
+// A ReplayOption configures Replay.
+type ReplayOption interface {
+	apply(*replayer)
+}
+
+// replayOptionFunc wraps a func so it satisfies the ReplayOption interface.
+type replayOptionFunc func(*replayer)
+
+func (f replayOptionFunc) apply(r *replayer) {
+	f(r)
+}
+
+// Speed scales the pacing of replayed entries: 2 replays them twice as fast
+// as they were written, and 0.5 half as fast. Zero or less replays them as
+// fast as the core accepts them. Defaults to 1, the original pacing.
+func Speed(factor float64) ReplayOption {
+	return replayOptionFunc(func(r *replayer) {
+		r.speed = factor
+	})
+}
+
+// Retime stamps replayed entries with the time they're replayed at, instead
+// of the time they were originally written, for sinks that reject or
+// mis-bucket old timestamps.
+func Retime() ReplayOption {
+	return replayOptionFunc(func(r *replayer) {
+		r.retime = true
+	})
+}
+
+// ReplayClock sets the clock that paces the replay. Defaults to the system
+// clock.
+func ReplayClock(clock ladcore.Clock) ReplayOption {
+	return replayOptionFunc(func(r *replayer) {
+		r.clock = clock
+	})
+}
+
+// ReplayStats summarizes a replay.
+type ReplayStats struct {
+	// Entries is the number of entries read.
+	Entries int
+	// Skipped is the number of entries the core didn't enable.
+	Skipped int
+	// Failed is the number of entries the core failed to write.
+	Failed int
+	// Elapsed is how long the replay took.
+	Elapsed time.Duration
+}
+
+type replayer struct {
+	speed  float64
+	retime bool
+	clock  ladcore.Clock
+}
+
+// Replay feeds the entries from r into core, spacing them out as they were
+// originally written, so that new sinks and pipelines can be load-tested with
+// the shape of real traffic. Entries go through the core's Check, so its
+// level and any sampling apply; write errors are counted rather than
+// stopping the replay. Entries without a time, or that are older than the
+// previous one, are written right away.
+//
+// Replay runs until r is exhausted or ctx is done, and then syncs the core.
+// It returns the first error from r, ctx, or the sync.
+func Replay(ctx context.Context, r *Reader, core ladcore.Core, opts ...ReplayOption) (ReplayStats, error) {
+	rp := replayer{speed: 1, clock: ladcore.DefaultClock}
+	for _, opt := range opts {
+		opt.apply(&rp)
+	}
+
+	var (
+		stats    ReplayStats
+		failures = &failureCounter{}
+		start    = rp.clock.Now()
+		first    time.Time // original time of the first timed entry
+		err      error
+	)
+	for err == nil && r.Next() {
+		ent := r.Entry()
+		stats.Entries++
+
+		if rp.speed > 0 && !ent.Time.IsZero() {
+			if first.IsZero() {
+				first = ent.Time
+			}
+			due := time.Duration(float64(ent.Time.Sub(first)) / rp.speed)
+			if err = rp.wait(ctx, due-rp.clock.Now().Sub(start)); err != nil {
+				break
+			}
+		}
+		if err = ctx.Err(); err != nil {
+			break
+		}
+
+		if rp.retime {
+			ent.Time = rp.clock.Now()
+		}
+		ce := core.Check(ent.Entry, nil)
+		if ce == nil {
+			stats.Skipped++
+			continue
+		}
+		ce.ErrorOutput = failures
+		ce.Write(ent.Fields...)
+	}
+	if err == nil {
+		err = r.Err()
+	}
+	if syncErr := core.Sync(); err == nil {
+		err = syncErr
+	}
+
+	stats.Failed = failures.n
+	stats.Elapsed = rp.clock.Now().Sub(start)
+	return stats, err
+}
+
+// wait blocks for d, or until ctx is done.
+func (rp *replayer) wait(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := rp.clock.NewTicker(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// failureCounter counts the write errors that a CheckedEntry reports: one
+// line per failed entry.
+type failureCounter struct {
+	n int
+}
+
+func (c *failureCounter) Write(p []byte) (int, error) {
+	c.n++
+	return len(p), nil
+}
+
+func (c *failureCounter) Sync() error {
+	return nil
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladread
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/auwixcom/lad"
+	"github.com/auwixcom/lad/internal/ztest"
+	"github.com/auwixcom/lad/ladcore"
+	"github.com/auwixcom/lad/ladtest/observer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sleeplessClock records the waits it's asked for and skips them.
+type sleeplessClock struct {
+	now   time.Time
+	waits []time.Duration
+}
+
+func (c *sleeplessClock) Now() time.Time {
+	return c.now
+}
+
+func (c *sleeplessClock) NewTicker(d time.Duration) *time.Ticker {
+	c.waits = append(c.waits, d)
+	c.now = c.now.Add(d)
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return &time.Ticker{C: ch}
+}
+
+func TestReplay(t *testing.T) {
+	core, logs := observer.New(lad.WarnLevel)
+	clock := &sleeplessClock{now: time.Unix(100, 0)}
+	r := NewReader(bytes.NewReader(writeLogs("a", "b", "c", "d")))
+
+	stats, err := Replay(context.Background(), r, core, Speed(2), ReplayClock(clock))
+	require.NoError(t, err, "Unexpected error replaying.")
+	assert.Equal(t, ReplayStats{Entries: 4, Skipped: 2, Elapsed: 1500 * time.Millisecond}, stats, "Unexpected stats.")
+	assert.Equal(t, []time.Duration{500 * time.Millisecond, 500 * time.Millisecond, 500 * time.Millisecond}, clock.waits,
+		"Expected entries to be paced at twice the original speed.")
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 2, "Expected the core's level to apply.")
+	assert.Equal(t, "b", entries[0].Message, "Unexpected message.")
+	assert.Equal(t, "app", entries[0].LoggerName, "Expected entries to be replayed intact.")
+	assert.Equal(t, []ladcore.Field{
+		lad.Int64("n", 1),
+		lad.Float64("ratio", 0.5),
+		lad.Any("tags", []interface{}{"a"}),
+	}, entries[0].Context, "Expected fields to be replayed.")
+	assert.Equal(t, int64(2), logs.All()[0].Time.Unix(), "Expected original times.")
+}
+
+func TestReplayUnpacedAndRetimed(t *testing.T) {
+	core, logs := observer.New(lad.DebugLevel)
+	clock := &sleeplessClock{now: time.Unix(100, 0)}
+	r := NewReader(bytes.NewReader(writeLogs("a", "b")))
+
+	stats, err := Replay(context.Background(), r, core, Speed(0), Retime(), ReplayClock(clock))
+	require.NoError(t, err, "Unexpected error replaying.")
+	assert.Equal(t, 2, stats.Entries, "Unexpected number of entries.")
+	assert.Empty(t, clock.waits, "Expected no pacing.")
+	for _, ent := range logs.All() {
+		assert.Equal(t, clock.now, ent.Time, "Expected entries to be retimed.")
+	}
+}
+
+func TestReplayWriteFailures(t *testing.T) {
+	core := ladcore.NewCore(ladcore.NewJSONEncoder(lad.NewProductionEncoderConfig()), &ztest.FailWriter{}, lad.DebugLevel)
+	r := NewReader(bytes.NewReader(writeLogs("a", "b")))
+
+	stats, err := Replay(context.Background(), r, core, Speed(0))
+	require.NoError(t, err, "Expected write errors to be counted, not returned.")
+	assert.Equal(t, 2, stats.Failed, "Expected every write to fail.")
+}
+
+func TestReplayCanceled(t *testing.T) {
+	core, logs := observer.New(lad.DebugLevel)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	r := NewReader(bytes.NewReader(writeLogs("a", "b")))
+	_, err := Replay(ctx, r, core)
+	assert.ErrorIs(t, err, context.Canceled, "Expected the context's error.")
+	assert.Zero(t, logs.Len(), "Expected nothing to be replayed.")
+}
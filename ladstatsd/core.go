@@ -0,0 +1,315 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package ladstatsd forwards the numbers in lad log entries to a StatsD (or
+// DogStatsD) server, for services that already log their measurements and
+// don't carry a metrics client of their own.
+package ladstatsd
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/auwixcom/lad/buffer"
+	"github.com/auwixcom/lad/internal/bufferpool"
+	"github.com/auwixcom/lad/ladcore"
+)
+
+// An Option configures the Core returned by NewCore.
+type Option interface {
+	apply(*core)
+}
+
+// optionFunc wraps a func so it satisfies the Option interface.
+type optionFunc func(*core)
+
+func (f optionFunc) apply(c *core) {
+	f(c)
+}
+
+// WithPrefix prepends prefix to the name of every metric. No separator is
+// added, so a prefix usually ends with a period.
+func WithPrefix(prefix string) Option {
+	return optionFunc(func(c *core) {
+		c.prefix = sanitize(prefix)
+	})
+}
+
+// WithNamespace reports every numeric field logged under the named namespace
+// (see lad.Namespace) as a metric, in addition to the fields built with
+// lad.Count and lad.Gauge. Integers and floats are reported as gauges and
+// durations as timings in milliseconds. Metrics are named after their field
+// keys, qualified by any namespaces nested below key.
+func WithNamespace(key string) Option {
+	return optionFunc(func(c *core) {
+		c.namespace = key
+	})
+}
+
+// WithTags attaches the values of the top-level fields with the given keys to
+// every metric as DogStatsD tags, formatted as "key:value". Fields of other
+// types than strings, booleans, integers, and fmt.Stringers are ignored.
+//
+// Plain StatsD servers don't understand tags, so leave this option out when
+// talking to one.
+func WithTags(keys ...string) Option {
+	return optionFunc(func(c *core) {
+		c.tagKeys = append(c.tagKeys, keys...)
+	})
+}
+
+// NewCore creates a Core that writes the metrics found in each enabled entry,
+// including in fields added with With, to ws in the StatsD line protocol.
+// The metrics for an entry are written with a single call to ws, so
+// a WriteSyncer backed by a UDP connection sends them as one datagram:
+//
+//	conn, err := net.Dial("udp", "127.0.0.1:8125")
+//	if err != nil {
+//		return err
+//	}
+//	core := ladstatsd.NewCore(ladcore.AddSync(conn), lad.InfoLevel)
+//
+// The Core doesn't write the entries themselves, so it's typically combined
+// with other Cores using ladcore.NewTee.
+func NewCore(ws ladcore.WriteSyncer, enab ladcore.LevelEnabler, opts ...Option) ladcore.Core {
+	c := &core{
+		LevelEnabler: enab,
+		out:          ws,
+	}
+	for _, opt := range opts {
+		opt.apply(c)
+	}
+	return c
+}
+
+type core struct {
+	ladcore.LevelEnabler
+
+	out       ladcore.WriteSyncer
+	prefix    string
+	namespace string
+	tagKeys   []string
+	context   []ladcore.Field
+}
+
+var _ ladcore.Core = (*core)(nil)
+
+func (c *core) Level() ladcore.Level {
+	return ladcore.LevelOf(c.LevelEnabler)
+}
+
+func (c *core) With(fields []ladcore.Field) ladcore.Core {
+	clone := *c
+	clone.context = append(c.context[:len(c.context):len(c.context)], fields...)
+	return &clone
+}
+
+func (c *core) Check(ent ladcore.Entry, ce *ladcore.CheckedEntry) *ladcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *core) Write(_ ladcore.Entry, fields []ladcore.Field) error {
+	var (
+		tags    = c.tags(fields)
+		metrics []metric
+		path    []string
+	)
+	// Namespaces opened in the context stay open for the entry's fields, so
+	// both are walked as a single list.
+	for _, fs := range [][]ladcore.Field{c.context, fields} {
+		for _, f := range fs {
+			if f.Type == ladcore.NamespaceType {
+				path = append(path, f.Key)
+				continue
+			}
+			if m, ok := c.metricOf(path, f); ok {
+				if m.kind == "g" && strings.HasPrefix(m.value, "-") {
+					// StatsD reads a signed gauge value as a change to the
+					// gauge, so a negative value is set by zeroing it first.
+					metrics = append(metrics, metric{name: m.name, kind: "g", value: "0"})
+				}
+				metrics = append(metrics, m)
+			}
+		}
+	}
+	if len(metrics) == 0 {
+		return nil
+	}
+
+	buf := bufferpool.Get()
+	defer buf.Free()
+	for i, m := range metrics {
+		if i > 0 {
+			buf.AppendByte('\n')
+		}
+		m.appendTo(buf, c.prefix, tags)
+	}
+	_, err := c.out.Write(buf.Bytes())
+	return err
+}
+
+func (c *core) Sync() error {
+	return c.out.Sync()
+}
+
+// metricOf reports the metric carried by f, which was logged under the given
+// namespaces, if any.
+func (c *core) metricOf(path []string, f ladcore.Field) (metric, bool) {
+	inNamespace := c.namespace != "" && len(path) > 0 && path[0] == c.namespace
+	if inNamespace {
+		path = path[1:]
+	}
+	m := metric{name: sanitize(strings.Join(append(path[:len(path):len(path)], f.Key), "."))}
+
+	switch ladcore.MetricTypeOf(f) {
+	case ladcore.CounterMetric:
+		m.kind, m.value = "c", strconv.FormatInt(f.Integer, 10)
+		return m, true
+	case ladcore.GaugeMetric:
+		m.kind, m.value = "g", formatFloat(math.Float64frombits(uint64(f.Integer)))
+		return m, true
+	}
+	if !inNamespace {
+		return m, false
+	}
+
+	switch f.Type {
+	case ladcore.Int64Type, ladcore.Int32Type, ladcore.Int16Type, ladcore.Int8Type:
+		m.kind, m.value = "g", strconv.FormatInt(f.Integer, 10)
+	case ladcore.Uint64Type, ladcore.Uint32Type, ladcore.Uint16Type, ladcore.Uint8Type:
+		m.kind, m.value = "g", strconv.FormatUint(uint64(f.Integer), 10)
+	case ladcore.Float64Type:
+		m.kind, m.value = "g", formatFloat(math.Float64frombits(uint64(f.Integer)))
+	case ladcore.Float32Type:
+		m.kind, m.value = "g", formatFloat(float64(math.Float32frombits(uint32(f.Integer))))
+	case ladcore.DurationType:
+		m.kind, m.value = "ms", formatFloat(float64(f.Integer)/float64(time.Millisecond))
+	default:
+		return m, false
+	}
+	return m, true
+}
+
+// tags formats the configured tag fields found at the top level of the
+// context and fields.
+func (c *core) tags(fields []ladcore.Field) []string {
+	if len(c.tagKeys) == 0 {
+		return nil
+	}
+	var tags []string
+	for _, fs := range [][]ladcore.Field{c.context, fields} {
+		for _, f := range fs {
+			if f.Type == ladcore.NamespaceType {
+				// Everything after a namespace is nested.
+				return tags
+			}
+			if !c.isTagKey(f.Key) {
+				continue
+			}
+			if v, ok := tagValue(f); ok {
+				tags = append(tags, sanitize(f.Key)+":"+sanitize(v))
+			}
+		}
+	}
+	return tags
+}
+
+func (c *core) isTagKey(key string) bool {
+	for _, k := range c.tagKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+func tagValue(f ladcore.Field) (string, bool) {
+	switch f.Type {
+	case ladcore.StringType:
+		return f.String, true
+	case ladcore.BoolType:
+		return strconv.FormatBool(f.Integer == 1), true
+	case ladcore.Int64Type, ladcore.Int32Type, ladcore.Int16Type, ladcore.Int8Type:
+		return strconv.FormatInt(f.Integer, 10), true
+	case ladcore.Uint64Type, ladcore.Uint32Type, ladcore.Uint16Type, ladcore.Uint8Type:
+		return strconv.FormatUint(uint64(f.Integer), 10), true
+	case ladcore.StringerType:
+		s, ok := f.Interface.(interface{ String() string })
+		if !ok {
+			return "", false
+		}
+		return safeString(s)
+	default:
+		return "", false
+	}
+}
+
+// safeString calls String, treating a panic (typically from a nil
+// receiver) as a missing value.
+func safeString(s interface{ String() string }) (str string, ok bool) {
+	defer func() {
+		if recover() != nil {
+			str, ok = "", false
+		}
+	}()
+	return s.String(), true
+}
+
+type metric struct {
+	name  string
+	value string
+	kind  string // StatsD metric type: c, g, or ms
+}
+
+func (m metric) appendTo(buf *buffer.Buffer, prefix string, tags []string) {
+	buf.AppendString(prefix)
+	buf.AppendString(m.name)
+	buf.AppendByte(':')
+	buf.AppendString(m.value)
+	buf.AppendByte('|')
+	buf.AppendString(m.kind)
+	if len(tags) > 0 {
+		buf.AppendString("|#")
+		buf.AppendString(strings.Join(tags, ","))
+	}
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// _reserved holds the characters that delimit the parts of a StatsD line.
+var _reserved = strings.NewReplacer(
+	":", "_",
+	"|", "_",
+	"@", "_",
+	"#", "_",
+	",", "_",
+	"\n", "_",
+)
+
+func sanitize(s string) string {
+	return _reserved.Replace(s)
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladstatsd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/auwixcom/lad"
+	"github.com/auwixcom/lad/internal/ztest"
+	"github.com/auwixcom/lad/ladcore"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func withCore(t *testing.T, opts []Option, f func(*lad.Logger, *ztest.Buffer)) {
+	var sink ztest.Buffer
+	f(lad.New(NewCore(&sink, lad.InfoLevel, opts...)), &sink)
+}
+
+func TestCoreMetricFields(t *testing.T) {
+	withCore(t, nil, func(log *lad.Logger, sink *ztest.Buffer) {
+		log.With(lad.Count("requests", 1)).Info("done", lad.Gauge("queue.depth", 2.5), lad.Int("ignored", 3))
+		assert.Equal(t, "requests:1|c\nqueue.depth:2.5|g", sink.String(), "Unexpected StatsD output.")
+
+		sink.Reset()
+		log.Info("nothing to report", lad.Int("ignored", 3))
+		assert.Empty(t, sink.String(), "Expected no output for entries without metrics.")
+
+		sink.Reset()
+		log.Debug("disabled", lad.Count("requests", 1))
+		assert.Empty(t, sink.String(), "Expected no output for disabled entries.")
+	})
+}
+
+func TestCoreNegativeGauges(t *testing.T) {
+	opts := []Option{WithNamespace("stats")}
+	withCore(t, opts, func(log *lad.Logger, sink *ztest.Buffer) {
+		log.Info("done", lad.Gauge("temp", -5), lad.Namespace("stats"), lad.Int("delta", -2), lad.Int("zero", 0))
+		assert.Equal(t, strings.Join([]string{
+			"temp:0|g",
+			"temp:-5|g",
+			"delta:0|g",
+			"delta:-2|g",
+			"zero:0|g",
+		}, "\n"), sink.String(), "Expected negative gauges to be set rather than decremented.")
+	})
+}
+
+func TestCoreNamespace(t *testing.T) {
+	opts := []Option{WithNamespace("stats"), WithPrefix("svc.")}
+	withCore(t, opts, func(log *lad.Logger, sink *ztest.Buffer) {
+		log.With(lad.Int("outside", 1), lad.Namespace("stats")).Info(
+			"done",
+			lad.Int64("rows", 42),
+			lad.Uint8("retries", 2),
+			lad.Float32("ratio", 0.5),
+			lad.Duration("latency", 1500*time.Microsecond),
+			lad.String("name", "not a number"),
+			lad.Namespace("db"),
+			lad.Int("conns", 7),
+		)
+		assert.Equal(t, strings.Join([]string{
+			"svc.rows:42|g",
+			"svc.retries:2|g",
+			"svc.ratio:0.5|g",
+			"svc.latency:1.5|ms",
+			"svc.db.conns:7|g",
+		}, "\n"), sink.String(), "Unexpected StatsD output.")
+	})
+}
+
+func TestCoreTags(t *testing.T) {
+	opts := []Option{WithTags("region", "canary", "shard", "kind")}
+	withCore(t, opts, func(log *lad.Logger, sink *ztest.Buffer) {
+		log.With(lad.String("region", "us|east")).Info(
+			"done",
+			lad.Bool("canary", true),
+			lad.Int("shard", 3),
+			lad.Object("kind", nil),
+			lad.String("other", "x"),
+			lad.Count("requests", 2),
+			lad.Namespace("nested"),
+			lad.String("region", "ignored"),
+		)
+		assert.Equal(t, "requests:2|c|#region:us_east,canary:true,shard:3", sink.String(), "Unexpected StatsD output.")
+	})
+}
+
+func TestCoreWriteError(t *testing.T) {
+	core := NewCore(&ztest.FailWriter{}, lad.InfoLevel)
+	err := core.Write(ladcore.Entry{}, []ladcore.Field{lad.Count("requests", 1)})
+	require.Error(t, err, "Expected write errors to propagate.")
+
+	sink := &ztest.Discarder{}
+	sink.SetError(errors.New("fail"))
+	assert.Error(t, NewCore(sink, lad.InfoLevel).Sync(), "Expected sync errors to propagate.")
+}
+
+func TestCoreLevel(t *testing.T) {
+	assert.Equal(t, lad.WarnLevel, ladcore.LevelOf(NewCore(&ztest.Buffer{}, lad.WarnLevel)), "Unexpected level.")
+}
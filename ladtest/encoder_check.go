@@ -0,0 +1,304 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladtest
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"unicode/utf8"
+
+	"github.com/auwixcom/lad/ladcore"
+)
+
+// An EncoderInvariant inspects the output of a single EncodeEntry call and
+// returns an error describing any violation.
+type EncoderInvariant func(out []byte) error
+
+// ValidUTF8 requires encoder output to be valid UTF-8.
+func ValidUTF8(out []byte) error {
+	if !utf8.Valid(out) {
+		return errors.New("output is not valid UTF-8")
+	}
+	return nil
+}
+
+// ValidJSON requires encoder output to be a single JSON value, optionally
+// followed by whitespace such as a line ending.
+func ValidJSON(out []byte) error {
+	if !json.Valid(out) {
+		return errors.New("output is not valid JSON")
+	}
+	return nil
+}
+
+// UniqueKeys requires that no JSON object in the encoder output repeats a
+// key. Output that isn't valid JSON is reported as a violation too.
+func UniqueKeys(out []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(out))
+	dec.UseNumber()
+
+	// One set of keys per open object; nil marks an open array. Inside an
+	// object the decoder returns keys and values alike, so afterKey tracks
+	// which one comes next.
+	var scopes []map[string]struct{}
+	var afterKey []bool
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF && len(scopes) == 0 {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("output is not valid JSON: %v", err)
+		}
+
+		top := len(scopes) - 1
+		if top >= 0 && scopes[top] != nil && !afterKey[top] {
+			if key, ok := tok.(string); ok {
+				if _, dup := scopes[top][key]; dup {
+					return fmt.Errorf("duplicate key %q", key)
+				}
+				scopes[top][key] = struct{}{}
+				afterKey[top] = true
+				continue
+			}
+		}
+		if top >= 0 {
+			afterKey[top] = false
+		}
+
+		switch tok {
+		case json.Delim('{'):
+			scopes = append(scopes, make(map[string]struct{}))
+			afterKey = append(afterKey, false)
+		case json.Delim('['):
+			scopes = append(scopes, nil)
+			afterKey = append(afterKey, false)
+		case json.Delim('}'), json.Delim(']'):
+			scopes = scopes[:top]
+			afterKey = afterKey[:top]
+		}
+	}
+}
+
+// ValidMessagePack requires encoder output to be a single well-formed
+// MessagePack value with nothing after it, and its strings and map keys to be
+// valid UTF-8.
+func ValidMessagePack(out []byte) error {
+	n, err := msgpackValue(out, 0)
+	if err != nil {
+		return fmt.Errorf("output is not valid MessagePack: %v", err)
+	}
+	if n != len(out) {
+		return fmt.Errorf("output is not valid MessagePack: %d trailing bytes", len(out)-n)
+	}
+	return nil
+}
+
+// msgpackValue returns the length of the MessagePack value at the start of b.
+func msgpackValue(b []byte, depth int) (int, error) {
+	if depth > 10000 {
+		return 0, errors.New("values nested too deeply")
+	}
+	if len(b) == 0 {
+		return 0, io.ErrUnexpectedEOF
+	}
+
+	// size returns the big-endian length of width bytes following the
+	// marker.
+	size := func(width int) (int, error) {
+		if len(b) < 1+width {
+			return 0, io.ErrUnexpectedEOF
+		}
+		switch width {
+		case 1:
+			return int(b[1]), nil
+		case 2:
+			return int(binary.BigEndian.Uint16(b[1:])), nil
+		default:
+			return int(binary.BigEndian.Uint32(b[1:])), nil
+		}
+	}
+	// raw returns the length of a header followed by n bytes of data.
+	raw := func(header, n int, str bool) (int, error) {
+		if len(b) < header+n {
+			return 0, io.ErrUnexpectedEOF
+		}
+		if str && !utf8.Valid(b[header:header+n]) {
+			return 0, errors.New("string is not valid UTF-8")
+		}
+		return header + n, nil
+	}
+	// container returns the length of a header followed by n values.
+	container := func(header, n int) (int, error) {
+		off := header
+		for i := 0; i < n; i++ {
+			m, err := msgpackValue(b[off:], depth+1)
+			if err != nil {
+				return 0, err
+			}
+			off += m
+		}
+		return off, nil
+	}
+
+	switch c := b[0]; {
+	case c <= 0x7f, c >= 0xe0, c == 0xc0, c == 0xc2, c == 0xc3:
+		return 1, nil // fixint, nil, or bool
+	case c <= 0x8f:
+		return container(1, 2*int(c&0x0f))
+	case c <= 0x9f:
+		return container(1, int(c&0x0f))
+	case c <= 0xbf:
+		return raw(1, int(c&0x1f), true)
+	}
+
+	switch c := b[0]; c {
+	case 0xcc, 0xd0:
+		return raw(1, 1, false)
+	case 0xcd, 0xd1:
+		return raw(1, 2, false)
+	case 0xca, 0xce, 0xd2:
+		return raw(1, 4, false)
+	case 0xcb, 0xcf, 0xd3:
+		return raw(1, 8, false)
+	case 0xd4, 0xd5, 0xd6, 0xd7, 0xd8: // fixext: type and 1 to 16 bytes
+		return raw(2, 1<<(c-0xd4), false)
+	case 0xc4, 0xc5, 0xc6, 0xd9, 0xda, 0xdb: // bin and str
+		width := msgpackLengthWidth(c)
+		n, err := size(width)
+		if err != nil {
+			return 0, err
+		}
+		return raw(1+width, n, c >= 0xd9)
+	case 0xc7, 0xc8, 0xc9: // ext: length, type, and data
+		width := msgpackLengthWidth(c)
+		n, err := size(width)
+		if err != nil {
+			return 0, err
+		}
+		return raw(2+width, n, false)
+	case 0xdc, 0xdd, 0xde, 0xdf: // array and map
+		width := msgpackLengthWidth(c)
+		n, err := size(width)
+		if err != nil {
+			return 0, err
+		}
+		if c >= 0xde {
+			n *= 2
+		}
+		return container(1+width, n)
+	}
+	return 0, fmt.Errorf("unknown type marker 0x%02x", b[0])
+}
+
+// msgpackLengthWidth returns the size of the length that follows a bin, str,
+// ext, array, or map marker.
+func msgpackLengthWidth(c byte) int {
+	switch c {
+	case 0xc4, 0xc7, 0xd9:
+		return 1
+	case 0xc5, 0xc8, 0xda, 0xdc, 0xde:
+		return 2
+	}
+	return 4
+}
+
+// DefaultEncoderInvariants are the checks CheckEncoder runs if none are
+// supplied. They suit JSON encoders; pass ValidUTF8 alone for line-oriented
+// formats like the console encoder, and ValidMessagePack for the MessagePack
+// encoder.
+var DefaultEncoderInvariants = []EncoderInvariant{ValidUTF8, ValidJSON, UniqueKeys}
+
+// CheckEncoder wraps core so that every entry it logs is also encoded with
+// enc, including any context added with With, and checked against the given
+// invariants. Encoding errors and violations fail the test via t.Errorf,
+// along with the offending output. Entries still reach core unchanged.
+//
+// It's intended for property-style tests of custom encoders: drive the
+// returned Core with many different fields and let the invariants catch
+// malformed output.
+func CheckEncoder(t TestingT, core ladcore.Core, enc ladcore.Encoder, invariants ...EncoderInvariant) ladcore.Core {
+	if len(invariants) == 0 {
+		invariants = DefaultEncoderInvariants
+	}
+	return ladcore.NewTee(core, &invariantCore{
+		LevelEnabler: core,
+		t:            t,
+		enc:          enc,
+		invariants:   invariants,
+	})
+}
+
+// invariantCore encodes entries and checks the output without writing it
+// anywhere.
+type invariantCore struct {
+	ladcore.LevelEnabler
+
+	t          TestingT
+	enc        ladcore.Encoder
+	invariants []EncoderInvariant
+}
+
+var _ ladcore.Core = (*invariantCore)(nil)
+
+func (c *invariantCore) Level() ladcore.Level {
+	return ladcore.LevelOf(c.LevelEnabler)
+}
+
+func (c *invariantCore) With(fields []ladcore.Field) ladcore.Core {
+	clone := *c
+	clone.enc = c.enc.Clone()
+	for _, f := range fields {
+		f.AddTo(clone.enc)
+	}
+	return &clone
+}
+
+func (c *invariantCore) Check(ent ladcore.Entry, ce *ladcore.CheckedEntry) *ladcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *invariantCore) Write(ent ladcore.Entry, fields []ladcore.Field) error {
+	buf, err := c.enc.EncodeEntry(ent, fields)
+	if err != nil {
+		c.t.Errorf("encoding entry %q: %v", ent.Message, err)
+		return nil
+	}
+	defer buf.Free()
+
+	for _, inv := range c.invariants {
+		if err := inv(buf.Bytes()); err != nil {
+			c.t.Errorf("encoder invariant violated: %v\noutput: %q", err, buf.Bytes())
+		}
+	}
+	return nil
+}
+
+func (c *invariantCore) Sync() error {
+	return nil
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladtest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/auwixcom/lad"
+	"github.com/auwixcom/lad/ladcore"
+	"github.com/auwixcom/lad/ladtest/observer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// errorSpy is a TestingT that records Errorf calls instead of failing.
+type errorSpy struct {
+	TestingT
+
+	Errors []string
+}
+
+func (t *errorSpy) Errorf(format string, args ...interface{}) {
+	t.Errors = append(t.Errors, fmt.Sprintf(format, args...))
+}
+
+func TestUniqueKeys(t *testing.T) {
+	tests := []struct {
+		give    string
+		wantErr string
+	}{
+		{give: `{}`},
+		{give: `{"a":1,"b":{"a":2},"c":[{"a":3},{"a":4}]}`},
+		{give: `{"a":"a","b":"a"}` + "\n"},
+		{give: `{"a":1,"a":2}`, wantErr: `duplicate key "a"`},
+		{give: `{"a":{"b":1,"b":2}}`, wantErr: `duplicate key "b"`},
+		{give: `{"a":[{"b":1}],"a":2}`, wantErr: `duplicate key "a"`},
+		{give: `{"a":`, wantErr: "not valid JSON"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			err := UniqueKeys([]byte(tt.give))
+			if tt.wantErr == "" {
+				assert.NoError(t, err, "Unexpected error.")
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr, "Unexpected error.")
+			}
+		})
+	}
+}
+
+func TestValidMessagePack(t *testing.T) {
+	tests := []struct {
+		desc    string
+		give    []byte
+		wantErr string
+	}{
+		{desc: "fixmap", give: []byte{0x81, 0xa1, 'a', 0x01}},
+		{desc: "nested", give: []byte{0x82, 0xa1, 'a', 0x92, 0xc3, 0xff, 0xa1, 'b', 0xde, 0x00, 0x01, 0xa1, 'c', 0xc0}},
+		{desc: "numbers", give: []byte{0x94, 0xcc, 0xff, 0xd1, 0x80, 0x00, 0xca, 0, 0, 0, 0, 0xd3, 0, 0, 0, 0, 0, 0, 0, 1}},
+		{desc: "bin and str8", give: []byte{0x92, 0xc4, 0x02, 0xff, 0xfe, 0xd9, 0x01, 'x'}},
+		{desc: "ext", give: []byte{0x92, 0xd4, 0x01, 0x00, 0xc7, 0x02, 0x01, 0x00, 0x00}},
+		{desc: "empty", give: nil, wantErr: "unexpected EOF"},
+		{desc: "truncated map", give: []byte{0x82, 0xa1, 'a', 0x01}, wantErr: "unexpected EOF"},
+		{desc: "truncated string", give: []byte{0xdb, 0x00, 0x00, 0x00, 0x05, 'a'}, wantErr: "unexpected EOF"},
+		{desc: "trailing bytes", give: []byte{0x80, 0x80}, wantErr: "1 trailing bytes"},
+		{desc: "invalid UTF-8", give: []byte{0xa1, 0xff}, wantErr: "not valid UTF-8"},
+		{desc: "unknown marker", give: []byte{0xc1}, wantErr: "unknown type marker 0xc1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := ValidMessagePack(tt.give)
+			if tt.wantErr == "" {
+				assert.NoError(t, err, "Unexpected error.")
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr, "Unexpected error.")
+			}
+		})
+	}
+}
+
+func TestCheckEncoder(t *testing.T) {
+	enc := ladcore.NewJSONEncoder(lad.NewProductionEncoderConfig())
+
+	t.Run("passes", func(t *testing.T) {
+		spy := &errorSpy{TestingT: t}
+		inner, logs := observer.New(ladcore.InfoLevel)
+		log := lad.New(CheckEncoder(spy, inner, enc)).With(lad.String("a", "b"))
+
+		log.Info("hello", lad.String("c", "\xff"), lad.Namespace("n"), lad.String("a", "d"))
+		log.Debug("disabled", lad.String("a", "dup"))
+
+		assert.Empty(t, spy.Errors, "Unexpected invariant violations.")
+		assert.Equal(t, 1, logs.Len(), "Expected entries to reach the wrapped core.")
+	})
+
+	t.Run("duplicate keys", func(t *testing.T) {
+		spy := &errorSpy{TestingT: t}
+		inner, _ := observer.New(ladcore.InfoLevel)
+		log := lad.New(CheckEncoder(spy, inner, enc)).With(lad.String("a", "b"))
+
+		log.Info("hello", lad.String("a", "c"))
+
+		require.Len(t, spy.Errors, 1, "Expected one invariant violation.")
+		assert.Contains(t, spy.Errors[0], `duplicate key "a"`, "Unexpected violation.")
+	})
+
+	t.Run("custom invariants", func(t *testing.T) {
+		spy := &errorSpy{TestingT: t}
+		inner, _ := observer.New(ladcore.InfoLevel)
+		console := ladcore.NewConsoleEncoder(lad.NewDevelopmentEncoderConfig())
+		log := lad.New(CheckEncoder(spy, inner, console, ValidUTF8))
+		log.Info("hello")
+		assert.Empty(t, spy.Errors, "Unexpected invariant violations.")
+
+		log = lad.New(CheckEncoder(spy, inner, console))
+		log.Info("hello")
+		assert.NotEmpty(t, spy.Errors, "Expected console output to fail the JSON invariants.")
+	})
+
+	t.Run("msgpack", func(t *testing.T) {
+		spy := &errorSpy{TestingT: t}
+		inner, _ := observer.New(ladcore.InfoLevel)
+		mp := ladcore.NewMsgpackEncoder(lad.NewProductionEncoderConfig())
+		log := lad.New(CheckEncoder(spy, inner, mp, ValidMessagePack)).With(lad.String("a", "b"), lad.Namespace("n"))
+		log.Info("hello", lad.Binary("bin", []byte{0xff}), lad.Ints("ints", []int{1, -300}), lad.Float64("f", 1.5))
+		assert.Empty(t, spy.Errors, "Unexpected invariant violations.")
+
+		log = lad.New(CheckEncoder(spy, inner, enc, ValidMessagePack))
+		log.Info("hello")
+		assert.NotEmpty(t, spy.Errors, "Expected JSON output to fail the MessagePack invariant.")
+	})
+}
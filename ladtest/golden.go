@@ -0,0 +1,252 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/auwixcom/lad/ladtest/observer"
+)
+
+// Placeholders substituted for values that change from run to run.
+const (
+	goldenTime     = "<time>"
+	goldenDuration = "<duration>"
+	goldenCaller   = "<caller>"
+	goldenStack    = "<stack>"
+	goldenMasked   = "<masked>"
+)
+
+// A GoldenOption configures AssertGolden.
+type GoldenOption interface {
+	apply(*goldenConfig)
+}
+
+type goldenOptionFunc func(*goldenConfig)
+
+func (f goldenOptionFunc) apply(cfg *goldenConfig) {
+	f(cfg)
+}
+
+type goldenConfig struct {
+	update *bool
+	masked map[string]struct{}
+}
+
+// GoldenUpdate makes AssertGolden rewrite the golden file, or not, regardless
+// of the -update flag.
+func GoldenUpdate(update bool) GoldenOption {
+	return goldenOptionFunc(func(cfg *goldenConfig) {
+		cfg.update = &update
+	})
+}
+
+// GoldenMask replaces the values of the fields with the given keys, at any
+// depth, with a placeholder. Use it for request IDs, ports, and other values
+// that AssertGolden doesn't already normalize.
+func GoldenMask(keys ...string) GoldenOption {
+	return goldenOptionFunc(func(cfg *goldenConfig) {
+		for _, k := range keys {
+			cfg.masked[k] = struct{}{}
+		}
+	})
+}
+
+// goldenEntry is the form in which entries are stored in golden files.
+type goldenEntry struct {
+	Level      string                 `json:"level"`
+	Time       string                 `json:"time,omitempty"`
+	LoggerName string                 `json:"logger,omitempty"`
+	Caller     string                 `json:"caller,omitempty"`
+	Message    string                 `json:"message"`
+	Stack      string                 `json:"stack,omitempty"`
+	Fields     map[string]interface{} `json:"fields,omitempty"`
+}
+
+// AssertGolden compares the observed entries against a golden file holding
+// them as a JSON array. Timestamps, callers, stack traces, and durations are
+// replaced with placeholders first, so the file only changes when the logging
+// itself does. It reports any mismatch to t and returns whether the assertion
+// held.
+//
+//	core, logs := observer.New(lad.DebugLevel)
+//	run(lad.New(core))
+//	ladtest.AssertGolden(t, logs, "testdata/run.json")
+//
+// If the test binary defines a boolean -update flag, running the tests with
+// -update rewrites the golden file instead of comparing against it. ladtest
+// doesn't define the flag itself, to avoid clashing with packages that do;
+// declare it in the test package:
+//
+//	var _ = flag.Bool("update", false, "update golden files")
+func AssertGolden(t TestingT, logs *observer.ObservedLogs, path string, opts ...GoldenOption) bool {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+
+	cfg := goldenConfig{masked: make(map[string]struct{})}
+	for _, opt := range opts {
+		opt.apply(&cfg)
+	}
+
+	got, err := marshalGolden(logs.All(), cfg.masked)
+	if err != nil {
+		t.Errorf("can't encode observed logs for golden file %s: %v", path, err)
+		return false
+	}
+
+	if cfg.updating() {
+		if err := writeGolden(path, got); err != nil {
+			t.Errorf("can't update golden file: %v", err)
+			return false
+		}
+		t.Logf("updated golden file %s", path)
+		return true
+	}
+
+	want, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		t.Errorf("golden file %s doesn't exist; run the tests with -update to create it", path)
+		return false
+	}
+	if err != nil {
+		t.Errorf("can't read golden file: %v", err)
+		return false
+	}
+
+	if line, w, g, ok := firstDiff(want, got); !ok {
+		t.Errorf("observed logs don't match golden file %s at line %d:\n  want: %s\n  got:  %s\nrun the tests with -update to rewrite it",
+			path, line, w, g)
+		return false
+	}
+	return true
+}
+
+func (cfg goldenConfig) updating() bool {
+	if cfg.update != nil {
+		return *cfg.update
+	}
+	f := flag.Lookup("update")
+	if f == nil {
+		return false
+	}
+	update, _ := strconv.ParseBool(f.Value.String())
+	return update
+}
+
+func marshalGolden(entries []observer.LoggedEntry, masked map[string]struct{}) ([]byte, error) {
+	golden := make([]goldenEntry, len(entries))
+	for i, e := range entries {
+		g := goldenEntry{
+			Level:      e.Level.String(),
+			LoggerName: e.LoggerName,
+			Message:    e.Message,
+		}
+		if !e.Time.IsZero() {
+			g.Time = goldenTime
+		}
+		if e.Caller.Defined {
+			g.Caller = goldenCaller
+		}
+		if e.Stack != "" {
+			g.Stack = goldenStack
+		}
+		if len(e.Context) > 0 {
+			g.Fields = normalizeGolden(e.ContextMap(), masked).(map[string]interface{})
+		}
+		golden[i] = g
+	}
+
+	// Placeholders would otherwise be escaped as HTML.
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(golden); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// normalizeGolden replaces volatile values, and those of masked fields, with
+// placeholders.
+func normalizeGolden(v interface{}, masked map[string]struct{}) interface{} {
+	switch v := v.(type) {
+	case time.Time:
+		return goldenTime
+	case time.Duration:
+		return goldenDuration
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, val := range v {
+			if _, ok := masked[k]; ok {
+				out[k] = goldenMasked
+				continue
+			}
+			out[k] = normalizeGolden(val, masked)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, val := range v {
+			out[i] = normalizeGolden(val, masked)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+func writeGolden(path string, contents []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, contents, 0o644)
+}
+
+// firstDiff reports the first line, counting from one, at which want and got
+// differ, along with their contents there.
+func firstDiff(want, got []byte) (line int, w, g string, ok bool) {
+	if bytes.Equal(want, got) {
+		return 0, "", "", true
+	}
+	wantLines := bytes.Split(want, []byte("\n"))
+	gotLines := bytes.Split(got, []byte("\n"))
+	for i := 0; ; i++ {
+		w, g := "<EOF>", "<EOF>"
+		if i < len(wantLines) {
+			w = string(wantLines[i])
+		}
+		if i < len(gotLines) {
+			g = string(gotLines[i])
+		}
+		if w != g || (i >= len(wantLines) && i >= len(gotLines)) {
+			return i + 1, w, g, false
+		}
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladtest
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/auwixcom/lad"
+	"github.com/auwixcom/lad/ladtest/observer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _update = flag.Bool("update", false, "update golden files")
+
+func logGoldenRun(took time.Duration) *observer.ObservedLogs {
+	core, logs := observer.New(lad.DebugLevel)
+	log := lad.New(core, lad.AddCaller()).Named("server")
+	log.Info("started", lad.Time("at", time.Now()), lad.Int("port", 8080))
+	log.Warn("slow request",
+		lad.Duration("took", took),
+		lad.String("request_id", took.String()),
+		lad.Error(errors.New("deadline exceeded")),
+	)
+	return logs
+}
+
+func TestAssertGolden(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "testdata", "run.json")
+
+	spy := &errorSpy{TestingT: t}
+	assert.False(t, AssertGolden(spy, logGoldenRun(time.Second), path), "Expected a missing golden file to fail.")
+	require.Len(t, spy.Errors, 1, "Expected an error.")
+	assert.Contains(t, spy.Errors[0], "run the tests with -update", "Expected a hint to create the golden file.")
+
+	spy = &errorSpy{TestingT: t}
+	require.True(t, AssertGolden(spy, logGoldenRun(time.Second), path, GoldenUpdate(true)), "Expected to update the golden file.")
+	assert.Empty(t, spy.Errors, "Unexpected errors.")
+
+	contents, err := os.ReadFile(path)
+	require.NoError(t, err, "Expected the golden file to be written.")
+	assert.Equal(t, `[
+  {
+    "level": "info",
+    "time": "<time>",
+    "logger": "server",
+    "caller": "<caller>",
+    "message": "started",
+    "fields": {
+      "at": "<time>",
+      "port": 8080
+    }
+  },
+  {
+    "level": "warn",
+    "time": "<time>",
+    "logger": "server",
+    "caller": "<caller>",
+    "message": "slow request",
+    "fields": {
+      "error": "deadline exceeded",
+      "request_id": "1s",
+      "took": "<duration>"
+    }
+  }
+]
+`, string(contents), "Unexpected golden file.")
+
+	t.Run("match", func(t *testing.T) {
+		spy := &errorSpy{TestingT: t}
+		assert.True(t, AssertGolden(spy, logGoldenRun(time.Second), path), "Expected the logs to match.")
+		assert.Empty(t, spy.Errors, "Unexpected errors.")
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		spy := &errorSpy{TestingT: t}
+		assert.False(t, AssertGolden(spy, logGoldenRun(time.Minute), path), "Expected the logs not to match.")
+		require.Len(t, spy.Errors, 1, "Expected an error.")
+		assert.Contains(t, spy.Errors[0], "at line 21:", "Expected the first differing line.")
+		assert.Contains(t, spy.Errors[0], `want:       "request_id": "1s",`, "Expected the golden line.")
+		assert.Contains(t, spy.Errors[0], `got:        "request_id": "1m0s",`, "Expected the observed line.")
+	})
+
+	t.Run("masked", func(t *testing.T) {
+		masked := filepath.Join(t.TempDir(), "masked.json")
+		require.True(t, AssertGolden(t, logGoldenRun(time.Second), masked, GoldenMask("request_id"), GoldenUpdate(true)),
+			"Expected to update the golden file.")
+
+		spy := &errorSpy{TestingT: t}
+		assert.True(t, AssertGolden(spy, logGoldenRun(time.Minute), masked, GoldenMask("request_id")),
+			"Expected masked fields to be ignored.")
+		assert.Empty(t, spy.Errors, "Unexpected errors.")
+	})
+}
+
+func TestAssertGoldenUpdateFlag(t *testing.T) {
+	require.NoError(t, flag.Set("update", "true"), "Failed to set -update.")
+	defer func() { *_update = false }()
+
+	path := filepath.Join(t.TempDir(), "run.json")
+	assert.True(t, AssertGolden(t, logGoldenRun(time.Second), path), "Expected -update to write the golden file.")
+	assert.FileExists(t, path, "Expected the golden file to be written.")
+
+	spy := &errorSpy{TestingT: t}
+	assert.False(t, AssertGolden(spy, logGoldenRun(time.Minute), path, GoldenUpdate(false)),
+		"Expected GoldenUpdate to override the flag.")
+	assert.Len(t, spy.Errors, 1, "Expected the second run to compare against the file.")
+}
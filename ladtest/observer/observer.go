@@ -25,6 +25,7 @@
 package observer // import "github.com/auwixcom/lad/ladtest/observer"
 
 import (
+	"regexp"
 	"strings"
 	"sync"
 	"time"
@@ -84,6 +85,14 @@ func (o *ObservedLogs) FilterLevelExact(level ladcore.Level) *ObservedLogs {
 	})
 }
 
+// FilterLevelRange filters entries to those logged at or above min and at or
+// below max.
+func (o *ObservedLogs) FilterLevelRange(min, max ladcore.Level) *ObservedLogs {
+	return o.Filter(func(e LoggedEntry) bool {
+		return e.Level >= min && e.Level <= max
+	})
+}
+
 // FilterMessage filters entries to those that have the specified message.
 func (o *ObservedLogs) FilterMessage(msg string) *ObservedLogs {
 	return o.Filter(func(e LoggedEntry) bool {
@@ -105,6 +114,14 @@ func (o *ObservedLogs) FilterMessageSnippet(snippet string) *ObservedLogs {
 	})
 }
 
+// FilterMessageRegexp filters entries to those with a message matching the
+// specified regular expression.
+func (o *ObservedLogs) FilterMessageRegexp(re *regexp.Regexp) *ObservedLogs {
+	return o.Filter(func(e LoggedEntry) bool {
+		return re.MatchString(e.Message)
+	})
+}
+
 // FilterField filters entries to those that have the specified field.
 func (o *ObservedLogs) FilterField(field ladcore.Field) *ObservedLogs {
 	return o.Filter(func(e LoggedEntry) bool {
@@ -144,6 +161,48 @@ func (o *ObservedLogs) Filter(keep func(LoggedEntry) bool) *ObservedLogs {
 	return &ObservedLogs{logs: filtered}
 }
 
+// TestingT is the subset of *testing.T used by the assertions on
+// ObservedLogs.
+type TestingT interface {
+	Errorf(string, ...interface{})
+}
+
+// AssertLoggedInOrder checks that entries with each of the given messages
+// were observed, in the given order. Other entries may appear before, after,
+// and between them. It reports any mismatch to t and returns whether the
+// assertion held.
+//
+//	logs.FilterLoggerName("db").AssertLoggedInOrder(t, "connecting", "connected")
+func (o *ObservedLogs) AssertLoggedInOrder(t TestingT, msgs ...string) bool {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	next := 0
+	for _, e := range o.logs {
+		if next < len(msgs) && e.Message == msgs[next] {
+			next++
+		}
+	}
+	if next == len(msgs) {
+		return true
+	}
+
+	seen := make([]string, len(o.logs))
+	for i, e := range o.logs {
+		seen[i] = e.Message
+	}
+	if next == 0 {
+		t.Errorf("expected %q to be logged; observed messages: %q", msgs[next], seen)
+	} else {
+		t.Errorf("expected %q to be logged after %q; observed messages: %q", msgs[next], msgs[next-1], seen)
+	}
+	return false
+}
+
 func (o *ObservedLogs) add(log LoggedEntry) {
 	o.mu.Lock()
 	o.logs = append(o.logs, log)
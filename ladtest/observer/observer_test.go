@@ -21,6 +21,8 @@
 package observer_test
 
 import (
+	"fmt"
+	"regexp"
 	"testing"
 	"time"
 
@@ -255,6 +257,21 @@ func TestFilters(t *testing.T) {
 			filtered: sink.FilterLevelExact(lad.WarnLevel),
 			want:     logs[9:10],
 		},
+		{
+			msg:      "filter by message regexp",
+			filtered: sink.FilterMessageRegexp(regexp.MustCompile(`^log [bc]$`)),
+			want:     logs[2:4],
+		},
+		{
+			msg:      "filter level range",
+			filtered: sink.FilterLevelRange(lad.WarnLevel, lad.ErrorLevel),
+			want:     logs[9:12],
+		},
+		{
+			msg:      "filter level range and field key",
+			filtered: sink.FilterLevelRange(lad.DebugLevel, lad.WarnLevel).FilterFieldKey("b"),
+			want:     []LoggedEntry{logs[1], logs[2], logs[7], logs[9]},
+		},
 		{
 			msg:      "filter logger name",
 			filtered: sink.FilterLoggerName("my.logger"),
@@ -267,3 +284,54 @@ func TestFilters(t *testing.T) {
 		assert.Equal(t, tt.want, got, tt.msg)
 	}
 }
+
+type errorRecorder struct {
+	errors []string
+}
+
+func (r *errorRecorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertLoggedInOrder(t *testing.T) {
+	logger, sink := New(lad.InfoLevel)
+	for _, msg := range []string{"start", "connecting", "retrying", "connected", "stop"} {
+		assert.NoError(t, logger.Write(ladcore.Entry{Level: lad.InfoLevel, Message: msg}, nil), "Unexpected error writing log entry.")
+	}
+
+	tests := []struct {
+		msg     string
+		ordered []string
+		wantErr string
+	}{
+		{msg: "no messages", ordered: nil},
+		{msg: "all messages", ordered: []string{"start", "connecting", "retrying", "connected", "stop"}},
+		{msg: "gaps between messages", ordered: []string{"connecting", "connected"}},
+		{
+			msg:     "wrong order",
+			ordered: []string{"connected", "connecting"},
+			wantErr: `expected "connecting" to be logged after "connected"; observed messages: ["start" "connecting" "retrying" "connected" "stop"]`,
+		},
+		{
+			msg:     "missing message",
+			ordered: []string{"done"},
+			wantErr: `expected "done" to be logged; observed messages: ["start" "connecting" "retrying" "connected" "stop"]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.msg, func(t *testing.T) {
+			var rec errorRecorder
+			ok := sink.AssertLoggedInOrder(&rec, tt.ordered...)
+			if tt.wantErr == "" {
+				assert.True(t, ok, "Expected assertion to hold.")
+				assert.Empty(t, rec.errors, "Unexpected errors.")
+				return
+			}
+			assert.False(t, ok, "Expected assertion to fail.")
+			assert.Equal(t, []string{tt.wantErr}, rec.errors, "Unexpected errors.")
+		})
+	}
+
+	assert.True(t, sink.AssertLoggedInOrder(t, "start", "stop"), "Expected *testing.T to satisfy TestingT.")
+}
@@ -0,0 +1,310 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package observer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/auwixcom/lad/ladcore"
+)
+
+// OTLPLogs mirrors the JSON encoding of an OTLP ExportLogsServiceRequest,
+// which is what an OpenTelemetry collector receives over OTLP/HTTP with
+// JSON encoding and writes with its file exporter. It's built without
+// depending on the OpenTelemetry libraries, so only the fields lad can
+// populate are present.
+type OTLPLogs struct {
+	ResourceLogs []OTLPResourceLogs `json:"resourceLogs"`
+}
+
+// OTLPResourceLogs holds the logs of a single resource.
+type OTLPResourceLogs struct {
+	Resource  OTLPResource    `json:"resource"`
+	ScopeLogs []OTLPScopeLogs `json:"scopeLogs"`
+}
+
+// OTLPResource describes the entity producing logs, such as a service.
+type OTLPResource struct {
+	Attributes []OTLPKeyValue `json:"attributes,omitempty"`
+}
+
+// OTLPScopeLogs holds the logs of a single instrumentation scope. Each
+// logger name becomes a scope.
+type OTLPScopeLogs struct {
+	Scope      OTLPScope       `json:"scope"`
+	LogRecords []OTLPLogRecord `json:"logRecords"`
+}
+
+// OTLPScope identifies an instrumentation scope.
+type OTLPScope struct {
+	Name string `json:"name,omitempty"`
+}
+
+// OTLPLogRecord is a single log record.
+type OTLPLogRecord struct {
+	TimeUnixNano   string         `json:"timeUnixNano,omitempty"`
+	SeverityNumber int            `json:"severityNumber"`
+	SeverityText   string         `json:"severityText"`
+	Body           OTLPAnyValue   `json:"body"`
+	Attributes     []OTLPKeyValue `json:"attributes,omitempty"`
+	TraceID        string         `json:"traceId,omitempty"`
+	SpanID         string         `json:"spanId,omitempty"`
+}
+
+// OTLPKeyValue is an attribute.
+type OTLPKeyValue struct {
+	Key   string       `json:"key"`
+	Value OTLPAnyValue `json:"value"`
+}
+
+// OTLPAnyValue holds exactly one value. As in OTLP/JSON, 64-bit integers are
+// encoded as strings and bytes as base64.
+type OTLPAnyValue struct {
+	StringValue *string           `json:"stringValue,omitempty"`
+	BoolValue   *bool             `json:"boolValue,omitempty"`
+	IntValue    *string           `json:"intValue,omitempty"`
+	DoubleValue *OTLPDouble       `json:"doubleValue,omitempty"`
+	BytesValue  []byte            `json:"bytesValue,omitempty"`
+	ArrayValue  *OTLPArrayValue   `json:"arrayValue,omitempty"`
+	KvlistValue *OTLPKeyValueList `json:"kvlistValue,omitempty"`
+}
+
+// OTLPDouble is a floating-point value. As in OTLP/JSON, NaN and the
+// infinities are encoded as the strings "NaN", "Infinity", and "-Infinity",
+// which encoding/json can't otherwise represent.
+type OTLPDouble float64
+
+// MarshalJSON implements json.Marshaler.
+func (d OTLPDouble) MarshalJSON() ([]byte, error) {
+	switch f := float64(d); {
+	case math.IsNaN(f):
+		return []byte(`"NaN"`), nil
+	case math.IsInf(f, 1):
+		return []byte(`"Infinity"`), nil
+	case math.IsInf(f, -1):
+		return []byte(`"-Infinity"`), nil
+	default:
+		return json.Marshal(f)
+	}
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *OTLPDouble) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		var f float64
+		if err := json.Unmarshal(b, &f); err != nil {
+			return err
+		}
+		*d = OTLPDouble(f)
+		return nil
+	}
+	switch s {
+	case "NaN":
+		*d = OTLPDouble(math.NaN())
+	case "Infinity":
+		*d = OTLPDouble(math.Inf(1))
+	case "-Infinity":
+		*d = OTLPDouble(math.Inf(-1))
+	default:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return fmt.Errorf("invalid OTLP double %q", s)
+		}
+		*d = OTLPDouble(f)
+	}
+	return nil
+}
+
+// OTLPArrayValue is a list of values.
+type OTLPArrayValue struct {
+	Values []OTLPAnyValue `json:"values"`
+}
+
+// OTLPKeyValueList is a list of attributes, used for nested objects.
+type OTLPKeyValueList struct {
+	Values []OTLPKeyValue `json:"values"`
+}
+
+// OTLP converts the observed logs to OTLP, attaching the given resource
+// attributes (such as "service.name"). Entries are grouped into scopes by
+// logger name, in order of first appearance.
+//
+// Levels map to severities as in ladcore.OTelSeverity, fields become
+// attributes, and the caller and stack trace use the OpenTelemetry semantic
+// conventions' code.* attributes. Hex-encoded "trace_id" and "span_id"
+// fields, like those added by ladotel, populate the record's trace context.
+func (o *ObservedLogs) OTLP(resource map[string]interface{}) *OTLPLogs {
+	var scopes []OTLPScopeLogs
+	index := make(map[string]int) // logger name to scope
+	for _, e := range o.All() {
+		i, ok := index[e.LoggerName]
+		if !ok {
+			i = len(scopes)
+			index[e.LoggerName] = i
+			scopes = append(scopes, OTLPScopeLogs{Scope: OTLPScope{Name: e.LoggerName}})
+		}
+		scopes[i].LogRecords = append(scopes[i].LogRecords, e.otlpRecord())
+	}
+
+	return &OTLPLogs{
+		ResourceLogs: []OTLPResourceLogs{{
+			Resource:  OTLPResource{Attributes: otlpAttributes(resource)},
+			ScopeLogs: scopes,
+		}},
+	}
+}
+
+// WriteOTLPFile writes the observed logs to path as OTLP/JSON, in the
+// format of the collector's file exporter, so tests can compare it with
+// what a collector received or feed it to other tools.
+func (o *ObservedLogs) WriteOTLPFile(path string, resource map[string]interface{}) (retErr error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := f.Close(); retErr == nil {
+			retErr = err
+		}
+	}()
+	return o.OTLP(resource).WriteJSON(f)
+}
+
+// WriteJSON writes the logs to w as a single line of OTLP/JSON.
+func (l *OTLPLogs) WriteJSON(w io.Writer) error {
+	return json.NewEncoder(w).Encode(l)
+}
+
+func (e LoggedEntry) otlpRecord() OTLPLogRecord {
+	fields := e.ContextMap()
+	rec := OTLPLogRecord{
+		SeverityNumber: ladcore.OTelSeverity.Number(e.Level),
+		SeverityText:   ladcore.OTelSeverity.Text(e.Level),
+		Body:           otlpValue(e.Message),
+	}
+	if !e.Time.IsZero() {
+		rec.TimeUnixNano = strconv.FormatInt(e.Time.UnixNano(), 10)
+	}
+	if id, ok := fields["trace_id"].(string); ok {
+		rec.TraceID = id
+		delete(fields, "trace_id")
+	}
+	if id, ok := fields["span_id"].(string); ok {
+		rec.SpanID = id
+		delete(fields, "span_id")
+	}
+	if e.Caller.Defined {
+		fields["code.filepath"] = e.Caller.File
+		fields["code.lineno"] = int64(e.Caller.Line)
+		if e.Caller.Function != "" {
+			fields["code.function"] = e.Caller.Function
+		}
+	}
+	if e.Stack != "" {
+		fields["code.stacktrace"] = e.Stack
+	}
+	rec.Attributes = otlpAttributes(fields)
+	return rec
+}
+
+// otlpAttributes converts a map to attributes, sorted by key.
+func otlpAttributes(m map[string]interface{}) []OTLPKeyValue {
+	if len(m) == 0 {
+		return nil
+	}
+	kvs := make([]OTLPKeyValue, 0, len(m))
+	for k, v := range m {
+		kvs = append(kvs, OTLPKeyValue{Key: k, Value: otlpValue(v)})
+	}
+	sort.Slice(kvs, func(i, j int) bool { return kvs[i].Key < kvs[j].Key })
+	return kvs
+}
+
+// otlpValue converts a value produced by ladcore.MapObjectEncoder.
+func otlpValue(v interface{}) OTLPAnyValue {
+	str := func(s string) OTLPAnyValue { return OTLPAnyValue{StringValue: &s} }
+	integer := func(i int64) OTLPAnyValue {
+		s := strconv.FormatInt(i, 10)
+		return OTLPAnyValue{IntValue: &s}
+	}
+	double := func(f float64) OTLPAnyValue {
+		d := OTLPDouble(f)
+		return OTLPAnyValue{DoubleValue: &d}
+	}
+
+	switch v := v.(type) {
+	case string:
+		return str(v)
+	case []byte:
+		return OTLPAnyValue{BytesValue: v}
+	case bool:
+		return OTLPAnyValue{BoolValue: &v}
+	case int:
+		return integer(int64(v))
+	case int8:
+		return integer(int64(v))
+	case int16:
+		return integer(int64(v))
+	case int32:
+		return integer(int64(v))
+	case int64:
+		return integer(v)
+	case uint8:
+		return integer(int64(v))
+	case uint16:
+		return integer(int64(v))
+	case uint32:
+		return integer(int64(v))
+	case uint, uint64, uintptr:
+		// May not fit in an int64; OTLP has no unsigned type.
+		return str(fmt.Sprint(v))
+	case float32:
+		return double(float64(v))
+	case float64:
+		return double(v)
+	case time.Time:
+		return str(v.Format(time.RFC3339Nano))
+	case time.Duration:
+		return str(v.String())
+	case []interface{}:
+		arr := &OTLPArrayValue{Values: make([]OTLPAnyValue, len(v))}
+		for i := range v {
+			arr.Values[i] = otlpValue(v[i])
+		}
+		return OTLPAnyValue{ArrayValue: arr}
+	case map[string]interface{}:
+		kvs := otlpAttributes(v)
+		if kvs == nil {
+			kvs = []OTLPKeyValue{} // encode as [], not null
+		}
+		return OTLPAnyValue{KvlistValue: &OTLPKeyValueList{Values: kvs}}
+	default:
+		return str(fmt.Sprint(v))
+	}
+}
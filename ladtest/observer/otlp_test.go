@@ -0,0 +1,137 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package observer_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/auwixcom/lad"
+	"github.com/auwixcom/lad/ladcore"
+
+	//revive:disable:dot-imports
+	. "github.com/auwixcom/lad/ladtest/observer"
+)
+
+func TestOTLP(t *testing.T) {
+	core, logs := New(ladcore.DebugLevel)
+	logger := lad.New(core)
+	ts := time.Unix(1700000000, 5)
+
+	logger.Named("db").Check(lad.WarnLevel, "slow query").Write(
+		lad.Timestamp(ts),
+		lad.String("trace_id", "4bf92f3577b34da6a3ce929d0e0e4736"),
+		lad.String("span_id", "00f067aa0ba902b7"),
+		lad.Int("rows", 3),
+		lad.Bool("cached", false),
+		lad.Float64("ratio", 0.5),
+		lad.Uint64("big", 1<<63),
+		lad.Strings("tables", []string{"a", "b"}),
+		lad.Namespace("query"),
+		lad.Duration("took", time.Second),
+	)
+	logger.Info("started")
+	logger.Named("db").Info("connected")
+
+	out := logs.OTLP(map[string]interface{}{"service.name": "api"})
+	require.Len(t, out.ResourceLogs, 1, "Expected one resource.")
+	rl := out.ResourceLogs[0]
+	assert.Equal(t, []OTLPKeyValue{{Key: "service.name", Value: strValue("api")}}, rl.Resource.Attributes, "Unexpected resource.")
+
+	require.Len(t, rl.ScopeLogs, 2, "Expected a scope per logger name.")
+	assert.Equal(t, "db", rl.ScopeLogs[0].Scope.Name, "Unexpected first scope.")
+	assert.Equal(t, "", rl.ScopeLogs[1].Scope.Name, "Unexpected second scope.")
+	require.Len(t, rl.ScopeLogs[0].LogRecords, 2, "Unexpected records in the db scope.")
+
+	rec := rl.ScopeLogs[0].LogRecords[0]
+	assert.Equal(t, "1700000000000000005", rec.TimeUnixNano, "Unexpected time.")
+	assert.Equal(t, 13, rec.SeverityNumber, "Unexpected severity number.")
+	assert.Equal(t, "WARN", rec.SeverityText, "Unexpected severity text.")
+	assert.Equal(t, strValue("slow query"), rec.Body, "Unexpected body.")
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", rec.TraceID, "Unexpected trace ID.")
+	assert.Equal(t, "00f067aa0ba902b7", rec.SpanID, "Unexpected span ID.")
+
+	f := OTLPDouble(0.5)
+	b := false
+	assert.Equal(t, []OTLPKeyValue{
+		{Key: "big", Value: strValue("9223372036854775808")},
+		{Key: "cached", Value: OTLPAnyValue{BoolValue: &b}},
+		{Key: "query", Value: OTLPAnyValue{KvlistValue: &OTLPKeyValueList{Values: []OTLPKeyValue{
+			{Key: "took", Value: strValue("1s")},
+		}}}},
+		{Key: "ratio", Value: OTLPAnyValue{DoubleValue: &f}},
+		{Key: "rows", Value: intValue("3")},
+		{Key: "tables", Value: OTLPAnyValue{ArrayValue: &OTLPArrayValue{Values: []OTLPAnyValue{strValue("a"), strValue("b")}}}},
+	}, rec.Attributes, "Unexpected attributes.")
+}
+
+func TestWriteOTLPFile(t *testing.T) {
+	core, logs := New(ladcore.DebugLevel)
+	lad.New(core, lad.AddCaller()).Error("boom")
+
+	path := filepath.Join(t.TempDir(), "logs.json")
+	require.NoError(t, logs.WriteOTLPFile(path, nil), "Unexpected error writing file.")
+	contents, err := os.ReadFile(path)
+	require.NoError(t, err, "Failed to read file.")
+
+	assert.Regexp(t, `^\{"resourceLogs":\[\{"resource":\{\},"scopeLogs":\[\{"scope":\{\},"logRecords":\[\{`+
+		`"timeUnixNano":"\d+","severityNumber":17,"severityText":"ERROR","body":\{"stringValue":"boom"\},`+
+		`"attributes":\[\{"key":"code.filepath","value":\{"stringValue":".+/otlp_test.go"\}\},`+
+		`\{"key":"code.function","value":\{"stringValue":".+TestWriteOTLPFile"\}\},`+
+		`\{"key":"code.lineno","value":\{"intValue":"\d+"\}\}\]\}\]\}\]\}\]\}\n$`, string(contents), "Unexpected OTLP/JSON.")
+}
+
+func TestOTLPSpecialValues(t *testing.T) {
+	core, logs := New(ladcore.DebugLevel)
+	lad.New(core).Info("special",
+		lad.Float64("nan", math.NaN()),
+		lad.Float64("inf", math.Inf(1)),
+		lad.Float64("neginf", math.Inf(-1)),
+		lad.Binary("raw", []byte("hi")),
+	)
+
+	var buf bytes.Buffer
+	require.NoError(t, logs.OTLP(nil).WriteJSON(&buf), "Unexpected error encoding NaN and infinities.")
+	assert.Contains(t, buf.String(), `"attributes":[`+
+		`{"key":"inf","value":{"doubleValue":"Infinity"}},`+
+		`{"key":"nan","value":{"doubleValue":"NaN"}},`+
+		`{"key":"neginf","value":{"doubleValue":"-Infinity"}},`+
+		`{"key":"raw","value":{"bytesValue":"aGk="}}]`, "Unexpected OTLP/JSON.")
+
+	var decoded OTLPLogs
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded), "Unexpected error decoding.")
+	attrs := decoded.ResourceLogs[0].ScopeLogs[0].LogRecords[0].Attributes
+	assert.True(t, math.IsInf(float64(*attrs[0].Value.DoubleValue), 1), "Expected infinity to round-trip.")
+	assert.True(t, math.IsNaN(float64(*attrs[1].Value.DoubleValue)), "Expected NaN to round-trip.")
+	assert.Equal(t, []byte("hi"), attrs[3].Value.BytesValue, "Expected bytes to round-trip.")
+}
+
+func strValue(s string) OTLPAnyValue { return OTLPAnyValue{StringValue: &s} }
+
+func intValue(s string) OTLPAnyValue { return OTLPAnyValue{IntValue: &s} }
@@ -0,0 +1,125 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package observer
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/auwixcom/lad/ladcore"
+)
+
+// SimulationReport describes which entries a candidate sampling or rate
+// limiting configuration would keep when replayed over a captured stream of
+// logs. See Simulate.
+type SimulationReport struct {
+	Kept    int // entries the configuration would log
+	Dropped int // entries the configuration would drop
+
+	// Messages breaks the totals down by level and message, in the order
+	// each pair first appeared in the stream.
+	Messages []MessageSimulation
+}
+
+// MessageSimulation counts the entries with one level and message that a
+// simulated configuration would keep and drop.
+type MessageSimulation struct {
+	Level   ladcore.Level
+	Message string
+	Kept    int
+	Dropped int
+}
+
+// DropRate returns the fraction of entries that would be dropped, between 0
+// and 1.
+func (r *SimulationReport) DropRate() float64 {
+	if total := r.Kept + r.Dropped; total > 0 {
+		return float64(r.Dropped) / float64(total)
+	}
+	return 0
+}
+
+// String formats the report as a table, one row per level and message.
+func (r *SimulationReport) String() string {
+	var sb strings.Builder
+	w := tabwriter.NewWriter(&sb, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "LEVEL\tMESSAGE\tKEPT\tDROPPED")
+	for _, m := range r.Messages {
+		fmt.Fprintf(w, "%v\t%q\t%d\t%d\n", m.Level, m.Message, m.Kept, m.Dropped)
+	}
+	fmt.Fprintf(w, "\ttotal\t%d\t%d\n", r.Kept, r.Dropped)
+	_ = w.Flush()
+	return sb.String()
+}
+
+// Simulate replays entries, typically captured with an observer in a staging
+// environment, through the Core built by wrap and reports which of them it
+// would keep. Entries are replayed in order with their original times, so
+// time-based configurations behave as they would have when the entries were
+// logged.
+//
+//	report := observer.Simulate(logs.All(), func(c ladcore.Core) ladcore.Core {
+//	  return ladcore.NewSamplerWithOptions(c, time.Second, 10, 100)
+//	})
+//	fmt.Print(report)
+//
+// wrap is called once, with a Core that records what reaches it and enables
+// every level.
+func Simulate(entries []LoggedEntry, wrap func(ladcore.Core) ladcore.Core) *SimulationReport {
+	sink, kept := New(ladcore.DebugLevel)
+	core := wrap(sink)
+
+	type key struct {
+		level   ladcore.Level
+		message string
+	}
+	index := make(map[key]int)
+	report := &SimulationReport{}
+	for _, e := range entries {
+		k := key{e.Level, e.Message}
+		i, ok := index[k]
+		if !ok {
+			i = len(report.Messages)
+			index[k] = i
+			report.Messages = append(report.Messages, MessageSimulation{Level: e.Level, Message: e.Message})
+		}
+
+		before := kept.Len()
+		if ce := core.Check(e.Entry, nil); ce != nil {
+			ce.Write(e.Context...)
+		}
+		if kept.Len() > before {
+			report.Kept++
+			report.Messages[i].Kept++
+		} else {
+			report.Dropped++
+			report.Messages[i].Dropped++
+		}
+	}
+	return report
+}
+
+// Simulate replays the observed logs through the Core built by wrap. See the
+// package-level Simulate function for details.
+func (o *ObservedLogs) Simulate(wrap func(ladcore.Core) ladcore.Core) *SimulationReport {
+	return Simulate(o.All(), wrap)
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package observer_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/auwixcom/lad/ladcore"
+
+	//revive:disable:dot-imports
+	. "github.com/auwixcom/lad/ladtest/observer"
+)
+
+func TestSimulate(t *testing.T) {
+	start := time.Unix(0, 0)
+	var entries []LoggedEntry
+	for i := 0; i < 10; i++ {
+		at := start.Add(time.Duration(i) * 100 * time.Millisecond)
+		entries = append(entries,
+			LoggedEntry{Entry: ladcore.Entry{Level: ladcore.ErrorLevel, Message: "connection refused", Time: at}},
+			LoggedEntry{Entry: ladcore.Entry{Level: ladcore.InfoLevel, Message: "request", Time: at}},
+		)
+	}
+
+	report := Simulate(entries, func(c ladcore.Core) ladcore.Core {
+		return ladcore.NewRateLimitedCore(c, 1, 2, ladcore.KeyByMessages("connection refused"))
+	})
+	assert.Equal(t, []MessageSimulation{
+		{Level: ladcore.ErrorLevel, Message: "connection refused", Kept: 2, Dropped: 8},
+		{Level: ladcore.InfoLevel, Message: "request", Kept: 10},
+	}, report.Messages, "Unexpected per-message results.")
+	assert.Equal(t, 12, report.Kept, "Unexpected number of kept entries.")
+	assert.Equal(t, 8, report.Dropped, "Unexpected number of dropped entries.")
+	assert.Equal(t, 0.4, report.DropRate(), "Unexpected drop rate.")
+	assert.Equal(t,
+		"LEVEL  MESSAGE               KEPT  DROPPED\n"+
+			"error  \"connection refused\"  2     8\n"+
+			"info   \"request\"             10    0\n"+
+			"       total                 12    8\n",
+		report.String(), "Unexpected report table.")
+}
+
+func TestObservedLogsSimulate(t *testing.T) {
+	core, logs := New(ladcore.DebugLevel)
+	for i := 0; i < 5; i++ {
+		if ce := core.Check(ladcore.Entry{Level: ladcore.InfoLevel, Message: "hello", Time: time.Now()}, nil); ce != nil {
+			ce.Write()
+		}
+	}
+
+	report := logs.Simulate(func(c ladcore.Core) ladcore.Core {
+		return ladcore.NewSamplerWithOptions(c, time.Minute, 2, 0)
+	})
+	assert.Equal(t, 2, report.Kept, "Unexpected number of kept entries.")
+	assert.Equal(t, 3, report.Dropped, "Unexpected number of dropped entries.")
+	assert.Equal(t, 0.0, (&SimulationReport{}).DropRate(), "Expected empty report to drop nothing.")
+}
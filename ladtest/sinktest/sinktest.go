@@ -0,0 +1,343 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package sinktest provides conformance suites for WriteSyncer and Core
+// implementations. Authors of third-party sinks can run them from their own
+// tests to check that their implementations honor the contracts lad relies
+// on:
+//
+//	func TestMySink(t *testing.T) {
+//	  sinktest.TestWriteSyncer(t, func(t *testing.T) (ladcore.WriteSyncer, func() []byte) {
+//	    s := mysink.New(...)
+//	    return s, s.Contents
+//	  })
+//	}
+package sinktest // import "github.com/auwixcom/lad/ladtest/sinktest"
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/auwixcom/lad/ladcore"
+)
+
+const (
+	_goroutines         = 8
+	_writesPerGoroutine = 100
+)
+
+// WriteSyncerFactory builds a fresh WriteSyncer for a single check. read
+// returns everything that has reached the sink so far; for buffered sinks,
+// it's only called after Sync.
+type WriteSyncerFactory func(t *testing.T) (ws ladcore.WriteSyncer, read func() []byte)
+
+// A SuiteOption configures a conformance suite.
+type SuiteOption interface {
+	apply(*suiteOptions)
+}
+
+type suiteOptions struct {
+	skipConcurrency bool
+}
+
+type suiteOptionFunc func(*suiteOptions)
+
+func (f suiteOptionFunc) apply(o *suiteOptions) { f(o) }
+
+// SkipConcurrency skips the checks that use the sink from several goroutines
+// at once, for sinks that are documented as unsafe for concurrent use and
+// are expected to be wrapped with ladcore.Lock.
+func SkipConcurrency() SuiteOption {
+	return suiteOptionFunc(func(o *suiteOptions) {
+		o.skipConcurrency = true
+	})
+}
+
+// TestWriteSyncer runs the WriteSyncer conformance suite against the
+// WriteSyncers built by newWS. It checks that:
+//
+//   - writes are reported accurately: n never exceeds len(p), and short
+//     writes always return an error
+//   - writes don't retain the slices passed to them
+//   - Sync makes written data visible and can be called repeatedly
+//   - concurrent writes neither race nor interleave within a single Write
+//   - if the sink implements io.Closer, Close can be called more than once,
+//     and Writes after Close fail instead of panicking
+func TestWriteSyncer(t *testing.T, newWS WriteSyncerFactory, opts ...SuiteOption) {
+	var o suiteOptions
+	for _, opt := range opts {
+		opt.apply(&o)
+	}
+
+	t.Run("WriteAndSync", func(t *testing.T) {
+		ws, read := newWS(t)
+		want := writeLines(t, ws, "first", "second", "third")
+		if err := ws.Sync(); err != nil {
+			t.Fatalf("Sync failed: %v", err)
+		}
+		if got := string(read()); got != want {
+			t.Errorf("Unexpected contents after Sync:\n got: %q\nwant: %q", got, want)
+		}
+	})
+
+	t.Run("RepeatedSync", func(t *testing.T) {
+		ws, _ := newWS(t)
+		for i := 0; i < 3; i++ {
+			if err := ws.Sync(); err != nil {
+				t.Fatalf("Sync %d without writes failed: %v", i, err)
+			}
+		}
+	})
+
+	t.Run("EmptyWrite", func(t *testing.T) {
+		ws, _ := newWS(t)
+		n, err := ws.Write(nil)
+		if err != nil || n != 0 {
+			t.Errorf("Write(nil) = (%d, %v), want (0, nil)", n, err)
+		}
+	})
+
+	t.Run("NoRetain", func(t *testing.T) {
+		ws, read := newWS(t)
+		p := []byte("original\n")
+		if _, err := ws.Write(p); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+		copy(p, "mutated!\n")
+		if err := ws.Sync(); err != nil {
+			t.Fatalf("Sync failed: %v", err)
+		}
+		if got := string(read()); got != "original\n" {
+			t.Errorf("Sink retained the written slice: got %q", got)
+		}
+	})
+
+	if !o.skipConcurrency {
+		t.Run("Concurrency", func(t *testing.T) {
+			ws, read := newWS(t)
+
+			var wg sync.WaitGroup
+			for g := 0; g < _goroutines; g++ {
+				wg.Add(1)
+				go func(g int) {
+					defer wg.Done()
+					for i := 0; i < _writesPerGoroutine; i++ {
+						line := fmt.Sprintf("goroutine-%d-write-%d\n", g, i)
+						checkWrite(t, ws, []byte(line))
+					}
+				}(g)
+			}
+			wg.Wait()
+			if err := ws.Sync(); err != nil {
+				t.Fatalf("Sync failed: %v", err)
+			}
+
+			seen := make(map[string]bool)
+			for _, line := range strings.Split(strings.TrimSuffix(string(read()), "\n"), "\n") {
+				seen[line] = true
+			}
+			for g := 0; g < _goroutines; g++ {
+				for i := 0; i < _writesPerGoroutine; i++ {
+					if line := fmt.Sprintf("goroutine-%d-write-%d", g, i); !seen[line] {
+						t.Errorf("Missing or interleaved write %q.", line)
+						return
+					}
+				}
+			}
+		})
+	}
+
+	t.Run("CloseIdempotent", func(t *testing.T) {
+		ws, _ := newWS(t)
+		c, ok := ws.(io.Closer)
+		if !ok {
+			t.Skip("WriteSyncer doesn't implement io.Closer.")
+		}
+		if err := c.Close(); err != nil {
+			t.Fatalf("First Close failed: %v", err)
+		}
+		noPanic(t, "second Close", func() { _ = c.Close() })
+		noPanic(t, "Write after Close", func() { _, _ = ws.Write([]byte("late\n")) })
+		noPanic(t, "Sync after Close", func() { _ = ws.Sync() })
+	})
+}
+
+// CoreFactory builds a fresh Core enabled at enab for a single check. read
+// returns everything the core has written so far, and it's only called after
+// Sync. The suite only assumes that each entry's message and string field
+// values appear verbatim in the output.
+type CoreFactory func(t *testing.T, enab ladcore.LevelEnabler) (core ladcore.Core, read func() []byte)
+
+// TestCore runs the Core conformance suite against the cores built by
+// newCore. It checks that:
+//
+//   - Enabled and Check honor the core's level
+//   - entries added by Check are written, along with their fields
+//   - With adds fields without affecting the parent core
+//   - concurrent writes through the core and its children don't race
+func TestCore(t *testing.T, newCore CoreFactory, opts ...SuiteOption) {
+	var o suiteOptions
+	for _, opt := range opts {
+		opt.apply(&o)
+	}
+
+	t.Run("Levels", func(t *testing.T) {
+		core, read := newCore(t, ladcore.WarnLevel)
+		if core.Enabled(ladcore.InfoLevel) {
+			t.Error("Core enabled at WarnLevel reports InfoLevel as enabled.")
+		}
+		if !core.Enabled(ladcore.ErrorLevel) {
+			t.Error("Core enabled at WarnLevel reports ErrorLevel as disabled.")
+		}
+		write(core, ladcore.InfoLevel, "filtered-out")
+		write(core, ladcore.ErrorLevel, "kept-entry")
+		syncCore(t, core)
+
+		out := read()
+		if bytes.Contains(out, []byte("filtered-out")) {
+			t.Error("Core wrote an entry below its level.")
+		}
+		if !bytes.Contains(out, []byte("kept-entry")) {
+			t.Error("Core didn't write an enabled entry.")
+		}
+	})
+
+	t.Run("Fields", func(t *testing.T) {
+		core, read := newCore(t, ladcore.DebugLevel)
+		write(core, ladcore.InfoLevel, "with-fields", stringField("field-key", "field-value"))
+		syncCore(t, core)
+		if out := read(); !bytes.Contains(out, []byte("field-value")) {
+			t.Errorf("Core didn't write entry fields: %q", out)
+		}
+	})
+
+	t.Run("With", func(t *testing.T) {
+		core, read := newCore(t, ladcore.DebugLevel)
+		child := core.With([]ladcore.Field{stringField("context-key", "child-context")})
+		write(child, ladcore.InfoLevel, "from-child")
+		write(core, ladcore.InfoLevel, "from-parent")
+		syncCore(t, core)
+
+		out := string(read())
+		childLine, parentLine := lineContaining(out, "from-child"), lineContaining(out, "from-parent")
+		if !strings.Contains(childLine, "child-context") {
+			t.Errorf("Child core didn't include its context: %q", childLine)
+		}
+		if parentLine == "" {
+			t.Errorf("Parent core didn't write its entry: %q", out)
+		} else if strings.Contains(parentLine, "child-context") {
+			t.Errorf("With modified the parent core: %q", parentLine)
+		}
+	})
+
+	if !o.skipConcurrency {
+		t.Run("Concurrency", func(t *testing.T) {
+			core, read := newCore(t, ladcore.DebugLevel)
+
+			var wg sync.WaitGroup
+			for g := 0; g < _goroutines; g++ {
+				wg.Add(1)
+				go func(g int) {
+					defer wg.Done()
+					c := core.With([]ladcore.Field{stringField("goroutine", fmt.Sprint(g))})
+					for i := 0; i < _writesPerGoroutine; i++ {
+						write(c, ladcore.InfoLevel, fmt.Sprintf("concurrent-%d-%d", g, i))
+					}
+				}(g)
+			}
+			wg.Wait()
+			syncCore(t, core)
+
+			out := string(read())
+			for g := 0; g < _goroutines; g++ {
+				for i := 0; i < _writesPerGoroutine; i++ {
+					if msg := fmt.Sprintf("concurrent-%d-%d", g, i); lineContaining(out, msg) == "" {
+						t.Errorf("Missing concurrent entry %q.", msg)
+						return
+					}
+				}
+			}
+		})
+	}
+}
+
+func writeLines(t *testing.T, ws ladcore.WriteSyncer, lines ...string) string {
+	var sb strings.Builder
+	for _, line := range lines {
+		p := []byte(line + "\n")
+		checkWrite(t, ws, p)
+		sb.Write(p)
+	}
+	return sb.String()
+}
+
+// checkWrite writes p and checks that the result honors the io.Writer
+// contract.
+func checkWrite(t *testing.T, ws ladcore.WriteSyncer, p []byte) {
+	n, err := ws.Write(p)
+	switch {
+	case n < 0 || n > len(p):
+		t.Errorf("Write(%q) reported %d bytes written, want 0 to %d.", p, n, len(p))
+	case n < len(p) && err == nil:
+		t.Errorf("Write(%q) reported a short write (%d bytes) without an error.", p, n)
+	case err != nil:
+		t.Errorf("Write(%q) failed: %v", p, err)
+	}
+}
+
+func noPanic(t *testing.T, desc string, f func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			t.Errorf("%s panicked: %v", desc, r)
+		}
+	}()
+	f()
+}
+
+func stringField(key, val string) ladcore.Field {
+	return ladcore.Field{Key: key, Type: ladcore.StringType, String: val}
+}
+
+func write(core ladcore.Core, lvl ladcore.Level, msg string, fields ...ladcore.Field) {
+	ent := ladcore.Entry{Level: lvl, Time: time.Now(), Message: msg}
+	if ce := core.Check(ent, nil); ce != nil {
+		ce.Write(fields...)
+	}
+}
+
+func syncCore(t *testing.T, core ladcore.Core) {
+	if err := core.Sync(); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+}
+
+func lineContaining(out, s string) string {
+	for _, line := range strings.Split(out, "\n") {
+		if strings.Contains(line, s) {
+			return line
+		}
+	}
+	return ""
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package sinktest_test
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/auwixcom/lad/ladcore"
+	"github.com/auwixcom/lad/ladtest"
+	"github.com/auwixcom/lad/ladtest/sinktest"
+)
+
+// lockedBuffer is a ladtest.Buffer that's safe to read while being written.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf ladtest.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) Sync() error { return nil }
+
+func (b *lockedBuffer) Bytes() []byte {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]byte(nil), b.buf.Bytes()...)
+}
+
+func TestLockedWriteSyncer(t *testing.T) {
+	sinktest.TestWriteSyncer(t, func(t *testing.T) (ladcore.WriteSyncer, func() []byte) {
+		buf := &lockedBuffer{}
+		return ladcore.Lock(buf), buf.Bytes
+	})
+}
+
+func TestBufferedWriteSyncer(t *testing.T) {
+	sinktest.TestWriteSyncer(t, func(t *testing.T) (ladcore.WriteSyncer, func() []byte) {
+		buf := &lockedBuffer{}
+		ws := &ladcore.BufferedWriteSyncer{WS: buf}
+		t.Cleanup(func() { _ = ws.Stop() })
+		return ws, buf.Bytes
+	})
+}
+
+func TestFileWriteSyncer(t *testing.T) {
+	sinktest.TestWriteSyncer(t, func(t *testing.T) (ladcore.WriteSyncer, func() []byte) {
+		f, err := os.Create(filepath.Join(t.TempDir(), "log"))
+		if err != nil {
+			t.Fatalf("Failed to create file: %v", err)
+		}
+		t.Cleanup(func() { _ = f.Close() })
+		return f, func() []byte {
+			contents, err := os.ReadFile(f.Name())
+			if err != nil {
+				t.Fatalf("Failed to read file: %v", err)
+			}
+			return contents
+		}
+	})
+}
+
+func TestUnlockedWriteSyncer(t *testing.T) {
+	sinktest.TestWriteSyncer(t, func(t *testing.T) (ladcore.WriteSyncer, func() []byte) {
+		buf := &ladtest.Buffer{}
+		return buf, buf.Bytes
+	}, sinktest.SkipConcurrency())
+}
+
+func TestIOCore(t *testing.T) {
+	sinktest.TestCore(t, func(t *testing.T, enab ladcore.LevelEnabler) (ladcore.Core, func() []byte) {
+		buf := &lockedBuffer{}
+		enc := ladcore.NewJSONEncoder(ladcore.EncoderConfig{MessageKey: "msg"})
+		return ladcore.NewCore(enc, buf, enab), buf.Bytes
+	})
+}
+
+func TestTeeCore(t *testing.T) {
+	sinktest.TestCore(t, func(t *testing.T, enab ladcore.LevelEnabler) (ladcore.Core, func() []byte) {
+		buf := &lockedBuffer{}
+		enc := ladcore.NewConsoleEncoder(ladcore.EncoderConfig{MessageKey: "msg"})
+		return ladcore.NewTee(
+			ladcore.NewCore(enc, buf, enab),
+			ladcore.NewNopCore(),
+		), buf.Bytes
+	})
+}
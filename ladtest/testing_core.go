@@ -0,0 +1,174 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladtest
+
+import (
+	"sync"
+
+	"github.com/auwixcom/lad/ladcore"
+	"github.com/auwixcom/lad/ladtest/observer"
+)
+
+// A WriteError records an entry that a TestingCore failed to write.
+type WriteError struct {
+	Entry observer.LoggedEntry
+	Err   error
+}
+
+// A SyncCall records a call to a TestingCore's Sync.
+type SyncCall struct {
+	// Entries are the entries successfully written since the previous call
+	// to Sync, which the call was meant to flush.
+	Entries []observer.LoggedEntry
+	// Err is the error Sync returned, if any.
+	Err error
+}
+
+// TestingCore is a ladcore.Core for testing error handling. It records the
+// entries it writes, and fails writes and syncs on demand, keeping the
+// errors and the entries they affected as values to assert against. This
+// avoids wiring a FailWriter into a real Core and parsing the logger's error
+// output.
+//
+//	core := ladtest.NewTestingCore(lad.DebugLevel)
+//	core.SetWriteError(errors.New("disk full"))
+//	logger := lad.New(core, lad.ErrorOutput(ladcore.AddSync(io.Discard)))
+//	logger.Info("hello")
+//	// core.WriteErrors() now holds the "hello" entry and the error.
+//
+// Cores derived with With share their parent's records. TestingCore is safe
+// for concurrent use.
+type TestingCore struct {
+	ladcore.LevelEnabler
+
+	s       *testingCoreState
+	context []ladcore.Field
+}
+
+var _ ladcore.Core = (*TestingCore)(nil)
+
+type testingCoreState struct {
+	mu        sync.Mutex
+	writeErr  error
+	syncErr   error
+	written   []observer.LoggedEntry
+	unsynced  []observer.LoggedEntry
+	writeErrs []WriteError
+	syncs     []SyncCall
+}
+
+// NewTestingCore creates a TestingCore enabled at the given level.
+func NewTestingCore(enab ladcore.LevelEnabler) *TestingCore {
+	return &TestingCore{
+		LevelEnabler: enab,
+		s:            &testingCoreState{},
+	}
+}
+
+// SetWriteError makes subsequent writes fail with err. Pass nil to make
+// them succeed again.
+func (c *TestingCore) SetWriteError(err error) {
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+	c.s.writeErr = err
+}
+
+// SetSyncError makes subsequent syncs fail with err. Pass nil to make them
+// succeed again.
+func (c *TestingCore) SetSyncError(err error) {
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+	c.s.syncErr = err
+}
+
+// Entries returns the entries written successfully, in order.
+func (c *TestingCore) Entries() []observer.LoggedEntry {
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+	return append([]observer.LoggedEntry(nil), c.s.written...)
+}
+
+// WriteErrors returns the failed writes, in order.
+func (c *TestingCore) WriteErrors() []WriteError {
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+	return append([]WriteError(nil), c.s.writeErrs...)
+}
+
+// Syncs returns the calls to Sync, in order.
+func (c *TestingCore) Syncs() []SyncCall {
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+	return append([]SyncCall(nil), c.s.syncs...)
+}
+
+// Level returns the minimum enabled level of the TestingCore.
+func (c *TestingCore) Level() ladcore.Level {
+	return ladcore.LevelOf(c.LevelEnabler)
+}
+
+// With adds structured context to the TestingCore. The returned Core shares
+// this one's records.
+func (c *TestingCore) With(fields []ladcore.Field) ladcore.Core {
+	return &TestingCore{
+		LevelEnabler: c.LevelEnabler,
+		s:            c.s,
+		context:      append(c.context[:len(c.context):len(c.context)], fields...),
+	}
+}
+
+// Check adds the TestingCore to the CheckedEntry if it's enabled at the
+// entry's level.
+func (c *TestingCore) Check(ent ladcore.Entry, ce *ladcore.CheckedEntry) *ladcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write records the entry, or fails if SetWriteError is in effect.
+func (c *TestingCore) Write(ent ladcore.Entry, fields []ladcore.Field) error {
+	all := make([]ladcore.Field, 0, len(c.context)+len(fields))
+	all = append(all, c.context...)
+	all = append(all, fields...)
+	logged := observer.LoggedEntry{Entry: ent, Context: all}
+
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+	if err := c.s.writeErr; err != nil {
+		c.s.writeErrs = append(c.s.writeErrs, WriteError{Entry: logged, Err: err})
+		return err
+	}
+	c.s.written = append(c.s.written, logged)
+	c.s.unsynced = append(c.s.unsynced, logged)
+	return nil
+}
+
+// Sync records the call, along with the entries written since the previous
+// one, and fails if SetSyncError is in effect.
+func (c *TestingCore) Sync() error {
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+	err := c.s.syncErr
+	c.s.syncs = append(c.s.syncs, SyncCall{Entries: c.s.unsynced, Err: err})
+	c.s.unsynced = nil
+	return err
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ladtest
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/auwixcom/lad"
+	"github.com/auwixcom/lad/ladcore"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTestingCore(t *testing.T) {
+	core := NewTestingCore(lad.InfoLevel)
+	logger := lad.New(core, lad.ErrorOutput(ladcore.AddSync(io.Discard))).With(lad.String("k", "v"))
+	assert.Equal(t, lad.InfoLevel, ladcore.LevelOf(core), "Unexpected level.")
+
+	logger.Debug("disabled")
+	logger.Info("first")
+	require.NoError(t, logger.Sync(), "Unexpected error syncing.")
+
+	failed := errors.New("disk full")
+	core.SetWriteError(failed)
+	logger.Info("lost", lad.Int("n", 1))
+	core.SetWriteError(nil)
+	logger.Info("second")
+
+	syncFailed := errors.New("sync failed")
+	core.SetSyncError(syncFailed)
+	assert.Equal(t, syncFailed, logger.Sync(), "Expected the configured sync error.")
+
+	errs := core.WriteErrors()
+	require.Len(t, errs, 1, "Expected one failed write.")
+	assert.Equal(t, failed, errs[0].Err, "Unexpected write error.")
+	assert.Equal(t, "lost", errs[0].Entry.Message, "Unexpected failed entry.")
+	assert.Equal(t, map[string]interface{}{"k": "v", "n": int64(1)}, errs[0].Entry.ContextMap(), "Expected context and log-site fields.")
+
+	var msgs []string
+	for _, e := range core.Entries() {
+		msgs = append(msgs, e.Message)
+	}
+	assert.Equal(t, []string{"first", "second"}, msgs, "Unexpected written entries.")
+
+	syncs := core.Syncs()
+	require.Len(t, syncs, 2, "Expected two syncs.")
+	assert.NoError(t, syncs[0].Err, "Unexpected error from the first sync.")
+	assert.Equal(t, syncFailed, syncs[1].Err, "Unexpected error from the second sync.")
+	if assert.Len(t, syncs[0].Entries, 1, "Expected the first sync to cover one entry.") {
+		assert.Equal(t, "first", syncs[0].Entries[0].Message, "Unexpected synced entry.")
+	}
+	if assert.Len(t, syncs[1].Entries, 1, "Expected failed writes not to be synced.") {
+		assert.Equal(t, "second", syncs[1].Entries[0].Message, "Unexpected synced entry.")
+	}
+}
@@ -24,11 +24,14 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"runtime"
 	"strings"
 
 	"github.com/auwixcom/lad/internal/bufferpool"
 	"github.com/auwixcom/lad/internal/stacktrace"
 	"github.com/auwixcom/lad/ladcore"
+
+	"go.uber.org/multierr"
 )
 
 // A Logger provides fast, leveled, structured logging. All methods are safe
@@ -43,15 +46,30 @@ type Logger struct {
 
 	development bool
 	addCaller   bool
+	addFunction bool
 	onPanic     ladcore.CheckWriteHook // default is WriteThenPanic
 	onFatal     ladcore.CheckWriteHook // default is WriteThenFatal
 
 	name        string
+	namedFields map[string][]Field // see NamedFields
 	errorOutput ladcore.WriteSyncer
 
-	addStack ladcore.LevelEnabler
+	addStack          ladcore.LevelEnabler
+	stackDepth        int      // see StacktraceDepth
+	stackSkipPackages []string // see StacktraceSkipPackages
+
+	callerSkip         int
+	callerSkipPackages []string // see AddCallerSkipPackages
+
+	contextExtractors []ContextExtractor // see WithContextExtractors
 
-	callerSkip int
+	events     *events // see WithEvents
+	eventIDKey string  // see WithEventIDs
+
+	ids ladcore.IDGenerator // see WithIDGenerator
+
+	sugarStrict   bool   // see StrictKeyValues
+	sugarErrorKey string // see SugarErrorKey
 
 	clock ladcore.Clock
 }
@@ -145,7 +163,7 @@ func NewExample(options ...Option) *Logger {
 // between them on the boundaries of performance-sensitive code.
 func (log *Logger) Sugar() *SugaredLogger {
 	core := log.clone()
-	core.callerSkip += 2
+	core.callerSkip += sugarCallerSkip
 	return &SugaredLogger{core}
 }
 
@@ -161,6 +179,12 @@ func (log *Logger) Named(s string) *Logger {
 	} else {
 		l.name = strings.Join([]string{l.name, s}, ".")
 	}
+	if fs := l.namedFields[l.name]; len(fs) > 0 {
+		l.core = l.core.With(fs)
+		if l.events != nil {
+			l.events = l.events.with(fs)
+		}
+	}
 	return l
 }
 
@@ -183,6 +207,9 @@ func (log *Logger) With(fields ...Field) *Logger {
 	}
 	l := log.clone()
 	l.core = l.core.With(fields)
+	if l.events != nil {
+		l.events = l.events.with(fields)
+	}
 	return l
 }
 
@@ -203,9 +230,12 @@ func (log *Logger) WithLazy(fields ...Field) *Logger {
 	if len(fields) == 0 {
 		return log
 	}
-	return log.WithOptions(WrapCore(func(core ladcore.Core) ladcore.Core {
-		return ladcore.NewLazyWith(core, fields)
-	}))
+	l := log.clone()
+	l.core = ladcore.NewLazyWith(l.core, fields)
+	if l.events != nil {
+		l.events = l.events.withLazy(fields)
+	}
+	return l
 }
 
 // Level reports the minimum enabled level for this logger.
@@ -240,6 +270,27 @@ func (log *Logger) Debug(msg string, fields ...Field) {
 	}
 }
 
+// LogFields logs a message at the specified level with the fields returned
+// by fn. Unlike Log, it only calls fn if the entry will be written, so it
+// costs close to nothing at disabled levels:
+//
+//	logger.LogFields(lad.DebugLevel, "cache state", func() []lad.Field {
+//	  return []lad.Field{lad.Any("entries", cache.Snapshot())}
+//	})
+func (log *Logger) LogFields(lvl ladcore.Level, msg string, fn func() []Field) {
+	if ce := log.check(lvl, msg); ce != nil {
+		ce.Write(fn()...)
+	}
+}
+
+// DebugFields logs a message at DebugLevel with the fields returned by fn,
+// which is only called if the entry will be written. See LogFields.
+func (log *Logger) DebugFields(msg string, fn func() []Field) {
+	if ce := log.check(DebugLevel, msg); ce != nil {
+		ce.Write(fn()...)
+	}
+}
+
 // Info logs a message at InfoLevel. The message includes any fields passed
 // at the log site, as well as any fields accumulated on the logger.
 func (log *Logger) Info(msg string, fields ...Field) {
@@ -299,7 +350,12 @@ func (log *Logger) Fatal(msg string, fields ...Field) {
 
 // Sync calls the underlying Core's Sync method, flushing any buffered log
 // entries. Applications should take care to call Sync before exiting.
+//
+// If the Logger has an event core, it's synced too.
 func (log *Logger) Sync() error {
+	if log.events != nil {
+		return multierr.Append(log.core.Sync(), log.events.core.Sync())
+	}
 	return log.core.Sync()
 }
 
@@ -314,6 +370,13 @@ func (log *Logger) Name() string {
 	return log.name
 }
 
+func (log *Logger) idGenerator() ladcore.IDGenerator {
+	if log.ids == nil {
+		return ladcore.ULIDGenerator{Clock: log.clock}
+	}
+	return log.ids
+}
+
 func (log *Logger) clone() *Logger {
 	clone := *log
 	return &clone
@@ -366,14 +429,14 @@ func (log *Logger) check(lvl ladcore.Level, msg string) *ladcore.CheckedEntry {
 	ce.ErrorOutput = log.errorOutput
 
 	addStack := log.addStack.Enabled(ce.Level)
-	if !log.addCaller && !addStack {
+	if !log.addCaller && !log.addFunction && !addStack {
 		return ce
 	}
 
 	// Adding the caller or stack trace requires capturing the callers of
 	// this function. We'll share information between these two.
 	stackDepth := stacktrace.First
-	if addStack {
+	if addStack || len(log.callerSkipPackages) > 0 {
 		stackDepth = stacktrace.Full
 	}
 	stack := stacktrace.Capture(log.callerSkip+callerSkipOffset, stackDepth)
@@ -392,6 +455,9 @@ func (log *Logger) check(lvl ladcore.Level, msg string) *ladcore.CheckedEntry {
 	}
 
 	frame, more := stack.Next()
+	for more && log.skipsCallerFrame(frame) {
+		frame, more = stack.Next()
+	}
 
 	if log.addCaller {
 		ce.Caller = ladcore.EntryCaller{
@@ -401,6 +467,12 @@ func (log *Logger) check(lvl ladcore.Level, msg string) *ladcore.CheckedEntry {
 			Line:     frame.Line,
 			Function: frame.Function,
 		}
+	} else if log.addFunction {
+		// Leave the caller undefined so that only the function is encoded.
+		ce.Caller = ladcore.EntryCaller{
+			PC:       frame.PC,
+			Function: frame.Function,
+		}
 	}
 
 	if addStack {
@@ -409,11 +481,15 @@ func (log *Logger) check(lvl ladcore.Level, msg string) *ladcore.CheckedEntry {
 
 		stackfmt := stacktrace.NewFormatter(buffer)
 
-		// We've already extracted the first frame, so format that
-		// separately and defer to stackfmt for the rest.
-		stackfmt.FormatFrame(frame)
-		if more {
-			stackfmt.FormatStack(stack)
+		if log.stackDepth == 0 && len(log.stackSkipPackages) == 0 {
+			// We've already extracted the first frame, so format that
+			// separately and defer to stackfmt for the rest.
+			stackfmt.FormatFrame(frame)
+			if more {
+				stackfmt.FormatStack(stack)
+			}
+		} else {
+			log.formatStack(&stackfmt, frame, more, stack)
 		}
 		ce.Stack = buffer.String()
 	}
@@ -421,6 +497,56 @@ func (log *Logger) check(lvl ladcore.Level, msg string) *ladcore.CheckedEntry {
 	return ce
 }
 
+// formatStack formats the stack trace starting at first, leaving out frames
+// from the packages registered with StacktraceSkipPackages and stopping
+// after StacktraceDepth frames. Like Formatter.FormatStack, it omits the
+// final runtime frame.
+func (log *Logger) formatStack(stackfmt *stacktrace.Formatter, first runtime.Frame, more bool, stack *stacktrace.Stack) {
+	var n int
+	for frame := first; log.stackDepth == 0 || n < log.stackDepth; {
+		if !log.skipsStackFrame(frame) {
+			stackfmt.FormatFrame(frame)
+			n++
+		}
+		if !more {
+			return
+		}
+		if frame, more = stack.Next(); !more {
+			return
+		}
+	}
+}
+
+// skipsStackFrame reports whether frame belongs to one of the packages
+// registered with StacktraceSkipPackages, or to a package nested under them.
+func (log *Logger) skipsStackFrame(frame runtime.Frame) bool {
+	if len(log.stackSkipPackages) == 0 {
+		return false
+	}
+	pkg := stacktrace.PackagePath(frame.Function)
+	for _, p := range log.stackSkipPackages {
+		if pkg == p || (strings.HasPrefix(pkg, p) && pkg[len(p)] == '/') {
+			return true
+		}
+	}
+	return false
+}
+
+// skipsCallerFrame reports whether frame belongs to one of the packages
+// registered with AddCallerSkipPackages.
+func (log *Logger) skipsCallerFrame(frame runtime.Frame) bool {
+	if len(log.callerSkipPackages) == 0 {
+		return false
+	}
+	pkg := stacktrace.PackagePath(frame.Function)
+	for _, p := range log.callerSkipPackages {
+		if p == pkg {
+			return true
+		}
+	}
+	return false
+}
+
 func terminalHookOverride(defaultHook, override ladcore.CheckWriteHook) ladcore.CheckWriteHook {
 	// A nil or WriteThenNoop hook will lead to continued execution after
 	// a Panic or Fatal log entry, which is unexpected. For example,
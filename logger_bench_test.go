@@ -359,3 +359,19 @@ func BenchmarkAny(b *testing.B) {
 		})
 	}
 }
+
+func BenchmarkDisabledDebugFields(b *testing.B) {
+	logger := New(ladcore.NewCore(
+		ladcore.NewJSONEncoder(NewProductionConfig().EncoderConfig),
+		&ztest.Discarder{},
+		InfoLevel,
+	))
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			logger.DebugFields("Debug message.", func() []Field {
+				return []Field{Object("user", _jane)}
+			})
+		}
+	})
+}
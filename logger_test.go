@@ -24,9 +24,11 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/auwixcom/lad/internal/exit"
 	"github.com/auwixcom/lad/internal/ztest"
@@ -548,6 +550,36 @@ func TestLoggerNoOpsDisabledLevels(t *testing.T) {
 	})
 }
 
+func TestLoggerLogFields(t *testing.T) {
+	withLogger(t, InfoLevel, nil, func(logger *Logger, logs *observer.ObservedLogs) {
+		var calls int
+		fields := func() []Field {
+			calls++
+			return []Field{Int("calls", calls)}
+		}
+
+		logger.DebugFields("debug", fields)
+		logger.LogFields(DebugLevel, "debug", fields)
+		assert.Equal(t, 0, calls, "Expected fields not to be built at disabled levels.")
+		assert.Equal(t, 0, logs.Len(), "Expected no output at disabled levels.")
+
+		logger.LogFields(WarnLevel, "warn", fields)
+		assert.Equal(t, 1, calls, "Expected fields to be built once at enabled levels.")
+		assert.Equal(t, []observer.LoggedEntry{{
+			Entry:   ladcore.Entry{Level: WarnLevel, Message: "warn"},
+			Context: []Field{Int("calls", 1)},
+		}}, logs.AllUntimed(), "Unexpected output.")
+	})
+
+	withLogger(t, DebugLevel, nil, func(logger *Logger, logs *observer.ObservedLogs) {
+		logger.DebugFields("debug", func() []Field { return []Field{String("k", "v")} })
+		assert.Equal(t, []observer.LoggedEntry{{
+			Entry:   ladcore.Entry{Level: DebugLevel, Message: "debug"},
+			Context: []Field{String("k", "v")},
+		}}, logs.AllUntimed(), "Unexpected output.")
+	})
+}
+
 func TestLoggerNames(t *testing.T) {
 	tests := []struct {
 		names    []string
@@ -587,6 +619,35 @@ func TestLoggerNames(t *testing.T) {
 	}
 }
 
+func TestLoggerNamedFields(t *testing.T) {
+	fieldOpts := opts(
+		NamedFields(map[string][]Field{
+			"payments":    {String("component", "payments")},
+			"payments.db": {String("db", "primary")},
+		}),
+		NamedFields(map[string][]Field{
+			"payments": {Int("team", 7)},
+		}),
+	)
+	withLogger(t, DebugLevel, fieldOpts, func(logger *Logger, logs *observer.ObservedLogs) {
+		logger.Named("payments").Named("db").Info("nested")
+		logger.Named("payments.db").Info("dotted")
+		logger.Named("other").Info("unnamed")
+		logger.Info("root")
+
+		var contexts [][]Field
+		for _, e := range logs.AllUntimed() {
+			contexts = append(contexts, e.Context)
+		}
+		assert.Equal(t, [][]Field{
+			{String("component", "payments"), Int("team", 7), String("db", "primary")},
+			{String("db", "primary")},
+			{},
+			{},
+		}, contexts, "Unexpected fields added by name.")
+	})
+}
+
 func TestLoggerWriteFailure(t *testing.T) {
 	errSink := &ztest.Buffer{}
 	logger := New(
@@ -656,6 +717,161 @@ func TestLoggerAddCaller(t *testing.T) {
 	}
 }
 
+func TestLoggerAddFunction(t *testing.T) {
+	tests := []struct {
+		desc    string
+		options []Option
+		defined bool
+	}{
+		{"function only", opts(AddFunction()), false},
+		{"with caller", opts(AddFunction(), AddCaller()), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			withLogger(t, DebugLevel, tt.options, func(logger *Logger, logs *observer.ObservedLogs) {
+				logger.Info("")
+				output := logs.AllUntimed()
+				require.Len(t, output, 1, "Unexpected number of logs written out.")
+				caller := output[0].Caller
+				assert.Equal(t, "github.com/auwixcom/lad.TestLoggerAddFunction.func1.1", caller.Function, "Unexpected function.")
+				assert.Equal(t, tt.defined, caller.Defined, "Unexpected caller definition.")
+				if !tt.defined {
+					assert.Empty(t, caller.File, "Expected no file without AddCaller.")
+					assert.Zero(t, caller.Line, "Expected no line without AddCaller.")
+				}
+			})
+		})
+	}
+}
+
+func TestLoggerAddCallerSkipPackages(t *testing.T) {
+	const helperPkg = "github.com/auwixcom/lad/internal/ztest"
+	tests := []struct {
+		desc    string
+		options []Option
+		depth   int
+		pat     string
+	}{
+		{"no packages", opts(AddCaller()), 0, `.+/internal/ztest/helper.go:[\d]+$`},
+		{"helper", opts(AddCaller(), AddCallerSkipPackages(helperPkg)), 0, `.+/logger_test.go:[\d]+$`},
+		{"nested helpers", opts(AddCaller(), AddCallerSkipPackages("unused", helperPkg)), 3, `.+/logger_test.go:[\d]+$`},
+		{"unrelated package", opts(AddCaller(), AddCallerSkipPackages("github.com/auwixcom/lad/other")), 0, `.+/internal/ztest/helper.go:[\d]+$`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			withLogger(t, DebugLevel, tt.options, func(logger *Logger, logs *observer.ObservedLogs) {
+				ztest.InfoFromHelper(logger.Sugar(), tt.depth, "msg")
+				output := logs.AllUntimed()
+				require.Len(t, output, 1, "Unexpected number of logs written out.")
+				assert.Regexp(t, tt.pat, output[0].Caller, "Unexpected caller.")
+			})
+		})
+	}
+}
+
+func TestLoggerStacktraceFiltering(t *testing.T) {
+	tests := []struct {
+		desc     string
+		options  []Option
+		frames   int // zero to not check
+		prefix   string
+		excluded string
+	}{
+		{
+			desc:   "unfiltered",
+			prefix: "github.com/auwixcom/lad.TestLoggerStacktraceFiltering",
+		},
+		{
+			desc:    "depth",
+			options: opts(StacktraceDepth(1)),
+			frames:  1,
+			prefix:  "github.com/auwixcom/lad.TestLoggerStacktraceFiltering",
+		},
+		{
+			desc:     "skip packages",
+			options:  opts(StacktraceSkipPackages("testing")),
+			prefix:   "github.com/auwixcom/lad.TestLoggerStacktraceFiltering",
+			excluded: "testing.tRunner",
+		},
+		{
+			desc:     "skip nested packages",
+			options:  opts(StacktraceSkipPackages("github.com/auwixcom/")),
+			prefix:   "testing.tRunner",
+			excluded: "github.com/auwixcom/lad",
+		},
+		{
+			desc:     "depth after skipping",
+			options:  opts(StacktraceSkipPackages("github.com/auwixcom/lad"), StacktraceDepth(1)),
+			frames:   1,
+			prefix:   "testing.tRunner",
+			excluded: "github.com/auwixcom/lad",
+		},
+		{
+			desc:    "negative depth",
+			options: opts(StacktraceDepth(-1)),
+			prefix:  "github.com/auwixcom/lad.TestLoggerStacktraceFiltering",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			options := append(opts(AddStacktrace(InfoLevel)), tt.options...)
+			withLogger(t, DebugLevel, options, func(logger *Logger, logs *observer.ObservedLogs) {
+				logger.Info("msg")
+				output := logs.AllUntimed()
+				require.Len(t, output, 1, "Unexpected number of logs written out.")
+				stack := output[0].Stack
+				assert.True(t, strings.HasPrefix(stack, tt.prefix), "Unexpected first frame in stack:\n%s", stack)
+				if tt.frames > 0 {
+					assert.Equal(t, 2*tt.frames, strings.Count(stack, "\n")+1, "Unexpected number of frames in stack:\n%s", stack)
+				}
+				if tt.excluded != "" {
+					assert.NotContains(t, stack, tt.excluded, "Expected frames to be left out.")
+				}
+			})
+		})
+	}
+}
+
+func TestLoggerAddCallerSkipPackagesStack(t *testing.T) {
+	withLogger(t, DebugLevel, opts(AddStacktrace(InfoLevel), AddCallerSkipPackages("github.com/auwixcom/lad/internal/ztest")), func(logger *Logger, logs *observer.ObservedLogs) {
+		ztest.InfoFromHelper(logger.Sugar(), 1, "msg")
+		output := logs.AllUntimed()
+		require.Len(t, output, 1, "Unexpected number of logs written out.")
+		assert.Regexp(t, `^github.com/auwixcom/lad.TestLoggerAddCallerSkipPackagesStack`, output[0].Stack, "Expected helper frames to be skipped in the stack trace.")
+	})
+}
+
+func TestLoggerTimestampField(t *testing.T) {
+	clock := ztest.NewMockClock()
+	now := clock.Now()
+	then := now.Add(-time.Hour)
+
+	withLogger(t, DebugLevel, opts(WithClock(clock)), func(logger *Logger, logs *observer.ObservedLogs) {
+		fields := []Field{String("k", "v"), Timestamp(then)}
+		logger.Info("replaced", fields...)
+		logger.Info("preserved", TimestampWithEmitTime(then, "logged_at"))
+		logger.With(Timestamp(then)).Info("ignored in With")
+		logger.Sugar().Infow("sugared", Timestamp(then))
+
+		entries := logs.All()
+		require.Len(t, entries, 4, "Unexpected number of entries.")
+
+		assert.True(t, then.Equal(entries[0].Time), "Expected the entry time to be replaced.")
+		assert.Equal(t, map[string]interface{}{"k": "v"}, entries[0].ContextMap(), "Expected the timestamp field to be hidden.")
+		assert.Equal(t, Timestamp(then), fields[1], "Expected the caller's fields to be unchanged.")
+
+		assert.True(t, then.Equal(entries[1].Time), "Expected the entry time to be replaced.")
+		loggedAt, ok := entries[1].ContextMap()["logged_at"].(time.Time)
+		require.True(t, ok, "Expected the emit time to be preserved.")
+		assert.True(t, now.Equal(loggedAt), "Unexpected emit time.")
+
+		assert.True(t, now.Equal(entries[2].Time), "Expected timestamps in With to be ignored.")
+		assert.Empty(t, entries[2].ContextMap(), "Expected the timestamp field to be hidden.")
+
+		assert.True(t, then.Equal(entries[3].Time), "Expected the sugared logger to support timestamps.")
+	})
+}
+
 func TestLoggerAddCallerFunction(t *testing.T) {
 	tests := []struct {
 		options         []Option
@@ -786,6 +1002,21 @@ func TestLoggerIncreaseLevel(t *testing.T) {
 	})
 }
 
+func TestLoggerFatalJournal(t *testing.T) {
+	journal := &ztest.Buffer{}
+	withLogger(t, InfoLevel, opts(FatalJournal(journal)), func(logger *Logger, logs *observer.ObservedLogs) {
+		logger = logger.With(String("k", "v"))
+		logger.Error("not journaled")
+		assert.Panics(t, func() { logger.Panic("wedged") }, "Expected Panic to panic.")
+
+		assert.Equal(t, 2, logs.Len(), "Expected the core to write both entries.")
+		lines := journal.Lines()
+		if assert.Len(t, lines, 1, "Expected only the panic in the journal.") {
+			assert.Contains(t, lines[0], `"msg":"wedged","k":"v"`, "Unexpected journal entry.")
+		}
+	})
+}
+
 func TestLoggerHooks(t *testing.T) {
 	hook, seen := makeCountingHook()
 	withLogger(t, DebugLevel, opts(Hooks(hook)), func(logger *Logger, logs *observer.ObservedLogs) {
@@ -795,6 +1026,26 @@ func TestLoggerHooks(t *testing.T) {
 	assert.Equal(t, int64(2), seen.Load(), "Hook saw an unexpected number of logs.")
 }
 
+func TestLoggerFieldHooks(t *testing.T) {
+	var seen []map[string]interface{}
+	hook := func(ent ladcore.Entry, fields []Field) error {
+		enc := ladcore.NewMapObjectEncoder()
+		for _, f := range fields {
+			f.AddTo(enc)
+		}
+		seen = append(seen, enc.Fields)
+		return nil
+	}
+	withLogger(t, InfoLevel, opts(FieldHooks(hook)), func(logger *Logger, logs *observer.ObservedLogs) {
+		logger = logger.With(String("tenant", "acme"))
+		logger.Debug("disabled", Int("n", 0))
+		logger.Info("charged", Int("cents", 100))
+	})
+	assert.Equal(t, []map[string]interface{}{
+		{"tenant": "acme", "cents": int64(100)},
+	}, seen, "Hook saw unexpected fields.")
+}
+
 func TestLoggerConcurrent(t *testing.T) {
 	withLogger(t, DebugLevel, nil, func(logger *Logger, logs *observer.ObservedLogs) {
 		child := logger.With(String("foo", "bar"))
@@ -22,6 +22,7 @@ package lad
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/auwixcom/lad/ladcore"
 )
@@ -49,8 +50,8 @@ func WrapCore(f func(ladcore.Core) ladcore.Core) Option {
 // out an Entry. Repeated use of Hooks is additive.
 //
 // Hooks are useful for simple side effects, like capturing metrics for the
-// number of emitted logs. More complex side effects, including anything that
-// requires access to the Entry's structured fields, should be implemented as
+// number of emitted logs. Side effects that need the Entry's structured
+// fields can use FieldHooks; anything more complex should be implemented as
 // a ladcore.Core instead. See ladcore.RegisterHooks for details.
 func Hooks(hooks ...func(ladcore.Entry) error) Option {
 	return optionFunc(func(log *Logger) {
@@ -58,6 +59,15 @@ func Hooks(hooks ...func(ladcore.Entry) error) Option {
 	})
 }
 
+// FieldHooks is like Hooks, but the functions also receive the Entry's
+// structured fields, including those added with With. Repeated use of
+// FieldHooks is additive. See ladcore.RegisterFieldHooks for details.
+func FieldHooks(hooks ...func(ladcore.Entry, []Field) error) Option {
+	return optionFunc(func(log *Logger) {
+		log.core = ladcore.RegisterFieldHooks(log.core, hooks...)
+	})
+}
+
 // Fields adds fields to the Logger.
 func Fields(fs ...Field) Option {
 	return optionFunc(func(log *Logger) {
@@ -65,6 +75,28 @@ func Fields(fs ...Field) Option {
 	})
 }
 
+// NamedFields adds fields to the loggers that Named creates, keyed by their
+// full, period-separated names. For example, the following tags everything
+// logged through log.Named("payments") and its descendants:
+//
+//	lad.NamedFields(map[string][]lad.Field{
+//	  "payments": {lad.String("component", "payments")},
+//	})
+//
+// Repeated use of NamedFields is additive.
+func NamedFields(fields map[string][]Field) Option {
+	return optionFunc(func(log *Logger) {
+		merged := make(map[string][]Field, len(log.namedFields)+len(fields))
+		for name, fs := range log.namedFields {
+			merged[name] = fs
+		}
+		for name, fs := range fields {
+			merged[name] = append(merged[name][:len(merged[name]):len(merged[name])], fs...)
+		}
+		log.namedFields = merged
+	})
+}
+
 // ErrorOutput sets the destination for errors generated by the Logger. Note
 // that this option only affects internal errors; for sample code that sends
 // error-level logs to a different location from info- and debug-level logs,
@@ -78,6 +110,25 @@ func ErrorOutput(w ladcore.WriteSyncer) Option {
 	})
 }
 
+// FatalJournal writes Panic- and Fatal-level entries, as JSON lines, to a
+// pre-opened journal before the Logger's core gets to write them, so that
+// the reason the process died survives even if the core's pipeline is
+// wedged. Open the journal up front, in append mode:
+//
+//	f, err := os.OpenFile("/var/log/app/fatal.json", os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+//	// ...
+//	logger := lad.New(core, lad.FatalJournal(f))
+//
+// The journal is locked, so it needn't be safe for concurrent use. See
+// ladcore.NewJournalCore for details.
+func FatalJournal(w ladcore.WriteSyncer) Option {
+	journal := ladcore.Lock(w)
+	return WrapCore(func(core ladcore.Core) ladcore.Core {
+		enc := ladcore.NewJSONEncoder(NewProductionEncoderConfig())
+		return ladcore.NewJournalCore(core, enc, journal)
+	})
+}
+
 // Development puts the logger in development mode, which makes DPanic-level
 // logs panic instead of simply logging an error.
 func Development() Option {
@@ -101,6 +152,20 @@ func WithCaller(enabled bool) Option {
 	})
 }
 
+// AddFunction configures the Logger to annotate each message with the
+// function name of lad's caller, without its filename and line number. The
+// JSON and MessagePack encoders log the function under
+// EncoderConfig.FunctionKey, or under ladcore.DefaultFunctionKey if that's
+// empty; the console encoder logs it where the caller would go.
+//
+// AddCaller takes precedence. With it, the function name is logged only if
+// EncoderConfig.FunctionKey is set, as usual.
+func AddFunction() Option {
+	return optionFunc(func(log *Logger) {
+		log.addFunction = true
+	})
+}
+
 // AddCallerSkip increases the number of callers skipped by caller annotation
 // (as enabled by the AddCaller option). When building wrappers around the
 // Logger and SugaredLogger, supplying this Option prevents lad from always
@@ -111,6 +176,52 @@ func AddCallerSkip(skip int) Option {
 	})
 }
 
+// AddCallerSkipPackages registers logging helper packages, by import path,
+// whose frames are skipped when annotating entries with the caller (and when
+// capturing stack traces). It's applied after AddCallerSkip, and lets shared
+// logging wrappers report their callers without counting frames: however
+// deeply the helpers call each other, the first frame outside them is used.
+//
+// Only frames directly above the logger are skipped; a helper package that
+// appears further up the stack is reported as usual.
+func AddCallerSkipPackages(pkgs ...string) Option {
+	return optionFunc(func(log *Logger) {
+		n := len(log.callerSkipPackages)
+		log.callerSkipPackages = append(log.callerSkipPackages[:n:n], pkgs...)
+	})
+}
+
+// WithIDGenerator sets the IDGenerator the Logger uses for the identifiers it
+// generates, such as event IDs. Defaults to a ladcore.ULIDGenerator, so IDs
+// sort by the time they were generated.
+func WithIDGenerator(gen ladcore.IDGenerator) Option {
+	return optionFunc(func(log *Logger) {
+		log.ids = gen
+	})
+}
+
+// StrictKeyValues makes the SugaredLogger report malformed key-value pairs,
+// like orphaned keys, non-string keys, and extra unkeyed errors, at
+// DPanicLevel instead of ErrorLevel. In development, they panic, which
+// catches them in tests; in production, they're still logged and skipped.
+func StrictKeyValues() Option {
+	return optionFunc(func(log *Logger) {
+		log.sugarStrict = true
+	})
+}
+
+// SugarErrorKey sets the key under which the SugaredLogger logs errors passed
+// without a key, as in
+//
+//	sugar.Infow("request failed", err)
+//
+// Defaults to "error" if unspecified.
+func SugarErrorKey(key string) Option {
+	return optionFunc(func(log *Logger) {
+		log.sugarErrorKey = key
+	})
+}
+
 // AddStacktrace configures the Logger to record a stack trace for all messages at
 // or above a given level.
 func AddStacktrace(lvl ladcore.LevelEnabler) Option {
@@ -119,6 +230,35 @@ func AddStacktrace(lvl ladcore.LevelEnabler) Option {
 	})
 }
 
+// StacktraceDepth limits the stack traces recorded by AddStacktrace to the n
+// innermost frames, after any skipped with StacktraceSkipPackages. Zero or
+// less means no limit, which is the default.
+func StacktraceDepth(n int) Option {
+	return optionFunc(func(log *Logger) {
+		if n < 0 {
+			n = 0
+		}
+		log.stackDepth = n
+	})
+}
+
+// StacktraceSkipPackages leaves frames from the given packages, by import
+// path, out of the stack traces recorded by AddStacktrace, wherever they
+// appear in the stack. Packages nested under them are left out too, so
+// "github.com/labstack/echo" also covers "github.com/labstack/echo/middleware".
+// Use it to strip framework and middleware frames from deep stacks.
+//
+// Unlike AddCallerSkipPackages, it doesn't affect the reported caller.
+func StacktraceSkipPackages(prefixes ...string) Option {
+	return optionFunc(func(log *Logger) {
+		n := len(log.stackSkipPackages)
+		log.stackSkipPackages = log.stackSkipPackages[:n:n]
+		for _, p := range prefixes {
+			log.stackSkipPackages = append(log.stackSkipPackages, strings.TrimSuffix(p, "/"))
+		}
+	})
+}
+
 // IncreaseLevel increase the level of the logger. It has no effect if
 // the passed in level tries to decrease the level of the logger.
 func IncreaseLevel(lvl ladcore.LevelEnabler) Option {
@@ -0,0 +1,309 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lad
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+)
+
+const (
+	// _ringMagic identifies files written by a FileRing.
+	_ringMagic = "LADRING1"
+	// _ringHeaderSize is the size of a ring file's header: the magic, the
+	// number of slots, and the size of each slot.
+	_ringHeaderSize = len(_ringMagic) + 8
+	// _ringSlotHeaderSize is the size of each slot's header: the entry's
+	// sequence number and length.
+	_ringSlotHeaderSize = 12
+)
+
+// A RingStorage holds the most recent entries recorded by a CrashHandler,
+// each a single line of JSON. Implementations decide where the entries live
+// and so whether they outlive the process; they must be safe for concurrent
+// use.
+type RingStorage interface {
+	// Append stores a copy of entry, evicting the oldest entry if the
+	// storage is full.
+	Append(entry []byte) error
+	// Range calls fn with each stored entry, oldest first. fn must not
+	// retain the entries.
+	Range(fn func(entry []byte))
+}
+
+// NewMemoryRing creates a RingStorage that keeps the given number of
+// entries in memory. Its entries are lost with the process. This is the
+// storage a CrashHandler uses by default.
+func NewMemoryRing(size int) RingStorage {
+	if size <= 0 {
+		size = _defaultCrashRecent
+	}
+	return &memoryRing{entries: make([][]byte, 0, size)}
+}
+
+type memoryRing struct {
+	mu      sync.Mutex
+	entries [][]byte
+	next    int // next slot to overwrite once full
+}
+
+func (r *memoryRing) Append(entry []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.entries) < cap(r.entries) {
+		r.entries = append(r.entries, append([]byte(nil), entry...))
+		return nil
+	}
+	r.entries[r.next] = append(r.entries[r.next][:0], entry...)
+	r.next = (r.next + 1) % len(r.entries)
+	return nil
+}
+
+func (r *memoryRing) Range(fn func([]byte)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.entries {
+		// Once the ring is full, next is the oldest slot.
+		fn(r.entries[(r.next+i)%len(r.entries)])
+	}
+}
+
+// A FileRing is a RingStorage backed by a file of fixed-size slots. Where
+// the platform supports it, the file is memory-mapped, so appending costs
+// little more than a copy and entries reach the file even if the process is
+// killed. Crash-dump tooling can then recover them with ReadFileRing. Placing
+// the file on a shared-memory filesystem, like /dev/shm on Linux, keeps the
+// entries in memory for other processes to read without touching a disk.
+//
+// Each slot holds one entry; entries larger than a slot are rejected. A slot
+// is invalidated before it's overwritten, so an append interrupted by a
+// crash loses at most the entry it was replacing.
+type FileRing struct {
+	mu       sync.Mutex
+	f        *os.File
+	region   *fileRegion
+	slots    int
+	slotSize int
+	seq      uint64 // sequence number of the next entry
+}
+
+var _ RingStorage = (*FileRing)(nil)
+
+// OpenFileRing opens the ring file at path, creating it with the given
+// number of slots of slotSize bytes each if it doesn't exist. Each slot
+// needs 12 bytes of bookkeeping, so an entry can be at most slotSize-12
+// bytes long. An existing file must have the same geometry; its entries are
+// kept and appending continues after the newest of them.
+func OpenFileRing(path string, slots, slotSize int) (*FileRing, error) {
+	if slots <= 0 {
+		return nil, fmt.Errorf("ring must have at least one slot, got %d", slots)
+	}
+	if slotSize <= _ringSlotHeaderSize {
+		return nil, fmt.Errorf("ring slots must be larger than %d bytes, got %d", _ringSlotHeaderSize, slotSize)
+	}
+
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	size := _ringHeaderSize + slots*slotSize
+	r, err := openFileRing(f, size, slots, slotSize)
+	if err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("open ring %q: %w", path, err)
+	}
+	return r, nil
+}
+
+func openFileRing(f *os.File, size, slots, slotSize int) (*FileRing, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	fresh := info.Size() == 0
+	if fresh {
+		if err := f.Truncate(int64(size)); err != nil {
+			return nil, err
+		}
+	} else if info.Size() != int64(size) {
+		return nil, fmt.Errorf("file is %d bytes, want %d", info.Size(), size)
+	}
+
+	region, err := mapFile(f, size)
+	if err != nil {
+		return nil, err
+	}
+	r := &FileRing{f: f, region: region, slots: slots, slotSize: slotSize}
+
+	hdr := region.data[:_ringHeaderSize]
+	if fresh {
+		copy(hdr, _ringMagic)
+		binary.LittleEndian.PutUint32(hdr[len(_ringMagic):], uint32(slots))
+		binary.LittleEndian.PutUint32(hdr[len(_ringMagic)+4:], uint32(slotSize))
+		if err := region.flush(0, _ringHeaderSize); err != nil {
+			_ = region.close()
+			return nil, err
+		}
+		return r, nil
+	}
+
+	gotSlots, gotSize, err := parseRingHeader(region.data)
+	if err == nil && (gotSlots != slots || gotSize != slotSize) {
+		err = fmt.Errorf("ring has %d slots of %d bytes, want %d of %d", gotSlots, gotSize, slots, slotSize)
+	}
+	if err != nil {
+		_ = region.close()
+		return nil, err
+	}
+	for _, s := range ringSlots(region.data, slots, slotSize) {
+		if s.seq >= r.seq {
+			r.seq = s.seq + 1
+		}
+	}
+	return r, nil
+}
+
+// Append stores entry in the oldest slot.
+func (r *FileRing) Append(entry []byte) error {
+	if max := r.slotSize - _ringSlotHeaderSize; len(entry) > max {
+		return fmt.Errorf("entry of %d bytes exceeds ring slot capacity of %d", len(entry), max)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.region == nil {
+		return errors.New("ring is closed")
+	}
+	off := _ringHeaderSize + int(r.seq%uint64(r.slots))*r.slotSize
+	slot := r.region.data[off : off+r.slotSize]
+
+	// Invalidate the slot, then fill it in, then make it valid again.
+	binary.LittleEndian.PutUint32(slot[8:], 0)
+	if err := r.region.flush(off+8, 4); err != nil {
+		return err
+	}
+	binary.LittleEndian.PutUint64(slot, r.seq)
+	copy(slot[_ringSlotHeaderSize:], entry)
+	if err := r.region.flush(off, _ringSlotHeaderSize+len(entry)); err != nil {
+		return err
+	}
+	binary.LittleEndian.PutUint32(slot[8:], uint32(len(entry)))
+	if err := r.region.flush(off+8, 4); err != nil {
+		return err
+	}
+	r.seq++
+	return nil
+}
+
+// Range calls fn with each stored entry, oldest first.
+func (r *FileRing) Range(fn func([]byte)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.region == nil {
+		return
+	}
+	for _, s := range ringSlots(r.region.data, r.slots, r.slotSize) {
+		fn(s.entry)
+	}
+}
+
+// Close unmaps and closes the ring file. The entries stay in the file.
+func (r *FileRing) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.region == nil {
+		return nil
+	}
+	err := r.region.close()
+	r.region = nil
+	if cerr := r.f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// ReadFileRing returns the entries in the ring file at path, oldest first.
+// It's meant for crash-dump tooling recovering the last entries of a process
+// that has died.
+func ReadFileRing(path string) ([][]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	slots, slotSize, err := parseRingHeader(data)
+	if err != nil {
+		return nil, fmt.Errorf("read ring %q: %w", path, err)
+	}
+	if len(data) != _ringHeaderSize+slots*slotSize {
+		return nil, fmt.Errorf("read ring %q: file is truncated", path)
+	}
+	var entries [][]byte
+	for _, s := range ringSlots(data, slots, slotSize) {
+		entries = append(entries, s.entry)
+	}
+	return entries, nil
+}
+
+func parseRingHeader(data []byte) (slots, slotSize int, err error) {
+	if len(data) < _ringHeaderSize || !bytes.Equal(data[:len(_ringMagic)], []byte(_ringMagic)) {
+		return 0, 0, errors.New("not a ring file")
+	}
+	slots = int(binary.LittleEndian.Uint32(data[len(_ringMagic):]))
+	slotSize = int(binary.LittleEndian.Uint32(data[len(_ringMagic)+4:]))
+	if slots <= 0 || slotSize <= _ringSlotHeaderSize {
+		return 0, 0, errors.New("corrupt ring header")
+	}
+	return slots, slotSize, nil
+}
+
+type ringSlot struct {
+	seq   uint64
+	entry []byte
+}
+
+// ringSlots returns the valid slots of a ring file, oldest first.
+func ringSlots(data []byte, slots, slotSize int) []ringSlot {
+	var valid []ringSlot
+	for i := 0; i < slots; i++ {
+		off := _ringHeaderSize + i*slotSize
+		slot := data[off : off+slotSize]
+		n := int(binary.LittleEndian.Uint32(slot[8:]))
+		if n == 0 || n > slotSize-_ringSlotHeaderSize {
+			continue
+		}
+		valid = append(valid, ringSlot{
+			seq:   binary.LittleEndian.Uint64(slot),
+			entry: slot[_ringSlotHeaderSize : _ringSlotHeaderSize+n],
+		})
+	}
+	sort.Slice(valid, func(i, j int) bool { return valid[i].seq < valid[j].seq })
+	return valid
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lad
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/auwixcom/lad/internal/ztest"
+	"github.com/auwixcom/lad/ladcore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ringEntries(r RingStorage) []string {
+	var entries []string
+	r.Range(func(entry []byte) {
+		entries = append(entries, string(entry))
+	})
+	return entries
+}
+
+func TestMemoryRing(t *testing.T) {
+	r := NewMemoryRing(2)
+	assert.Empty(t, ringEntries(r), "Expected an empty ring.")
+	for _, e := range []string{"a", "b", "c"} {
+		require.NoError(t, r.Append([]byte(e)), "Unexpected error appending.")
+	}
+	assert.Equal(t, []string{"b", "c"}, ringEntries(r), "Expected the most recent entries, oldest first.")
+}
+
+func TestFileRing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ring")
+	r, err := OpenFileRing(path, 3, 32)
+	require.NoError(t, err, "Unexpected error opening ring.")
+
+	for _, e := range []string{"one", "two", "three", "four"} {
+		require.NoError(t, r.Append([]byte(e)), "Unexpected error appending.")
+	}
+	assert.ErrorContains(t, r.Append([]byte(strings.Repeat("x", 21))), "exceeds ring slot capacity of 20", "Expected oversized entries to be rejected.")
+	assert.Equal(t, []string{"two", "three", "four"}, ringEntries(r), "Unexpected entries.")
+
+	// Entries are recoverable without closing the ring, as after a crash.
+	recovered, err := ReadFileRing(path)
+	require.NoError(t, err, "Unexpected error reading ring.")
+	assert.Equal(t, [][]byte{[]byte("two"), []byte("three"), []byte("four")}, recovered, "Unexpected recovered entries.")
+	require.NoError(t, r.Close(), "Unexpected error closing ring.")
+	require.NoError(t, r.Close(), "Expected Close to be idempotent.")
+	assert.Error(t, r.Append([]byte("x")), "Expected appending to a closed ring to fail.")
+
+	// Reopening keeps the entries and appends after the newest.
+	r, err = OpenFileRing(path, 3, 32)
+	require.NoError(t, err, "Unexpected error reopening ring.")
+	require.NoError(t, r.Append([]byte("five")), "Unexpected error appending.")
+	assert.Equal(t, []string{"three", "four", "five"}, ringEntries(r), "Unexpected entries after reopening.")
+	require.NoError(t, r.Close(), "Unexpected error closing ring.")
+
+	_, err = OpenFileRing(path, 4, 32)
+	assert.Error(t, err, "Expected an error reopening with a different geometry.")
+}
+
+func TestFileRingErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := OpenFileRing(filepath.Join(dir, "a"), 0, 32)
+	assert.Error(t, err, "Expected an error without slots.")
+	_, err = OpenFileRing(filepath.Join(dir, "a"), 1, 12)
+	assert.Error(t, err, "Expected an error for tiny slots.")
+	_, err = OpenFileRing(filepath.Join(dir, "missing", "a"), 1, 32)
+	assert.Error(t, err, "Expected an error for a missing directory.")
+
+	junk := filepath.Join(dir, "junk")
+	require.NoError(t, os.WriteFile(junk, []byte("not a ring at all, honest"), 0o644), "Unexpected error writing file.")
+	_, err = ReadFileRing(junk)
+	assert.ErrorContains(t, err, "not a ring file", "Expected an error reading a non-ring file.")
+	_, err = OpenFileRing(junk, 1, 32)
+	assert.Error(t, err, "Expected an error opening a non-ring file.")
+	_, err = ReadFileRing(filepath.Join(dir, "missing"))
+	assert.Error(t, err, "Expected an error reading a missing file.")
+}
+
+func TestCrashHandlerFileRing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ring")
+	ring, err := OpenFileRing(path, 4, 512)
+	require.NoError(t, err, "Unexpected error opening ring.")
+	defer ring.Close()
+
+	out := &ztest.Buffer{}
+	h := &CrashHandler{Output: out, Storage: ring}
+	core := ladcore.NewCore(ladcore.NewJSONEncoder(NewProductionEncoderConfig()), &ztest.Discarder{}, DebugLevel)
+	logger := New(core, WrapCore(h.WrapCore))
+	logger.Info("one")
+	logger.Info("two")
+
+	recovered, err := ReadFileRing(path)
+	require.NoError(t, err, "Unexpected error reading ring.")
+	require.Len(t, recovered, 2, "Expected entries to be recoverable before a crash.")
+	assert.Contains(t, string(recovered[1]), `"msg":"two"`, "Unexpected recovered entry.")
+
+	h.Crash("boom")
+	ent := decodeCrash(t, out)
+	require.Len(t, ent.Recent, 2, "Expected the stored entries in the final entry.")
+	assert.Equal(t, "one", ent.Recent[0]["msg"], "Expected recent entries oldest first.")
+}
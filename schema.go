@@ -0,0 +1,219 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lad
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/auwixcom/lad/ladcore"
+)
+
+// Types reported in a Schema, named after their JSON Schema equivalents.
+const (
+	SchemaString  = "string"
+	SchemaInteger = "integer"
+	SchemaNumber  = "number"
+	SchemaBoolean = "boolean"
+	SchemaObject  = "object"
+	SchemaArray   = "array"
+	SchemaNull    = "null"
+)
+
+// A Schema describes the keys, and the JSON types of their values, that a
+// logger built from a Config writes. It's meant for generating index
+// templates, mappings, and dashboards from code, so that they can't drift
+// from the logging configuration; it marshals to JSON as-is.
+type Schema struct {
+	// Encoding is the Config's encoding. The types are those of the JSON
+	// encoder; the console encoder writes fields the same way, but not the
+	// entry's metadata.
+	Encoding string `json:"encoding"`
+	// Version is the Config's SchemaVersion, if any.
+	Version string `json:"version,omitempty"`
+	// Entry describes the keys the encoder writes for every entry's
+	// metadata, such as the time and message, in the order they're written.
+	Entry []SchemaField `json:"entry"`
+	// InitialFields describes the Config's InitialFields, sorted by key,
+	// followed by SchemaVersionKey if the Config has a SchemaVersion.
+	InitialFields []SchemaField `json:"initialFields,omitempty"`
+	// NamedFields describes the Config's NamedFields, by logger name.
+	NamedFields map[string][]SchemaField `json:"namedFields,omitempty"`
+}
+
+// A SchemaField describes a single key in a Schema.
+type SchemaField struct {
+	// Key is the key as it appears in the output.
+	Key string `json:"key"`
+	// Role identifies entry metadata, whatever its key has been renamed to:
+	// one of "time", "level", "name", "caller", "function", "message", or
+	// "stacktrace". It's empty for fields.
+	Role string `json:"role,omitempty"`
+	// Type is the JSON type of the value, like SchemaString. It's empty if the
+	// value couldn't be encoded.
+	Type string `json:"type"`
+}
+
+// Schema describes the output of a logger built from the Config. Types are
+// found by encoding representative values with the Config's EncoderConfig,
+// so custom time, level, and duration encoders are accounted for. Like
+// Explain, it doesn't open any outputs.
+func (cfg Config) Schema() (Schema, error) {
+	ec := cfg.EncoderConfig
+	// Make sure the probe entry has a message whatever the config says.
+	ec.OmitEmptyMessage = false
+
+	probe := ladcore.Entry{
+		Level:      InfoLevel,
+		Time:       time.Unix(1, 500000000).UTC(),
+		LoggerName: "probe",
+		Message:    "probe",
+		Caller:     ladcore.EntryCaller{Defined: true, File: "probe.go", Line: 1, Function: "probe"},
+		Stack:      "probe",
+	}
+	values, err := encodeProbe(ec, probe, nil)
+	if err != nil {
+		return Schema{}, err
+	}
+
+	s := Schema{Encoding: cfg.Encoding, Version: cfg.SchemaVersion}
+	for _, k := range []struct{ role, key string }{
+		{"time", ec.TimeKey},
+		{"level", ec.LevelKey},
+		{"name", ec.NameKey},
+		{"caller", ec.CallerKey},
+		{"function", ec.FunctionKey},
+		{"message", ec.MessageKey},
+		{"stacktrace", ec.StacktraceKey},
+	} {
+		if k.key == ladcore.OmitKey {
+			continue
+		}
+		s.Entry = append(s.Entry, SchemaField{Key: k.key, Role: k.role, Type: schemaType(values, k.key)})
+	}
+
+	if s.InitialFields, err = fieldSchema(ec, cfg.InitialFields); err != nil {
+		return Schema{}, err
+	}
+	if cfg.SchemaVersion != "" {
+		s.InitialFields = append(s.InitialFields, SchemaField{Key: SchemaVersionKey, Type: SchemaString})
+	}
+	if len(cfg.NamedFields) > 0 {
+		s.NamedFields = make(map[string][]SchemaField, len(cfg.NamedFields))
+		for name, fields := range cfg.NamedFields {
+			if s.NamedFields[name], err = fieldSchema(ec, fields); err != nil {
+				return Schema{}, err
+			}
+		}
+	}
+	return s, nil
+}
+
+// fieldSchema describes the fields that Config.Build makes from a map.
+func fieldSchema(ec ladcore.EncoderConfig, m map[string]interface{}) ([]SchemaField, error) {
+	if len(m) == 0 {
+		return nil, nil
+	}
+
+	// Only encode the fields.
+	ec.TimeKey, ec.LevelKey, ec.NameKey, ec.CallerKey = "", "", "", ""
+	ec.FunctionKey, ec.MessageKey, ec.StacktraceKey = "", "", ""
+
+	keys := sortedKeys(m)
+	fields := make([]Field, len(keys))
+	for i, k := range keys {
+		fields[i] = probeField(Any(k, m[k]))
+	}
+	values, err := encodeProbe(ec, ladcore.Entry{}, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	schema := make([]SchemaField, len(fields))
+	for i, f := range fields {
+		typ := schemaType(values, f.Key)
+		if typ == SchemaInteger && (f.Type == ladcore.Float64Type || f.Type == ladcore.Float32Type) {
+			// Whole floats are written without a fractional part.
+			typ = SchemaNumber
+		}
+		schema[i] = SchemaField{Key: f.Key, Type: typ}
+	}
+	return schema, nil
+}
+
+// probeField replaces times and durations with values that have fractional
+// seconds, so that encoders that write them as floats are reported as such.
+func probeField(f Field) Field {
+	switch f.Type {
+	case ladcore.TimeType, ladcore.TimeFullType:
+		return Time(f.Key, time.Unix(1, 500000000).UTC())
+	case ladcore.DurationType:
+		return Duration(f.Key, 1500*time.Millisecond)
+	}
+	return f
+}
+
+// encodeProbe encodes an entry with the JSON encoder and decodes the result.
+func encodeProbe(ec ladcore.EncoderConfig, ent ladcore.Entry, fields []Field) (map[string]interface{}, error) {
+	ec.SkipLineEnding = true
+	buf, err := ladcore.NewJSONEncoder(ec).EncodeEntry(ent, fields)
+	if err != nil {
+		return nil, err
+	}
+	defer buf.Free()
+
+	dec := json.NewDecoder(bytes.NewReader(buf.Bytes()))
+	dec.UseNumber()
+	var values map[string]interface{}
+	if err := dec.Decode(&values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// schemaType returns the JSON type of a decoded value, or an empty string if
+// it's missing.
+func schemaType(values map[string]interface{}, key string) string {
+	v, ok := values[key]
+	if !ok {
+		return ""
+	}
+	switch v := v.(type) {
+	case nil:
+		return SchemaNull
+	case string:
+		return SchemaString
+	case bool:
+		return SchemaBoolean
+	case json.Number:
+		if strings.ContainsAny(v.String(), ".eE") {
+			return SchemaNumber
+		}
+		return SchemaInteger
+	case map[string]interface{}:
+		return SchemaObject
+	case []interface{}:
+		return SchemaArray
+	}
+	return ""
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lad
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/auwixcom/lad/ladcore"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigSchema(t *testing.T) {
+	cfg := explainTestConfig()
+	cfg.InitialFields["ratio"] = 1.0
+	cfg.InitialFields["started"] = time.Unix(0, 0)
+	cfg.InitialFields["timeout"] = time.Second
+	cfg.InitialFields["tags"] = []string{"a"}
+	cfg.InitialFields["labels"] = map[string]interface{}{"k": "v"}
+
+	s, err := cfg.Schema()
+	require.NoError(t, err, "Unexpected error building schema.")
+	assert.Equal(t, "json", s.Encoding, "Unexpected encoding.")
+	assert.Equal(t, []SchemaField{
+		{Key: "ts", Role: "time", Type: SchemaNumber},
+		{Key: "level", Role: "level", Type: SchemaString},
+		{Key: "logger", Role: "name", Type: SchemaString},
+		{Key: "caller", Role: "caller", Type: SchemaString},
+		{Key: "msg", Role: "message", Type: SchemaString},
+		{Key: "stacktrace", Role: "stacktrace", Type: SchemaString},
+	}, s.Entry, "Unexpected entry keys.")
+	assert.Equal(t, []SchemaField{
+		{Key: "env", Type: SchemaString},
+		{Key: "labels", Type: SchemaObject},
+		{Key: "ratio", Type: SchemaNumber},
+		{Key: "service", Type: SchemaString},
+		{Key: "started", Type: SchemaNumber},
+		{Key: "tags", Type: SchemaArray},
+		{Key: "timeout", Type: SchemaNumber},
+	}, s.InitialFields, "Unexpected initial fields.")
+	assert.Equal(t, map[string][]SchemaField{
+		"payments.db": {{Key: "db", Type: SchemaString}},
+		"payments": {
+			{Key: "component", Type: SchemaString},
+			{Key: "team", Type: SchemaInteger},
+		},
+	}, s.NamedFields, "Unexpected named fields.")
+
+	_, err = json.Marshal(s)
+	assert.NoError(t, err, "Expected the schema to marshal to JSON.")
+}
+
+func TestConfigSchemaEncoders(t *testing.T) {
+	cfg := NewDevelopmentConfig()
+	cfg.EncoderConfig.TimeKey = "@timestamp"
+	cfg.EncoderConfig.EncodeTime = ladcore.EpochNanosTimeEncoder
+	cfg.EncoderConfig.EncodeDuration = ladcore.StringDurationEncoder
+	cfg.EncoderConfig.NameKey = ladcore.OmitKey
+	cfg.InitialFields = map[string]interface{}{
+		"started": time.Unix(0, 0),
+		"timeout": time.Second,
+	}
+
+	s, err := cfg.Schema()
+	require.NoError(t, err, "Unexpected error building schema.")
+	assert.Equal(t, SchemaField{Key: "@timestamp", Role: "time", Type: SchemaInteger}, s.Entry[0],
+		"Expected the renamed time key and the encoder's type.")
+	for _, f := range s.Entry {
+		assert.NotEqual(t, "name", f.Role, "Expected omitted keys to be left out.")
+	}
+	assert.Equal(t, []SchemaField{
+		{Key: "started", Type: SchemaInteger},
+		{Key: "timeout", Type: SchemaString},
+	}, s.InitialFields, "Unexpected initial fields.")
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lad
+
+import (
+	"errors"
+	"fmt"
+)
+
+// SchemaVersionKey is the key under which SchemaVersion stamps entries.
+const SchemaVersionKey = "schema_version"
+
+// SchemaVersion stamps every entry with the version of the application's log
+// schema, under SchemaVersionKey. Bump the version whenever fields are
+// renamed or change meaning, and register a Migration for the change, so
+// that consumers and replay tools can translate entries written by older
+// releases. It's most useful for logs that are kept for a long time, like
+// audit logs.
+func SchemaVersion(version string) Option {
+	return Fields(String(SchemaVersionKey, version))
+}
+
+// A Migration translates a decoded entry from one schema version to the
+// next. Migrate may modify the entry in place; the Migrator updates its
+// SchemaVersionKey afterwards.
+type Migration struct {
+	// From is the version the Migration applies to. Entries written without
+	// SchemaVersion have the empty version.
+	From string
+	// To is the version the Migration produces.
+	To string
+	// Migrate translates the entry.
+	Migrate func(entry map[string]interface{}) error
+}
+
+// RenameFields returns a Migration func that renames fields, mapping old
+// keys to new ones. Keys missing from the entry are skipped.
+func RenameFields(renames map[string]string) func(map[string]interface{}) error {
+	return func(entry map[string]interface{}) error {
+		for from, to := range renames {
+			if v, ok := entry[from]; ok {
+				delete(entry, from)
+				entry[to] = v
+			}
+		}
+		return nil
+	}
+}
+
+// ErrNoMigration is returned by Migrator.Migrate when an entry's version
+// can't be migrated to the target version.
+var ErrNoMigration = errors.New("no migration path")
+
+// A Migrator translates decoded entries between schema versions by chaining
+// Migrations. It's safe for concurrent use.
+type Migrator struct {
+	from map[string]Migration
+}
+
+// NewMigrator builds a Migrator from a set of Migrations, which must each
+// have a distinct From version.
+func NewMigrator(migrations ...Migration) (*Migrator, error) {
+	m := &Migrator{from: make(map[string]Migration, len(migrations))}
+	for _, mig := range migrations {
+		if mig.Migrate == nil {
+			return nil, fmt.Errorf("migration from %q to %q has no Migrate func", mig.From, mig.To)
+		}
+		if mig.From == mig.To {
+			return nil, fmt.Errorf("migration from %q to itself", mig.From)
+		}
+		if _, ok := m.from[mig.From]; ok {
+			return nil, fmt.Errorf("multiple migrations from %q", mig.From)
+		}
+		m.from[mig.From] = mig
+	}
+	return m, nil
+}
+
+// Migrate translates an entry, in place, to the target version by applying
+// Migrations in turn, starting from the entry's SchemaVersionKey. It returns
+// an error wrapping ErrNoMigration if there's no chain of Migrations to the
+// target, in which case the entry may have been partially migrated.
+func (m *Migrator) Migrate(entry map[string]interface{}, target string) error {
+	version, _ := entry[SchemaVersionKey].(string)
+	// Each Migration can only be applied once on the way to the target.
+	for steps := 0; version != target; steps++ {
+		mig, ok := m.from[version]
+		if !ok || steps == len(m.from) {
+			return fmt.Errorf("migrate from %q to %q: %w", version, target, ErrNoMigration)
+		}
+		if err := mig.Migrate(entry); err != nil {
+			return fmt.Errorf("migrate from %q to %q: %w", mig.From, mig.To, err)
+		}
+		version = mig.To
+		entry[SchemaVersionKey] = version
+	}
+	return nil
+}
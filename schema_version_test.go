@@ -0,0 +1,125 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lad
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/auwixcom/lad/ladcore"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	cfg := Config{
+		Level:            NewAtomicLevelAt(InfoLevel),
+		Encoding:         "json",
+		EncoderConfig:    ladcore.EncoderConfig{MessageKey: "msg"},
+		OutputPaths:      []string{path},
+		ErrorOutputPaths: []string{"stderr"},
+		SchemaVersion:    "3",
+	}
+	logger, err := cfg.Build()
+	require.NoError(t, err, "Unexpected error building logger.")
+	logger.Info("login", String("user", "alice"))
+	logger.With(String("k", "v")).Info("logout")
+	require.NoError(t, logger.Sync(), "Unexpected error syncing.")
+
+	out, err := os.ReadFile(path)
+	require.NoError(t, err, "Unexpected error reading log file.")
+	assert.Equal(t,
+		`{"msg":"login","schema_version":"3","user":"alice"}`+"\n"+
+			`{"msg":"logout","schema_version":"3","k":"v"}`+"\n",
+		string(out), "Expected every entry to be stamped.")
+
+	s, err := cfg.Schema()
+	require.NoError(t, err, "Unexpected error building schema.")
+	assert.Equal(t, "3", s.Version, "Unexpected schema version.")
+	assert.Equal(t, []SchemaField{{Key: SchemaVersionKey, Type: SchemaString}}, s.InitialFields, "Expected the stamp in the schema.")
+	assert.Contains(t, cfg.Explain(), `schema version: 3 (stamped as "schema_version")`, "Expected the version in the description.")
+}
+
+func TestMigrator(t *testing.T) {
+	m, err := NewMigrator(
+		Migration{From: "", To: "1", Migrate: RenameFields(map[string]string{"uid": "user_id"})},
+		Migration{From: "2", To: "3", Migrate: func(e map[string]interface{}) error {
+			e["actor"] = map[string]interface{}{"id": e["user_id"]}
+			delete(e, "user_id")
+			return nil
+		}},
+		Migration{From: "1", To: "2", Migrate: RenameFields(map[string]string{"missing": "ignored"})},
+	)
+	require.NoError(t, err, "Unexpected error building migrator.")
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"msg":"login","uid":"alice"}`), &entry), "Unexpected error decoding.")
+	require.NoError(t, m.Migrate(entry, "3"), "Unexpected error migrating.")
+	assert.Equal(t, map[string]interface{}{
+		"msg":            "login",
+		"actor":          map[string]interface{}{"id": "alice"},
+		SchemaVersionKey: "3",
+	}, entry, "Unexpected migrated entry.")
+	assert.NoError(t, m.Migrate(entry, "3"), "Expected migrating to the current version to be a no-op.")
+
+	err = m.Migrate(map[string]interface{}{SchemaVersionKey: "3"}, "4")
+	assert.ErrorIs(t, err, ErrNoMigration, "Expected an error without a path to the target.")
+	assert.ErrorContains(t, err, `migrate from "3" to "4"`, "Expected the error to name the versions.")
+
+	failed := errors.New("fail")
+	m, err = NewMigrator(Migration{From: "1", To: "2", Migrate: func(map[string]interface{}) error { return failed }})
+	require.NoError(t, err, "Unexpected error building migrator.")
+	assert.ErrorIs(t, m.Migrate(map[string]interface{}{SchemaVersionKey: "1"}, "2"), failed, "Expected Migrate errors.")
+}
+
+func TestMigratorCycle(t *testing.T) {
+	m, err := NewMigrator(
+		Migration{From: "a", To: "b", Migrate: RenameFields(nil)},
+		Migration{From: "b", To: "a", Migrate: RenameFields(nil)},
+	)
+	require.NoError(t, err, "Unexpected error building migrator.")
+	assert.ErrorIs(t, m.Migrate(map[string]interface{}{SchemaVersionKey: "a"}, "c"), ErrNoMigration,
+		"Expected cycles to stop.")
+}
+
+func TestNewMigratorErrors(t *testing.T) {
+	noop := RenameFields(nil)
+	tests := []struct {
+		desc       string
+		migrations []Migration
+		want       string
+	}{
+		{"missing func", []Migration{{From: "1", To: "2"}}, "has no Migrate func"},
+		{"self", []Migration{{From: "1", To: "1", Migrate: noop}}, "to itself"},
+		{"duplicate", []Migration{{From: "1", To: "2", Migrate: noop}, {From: "1", To: "3", Migrate: noop}}, "multiple migrations"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			_, err := NewMigrator(tt.migrations...)
+			assert.ErrorContains(t, err, tt.want, "Unexpected error.")
+		})
+	}
+}
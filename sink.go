@@ -151,9 +151,9 @@ func (sr *sinkRegistry) newFileSinkFromURL(u *url.URL) (Sink, error) {
 func (sr *sinkRegistry) newFileSinkFromPath(path string) (Sink, error) {
 	switch path {
 	case "stdout":
-		return nopCloserSink{os.Stdout}, nil
+		return nopCloserSink{ladcore.NewConsoleWriteSyncer(os.Stdout)}, nil
 	case "stderr":
-		return nopCloserSink{os.Stderr}, nil
+		return nopCloserSink{ladcore.NewConsoleWriteSyncer(os.Stderr)}, nil
 	}
 	return sr.openFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o666)
 }
@@ -22,6 +22,7 @@ package lad
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/auwixcom/lad/ladcore"
 
@@ -39,30 +40,42 @@ const (
 // method.
 //
 // Unlike the Logger, the SugaredLogger doesn't insist on structured logging.
-// For each log level, it exposes four methods:
+// For each log level, it exposes six methods:
 //
 //   - methods named after the log level for log.Print-style logging
 //   - methods ending in "w" for loosely-typed structured logging
+//   - methods ending in "m" for structured logging from a map
 //   - methods ending in "f" for log.Printf-style logging
+//   - methods ending in "fw" for log.Printf-style logging with structure
 //   - methods ending in "ln" for log.Println-style logging
 //
 // For example, the methods for InfoLevel are:
 //
 //	Info(...any)           Print-style logging
 //	Infow(...any)          Structured logging (read as "info with")
+//	Infom(string, map)     Structured logging from a map
 //	Infof(string, ...any)  Printf-style logging
+//	Infofw(string, ...any) Printf-style logging with structure
 //	Infoln(...any)         Println-style logging
 type SugaredLogger struct {
 	base *Logger
 }
 
+// sugarCallerSkip is the number of frames the SugaredLogger adds between its
+// caller and the Logger: the exported method and its unexported helper.
+const sugarCallerSkip = 2
+
 // Desugar unwraps a SugaredLogger, exposing the original Logger. Desugaring
 // is quite inexpensive, so it's reasonable for a single application to use
 // both Loggers and SugaredLoggers, converting between them on the boundaries
 // of performance-sensitive code.
+//
+// Caller skips added with WithOptions and AddCallerSkip survive the round
+// trip: they're kept when desugaring and re-sugaring, so wrappers that switch
+// between the two APIs still report their callers.
 func (s *SugaredLogger) Desugar() *Logger {
 	base := s.base.clone()
-	base.callerSkip -= 2
+	base.callerSkip -= sugarCallerSkip
 	return base
 }
 
@@ -72,7 +85,9 @@ func (s *SugaredLogger) Named(name string) *SugaredLogger {
 }
 
 // WithOptions clones the current SugaredLogger, applies the supplied Options,
-// and returns the result. It's safe to use concurrently.
+// and returns the result. It's safe to use concurrently. Unlike going through
+// Desugar and Sugar, it doesn't allocate an intermediate Logger, and any
+// AddCallerSkip it applies is kept by later calls to Desugar.
 func (s *SugaredLogger) WithOptions(opts ...Option) *SugaredLogger {
 	base := s.base.clone()
 	for _, opt := range opts {
@@ -112,7 +127,7 @@ func (s *SugaredLogger) WithOptions(opts ...Option) *SugaredLogger {
 // and execution continues. Passing an orphaned key triggers similar behavior:
 // panics in development and errors in production.
 func (s *SugaredLogger) With(args ...interface{}) *SugaredLogger {
-	return &SugaredLogger{base: s.base.With(s.sweetenFields(args)...)}
+	return &SugaredLogger{base: s.base.With(s.sweetenFields(args, 0)...)}
 }
 
 // WithLazy adds a variadic number of fields to the logging context lazily.
@@ -127,7 +142,23 @@ func (s *SugaredLogger) With(args ...interface{}) *SugaredLogger {
 // passing a non-string key panics, while in production it logs an error and skips the pair.
 // Passing an orphaned key has the same behavior.
 func (s *SugaredLogger) WithLazy(args ...interface{}) *SugaredLogger {
-	return &SugaredLogger{base: s.base.WithLazy(s.sweetenFields(args)...)}
+	return &SugaredLogger{base: s.base.WithLazy(s.sweetenFields(args, 0)...)}
+}
+
+// WithMap adds the entries of a map to the logging context, in key order.
+// Values are converted to fields as they are in With, so
+//
+//	sugaredLogger.WithMap(map[string]any{"user": "alice", "count": 42})
+//
+// is the equivalent of
+//
+//	sugaredLogger.With("count", 42, "user", "alice")
+//
+// Unlike alternating keys and values, a map can't have orphaned or non-string
+// keys, which makes it a better fit for call sites that already hold their
+// context in a map.
+func (s *SugaredLogger) WithMap(fields map[string]interface{}) *SugaredLogger {
+	return &SugaredLogger{base: s.base.With(mapFields(fields)...)}
 }
 
 // Level reports the minimum enabled level for this logger.
@@ -288,6 +319,123 @@ func (s *SugaredLogger) Fatalw(msg string, keysAndValues ...interface{}) {
 	s.log(FatalLevel, msg, nil, keysAndValues)
 }
 
+// Logm logs a message at the provided level with the entries of a map as
+// context. The map is treated as it is in WithMap.
+func (s *SugaredLogger) Logm(lvl ladcore.Level, msg string, fields map[string]interface{}) {
+	s.logm(lvl, msg, fields)
+}
+
+// Debugm logs a message with the entries of a map as context. The map is
+// treated as it is in WithMap.
+func (s *SugaredLogger) Debugm(msg string, fields map[string]interface{}) {
+	s.logm(DebugLevel, msg, fields)
+}
+
+// Infom logs a message with the entries of a map as context. The map is
+// treated as it is in WithMap.
+func (s *SugaredLogger) Infom(msg string, fields map[string]interface{}) {
+	s.logm(InfoLevel, msg, fields)
+}
+
+// Warnm logs a message with the entries of a map as context. The map is
+// treated as it is in WithMap.
+func (s *SugaredLogger) Warnm(msg string, fields map[string]interface{}) {
+	s.logm(WarnLevel, msg, fields)
+}
+
+// Errorm logs a message with the entries of a map as context. The map is
+// treated as it is in WithMap.
+func (s *SugaredLogger) Errorm(msg string, fields map[string]interface{}) {
+	s.logm(ErrorLevel, msg, fields)
+}
+
+// DPanicm logs a message with the entries of a map as context. In
+// development, the logger then panics. (See DPanicLevel for details.) The map
+// is treated as it is in WithMap.
+func (s *SugaredLogger) DPanicm(msg string, fields map[string]interface{}) {
+	s.logm(DPanicLevel, msg, fields)
+}
+
+// Panicm logs a message with the entries of a map as context, then panics.
+// The map is treated as it is in WithMap.
+func (s *SugaredLogger) Panicm(msg string, fields map[string]interface{}) {
+	s.logm(PanicLevel, msg, fields)
+}
+
+// Fatalm logs a message with the entries of a map as context, then calls
+// os.Exit. The map is treated as it is in WithMap.
+func (s *SugaredLogger) Fatalm(msg string, fields map[string]interface{}) {
+	s.logm(FatalLevel, msg, fields)
+}
+
+// Logfw formats the message according to the format specifier and logs it
+// at provided level with some additional context. The leading arguments
+// fill the template's verbs, and the rest are key-value pairs treated as
+// they are in With:
+//
+//	s.Logfw(InfoLevel, "retrying in %v", delay, "attempt", n)
+func (s *SugaredLogger) Logfw(lvl ladcore.Level, template string, args ...interface{}) {
+	fmtArgs, context := splitFormatArgs(template, args)
+	s.log(lvl, template, fmtArgs, context)
+}
+
+// Debugfw formats the message according to the format specifier and logs it
+// at [DebugLevel] with some additional context. Arguments are split between
+// the template and the context as they are in Logfw.
+func (s *SugaredLogger) Debugfw(template string, args ...interface{}) {
+	fmtArgs, context := splitFormatArgs(template, args)
+	s.log(DebugLevel, template, fmtArgs, context)
+}
+
+// Infofw formats the message according to the format specifier and logs it
+// at [InfoLevel] with some additional context. Arguments are split between
+// the template and the context as they are in Logfw.
+func (s *SugaredLogger) Infofw(template string, args ...interface{}) {
+	fmtArgs, context := splitFormatArgs(template, args)
+	s.log(InfoLevel, template, fmtArgs, context)
+}
+
+// Warnfw formats the message according to the format specifier and logs it
+// at [WarnLevel] with some additional context. Arguments are split between
+// the template and the context as they are in Logfw.
+func (s *SugaredLogger) Warnfw(template string, args ...interface{}) {
+	fmtArgs, context := splitFormatArgs(template, args)
+	s.log(WarnLevel, template, fmtArgs, context)
+}
+
+// Errorfw formats the message according to the format specifier and logs it
+// at [ErrorLevel] with some additional context. Arguments are split between
+// the template and the context as they are in Logfw.
+func (s *SugaredLogger) Errorfw(template string, args ...interface{}) {
+	fmtArgs, context := splitFormatArgs(template, args)
+	s.log(ErrorLevel, template, fmtArgs, context)
+}
+
+// DPanicfw formats the message according to the format specifier and logs it
+// at [DPanicLevel] with some additional context. In development, the logger
+// then panics. (See [DPanicLevel] for details.) Arguments are split between
+// the template and the context as they are in Logfw.
+func (s *SugaredLogger) DPanicfw(template string, args ...interface{}) {
+	fmtArgs, context := splitFormatArgs(template, args)
+	s.log(DPanicLevel, template, fmtArgs, context)
+}
+
+// Panicfw formats the message according to the format specifier, logs it
+// with some additional context, and panics. Arguments are split between the
+// template and the context as they are in Logfw.
+func (s *SugaredLogger) Panicfw(template string, args ...interface{}) {
+	fmtArgs, context := splitFormatArgs(template, args)
+	s.log(PanicLevel, template, fmtArgs, context)
+}
+
+// Fatalfw formats the message according to the format specifier, logs it
+// with some additional context, and calls os.Exit. Arguments are split
+// between the template and the context as they are in Logfw.
+func (s *SugaredLogger) Fatalfw(template string, args ...interface{}) {
+	fmtArgs, context := splitFormatArgs(template, args)
+	s.log(FatalLevel, template, fmtArgs, context)
+}
+
 // Logln logs a message at provided level.
 // Spaces are always added between arguments.
 func (s *SugaredLogger) Logln(lvl ladcore.Level, args ...interface{}) {
@@ -352,7 +500,30 @@ func (s *SugaredLogger) log(lvl ladcore.Level, template string, fmtArgs []interf
 
 	msg := getMessage(template, fmtArgs)
 	if ce := s.base.Check(lvl, msg); ce != nil {
-		ce.Write(s.sweetenFields(context)...)
+		ce.Write(s.sweetenFields(context, 1)...)
+	}
+}
+
+// splitFormatArgs splits args between the template's verbs and the context.
+// Callers pass its results straight to log, rather than going through another
+// helper, so that every logging method is the same number of frames above
+// Check.
+func splitFormatArgs(template string, args []interface{}) (fmtArgs, context []interface{}) {
+	n := countFormatArgs(template)
+	if n > len(args) {
+		n = len(args)
+	}
+	return args[:n], args[n:]
+}
+
+// logm logs a message with a map as context.
+func (s *SugaredLogger) logm(lvl ladcore.Level, msg string, fields map[string]interface{}) {
+	if lvl < DPanicLevel && !s.base.Core().Enabled(lvl) {
+		return
+	}
+
+	if ce := s.base.Check(lvl, msg); ce != nil {
+		ce.Write(mapFields(fields)...)
 	}
 }
 
@@ -364,7 +535,7 @@ func (s *SugaredLogger) logln(lvl ladcore.Level, fmtArgs []interface{}, context
 
 	msg := getMessageln(fmtArgs)
 	if ce := s.base.Check(lvl, msg); ce != nil {
-		ce.Write(s.sweetenFields(context)...)
+		ce.Write(s.sweetenFields(context, 1)...)
 	}
 }
 
@@ -386,13 +557,86 @@ func getMessage(template string, fmtArgs []interface{}) string {
 	return fmt.Sprint(fmtArgs...)
 }
 
+// countFormatArgs reports how many arguments fmt.Sprintf would consume for
+// template, including those for '*' widths and precisions and those selected
+// by explicit argument indexes.
+func countFormatArgs(template string) int {
+	var argNum, maxArgs int
+	use := func() {
+		argNum++
+		if argNum > maxArgs {
+			maxArgs = argNum
+		}
+	}
+	// index parses an explicit argument index like "[2]", if present.
+	index := func(i int) int {
+		if i >= len(template) || template[i] != '[' {
+			return i
+		}
+		j := i + 1
+		n := 0
+		for j < len(template) && '0' <= template[j] && template[j] <= '9' {
+			n = n*10 + int(template[j]-'0')
+			j++
+		}
+		if j >= len(template) || template[j] != ']' || j == i+1 || n < 1 {
+			return i
+		}
+		argNum = n - 1
+		return j + 1
+	}
+
+	for i := 0; i < len(template); i++ {
+		if template[i] != '%' {
+			continue
+		}
+		i++
+		if i < len(template) && template[i] == '%' {
+			continue
+		}
+		// Flags.
+		for i < len(template) && strings.IndexByte("+-# 0", template[i]) >= 0 {
+			i++
+		}
+		// Width.
+		i = index(i)
+		if i < len(template) && template[i] == '*' {
+			use()
+			i++
+		}
+		for i < len(template) && '0' <= template[i] && template[i] <= '9' {
+			i++
+		}
+		// Precision.
+		if i < len(template) && template[i] == '.' {
+			i = index(i + 1)
+			if i < len(template) && template[i] == '*' {
+				use()
+				i++
+			}
+			for i < len(template) && '0' <= template[i] && template[i] <= '9' {
+				i++
+			}
+		}
+		// Verb.
+		i = index(i)
+		if i < len(template) {
+			use()
+		}
+	}
+	return maxArgs
+}
+
 // getMessageln format with Sprintln.
 func getMessageln(fmtArgs []interface{}) string {
 	msg := fmt.Sprintln(fmtArgs...)
 	return msg[:len(msg)-1]
 }
 
-func (s *SugaredLogger) sweetenFields(args []interface{}) []Field {
+// sweetenFields converts loosely-typed key-value pairs to fields. depth is the
+// number of frames between the exported method and sweetenFields, so that
+// malformed pairs are reported against the right caller.
+func (s *SugaredLogger) sweetenFields(args []interface{}, depth int) []Field {
 	if len(args) == 0 {
 		return nil
 	}
@@ -417,9 +661,9 @@ func (s *SugaredLogger) sweetenFields(args []interface{}) []Field {
 		if err, ok := args[i].(error); ok {
 			if !seenError {
 				seenError = true
-				fields = append(fields, Error(err))
+				fields = append(fields, s.errorField(err))
 			} else {
-				s.base.Error(_multipleErrMsg, Error(err))
+				s.reportInvalid(depth, _multipleErrMsg, Error(err))
 			}
 			i++
 			continue
@@ -427,7 +671,7 @@ func (s *SugaredLogger) sweetenFields(args []interface{}) []Field {
 
 		// Make sure this element isn't a dangling key.
 		if i == len(args)-1 {
-			s.base.Error(_oddNumberErrMsg, Any("ignored", args[i]))
+			s.reportInvalid(depth, _oddNumberErrMsg, Any("ignored", args[i]))
 			break
 		}
 
@@ -448,11 +692,47 @@ func (s *SugaredLogger) sweetenFields(args []interface{}) []Field {
 
 	// If we encountered any invalid key-value pairs, log an error.
 	if len(invalid) > 0 {
-		s.base.Error(_nonStringKeyErrMsg, Array("invalid", invalid))
+		s.reportInvalid(depth, _nonStringKeyErrMsg, Array("invalid", invalid))
+	}
+	return fields
+}
+
+// mapFields converts a map to fields, sorted by key so that the output is
+// deterministic.
+func mapFields(m map[string]interface{}) []Field {
+	if len(m) == 0 {
+		return nil
+	}
+	keys := sortedKeys(m)
+	fields := make([]Field, len(keys))
+	for i, k := range keys {
+		fields[i] = Any(k, m[k])
 	}
 	return fields
 }
 
+// errorField converts an error passed without a key to a field.
+func (s *SugaredLogger) errorField(err error) Field {
+	if s.base.sugarErrorKey != "" {
+		return NamedError(s.base.sugarErrorKey, err)
+	}
+	return Error(err)
+}
+
+// reportInvalid logs malformed arguments, at DPanicLevel if the logger is
+// strict.
+func (s *SugaredLogger) reportInvalid(depth int, msg string, field Field) {
+	// Skip this frame, sweetenFields, and any helpers in between when
+	// annotating the caller.
+	base := s.base.clone()
+	base.callerSkip += 1 + depth
+	if base.sugarStrict {
+		base.DPanic(msg, field)
+		return
+	}
+	base.Error(msg, field)
+}
+
 type invalidPair struct {
 	position   int
 	key, value interface{}
@@ -287,6 +287,46 @@ func TestSugarFieldsInvalidPairs(t *testing.T) {
 	})
 }
 
+func TestSugarInvalidPairsCaller(t *testing.T) {
+	withSugar(t, DebugLevel, opts(AddCaller()), func(logger *SugaredLogger, logs *observer.ObservedLogs) {
+		logger.With("dangling")
+		output := logs.FilterMessage(_oddNumberErrMsg).AllUntimed()
+		require.Len(t, output, 1, "Expected the dangling key to be reported.")
+		assert.Regexp(t, `.+/sugar_test.go:[\d]+$`, output[0].Caller, "Expected the report to point at the log site.")
+	})
+}
+
+func TestSugarStrictKeyValues(t *testing.T) {
+	withSugar(t, DebugLevel, opts(StrictKeyValues()), func(logger *SugaredLogger, logs *observer.ObservedLogs) {
+		logger.Infow("msg", "dangling")
+		logger.Infow("msg", 42, "value")
+		logger.Infow("msg", errors.New("first"), errors.New("second"))
+
+		var reports []string
+		for _, e := range logs.FilterLevelExact(DPanicLevel).AllUntimed() {
+			reports = append(reports, e.Message)
+		}
+		assert.Equal(t, []string{_oddNumberErrMsg, _nonStringKeyErrMsg, _multipleErrMsg}, reports, "Expected malformed pairs to be reported at DPanicLevel.")
+		assert.Equal(t, 3, logs.FilterMessage("msg").Len(), "Expected the entries to be logged anyway.")
+	})
+
+	withSugar(t, DebugLevel, opts(StrictKeyValues(), Development()), func(logger *SugaredLogger, logs *observer.ObservedLogs) {
+		assert.Panics(t, func() { logger.Infow("msg", "dangling") }, "Expected malformed pairs to panic in development.")
+	})
+}
+
+func TestSugarErrorKey(t *testing.T) {
+	err := errors.New("fail")
+	withSugar(t, DebugLevel, opts(SugarErrorKey("err")), func(logger *SugaredLogger, logs *observer.ObservedLogs) {
+		logger.With(err).Info("with")
+		logger.Infow("infow", "k", "v", err)
+		output := logs.AllUntimed()
+		require.Len(t, output, 2, "Unexpected number of entries logged.")
+		assert.Equal(t, []Field{NamedError("err", err)}, output[0].Context, "Unexpected context from With.")
+		assert.Equal(t, []Field{String("k", "v"), NamedError("err", err)}, output[1].Context, "Unexpected context from Infow.")
+	})
+}
+
 func TestSugarStructuredLogging(t *testing.T) {
 	tests := []struct {
 		msg       string
@@ -325,6 +365,36 @@ func TestSugarStructuredLogging(t *testing.T) {
 	}
 }
 
+func TestSugarMapLogging(t *testing.T) {
+	err := errors.New("qux")
+	context := map[string]interface{}{"foo": "bar", "count": 42}
+	extra := map[string]interface{}{"failure": err, "baz": false}
+	expectedFields := []Field{
+		Int("count", 42), String("foo", "bar"),
+		Bool("baz", false), NamedError("failure", err),
+	}
+
+	withSugar(t, DebugLevel, opts(AddCaller()), func(logger *SugaredLogger, logs *observer.ObservedLogs) {
+		logger = logger.WithMap(context)
+		logger.Debugm("msg", extra)
+		logger.Infom("msg", extra)
+		logger.Warnm("msg", extra)
+		logger.Errorm("msg", extra)
+		logger.DPanicm("msg", extra)
+		logger.Logm(WarnLevel, "msg", extra)
+		logger.WithMap(nil).Infom("msg", nil)
+
+		entries := logs.AllUntimed()
+		require.Len(t, entries, 7, "Unexpected number of logs.")
+		for i, lvl := range []ladcore.Level{DebugLevel, InfoLevel, WarnLevel, ErrorLevel, DPanicLevel, WarnLevel} {
+			assert.Equal(t, lvl, entries[i].Level, "Unexpected level.")
+			assert.Equal(t, expectedFields, entries[i].Context, "Unexpected fields.")
+			assert.Regexp(t, `/sugar_test.go:\d+$`, entries[i].Caller.String(), "Unexpected caller.")
+		}
+		assert.Equal(t, expectedFields[:2], entries[6].Context, "Expected empty maps to add no fields.")
+	})
+}
+
 func TestSugarConcatenatingLogging(t *testing.T) {
 	tests := []struct {
 		args   []interface{}
@@ -395,6 +465,45 @@ func TestSugarTemplatedLogging(t *testing.T) {
 	}
 }
 
+func TestSugarTemplatedStructuredLogging(t *testing.T) {
+	tests := []struct {
+		format       string
+		args         []interface{}
+		expectMsg    string
+		expectFields []Field
+	}{
+		{"", nil, "", []Field{String("foo", "bar")}},
+		{"foo", []interface{}{"k", 1}, "foo", []Field{String("foo", "bar"), Int("k", 1)}},
+		{"n=%d", []interface{}{42, "k", "v"}, "n=42", []Field{String("foo", "bar"), String("k", "v")}},
+		{"%d%%", []interface{}{100}, "100%", []Field{String("foo", "bar")}},
+		{"%*d|%-.*f", []interface{}{4, 1, 2, 3.14159, "k", true}, "   1|3.14", []Field{String("foo", "bar"), Bool("k", true)}},
+		{"%[2]s %[1]s", []interface{}{"a", "b", "k", "v"}, "b a", []Field{String("foo", "bar"), String("k", "v")}},
+		// Too few arguments degrade the way fmt.Sprintf does.
+		{"%s %s", []interface{}{"a"}, "a %!s(MISSING)", []Field{String("foo", "bar")}},
+	}
+
+	for _, tt := range tests {
+		withSugar(t, DebugLevel, nil, func(logger *SugaredLogger, logs *observer.ObservedLogs) {
+			logger = logger.With("foo", "bar")
+			logger.Debugfw(tt.format, tt.args...)
+			logger.Infofw(tt.format, tt.args...)
+			logger.Warnfw(tt.format, tt.args...)
+			logger.Errorfw(tt.format, tt.args...)
+			logger.DPanicfw(tt.format, tt.args...)
+			logger.Logfw(WarnLevel, tt.format, tt.args...)
+
+			expected := make([]observer.LoggedEntry, 6)
+			for i, lvl := range []ladcore.Level{DebugLevel, InfoLevel, WarnLevel, ErrorLevel, DPanicLevel, WarnLevel} {
+				expected[i] = observer.LoggedEntry{
+					Entry:   ladcore.Entry{Message: tt.expectMsg, Level: lvl},
+					Context: tt.expectFields,
+				}
+			}
+			assert.Equal(t, expected, logs.AllUntimed(), "Unexpected log output for %q.", tt.format)
+		})
+	}
+}
+
 func TestSugarLnLogging(t *testing.T) {
 	tests := []struct {
 		args   []interface{}
@@ -454,6 +563,9 @@ func TestSugarPanicLogging(t *testing.T) {
 		{FatalLevel, func(s *SugaredLogger) { s.Panicw("foo") }, ""},
 		{PanicLevel, func(s *SugaredLogger) { s.Panicw("foo") }, "foo"},
 		{DebugLevel, func(s *SugaredLogger) { s.Panicw("foo") }, "foo"},
+		{FatalLevel, func(s *SugaredLogger) { s.Panicfw("%s", "foo") }, ""},
+		{PanicLevel, func(s *SugaredLogger) { s.Panicfw("%s", "foo") }, "foo"},
+		{DebugLevel, func(s *SugaredLogger) { s.Panicfw("%s", "foo") }, "foo"},
 		{FatalLevel, func(s *SugaredLogger) { s.Panicln("foo") }, ""},
 		{PanicLevel, func(s *SugaredLogger) { s.Panicln("foo") }, "foo"},
 		{DebugLevel, func(s *SugaredLogger) { s.Panicln("foo") }, "foo"},
@@ -489,6 +601,9 @@ func TestSugarFatalLogging(t *testing.T) {
 		{FatalLevel + 1, func(s *SugaredLogger) { s.Fatalw("foo") }, ""},
 		{FatalLevel, func(s *SugaredLogger) { s.Fatalw("foo") }, "foo"},
 		{DebugLevel, func(s *SugaredLogger) { s.Fatalw("foo") }, "foo"},
+		{FatalLevel + 1, func(s *SugaredLogger) { s.Fatalfw("%s", "foo") }, ""},
+		{FatalLevel, func(s *SugaredLogger) { s.Fatalfw("%s", "foo") }, "foo"},
+		{DebugLevel, func(s *SugaredLogger) { s.Fatalfw("%s", "foo") }, "foo"},
 		{FatalLevel + 1, func(s *SugaredLogger) { s.Fatalln("foo") }, ""},
 		{FatalLevel, func(s *SugaredLogger) { s.Fatalln("foo") }, "foo"},
 		{DebugLevel, func(s *SugaredLogger) { s.Fatalln("foo") }, "foo"},
@@ -535,6 +650,62 @@ func TestSugarAddCaller(t *testing.T) {
 	}
 }
 
+func TestSugarCallerAllMethods(t *testing.T) {
+	withSugar(t, DebugLevel, opts(AddCaller()), func(logger *SugaredLogger, logs *observer.ObservedLogs) {
+		logger.Info("Info")
+		logger.Infow("Infow")
+		logger.Infof("Infof")
+		logger.Infofw("Infofw %d", 1, "k", "v")
+		logger.Logfw(InfoLevel, "Logfw %d", 1, "k", "v")
+		logger.Infoln("Infoln")
+
+		// Malformed pairs are reported against the same caller.
+		logger.Infow("dangling Infow", "k")
+		logger.Infofw("dangling Infofw %d", 1, "k")
+		logger.With("k").Info("dangling With")
+
+		require.Equal(t, 12, logs.Len(), "Unexpected number of logs written out.")
+		for _, entry := range logs.AllUntimed() {
+			assert.Regexp(t, `.+/sugar_test.go:[\d]+$`, entry.Caller.String(), "Unexpected caller for %q.", entry.Message)
+		}
+	})
+}
+
+func TestSugarDesugarKeepsCallerSkip(t *testing.T) {
+	withSugar(t, DebugLevel, opts(AddCaller()), func(logger *SugaredLogger, logs *observer.ObservedLogs) {
+		logger.Desugar().Info("desugared")
+		logger.Desugar().Sugar().Desugar().Sugar().Info("round trip")
+		skipped := logger.WithOptions(AddCallerSkip(1), AddCallerSkip(-1))
+		skipped.Desugar().Sugar().Desugar().Info("skips added to sugar")
+		skipped = logger.Desugar().WithOptions(AddCallerSkip(1), AddCallerSkip(-1)).Sugar()
+		skipped.With("k", "v").Desugar().Sugar().Infow("skips added to desugared")
+
+		require.Equal(t, 4, logs.Len(), "Unexpected number of logs written out.")
+		for _, entry := range logs.AllUntimed() {
+			assert.Regexp(t, `.+/sugar_test.go:[\d]+$`, entry.Caller.String(), "Unexpected caller for %q.", entry.Message)
+		}
+	})
+
+	tests := []struct {
+		desc   string
+		logger func(*SugaredLogger) *Logger
+	}{
+		{"skip added to sugar", func(s *SugaredLogger) *Logger {
+			return s.WithOptions(AddCallerSkip(1)).Desugar()
+		}},
+		{"skip added to desugared", func(s *SugaredLogger) *Logger {
+			return s.Desugar().WithOptions(AddCallerSkip(1)).Sugar().Desugar()
+		}},
+	}
+	for _, tt := range tests {
+		withSugar(t, DebugLevel, opts(AddCaller()), func(logger *SugaredLogger, logs *observer.ObservedLogs) {
+			tt.logger(logger).Info("")
+			require.Equal(t, 1, logs.Len(), "Unexpected number of logs written out.")
+			assert.Regexp(t, `.+/common_test.go:[\d]+$`, logs.AllUntimed()[0].Caller.String(), "Unexpected caller for %s.", tt.desc)
+		})
+	}
+}
+
 func TestSugarAddCallerFail(t *testing.T) {
 	errBuf := &ztest.Buffer{}
 	withSugar(t, DebugLevel, opts(AddCaller(), AddCallerSkip(1e3), ErrorOutput(errBuf)), func(log *SugaredLogger, logs *observer.ObservedLogs) {
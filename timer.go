@@ -0,0 +1,51 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lad
+
+import "time"
+
+// A Timer measures the time elapsed since it was started, for logging how long
+// an operation took.
+//
+//	timer := lad.StartTimer()
+//	// ...
+//	logger.Info("done", timer.Field("elapsed"))
+//
+// The zero value isn't useful; use StartTimer.
+type Timer struct {
+	start time.Time
+}
+
+// StartTimer starts a new Timer.
+func StartTimer() Timer {
+	return Timer{start: time.Now()}
+}
+
+// Elapsed returns the time elapsed since the Timer was started.
+func (t Timer) Elapsed() time.Duration {
+	return time.Since(t.start)
+}
+
+// Field constructs a Duration field with the given key that carries the time
+// elapsed since the Timer was started.
+func (t Timer) Field(key string) Field {
+	return Duration(key, t.Elapsed())
+}
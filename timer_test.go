@@ -0,0 +1,54 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lad
+
+import (
+	"testing"
+	"time"
+
+	"github.com/auwixcom/lad/ladcore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimer(t *testing.T) {
+	before := time.Now()
+	timer := StartTimer()
+	time.Sleep(time.Millisecond)
+	elapsed := timer.Elapsed()
+	upper := time.Since(before)
+
+	assert.GreaterOrEqual(t, elapsed, time.Millisecond, "Expected at least the time slept.")
+	assert.LessOrEqual(t, elapsed, upper, "Expected no more than the time since before starting.")
+
+	f := timer.Field("elapsed")
+	require.Equal(t, ladcore.DurationType, f.Type, "Expected a duration field.")
+	assert.Equal(t, "elapsed", f.Key, "Unexpected key.")
+	assert.GreaterOrEqual(t, time.Duration(f.Integer), elapsed, "Expected the field to measure up to now.")
+}
+
+func TestSince(t *testing.T) {
+	start := time.Now().Add(-time.Minute)
+	f := Since("took", start)
+	require.Equal(t, ladcore.DurationType, f.Type, "Expected a duration field.")
+	assert.Equal(t, "took", f.Key, "Unexpected key.")
+	assert.GreaterOrEqual(t, time.Duration(f.Integer), time.Minute, "Unexpected duration.")
+}